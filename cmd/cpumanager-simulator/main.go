@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// cpumanager-simulator replays a sequence of exclusive CPU requests against
+// the static CPU manager policy and prints the assignments it would make.
+// The simulation config is a JSON document holding the machine topology, the
+// policy options and the requests; see the simulator package for the layout.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/simulator"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to the simulation config JSON; reads standard input when empty")
+	flag.Parse()
+
+	if err := run(*configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	// Every policy option is fair game in a what-if tool, no matter its
+	// maturity on real nodes.
+	if err := utilfeature.DefaultMutableFeatureGate.SetFromMap(map[string]bool{
+		string(features.CPUManagerPolicyAlphaOptions): true,
+		string(features.CPUManagerPolicyBetaOptions):  true,
+	}); err != nil {
+		return fmt.Errorf("could not enable the policy option feature gates: %w", err)
+	}
+
+	var data []byte
+	var err error
+	if configPath == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(configPath)
+	}
+	if err != nil {
+		return fmt.Errorf("could not read the simulation config: %w", err)
+	}
+
+	config, err := simulator.LoadConfig(data)
+	if err != nil {
+		return err
+	}
+	report, err := simulator.Run(config)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	_ "net/http/pprof" // Enable pprof HTTP handlers.
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -106,6 +107,33 @@ type KubeletFlags struct {
 	// This flag, if set, will avoid including `EvictionHard` limits while computing Node Allocatable.
 	// Refer to [Node Allocatable](https://kubernetes.io/docs/tasks/administer-cluster/reserve-compute-resources/#node-allocatable) doc for more information.
 	ExperimentalNodeAllocatableIgnoreEvictionThreshold bool
+	// ValidateResourceManagerConfig, if set, makes the kubelet validate the resource
+	// managers configuration (cpumanager, memorymanager, topologymanager) against the
+	// machine topology, print the computed reserved sets, shared pool and alignment
+	// settings, and exit without starting the node.
+	ValidateResourceManagerConfig bool
+	// MachineInfoFile is the path to a JSON file with cadvisor machine info to
+	// validate the resource managers configuration against, instead of the
+	// topology discovered on the local machine. Only used together with
+	// ValidateResourceManagerConfig.
+	MachineInfoFile string
+	// DeviceManagerAllocationStrategies maps device plugin resource names to the
+	// strategy ('pack' or 'spread') used to pick among equivalent devices across
+	// NUMA nodes when a container requests more than one of them.
+	DeviceManagerAllocationStrategies map[string]string
+	// DeviceManagerSharingCounts maps device plugin resource names to the number
+	// of containers which may share a single device of that resource. The device
+	// manager advertises the multiplied capacity and tracks per-slot allocations.
+	DeviceManagerSharingCounts map[string]string
+	// DevicePluginSlowAllocationThreshold is the device plugin Allocate RPC
+	// latency above which the kubelet emits a warning node event pointing at
+	// the slow plugin. Zero disables the alert.
+	DevicePluginSlowAllocationThreshold time.Duration
+	// RecordResourceAlignmentEvents makes the kubelet record an event per
+	// admitted pod summarizing the resource alignment decision: the merged
+	// NUMA affinity, the exclusive cpuset, the memory nodes and the device
+	// IDs granted to each container.
+	RecordResourceAlignmentEvents bool
 	// Node Labels are the node labels to add when registering the node in the cluster
 	NodeLabels map[string]string
 	// lockFilePath is the path that kubelet will use to as a lock file.
@@ -324,6 +352,12 @@ func (f *KubeletFlags) AddFlags(mainfs *pflag.FlagSet) {
 	fs.MarkDeprecated("cloud-config", "will be removed in 1.25 or later, in favor of removing cloud provider code from Kubelet.")
 	fs.BoolVar(&f.ExperimentalNodeAllocatableIgnoreEvictionThreshold, "experimental-allocatable-ignore-eviction", f.ExperimentalNodeAllocatableIgnoreEvictionThreshold, "When set to 'true', Hard Eviction Thresholds will be ignored while calculating Node Allocatable. See https://kubernetes.io/docs/tasks/administer-cluster/reserve-compute-resources/ for more details. [default=false]")
 	fs.MarkDeprecated("experimental-allocatable-ignore-eviction", "will be removed in 1.25 or later.")
+	fs.BoolVar(&f.ValidateResourceManagerConfig, "validate-resource-manager-config", f.ValidateResourceManagerConfig, "<Warning: Alpha feature> Validate the CPU, memory and topology manager configuration against the machine topology, print the computed reserved CPU set, shared pool and alignment settings, then exit without starting the node.")
+	fs.StringVar(&f.MachineInfoFile, "machine-info-file", f.MachineInfoFile, "<Warning: Alpha feature> Path to a JSON file containing cadvisor machine info to validate the resource manager configuration against, instead of the topology discovered on the local machine. Only used with --validate-resource-manager-config.")
+	fs.Var(cliflag.NewMapStringString(&f.DeviceManagerAllocationStrategies), "device-manager-allocation-strategies", "<Warning: Alpha feature> A set of ResourceName=Strategy (e.g. vendor.example.com/gpu=spread) pairs selecting how the device manager picks among equivalent devices of a resource when a container requests more than one. 'spread' distributes the devices across NUMA nodes for bandwidth, 'pack' groups them on as few NUMA nodes as possible for locality. Resources not listed keep the default behaviour.")
+	fs.Var(cliflag.NewMapStringString(&f.DeviceManagerSharingCounts), "device-manager-sharing-counts", "<Warning: Alpha feature> A set of ResourceName=N pairs declaring that every device of the named resource may be shared by N containers. The kubelet advertises N times the plugin-reported capacity and tracks allocations per device slot, while the device plugin keeps seeing only the physical device IDs. Resources not listed are not shared.")
+	fs.DurationVar(&f.DevicePluginSlowAllocationThreshold, "device-plugin-slow-allocation-threshold", f.DevicePluginSlowAllocationThreshold, "<Warning: Alpha feature> Device plugin Allocate RPC latency above which the kubelet emits a warning node event naming the slow plugin, since slow plugins inflate pod startup times. Set to 0 to disable the alert.")
+	fs.BoolVar(&f.RecordResourceAlignmentEvents, "record-resource-alignment-events", f.RecordResourceAlignmentEvents, "<Warning: Alpha feature> Record a Kubernetes event for every admitted pod summarizing the resource alignment decision: the merged NUMA affinity, the exclusive cpuset, the memory nodes and the device IDs granted to each container. Pods which received no aligned resources produce no event.")
 }
 
 // AddKubeletConfigFlags adds flags for a specific kubeletconfig.KubeletConfiguration to the specified FlagSet
@@ -502,7 +536,7 @@ func AddKubeletConfigFlags(mainfs *pflag.FlagSet, c *kubeletconfig.KubeletConfig
 	logsapi.AddFlags(&c.Logging, fs)
 
 	// Memory Manager Flags
-	fs.StringVar(&c.MemoryManagerPolicy, "memory-manager-policy", c.MemoryManagerPolicy, "Memory Manager policy to use. Possible values: 'None', 'Static'.")
+	fs.StringVar(&c.MemoryManagerPolicy, "memory-manager-policy", c.MemoryManagerPolicy, "Memory Manager policy to use. Possible values: 'None', 'Static', 'BestEffort'.")
 	fs.Var(&utilflag.ReservedMemoryVar{Value: &c.ReservedMemory}, "reserved-memory", "A comma separated list of memory reservations for NUMA nodes. (e.g. --reserved-memory 0:memory=1Gi,hugepages-1M=2Gi --reserved-memory 1:memory=2Gi). The total sum for each memory type should be equal to the sum of kube-reserved, system-reserved and eviction-threshold. See https://kubernetes.io/docs/tasks/administer-cluster/memory-manager/#reserved-memory-flag for more details.")
 
 	fs.BoolVar(&c.RegisterNode, "register-node", c.RegisterNode, "Register the node with the apiserver. If --kubeconfig is not provided, this flag is irrelevant, as the Kubelet won't have an apiserver to register with.")
@@ -522,6 +522,19 @@ func initConfigz(kc *kubeletconfiginternal.KubeletConfiguration) error {
 	return nil
 }
 
+// initResourceManagersConfigz registers the effective, post-defaulting
+// resource manager configuration under its own /configz section, next to the
+// kubeletconfig section which reports the configuration as it was given.
+func initResourceManagersConfigz(containerManager cm.ContainerManager) error {
+	cz, err := configz.New("kubeletresourcemanagers")
+	if err != nil {
+		klog.ErrorS(err, "Failed to register configz")
+		return err
+	}
+	cz.Set(containerManager.GetResourceManagersConfigz())
+	return nil
+}
+
 // makeEventRecorder sets up kubeDeps.Recorder if it's nil. It's a no-op otherwise.
 func makeEventRecorder(ctx context.Context, kubeDeps *kubelet.Dependencies, nodeName types.NodeName) {
 	if kubeDeps.Recorder != nil {
@@ -560,6 +573,21 @@ func getReservedCPUs(machineInfo *cadvisorapi.MachineInfo, cpus string) (cpuset.
 	return reservedCPUSet, nil
 }
 
+// loadMachineInfo reads cadvisor machine info from a JSON file, to validate
+// the resource manager configuration against a machine other than the local
+// one before rollout.
+func loadMachineInfo(path string) (*cadvisorapi.MachineInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read machine info file %q: %w", path, err)
+	}
+	machineInfo := &cadvisorapi.MachineInfo{}
+	if err := json.Unmarshal(data, machineInfo); err != nil {
+		return nil, fmt.Errorf("unable to parse machine info file %q: %w", path, err)
+	}
+	return machineInfo, nil
+}
+
 func run(ctx context.Context, s *options.KubeletServer, kubeDeps *kubelet.Dependencies, featureGate featuregate.FeatureGate) (err error) {
 	// Set global feature gates based on the value on the initial KubeletServer
 	err = utilfeature.DefaultMutableFeatureGate.SetFromMap(s.KubeletConfiguration.FeatureGates)
@@ -749,6 +777,12 @@ func run(ctx context.Context, s *options.KubeletServer, kubeDeps *kubelet.Depend
 		if err != nil {
 			return err
 		}
+		if s.ValidateResourceManagerConfig && s.MachineInfoFile != "" {
+			machineInfo, err = loadMachineInfo(s.MachineInfoFile)
+			if err != nil {
+				return err
+			}
+		}
 		reservedSystemCPUs, err := getReservedCPUs(machineInfo, s.ReservedSystemCPUs)
 		if err != nil {
 			return err
@@ -813,42 +847,58 @@ func run(ctx context.Context, s *options.KubeletServer, kubeDeps *kubelet.Depend
 			}
 		}
 
+		nodeConfig := cm.NodeConfig{
+			NodeName:              nodeName,
+			RuntimeCgroupsName:    s.RuntimeCgroups,
+			SystemCgroupsName:     s.SystemCgroups,
+			KubeletCgroupsName:    s.KubeletCgroups,
+			KubeletOOMScoreAdj:    s.OOMScoreAdj,
+			CgroupsPerQOS:         s.CgroupsPerQOS,
+			CgroupRoot:            s.CgroupRoot,
+			CgroupDriver:          s.CgroupDriver,
+			KubeletRootDir:        s.RootDirectory,
+			ProtectKernelDefaults: s.ProtectKernelDefaults,
+			NodeAllocatableConfig: cm.NodeAllocatableConfig{
+				KubeReservedCgroupName:   s.KubeReservedCgroup,
+				SystemReservedCgroupName: s.SystemReservedCgroup,
+				EnforceNodeAllocatable:   sets.New(s.EnforceNodeAllocatable...),
+				KubeReserved:             kubeReserved,
+				SystemReserved:           systemReserved,
+				ReservedSystemCPUs:       reservedSystemCPUs,
+				HardEvictionThresholds:   hardEvictionThresholds,
+			},
+			QOSReserved:                             *experimentalQOSReserved,
+			CPUManagerPolicy:                        s.CPUManagerPolicy,
+			CPUManagerPolicyOptions:                 cpuManagerPolicyOptions,
+			CPUManagerReconcilePeriod:               s.CPUManagerReconcilePeriod.Duration,
+			ExperimentalMemoryManagerPolicy:         s.MemoryManagerPolicy,
+			ExperimentalMemoryManagerReservedMemory: s.ReservedMemory,
+			MemoryManagerReconcilePeriod:            s.MemoryManagerReconcilePeriod.Duration,
+			PodPidsLimit:                            s.PodPidsLimit,
+			EnforceCPULimits:                        s.CPUCFSQuota,
+			CPUCFSQuotaPeriod:                       s.CPUCFSQuotaPeriod.Duration,
+			TopologyManagerPolicy:                   s.TopologyManagerPolicy,
+			TopologyManagerScope:                    s.TopologyManagerScope,
+			TopologyManagerPolicyOptions:            topologyManagerPolicyOptions,
+			DeviceManagerAllocationStrategies:       s.DeviceManagerAllocationStrategies,
+			DeviceManagerSharingCounts:              s.DeviceManagerSharingCounts,
+			DevicePluginSlowAllocationThreshold:     s.DevicePluginSlowAllocationThreshold,
+			RecordResourceAlignmentEvents:           s.RecordResourceAlignmentEvents,
+		}
+
+		if s.ValidateResourceManagerConfig {
+			report, err := cm.ValidateResourceManagersConfig(machineInfo, nodeConfig)
+			if err != nil {
+				return fmt.Errorf("resource manager configuration validation failed: %w", err)
+			}
+			fmt.Fprint(os.Stdout, report)
+			return nil
+		}
+
 		kubeDeps.ContainerManager, err = cm.NewContainerManager(
 			kubeDeps.Mounter,
 			kubeDeps.CAdvisorInterface,
-			cm.NodeConfig{
-				NodeName:              nodeName,
-				RuntimeCgroupsName:    s.RuntimeCgroups,
-				SystemCgroupsName:     s.SystemCgroups,
-				KubeletCgroupsName:    s.KubeletCgroups,
-				KubeletOOMScoreAdj:    s.OOMScoreAdj,
-				CgroupsPerQOS:         s.CgroupsPerQOS,
-				CgroupRoot:            s.CgroupRoot,
-				CgroupDriver:          s.CgroupDriver,
-				KubeletRootDir:        s.RootDirectory,
-				ProtectKernelDefaults: s.ProtectKernelDefaults,
-				NodeAllocatableConfig: cm.NodeAllocatableConfig{
-					KubeReservedCgroupName:   s.KubeReservedCgroup,
-					SystemReservedCgroupName: s.SystemReservedCgroup,
-					EnforceNodeAllocatable:   sets.New(s.EnforceNodeAllocatable...),
-					KubeReserved:             kubeReserved,
-					SystemReserved:           systemReserved,
-					ReservedSystemCPUs:       reservedSystemCPUs,
-					HardEvictionThresholds:   hardEvictionThresholds,
-				},
-				QOSReserved:                             *experimentalQOSReserved,
-				CPUManagerPolicy:                        s.CPUManagerPolicy,
-				CPUManagerPolicyOptions:                 cpuManagerPolicyOptions,
-				CPUManagerReconcilePeriod:               s.CPUManagerReconcilePeriod.Duration,
-				ExperimentalMemoryManagerPolicy:         s.MemoryManagerPolicy,
-				ExperimentalMemoryManagerReservedMemory: s.ReservedMemory,
-				PodPidsLimit:                            s.PodPidsLimit,
-				EnforceCPULimits:                        s.CPUCFSQuota,
-				CPUCFSQuotaPeriod:                       s.CPUCFSQuotaPeriod.Duration,
-				TopologyManagerPolicy:                   s.TopologyManagerPolicy,
-				TopologyManagerScope:                    s.TopologyManagerScope,
-				TopologyManagerPolicyOptions:            topologyManagerPolicyOptions,
-			},
+			nodeConfig,
 			s.FailSwapOn,
 			kubeDeps.Recorder,
 			kubeDeps.KubeClient,
@@ -859,6 +909,11 @@ func run(ctx context.Context, s *options.KubeletServer, kubeDeps *kubelet.Depend
 		}
 	}
 
+	// Register the effective resource manager configuration with /configz
+	if err := initResourceManagersConfigz(kubeDeps.ContainerManager); err != nil {
+		klog.ErrorS(err, "Failed to register resource managers configuration with configz")
+	}
+
 	if kubeDeps.PodStartupLatencyTracker == nil {
 		kubeDeps.PodStartupLatencyTracker = kubeletutil.NewPodStartupLatencyTracker()
 	}
@@ -156,6 +156,15 @@ const (
 	// Enable webhooks in cloud controller manager
 	CloudControllerManagerWebhook featuregate.Feature = "CloudControllerManagerWebhook"
 
+	// owner: @ffromani
+	// alpha: v1.32
+	//
+	// Mounts a read-only report file describing the exclusive resources
+	// assigned to a container (cpuset, NUMA nodes, devices) into the
+	// container filesystem, so workloads can self-configure without
+	// querying the podresources API.
+	ContainerAlignmentReport featuregate.Feature = "ContainerAlignmentReport"
+
 	// owner: @adrianreber
 	// kep: https://kep.k8s.io/2008
 	// alpha: v1.25
@@ -164,11 +173,30 @@ const (
 	// Enables container Checkpoint support in the kubelet
 	ContainerCheckpoint featuregate.Feature = "ContainerCheckpoint"
 
+	// owner: @ffromani
+	// alpha: v1.32
+	//
+	// Passes the uncore cache IDs and NUMA nodes of the cpus exclusively
+	// assigned to a container to the runtime as OCI annotations, so runtimes
+	// and NRI plugins can apply additional tuning consistent with the
+	// kubelet resource alignment decisions.
+	ContainerTopologyAnnotations featuregate.Feature = "ContainerTopologyAnnotations"
+
 	// owner: @helayoty
 	// beta: v1.28
 	// Set the scheduled time as an annotation in the job.
 	CronJobsScheduledAnnotation featuregate.Feature = "CronJobsScheduledAnnotation"
 
+	// owner: @ffromani
+	// alpha: v1.31
+	//
+	// Makes the device manager cap the allocatable count of each device
+	// plugin resource to the largest number of devices attached to a single
+	// NUMA node when the topology manager runs with the single-numa-node
+	// policy, so the scheduler does not place pods the kubelet is guaranteed
+	// to reject at admission time.
+	DeviceManagerSingleNUMAAllocatable featuregate.Feature = "DeviceManagerSingleNUMAAllocatable"
+
 	// owner: @elezar
 	// kep: http://kep.k8s.io/4009
 	// alpha: v1.28
@@ -603,6 +631,14 @@ const (
 	// Enable users to specify when a Pod is ready for scheduling.
 	PodSchedulingReadiness featuregate.Feature = "PodSchedulingReadiness"
 
+	// owner: @ffromani
+	// alpha: v1.31
+	//
+	// Enables reporting of the TopologyAligned condition in the pod status,
+	// reflecting whether the topology manager admitted the pod with a
+	// preferred resource alignment.
+	PodTopologyAlignedCondition featuregate.Feature = "PodTopologyAlignedCondition"
+
 	// owner: @seans3
 	// kep: http://kep.k8s.io/4006
 	// alpha: v1.30
@@ -996,8 +1032,12 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 
 	CloudControllerManagerWebhook: {Default: false, PreRelease: featuregate.Alpha},
 
+	ContainerAlignmentReport: {Default: false, PreRelease: featuregate.Alpha},
+
 	ContainerCheckpoint: {Default: true, PreRelease: featuregate.Beta},
 
+	ContainerTopologyAnnotations: {Default: false, PreRelease: featuregate.Alpha},
+
 	CronJobsScheduledAnnotation: {Default: true, PreRelease: featuregate.Beta},
 
 	DisableCloudProviders: {Default: true, PreRelease: featuregate.GA, LockToDefault: true},
@@ -1006,6 +1046,8 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 
 	DisableNodeKubeProxyVersion: {Default: true, PreRelease: featuregate.Beta},
 
+	DeviceManagerSingleNUMAAllocatable: {Default: false, PreRelease: featuregate.Alpha},
+
 	DevicePluginCDIDevices: {Default: true, PreRelease: featuregate.GA},
 
 	DynamicResourceAllocation: {Default: false, PreRelease: featuregate.Alpha},
@@ -1112,6 +1154,8 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 
 	PodSchedulingReadiness: {Default: true, PreRelease: featuregate.GA, LockToDefault: true}, // GA in 1.30; remove in 1.32
 
+	PodTopologyAlignedCondition: {Default: false, PreRelease: featuregate.Alpha},
+
 	PortForwardWebsockets: {Default: true, PreRelease: featuregate.Beta},
 
 	ProcMountType: {Default: false, PreRelease: featuregate.Alpha},
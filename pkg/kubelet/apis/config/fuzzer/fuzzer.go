@@ -68,6 +68,7 @@ func Funcs(codecs runtimeserializer.CodecFactory) []interface{} {
 			obj.MaxOpenFiles = 1000000
 			obj.MaxPods = 110
 			obj.MemoryManagerPolicy = v1beta1.NoneMemoryManagerPolicy
+			obj.MemoryManagerReconcilePeriod = metav1.Duration{Duration: 10 * time.Second}
 			obj.PodPidsLimit = -1
 			obj.NodeStatusUpdateFrequency = metav1.Duration{Duration: 10 * time.Second}
 			obj.NodeStatusReportFrequency = metav1.Duration{Duration: time.Minute}
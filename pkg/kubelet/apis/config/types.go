@@ -68,12 +68,21 @@ const (
 	// SingleNumaNodeTopologyManagerPolicy is a mode in which kubelet only allows
 	// pods with a single NUMA alignment of CPU and device resources.
 	SingleNumaNodeTopologyManagerPolicy = "single-numa-node"
+	// SingleNumaPreferredTopologyManagerPolicy is a mode in which kubelet favours
+	// a single NUMA alignment of CPU and device resources, but allows pods which
+	// cannot be aligned to a single NUMA node to spill over a small number of
+	// extra nodes instead of rejecting them.
+	SingleNumaPreferredTopologyManagerPolicy = "single-numa-preferred"
 	// ContainerTopologyManagerScope represents that
 	// topology policy is applied on a per-container basis.
 	ContainerTopologyManagerScope = "container"
 	// PodTopologyManagerScope represents that
 	// topology policy is applied on a per-pod basis.
 	PodTopologyManagerScope = "pod"
+	// DieTopologyManagerScope represents that
+	// topology policy is applied on a per-container basis, and the alignment
+	// is additionally kept within a single CPU die whenever possible.
+	DieTopologyManagerScope = "die"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -242,6 +251,9 @@ type KubeletConfiguration struct {
 	// MemoryManagerPolicy is the name of the policy to use.
 	// Requires the MemoryManager feature gate to be enabled.
 	MemoryManagerPolicy string
+	// Memory Manager reconciliation period.
+	// Requires the MemoryManager feature gate to be enabled.
+	MemoryManagerReconcilePeriod metav1.Duration
 	// TopologyManagerPolicy is the name of the policy to use.
 	TopologyManagerPolicy string
 	// TopologyManagerScope represents the scope of topology hint generation
@@ -161,6 +161,10 @@ func SetDefaults_KubeletConfiguration(obj *kubeletconfigv1beta1.KubeletConfigura
 	if obj.MemoryManagerPolicy == "" {
 		obj.MemoryManagerPolicy = kubeletconfigv1beta1.NoneMemoryManagerPolicy
 	}
+	if obj.MemoryManagerReconcilePeriod == zeroDuration {
+		// Keep the same as default CPUManagerReconcilePeriod
+		obj.MemoryManagerReconcilePeriod = metav1.Duration{Duration: 10 * time.Second}
+	}
 	if obj.TopologyManagerPolicy == "" {
 		obj.TopologyManagerPolicy = kubeletconfigv1beta1.NoneTopologyManagerPolicy
 	}
@@ -87,6 +87,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicy:                          "none",
 				CPUManagerReconcilePeriod:                 metav1.Duration{Duration: 10 * time.Second},
 				MemoryManagerPolicy:                       v1beta1.NoneMemoryManagerPolicy,
+				MemoryManagerReconcilePeriod:              metav1.Duration{Duration: 10 * time.Second},
 				TopologyManagerPolicy:                     v1beta1.NoneTopologyManagerPolicy,
 				TopologyManagerScope:                      v1beta1.ContainerTopologyManagerScope,
 				RuntimeRequestTimeout:                     metav1.Duration{Duration: 2 * time.Minute},
@@ -194,6 +195,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicyOptions:          map[string]string{},
 				CPUManagerReconcilePeriod:        zeroDuration,
 				MemoryManagerPolicy:              "",
+				MemoryManagerReconcilePeriod:     zeroDuration,
 				TopologyManagerPolicy:            "",
 				TopologyManagerScope:             "",
 				QOSReserved:                      map[string]string{},
@@ -308,6 +310,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicyOptions:                   map[string]string{},
 				CPUManagerReconcilePeriod:                 metav1.Duration{Duration: 10 * time.Second},
 				MemoryManagerPolicy:                       v1beta1.NoneMemoryManagerPolicy,
+				MemoryManagerReconcilePeriod:              metav1.Duration{Duration: 10 * time.Second},
 				TopologyManagerPolicy:                     v1beta1.NoneTopologyManagerPolicy,
 				TopologyManagerScope:                      v1beta1.ContainerTopologyManagerScope,
 				QOSReserved:                               map[string]string{},
@@ -425,6 +428,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicyOptions:        map[string]string{"key": "value"},
 				CPUManagerReconcilePeriod:      metav1.Duration{Duration: 60 * time.Second},
 				MemoryManagerPolicy:            v1beta1.StaticMemoryManagerPolicy,
+				MemoryManagerReconcilePeriod:   metav1.Duration{Duration: 60 * time.Second},
 				TopologyManagerPolicy:          v1beta1.RestrictedTopologyManagerPolicy,
 				TopologyManagerScope:           v1beta1.PodTopologyManagerScope,
 				QOSReserved:                    map[string]string{"memory": "10%"},
@@ -574,6 +578,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicyOptions:        map[string]string{"key": "value"},
 				CPUManagerReconcilePeriod:      metav1.Duration{Duration: 60 * time.Second},
 				MemoryManagerPolicy:            v1beta1.StaticMemoryManagerPolicy,
+				MemoryManagerReconcilePeriod:   metav1.Duration{Duration: 60 * time.Second},
 				TopologyManagerPolicy:          v1beta1.RestrictedTopologyManagerPolicy,
 				TopologyManagerScope:           v1beta1.PodTopologyManagerScope,
 				QOSReserved:                    map[string]string{"memory": "10%"},
@@ -711,6 +716,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicy:                          "none",
 				CPUManagerReconcilePeriod:                 metav1.Duration{Duration: 10 * time.Second},
 				MemoryManagerPolicy:                       v1beta1.NoneMemoryManagerPolicy,
+				MemoryManagerReconcilePeriod:              metav1.Duration{Duration: 10 * time.Second},
 				TopologyManagerPolicy:                     v1beta1.NoneTopologyManagerPolicy,
 				TopologyManagerScope:                      v1beta1.ContainerTopologyManagerScope,
 				RuntimeRequestTimeout:                     metav1.Duration{Duration: 2 * time.Minute},
@@ -803,6 +809,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicy:                          "none",
 				CPUManagerReconcilePeriod:                 metav1.Duration{Duration: 10 * time.Second},
 				MemoryManagerPolicy:                       v1beta1.NoneMemoryManagerPolicy,
+				MemoryManagerReconcilePeriod:              metav1.Duration{Duration: 10 * time.Second},
 				TopologyManagerPolicy:                     v1beta1.NoneTopologyManagerPolicy,
 				TopologyManagerScope:                      v1beta1.ContainerTopologyManagerScope,
 				RuntimeRequestTimeout:                     metav1.Duration{Duration: 2 * time.Minute},
@@ -895,6 +902,7 @@ func TestSetDefaultsKubeletConfiguration(t *testing.T) {
 				CPUManagerPolicy:                          "none",
 				CPUManagerReconcilePeriod:                 metav1.Duration{Duration: 10 * time.Second},
 				MemoryManagerPolicy:                       v1beta1.NoneMemoryManagerPolicy,
+				MemoryManagerReconcilePeriod:              metav1.Duration{Duration: 10 * time.Second},
 				TopologyManagerPolicy:                     v1beta1.NoneTopologyManagerPolicy,
 				TopologyManagerScope:                      v1beta1.ContainerTopologyManagerScope,
 				RuntimeRequestTimeout:                     metav1.Duration{Duration: 2 * time.Minute},
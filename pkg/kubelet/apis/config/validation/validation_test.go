@@ -101,6 +101,20 @@ func TestValidateKubeletConfiguration(t *testing.T) {
 			return conf
 		},
 		errMsg: "invalid configuration: nodeLeaseDurationSeconds must be greater than 0",
+	}, {
+		name: "invalid CPUManagerReconcilePeriod",
+		configure: func(conf *kubeletconfig.KubeletConfiguration) *kubeletconfig.KubeletConfiguration {
+			conf.CPUManagerReconcilePeriod = metav1.Duration{Duration: -1 * time.Second}
+			return conf
+		},
+		errMsg: "invalid configuration: cpuManagerReconcilePeriod (--cpu-manager-reconcile-period) -1s must not be negative",
+	}, {
+		name: "invalid MemoryManagerReconcilePeriod",
+		configure: func(conf *kubeletconfig.KubeletConfiguration) *kubeletconfig.KubeletConfiguration {
+			conf.MemoryManagerReconcilePeriod = metav1.Duration{Duration: -1 * time.Second}
+			return conf
+		},
+		errMsg: "invalid configuration: memoryManagerReconcilePeriod -1s must not be negative",
 	}, {
 		name: "specify EnforceNodeAllocatable without enabling CgroupsPerQOS",
 		configure: func(conf *kubeletconfig.KubeletConfiguration) *kubeletconfig.KubeletConfiguration {
@@ -311,14 +325,14 @@ func TestValidateKubeletConfiguration(t *testing.T) {
 			conf.TopologyManagerPolicy = "invalid-policy"
 			return conf
 		},
-		errMsg: "invalid configuration: topologyManagerPolicy (--topology-manager-policy) \"invalid-policy\" must be one of: [\"none\" \"best-effort\" \"restricted\" \"single-numa-node\"]",
+		errMsg: "invalid configuration: topologyManagerPolicy (--topology-manager-policy) \"invalid-policy\" must be one of: [\"none\" \"best-effort\" \"restricted\" \"single-numa-node\" \"single-numa-preferred\"]",
 	}, {
 		name: "invalid TopologyManagerScope",
 		configure: func(conf *kubeletconfig.KubeletConfiguration) *kubeletconfig.KubeletConfiguration {
 			conf.TopologyManagerScope = "invalid-scope"
 			return conf
 		},
-		errMsg: "invalid configuration: topologyManagerScope (--topology-manager-scope) \"invalid-scope\" must be one of: \"container\", or \"pod\"",
+		errMsg: "invalid configuration: topologyManagerScope (--topology-manager-scope) \"invalid-scope\" must be one of: \"container\", \"pod\", or \"die\"",
 	}, {
 		name: "ShutdownGracePeriodCriticalPods is greater than ShutdownGracePeriod",
 		configure: func(conf *kubeletconfig.KubeletConfiguration) *kubeletconfig.KubeletConfiguration {
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrorUnauthorized = status.Error(codes.PermissionDenied, "rejected by authorizer")
+)
+
+// Authorizer defines the interface to authorize a single gRPC request, based
+// on the request context (which carries the peer information) and on the
+// invoked method. Implementations may delegate to a node-local authorizer or
+// check the calling peer against an allowlist.
+type Authorizer interface {
+	// Authorize returns nil if the request described by the given context
+	// and full method name may proceed.
+	Authorize(ctx context.Context, fullMethod string) error
+}
+
+// AuthorizerUnaryServerInterceptor returns a new unary server interceptor that
+// rejects the requests the given authorizer does not allow.
+func AuthorizerUnaryServerInterceptor(authz Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authz.Authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// WithAuthorizer creates a server option enabling request authorization with
+// the given authorizer.
+func WithAuthorizer(serviceName string, authz Authorizer) grpc.ServerOption {
+	klog.InfoS("Setting authorization for endpoint", "service", serviceName)
+	return grpc.ChainUnaryInterceptor(AuthorizerUnaryServerInterceptor(authz))
+}
+
+type uidAllowlistAuthorizer struct {
+	uids sets.Set[int64]
+}
+
+// NewUIDAllowlistAuthorizer returns an Authorizer which allows only requests
+// from peers whose process runs with one of the given user IDs. It requires
+// the server to run with the PeerCredentials transport credentials; requests
+// carried over transports which do not report peer credentials are rejected.
+func NewUIDAllowlistAuthorizer(uids ...int64) Authorizer {
+	return &uidAllowlistAuthorizer{
+		uids: sets.New[int64](uids...),
+	}
+}
+
+func (a *uidAllowlistAuthorizer) Authorize(ctx context.Context, fullMethod string) error {
+	uid, ok := peerUID(ctx)
+	if !ok || !a.uids.Has(uid) {
+		return ErrorUnauthorized
+	}
+	return nil
+}
+
+// peerUID returns the user ID of the peer process, if the transport
+// credentials report it.
+func peerUID(ctx context.Context) (int64, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return -1, false
+	}
+	authInfo, ok := p.AuthInfo.(PeerCredAuthInfo)
+	if !ok || authInfo.UID < 0 {
+		return -1, false
+	}
+	return authInfo.UID, true
+}
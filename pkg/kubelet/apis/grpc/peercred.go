@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// PeerCredAuthInfo reports the identity of the connected peer, as resolved by
+// the PeerCredentials transport credentials.
+type PeerCredAuthInfo struct {
+	credentials.CommonAuthInfo
+	// UID is the user ID of the peer process, or -1 when the transport does
+	// not report peer credentials (e.g. non-unix sockets).
+	UID int64
+}
+
+// AuthType returns the type of this AuthInfo.
+func (PeerCredAuthInfo) AuthType() string {
+	return "peercred"
+}
+
+// PeerCredentials returns transport credentials which perform no handshake
+// and report the identity of the peer process connected over a unix socket,
+// to be consumed by the Authorizer implementations and by the per-peer rate
+// limiter. The connection itself stays as secure (or insecure) as it was.
+func PeerCredentials() credentials.TransportCredentials {
+	return peerCreds{}
+}
+
+type peerCreds struct{}
+
+func (peerCreds) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, newPeerCredAuthInfo(-1), nil
+}
+
+func (peerCreds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, newPeerCredAuthInfo(peerUIDFromConn(conn)), nil
+}
+
+func (peerCreds) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "peercred",
+	}
+}
+
+func (peerCreds) Clone() credentials.TransportCredentials {
+	return peerCreds{}
+}
+
+func (peerCreds) OverrideServerName(string) error {
+	return nil
+}
+
+func newPeerCredAuthInfo(uid int64) PeerCredAuthInfo {
+	return PeerCredAuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{
+			SecurityLevel: credentials.NoSecurity,
+		},
+		UID: uid,
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerUIDFromConn returns the user ID of the process connected on the other
+// end of the given unix socket connection, as reported by SO_PEERCRED, or -1
+// when the identity cannot be determined.
+func peerUIDFromConn(conn net.Conn) int64 {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return -1
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return -1
+	}
+	uid := int64(-1)
+	if err := raw.Control(func(fd uintptr) {
+		if ucred, err := unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED); err == nil {
+			uid = int64(ucred.Uid)
+		}
+	}); err != nil {
+		return -1
+	}
+	return uid
+}
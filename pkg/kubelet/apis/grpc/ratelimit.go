@@ -18,12 +18,15 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	gotimerate "golang.org/x/time/rate"
 	"k8s.io/klog/v2"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -48,10 +51,54 @@ func LimiterUnaryServerInterceptor(limiter Limiter) grpc.UnaryServerInterceptor
 	}
 }
 
+// PerPeerLimiterUnaryServerInterceptor returns a new unary server interceptor
+// that performs request rate limiting per calling peer, so a single
+// misbehaving caller cannot starve the other consumers of the service. Peers
+// are keyed by the user ID of their process when the transport reports it
+// (see PeerCredentials), falling back to the peer address. The consumers of
+// node-local services are few and long-lived, so the limiters are kept for
+// the lifetime of the server.
+func PerPeerLimiterUnaryServerInterceptor(qps, burstTokens int32) grpc.UnaryServerInterceptor {
+	var mutex sync.Mutex
+	limiters := make(map[string]Limiter)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := peerKey(ctx)
+		mutex.Lock()
+		limiter, ok := limiters[key]
+		if !ok {
+			limiter = gotimerate.NewLimiter(gotimerate.Limit(qps), int(burstTokens))
+			limiters[key] = limiter
+		}
+		mutex.Unlock()
+		if !limiter.Allow() {
+			return nil, ErrorLimitExceeded
+		}
+		return handler(ctx, req)
+	}
+}
+
 // WithRateLimiter creates new rate limiter with unary interceptor.
 func WithRateLimiter(serviceName string, qps, burstTokens int32) grpc.ServerOption {
 	qpsVal := gotimerate.Limit(qps)
 	burstVal := int(burstTokens)
 	klog.InfoS("Setting rate limiting for endpoint", "service", serviceName, "qps", qpsVal, "burstTokens", burstVal)
-	return grpc.UnaryInterceptor(LimiterUnaryServerInterceptor(gotimerate.NewLimiter(qpsVal, burstVal)))
+	return grpc.ChainUnaryInterceptor(LimiterUnaryServerInterceptor(gotimerate.NewLimiter(qpsVal, burstVal)))
+}
+
+// WithPerPeerRateLimiter creates new per-peer rate limiter with unary interceptor.
+func WithPerPeerRateLimiter(serviceName string, qps, burstTokens int32) grpc.ServerOption {
+	klog.InfoS("Setting per-peer rate limiting for endpoint", "service", serviceName, "qps", qps, "burstTokens", burstTokens)
+	return grpc.ChainUnaryInterceptor(PerPeerLimiterUnaryServerInterceptor(qps, burstTokens))
+}
+
+// peerKey returns the identity of the calling peer for rate limiting
+// purposes.
+func peerKey(ctx context.Context) string {
+	if uid, ok := peerUID(ctx); ok {
+		return fmt.Sprintf("uid:%d", uid)
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
 }
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package noderesourcetopology defines the format through which the kubelet
+// publishes the per-zone resource accounting of its resource managers, so
+// topology-aware consumers (most notably the scheduler) can predict whether
+// topology manager admission would succeed on a node without talking to it.
+package noderesourcetopology
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AnnotationKey is the node annotation under which the kubelet publishes its
+// NodeResourceTopology, JSON-encoded.
+const AnnotationKey = "kubelet.kubernetes.io/resource-topology"
+
+const (
+	// ZoneTypeNUMA is the type of the zones backed by a NUMA node.
+	ZoneTypeNUMA = "NUMA"
+	// ZoneTypeUncoreCache is the type of the zones backed by the CPUs
+	// sharing a last-level cache. They are nested inside a NUMA zone and
+	// carry only CPUs.
+	ZoneTypeUncoreCache = "UncoreCache"
+)
+
+const (
+	// PolicySingleNumaNode is the topology manager policy which restricts
+	// every admitted pod to a single NUMA node, and hence the only one whose
+	// admission outcome a consumer can predict from the zone accounting.
+	PolicySingleNumaNode = "single-numa-node"
+	// ScopeContainer is the topology manager scope aligning each container
+	// independently.
+	ScopeContainer = "container"
+	// ScopePod is the topology manager scope aligning the pod as a whole.
+	ScopePod = "pod"
+)
+
+// ResourceInfo is the accounting of a single resource inside a zone. The
+// cpu resource counts whole CPUs usable for exclusive allocation, memory and
+// hugepages count bytes, device plugin resources count devices.
+type ResourceInfo struct {
+	// Allocatable is the amount the zone offers to pods.
+	Allocatable int64 `json:"allocatable"`
+	// Available is the amount not yet exclusively allocated.
+	Available int64 `json:"available"`
+}
+
+// Zone is the resource accounting of a single topology zone.
+type Zone struct {
+	// Name identifies the zone within the node, e.g. "numa-0".
+	Name string `json:"name"`
+	// Type is one of the ZoneType constants.
+	Type string `json:"type"`
+	// Parent is the name of the enclosing zone, if any; uncore cache zones
+	// name their NUMA zone.
+	Parent string `json:"parent,omitempty"`
+	// Resources maps each resource name to its accounting in the zone.
+	Resources map[string]ResourceInfo `json:"resources,omitempty"`
+}
+
+// NodeResourceTopology is the topology manager configuration of a node
+// together with the per-zone accounting of its resource managers.
+type NodeResourceTopology struct {
+	// TopologyPolicy is the topology manager policy the node runs with.
+	TopologyPolicy string `json:"topologyPolicy"`
+	// TopologyScope is the topology manager scope the node runs with.
+	TopologyScope string `json:"topologyScope"`
+	// Zones is the list of the topology zones of the node.
+	Zones []Zone `json:"zones,omitempty"`
+}
+
+// ZoneName returns the canonical name of the zone of the given type backed by
+// the given id, e.g. "numa-0" or "uncorecache-3".
+func ZoneName(zoneType string, id int) string {
+	switch zoneType {
+	case ZoneTypeNUMA:
+		return fmt.Sprintf("numa-%d", id)
+	case ZoneTypeUncoreCache:
+		return fmt.Sprintf("uncorecache-%d", id)
+	}
+	return fmt.Sprintf("%s-%d", zoneType, id)
+}
+
+// Encode serializes the topology in the annotation format.
+func Encode(topology *NodeResourceTopology) (string, error) {
+	data, err := json.Marshal(topology)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the node resource topology: %w", err)
+	}
+	return string(data), nil
+}
+
+// Decode parses a topology from the annotation format.
+func Decode(value string) (*NodeResourceTopology, error) {
+	topology := &NodeResourceTopology{}
+	if err := json.Unmarshal([]byte(value), topology); err != nil {
+		return nil, fmt.Errorf("failed to decode the node resource topology: %w", err)
+	}
+	return topology, nil
+}
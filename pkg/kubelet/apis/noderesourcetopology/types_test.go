@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderesourcetopology
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestZoneName(t *testing.T) {
+	testCases := []struct {
+		zoneType string
+		id       int
+		expected string
+	}{
+		{ZoneTypeNUMA, 0, "numa-0"},
+		{ZoneTypeNUMA, 7, "numa-7"},
+		{ZoneTypeUncoreCache, 3, "uncorecache-3"},
+		{"Die", 1, "Die-1"},
+	}
+	for _, testCase := range testCases {
+		if name := ZoneName(testCase.zoneType, testCase.id); name != testCase.expected {
+			t.Errorf("ZoneName(%q, %d) = %q, expected %q", testCase.zoneType, testCase.id, name, testCase.expected)
+		}
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	topology := &NodeResourceTopology{
+		TopologyPolicy: PolicySingleNumaNode,
+		TopologyScope:  ScopeContainer,
+		Zones: []Zone{
+			{
+				Name: ZoneName(ZoneTypeNUMA, 0),
+				Type: ZoneTypeNUMA,
+				Resources: map[string]ResourceInfo{
+					"cpu":                    {Allocatable: 16, Available: 12},
+					"memory":                 {Allocatable: 32 * 1024 * 1024 * 1024, Available: 8 * 1024 * 1024 * 1024},
+					"vendor.example.com/gpu": {Allocatable: 2, Available: 1},
+				},
+			},
+			{
+				Name:   ZoneName(ZoneTypeUncoreCache, 1),
+				Type:   ZoneTypeUncoreCache,
+				Parent: ZoneName(ZoneTypeNUMA, 0),
+				Resources: map[string]ResourceInfo{
+					"cpu": {Allocatable: 8, Available: 4},
+				},
+			},
+		},
+	}
+
+	value, err := Encode(topology)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	decoded, err := Decode(value)
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if !reflect.DeepEqual(topology, decoded) {
+		t.Errorf("round trip changed the topology: expected %+v got %+v", topology, decoded)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	if _, err := Decode("not-json"); err == nil {
+		t.Errorf("expected a decode error, got none")
+	}
+}
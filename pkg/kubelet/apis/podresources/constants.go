@@ -29,4 +29,14 @@ const (
 	// DefaultBurstTokens is determined by empirically reviewing known consumers of the API.
 	// See the documentation of DefaultQPS, same caveats apply.
 	DefaultBurstTokens = 10
+
+	// DefaultPerPeerQPS bounds the load a single caller can impose, so a
+	// misbehaving node agent cannot starve the other consumers of the API.
+	// No single known consumer legitimately needs more than half of the
+	// total load the API accepts (see the documentation of DefaultQPS).
+	DefaultPerPeerQPS = 50
+
+	// DefaultPerPeerBurstTokens is determined like DefaultBurstTokens,
+	// considering a single caller. Same caveats apply.
+	DefaultPerPeerBurstTokens = 10
 )
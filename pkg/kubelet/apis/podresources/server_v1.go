@@ -19,8 +19,10 @@ package podresources
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	kubefeatures "k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
@@ -36,6 +38,7 @@ type v1PodResourcesServer struct {
 	cpusProvider             CPUsProvider
 	memoryProvider           MemoryProvider
 	dynamicResourcesProvider DynamicResourcesProvider
+	topologyProvider         TopologyAdmissionProvider
 }
 
 // NewV1PodResourcesServer returns a PodResourcesListerServer which lists pods provided by the PodsProvider
@@ -47,6 +50,7 @@ func NewV1PodResourcesServer(providers PodResourcesProviders) podresourcesv1.Pod
 		cpusProvider:             providers.Cpus,
 		memoryProvider:           providers.Memory,
 		dynamicResourcesProvider: providers.DynamicResources,
+		topologyProvider:         providers.TopologyAdmission,
 	}
 }
 
@@ -56,10 +60,15 @@ func (p *v1PodResourcesServer) List(ctx context.Context, req *podresourcesv1.Lis
 	metrics.PodResourcesEndpointRequestsListCount.WithLabelValues("v1").Inc()
 
 	pods := p.podsProvider.GetPods()
-	podResources := make([]*podresourcesv1.PodResources, len(pods))
+	podResources := make([]*podresourcesv1.PodResources, 0, len(pods))
 	p.devicesProvider.UpdateAllocatedDevices()
 
-	for i, pod := range pods {
+	filter := newListFilter(req)
+	for _, pod := range pods {
+		if !filter.matchesPod(pod) {
+			continue
+		}
+
 		pRes := podresourcesv1.PodResources{
 			Name:       pod.Name,
 			Namespace:  pod.Namespace,
@@ -74,14 +83,14 @@ func (p *v1PodResourcesServer) List(ctx context.Context, req *podresourcesv1.Lis
 					continue
 				}
 
-				pRes.Containers = append(pRes.Containers, p.getContainerResources(pod, &container))
+				pRes.Containers = append(pRes.Containers, filter.pruneContainerResources(p.getContainerResources(pod, &container)))
 			}
 		}
 
 		for _, container := range pod.Spec.Containers {
-			pRes.Containers = append(pRes.Containers, p.getContainerResources(pod, &container))
+			pRes.Containers = append(pRes.Containers, filter.pruneContainerResources(p.getContainerResources(pod, &container)))
 		}
-		podResources[i] = &pRes
+		podResources = append(podResources, &pRes)
 	}
 
 	response := &podresourcesv1.ListPodResourcesResponse{
@@ -96,9 +105,10 @@ func (p *v1PodResourcesServer) GetAllocatableResources(ctx context.Context, req
 	metrics.PodResourcesEndpointRequestsGetAllocatableCount.WithLabelValues("v1").Inc()
 
 	response := &podresourcesv1.AllocatableResourcesResponse{
-		Devices: p.devicesProvider.GetAllocatableDevices(),
-		CpuIds:  p.cpusProvider.GetAllocatableCPUs(),
-		Memory:  p.memoryProvider.GetAllocatableMemory(),
+		Devices:     p.devicesProvider.GetAllocatableDevices(),
+		CpuIds:      p.cpusProvider.GetAllocatableCPUs(),
+		Memory:      p.memoryProvider.GetAllocatableMemory(),
+		CpuTopology: p.cpusProvider.GetAllocatableCPUTopology(),
 	}
 
 	return response, nil
@@ -148,12 +158,78 @@ func (p *v1PodResourcesServer) Get(ctx context.Context, req *podresourcesv1.GetP
 	return response, nil
 }
 
+// listFilter holds the parsed filtering parameters of a List request.
+// The zero filter lets everything through, which keeps the empty request
+// behaving like it did before the parameters existed.
+type listFilter struct {
+	namespaces     sets.Set[string]
+	podNamePrefix  string
+	resourceNames  sets.Set[string]
+	includeDevices bool
+	includeCpus    bool
+	includeMemory  bool
+}
+
+func newListFilter(req *podresourcesv1.ListPodResourcesRequest) *listFilter {
+	filter := &listFilter{
+		podNamePrefix:  req.GetPodNamePrefix(),
+		includeDevices: req.GetIncludeDevices(),
+		includeCpus:    req.GetIncludeCpus(),
+		includeMemory:  req.GetIncludeMemory(),
+	}
+	// none requested means no restriction, for backward compatibility
+	if !filter.includeDevices && !filter.includeCpus && !filter.includeMemory {
+		filter.includeDevices = true
+		filter.includeCpus = true
+		filter.includeMemory = true
+	}
+	if namespaces := req.GetNamespaces(); len(namespaces) > 0 {
+		filter.namespaces = sets.New[string](namespaces...)
+	}
+	if resourceNames := req.GetResourceNames(); len(resourceNames) > 0 {
+		filter.resourceNames = sets.New[string](resourceNames...)
+	}
+	return filter
+}
+
+func (f *listFilter) matchesPod(pod *v1.Pod) bool {
+	if f.namespaces != nil && !f.namespaces.Has(pod.Namespace) {
+		return false
+	}
+	return strings.HasPrefix(pod.Name, f.podNamePrefix)
+}
+
+func (f *listFilter) pruneContainerResources(cRes *podresourcesv1.ContainerResources) *podresourcesv1.ContainerResources {
+	if !f.includeCpus {
+		cRes.CpuIds = nil
+		cRes.CpuTopology = nil
+	}
+	if !f.includeMemory {
+		cRes.Memory = nil
+	}
+	if !f.includeDevices {
+		cRes.Devices = nil
+		return cRes
+	}
+	if f.resourceNames != nil {
+		devices := make([]*podresourcesv1.ContainerDevices, 0, len(cRes.Devices))
+		for _, dev := range cRes.Devices {
+			if f.resourceNames.Has(dev.ResourceName) {
+				devices = append(devices, dev)
+			}
+		}
+		cRes.Devices = devices
+	}
+	return cRes
+}
+
 func (p *v1PodResourcesServer) getContainerResources(pod *v1.Pod, container *v1.Container) *podresourcesv1.ContainerResources {
 	containerResources := &podresourcesv1.ContainerResources{
-		Name:    container.Name,
-		Devices: p.devicesProvider.GetDevices(string(pod.UID), container.Name),
-		CpuIds:  p.cpusProvider.GetCPUs(string(pod.UID), container.Name),
-		Memory:  p.memoryProvider.GetMemory(string(pod.UID), container.Name),
+		Name:        container.Name,
+		Devices:     p.devicesProvider.GetDevices(string(pod.UID), container.Name),
+		CpuIds:      p.cpusProvider.GetCPUs(string(pod.UID), container.Name),
+		Memory:      p.memoryProvider.GetMemory(string(pod.UID), container.Name),
+		CpuTopology: p.cpusProvider.GetCPUTopology(string(pod.UID), container.Name),
 	}
 	if utilfeature.DefaultFeatureGate.Enabled(kubefeatures.KubeletPodResourcesDynamicResources) {
 		containerResources.DynamicResources = p.dynamicResourcesProvider.GetDynamicResources(pod, container)
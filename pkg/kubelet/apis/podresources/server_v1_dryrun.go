@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podresources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
+
+	podresourcesv1 "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+// DryRunAdmission reports the topology manager admission outcome for the pod
+// given in the request, as if it was admitted against the current resource
+// availability. No resources are allocated and no state is recorded: the
+// prediction holds until the availability changes, typically because another
+// pod is admitted or deleted.
+func (p *v1PodResourcesServer) DryRunAdmission(ctx context.Context, req *podresourcesv1.DryRunAdmissionRequest) (*podresourcesv1.DryRunAdmissionResponse, error) {
+	metrics.PodResourcesEndpointRequestsTotalCount.WithLabelValues("v1").Inc()
+	metrics.PodResourcesEndpointRequestsDryRunCount.WithLabelValues("v1").Inc()
+
+	pod := &v1.Pod{}
+	if err := json.Unmarshal(req.GetPod(), pod); err != nil {
+		return nil, fmt.Errorf("cannot decode pod: %w", err)
+	}
+
+	return p.topologyProvider.DryRunTopologyAdmission(pod), nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podresources
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	podresourcetest "k8s.io/kubernetes/pkg/kubelet/apis/podresources/testing"
+)
+
+func TestDryRunAdmissionV1(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-name",
+			Namespace: "pod-namespace",
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "container-name",
+				},
+			},
+		},
+	}
+	podBytes, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("cannot encode pod: %v", err)
+	}
+
+	expectedResponse := &podresourcesapi.DryRunAdmissionResponse{
+		Admit:          true,
+		TopologyPolicy: "single-numa-node",
+		TopologyScope:  "container",
+		Predictions: []*podresourcesapi.ContainerAdmissionPrediction{
+			{
+				ContainerName: "container-name",
+				NumaAffinity:  []int64{0},
+				Preferred:     true,
+				Admit:         true,
+			},
+		},
+	}
+
+	mockTopologyProvider := podresourcetest.NewMockTopologyAdmissionProvider(mockCtrl)
+	mockTopologyProvider.EXPECT().DryRunTopologyAdmission(gomock.Any()).Return(expectedResponse).Times(1)
+
+	providers := PodResourcesProviders{
+		TopologyAdmission: mockTopologyProvider,
+	}
+	server := NewV1PodResourcesServer(providers)
+
+	resp, err := server.DryRunAdmission(context.TODO(), &podresourcesapi.DryRunAdmissionRequest{Pod: podBytes})
+	if err != nil {
+		t.Fatalf("want err = %v, got %v", nil, err)
+	}
+	if !reflect.DeepEqual(resp, expectedResponse) {
+		t.Errorf("want response %v, got %v", expectedResponse, resp)
+	}
+}
+
+func TestDryRunAdmissionV1BadRequest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockTopologyProvider := podresourcetest.NewMockTopologyAdmissionProvider(mockCtrl)
+
+	providers := PodResourcesProviders{
+		TopologyAdmission: mockTopologyProvider,
+	}
+	server := NewV1PodResourcesServer(providers)
+
+	_, err := server.DryRunAdmission(context.TODO(), &podresourcesapi.DryRunAdmissionRequest{Pod: []byte("not a pod")})
+	if err == nil {
+		t.Errorf("want error, got nil")
+	}
+}
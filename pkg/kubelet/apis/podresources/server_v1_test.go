@@ -224,10 +224,12 @@ func TestListPodResourcesV1(t *testing.T) {
 			mockPodsProvider.EXPECT().GetPods().Return(tc.pods).AnyTimes()
 			mockDevicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(tc.devices).AnyTimes()
 			mockCPUsProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(tc.cpus).AnyTimes()
+			mockCPUsProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 			mockMemoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(tc.memory).AnyTimes()
 			mockDynamicResourcesProvider.EXPECT().GetDynamicResources(pods[0], &containers[0]).Return(tc.dynamicResources).AnyTimes()
 			mockDevicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 			mockCPUsProvider.EXPECT().GetAllocatableCPUs().Return([]int64{}).AnyTimes()
+			mockCPUsProvider.EXPECT().GetAllocatableCPUTopology().Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 			mockDevicesProvider.EXPECT().GetAllocatableDevices().Return([]*podresourcesapi.ContainerDevices{}).AnyTimes()
 			mockMemoryProvider.EXPECT().GetAllocatableMemory().Return([]*podresourcesapi.ContainerMemory{}).AnyTimes()
 
@@ -250,6 +252,172 @@ func TestListPodResourcesV1(t *testing.T) {
 	}
 }
 
+func TestListPodResourcesFilteringV1(t *testing.T) {
+	containerName := "container-name"
+	numaID := int64(1)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	devs := []*podresourcesapi.ContainerDevices{
+		{
+			ResourceName: "resource-a",
+			DeviceIds:    []string{"dev0"},
+			Topology:     &podresourcesapi.TopologyInfo{Nodes: []*podresourcesapi.NUMANode{{ID: numaID}}},
+		},
+		{
+			ResourceName: "resource-b",
+			DeviceIds:    []string{"dev1"},
+			Topology:     &podresourcesapi.TopologyInfo{Nodes: []*podresourcesapi.NUMANode{{ID: numaID}}},
+		},
+	}
+
+	cpus := []int64{12, 23, 30}
+
+	memory := []*podresourcesapi.ContainerMemory{
+		{
+			MemoryType: "memory",
+			Size_:      1073741824,
+			Topology:   &podresourcesapi.TopologyInfo{Nodes: []*podresourcesapi.NUMANode{{ID: numaID}}},
+		},
+	}
+
+	mkPod := func(namespace, name string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				UID:       types.UID(name + "-uid"),
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: containerName,
+					},
+				},
+			},
+		}
+	}
+	pods := []*v1.Pod{
+		mkPod("foo", "web-0"),
+		mkPod("bar", "db-0"),
+	}
+
+	fullContainer := &podresourcesapi.ContainerResources{
+		Name:    containerName,
+		Devices: devs,
+		CpuIds:  cpus,
+		Memory:  memory,
+	}
+
+	for _, tc := range []struct {
+		desc             string
+		request          *podresourcesapi.ListPodResourcesRequest
+		expectedResponse *podresourcesapi.ListPodResourcesResponse
+	}{
+		{
+			desc:    "namespace filter",
+			request: &podresourcesapi.ListPodResourcesRequest{Namespaces: []string{"foo"}},
+			expectedResponse: &podresourcesapi.ListPodResourcesResponse{
+				PodResources: []*podresourcesapi.PodResources{
+					{
+						Name:       "web-0",
+						Namespace:  "foo",
+						Containers: []*podresourcesapi.ContainerResources{fullContainer},
+					},
+				},
+			},
+		},
+		{
+			desc:    "pod name prefix filter",
+			request: &podresourcesapi.ListPodResourcesRequest{PodNamePrefix: "db"},
+			expectedResponse: &podresourcesapi.ListPodResourcesResponse{
+				PodResources: []*podresourcesapi.PodResources{
+					{
+						Name:       "db-0",
+						Namespace:  "bar",
+						Containers: []*podresourcesapi.ContainerResources{fullContainer},
+					},
+				},
+			},
+		},
+		{
+			desc:    "no pod matches both filters",
+			request: &podresourcesapi.ListPodResourcesRequest{Namespaces: []string{"foo"}, PodNamePrefix: "db"},
+			expectedResponse: &podresourcesapi.ListPodResourcesResponse{
+				PodResources: []*podresourcesapi.PodResources{},
+			},
+		},
+		{
+			desc:    "cpus only",
+			request: &podresourcesapi.ListPodResourcesRequest{Namespaces: []string{"foo"}, IncludeCpus: true},
+			expectedResponse: &podresourcesapi.ListPodResourcesResponse{
+				PodResources: []*podresourcesapi.PodResources{
+					{
+						Name:      "web-0",
+						Namespace: "foo",
+						Containers: []*podresourcesapi.ContainerResources{
+							{
+								Name:   containerName,
+								CpuIds: cpus,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:    "devices restricted by resource name",
+			request: &podresourcesapi.ListPodResourcesRequest{Namespaces: []string{"foo"}, ResourceNames: []string{"resource-a"}, IncludeDevices: true},
+			expectedResponse: &podresourcesapi.ListPodResourcesResponse{
+				PodResources: []*podresourcesapi.PodResources{
+					{
+						Name:      "web-0",
+						Namespace: "foo",
+						Containers: []*podresourcesapi.ContainerResources{
+							{
+								Name:    containerName,
+								Devices: devs[0:1],
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			mockDevicesProvider := podresourcetest.NewMockDevicesProvider(mockCtrl)
+			mockPodsProvider := podresourcetest.NewMockPodsProvider(mockCtrl)
+			mockCPUsProvider := podresourcetest.NewMockCPUsProvider(mockCtrl)
+			mockMemoryProvider := podresourcetest.NewMockMemoryProvider(mockCtrl)
+
+			mockPodsProvider.EXPECT().GetPods().Return(pods).AnyTimes()
+			for _, pod := range pods {
+				mockDevicesProvider.EXPECT().GetDevices(string(pod.UID), containerName).Return(devs).AnyTimes()
+				mockCPUsProvider.EXPECT().GetCPUs(string(pod.UID), containerName).Return(cpus).AnyTimes()
+				mockCPUsProvider.EXPECT().GetCPUTopology(string(pod.UID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
+				mockMemoryProvider.EXPECT().GetMemory(string(pod.UID), containerName).Return(memory).AnyTimes()
+			}
+			mockDevicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
+
+			providers := PodResourcesProviders{
+				Pods:    mockPodsProvider,
+				Devices: mockDevicesProvider,
+				Cpus:    mockCPUsProvider,
+				Memory:  mockMemoryProvider,
+			}
+			server := NewV1PodResourcesServer(providers)
+			resp, err := server.List(context.TODO(), tc.request)
+			if err != nil {
+				t.Errorf("want err = %v, got %q", nil, err)
+			}
+			if !equalListResponse(tc.expectedResponse, resp) {
+				t.Errorf("want resp = %s, got %s", tc.expectedResponse.String(), resp.String())
+			}
+		})
+	}
+}
+
 func TestListPodResourcesWithInitContainersV1(t *testing.T) {
 	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.KubeletPodResourcesDynamicResources, true)
 
@@ -330,6 +498,7 @@ func TestListPodResourcesWithInitContainersV1(t *testing.T) {
 				devicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pods[0], &pods[0].Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -380,6 +549,7 @@ func TestListPodResourcesWithInitContainersV1(t *testing.T) {
 				devicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pods[0], &pods[0].Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -433,6 +603,7 @@ func TestListPodResourcesWithInitContainersV1(t *testing.T) {
 
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pods[0], &pods[0].Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -485,11 +656,13 @@ func TestListPodResourcesWithInitContainersV1(t *testing.T) {
 
 				devicesProvider.EXPECT().GetDevices(string(podUID), initContainerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), initContainerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), initContainerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), initContainerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pods[0], &pods[0].Spec.InitContainers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pods[0], &pods[0].Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -615,6 +788,13 @@ func TestAllocatableResources(t *testing.T) {
 
 	allCPUs := []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
 
+	allCPUTopology := []*podresourcesapi.CPUTopologyInfo{
+		{CPUID: 0, SocketID: 0, UncoreCacheID: 0},
+		{CPUID: 1, SocketID: 0, UncoreCacheID: 0},
+		{CPUID: 2, SocketID: 0, UncoreCacheID: 1},
+		{CPUID: 3, SocketID: 1, UncoreCacheID: 2},
+	}
+
 	allMemory := []*podresourcesapi.ContainerMemory{
 		{
 			MemoryType: "memory",
@@ -665,6 +845,7 @@ func TestAllocatableResources(t *testing.T) {
 	for _, tc := range []struct {
 		desc                                 string
 		allCPUs                              []int64
+		allCPUTopology                       []*podresourcesapi.CPUTopologyInfo
 		allDevices                           []*podresourcesapi.ContainerDevices
 		allMemory                            []*podresourcesapi.ContainerMemory
 		expectedAllocatableResourcesResponse *podresourcesapi.AllocatableResourcesResponse
@@ -684,6 +865,16 @@ func TestAllocatableResources(t *testing.T) {
 				CpuIds: allCPUs,
 			},
 		},
+		{
+			desc:           "no devices, all CPUs with topology",
+			allCPUs:        allCPUs,
+			allCPUTopology: allCPUTopology,
+			allDevices:     []*podresourcesapi.ContainerDevices{},
+			expectedAllocatableResourcesResponse: &podresourcesapi.AllocatableResourcesResponse{
+				CpuIds:      allCPUs,
+				CpuTopology: allCPUTopology,
+			},
+		},
 		{
 			desc:       "no devices, no CPUs, all memory",
 			allCPUs:    []int64{},
@@ -822,10 +1013,12 @@ func TestAllocatableResources(t *testing.T) {
 
 			mockDevicesProvider.EXPECT().GetDevices("", "").Return([]*podresourcesapi.ContainerDevices{}).AnyTimes()
 			mockCPUsProvider.EXPECT().GetCPUs("", "").Return([]int64{}).AnyTimes()
+			mockCPUsProvider.EXPECT().GetCPUTopology("", "").Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 			mockMemoryProvider.EXPECT().GetMemory("", "").Return([]*podresourcesapi.ContainerMemory{}).AnyTimes()
 			mockDevicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 			mockDevicesProvider.EXPECT().GetAllocatableDevices().Return(tc.allDevices).AnyTimes()
 			mockCPUsProvider.EXPECT().GetAllocatableCPUs().Return(tc.allCPUs).AnyTimes()
+			mockCPUsProvider.EXPECT().GetAllocatableCPUTopology().Return(tc.allCPUTopology).AnyTimes()
 			mockMemoryProvider.EXPECT().GetAllocatableMemory().Return(tc.allMemory).AnyTimes()
 
 			providers := PodResourcesProviders{
@@ -993,10 +1186,12 @@ func TestGetPodResourcesV1(t *testing.T) {
 			mockPodsProvider.EXPECT().GetPodByName(podNamespace, podName).Return(tc.pod, tc.exist).AnyTimes()
 			mockDevicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(tc.devices).AnyTimes()
 			mockCPUsProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(tc.cpus).AnyTimes()
+			mockCPUsProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 			mockMemoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(tc.memory).AnyTimes()
 			mockDynamicResourcesProvider.EXPECT().GetDynamicResources(pod, &containers[0]).Return(tc.dynamicResources).AnyTimes()
 			mockDevicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 			mockCPUsProvider.EXPECT().GetAllocatableCPUs().Return([]int64{}).AnyTimes()
+			mockCPUsProvider.EXPECT().GetAllocatableCPUTopology().Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 			mockDevicesProvider.EXPECT().GetAllocatableDevices().Return([]*podresourcesapi.ContainerDevices{}).AnyTimes()
 			mockMemoryProvider.EXPECT().GetAllocatableMemory().Return([]*podresourcesapi.ContainerMemory{}).AnyTimes()
 
@@ -1108,6 +1303,7 @@ func TestGetPodResourcesWithInitContainersV1(t *testing.T) {
 				devicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pod, &pod.Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -1154,6 +1350,7 @@ func TestGetPodResourcesWithInitContainersV1(t *testing.T) {
 				devicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pod, &pod.Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -1203,6 +1400,7 @@ func TestGetPodResourcesWithInitContainersV1(t *testing.T) {
 
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pod, &pod.Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -1251,11 +1449,13 @@ func TestGetPodResourcesWithInitContainersV1(t *testing.T) {
 
 				devicesProvider.EXPECT().GetDevices(string(podUID), initContainerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), initContainerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), initContainerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), initContainerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pod, &pod.Spec.InitContainers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
 				devicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return(devs).AnyTimes()
 				cpusProvider.EXPECT().GetCPUs(string(podUID), containerName).Return(cpus).AnyTimes()
+				cpusProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
 				memoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return(memory).AnyTimes()
 				dynamicResourcesProvider.EXPECT().GetDynamicResources(pod, &pod.Spec.Containers[0]).Return([]*podresourcesapi.DynamicResource{}).AnyTimes()
 
@@ -1454,10 +1654,33 @@ func equalTopology(a, b *podresourcesapi.TopologyInfo) bool {
 	return reflect.DeepEqual(a, b)
 }
 
+func equalCPUTopology(topoA, topoB []*podresourcesapi.CPUTopologyInfo) bool {
+	if len(topoA) != len(topoB) {
+		return false
+	}
+	for idx := 0; idx < len(topoA); idx++ {
+		cpuA := topoA[idx]
+		cpuB := topoB[idx]
+		if cpuA.CPUID != cpuB.CPUID {
+			return false
+		}
+		if cpuA.SocketID != cpuB.SocketID {
+			return false
+		}
+		if cpuA.UncoreCacheID != cpuB.UncoreCacheID {
+			return false
+		}
+	}
+	return true
+}
+
 func equalAllocatableResourcesResponse(respA, respB *podresourcesapi.AllocatableResourcesResponse) bool {
 	if !equalInt64s(respA.CpuIds, respB.CpuIds) {
 		return false
 	}
+	if !equalCPUTopology(respA.CpuTopology, respB.CpuTopology) {
+		return false
+	}
 	return equalContainerDevices(respA.Devices, respB.Devices)
 }
 
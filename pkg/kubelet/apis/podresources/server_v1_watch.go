@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podresources
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubefeatures "k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
+	"k8s.io/kubernetes/pkg/kubelet/types"
+
+	podresourcesv1 "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+// watchPollInterval is how often the Watch endpoint refreshes its view of the
+// per-container assignments to detect changes. A variable to shorten it in tests.
+var watchPollInterval = 1 * time.Second
+
+// Watch streams the resource assignment of the pods on the node: first the
+// current assignment of every known pod, as ADDED events, then one event per
+// pod whose assignment changes, until the client goes away. The resource
+// managers keep their state behind their own locks and expose no change
+// notification, so the endpoint detects changes by polling them, trading a
+// little latency for staying out of the managers allocation paths.
+func (p *v1PodResourcesServer) Watch(req *podresourcesv1.WatchPodResourcesRequest, stream podresourcesv1.PodResourcesLister_WatchServer) error {
+	metrics.PodResourcesEndpointRequestsTotalCount.WithLabelValues("v1").Inc()
+	metrics.PodResourcesEndpointRequestsWatchCount.WithLabelValues("v1").Inc()
+
+	known := make(map[string]*podresourcesv1.PodResources)
+	if err := p.sendWatchEvents(stream, known); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+			if err := p.sendWatchEvents(stream, known); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendWatchEvents diffs the current assignments against the last observed
+// ones, streams one event per changed pod and updates known in place.
+func (p *v1PodResourcesServer) sendWatchEvents(stream podresourcesv1.PodResourcesLister_WatchServer, known map[string]*podresourcesv1.PodResources) error {
+	current := p.podResourcesSnapshot()
+
+	for key, pRes := range current {
+		action := podresourcesv1.WatchPodAction_ADDED
+		if prev, ok := known[key]; ok {
+			if podResourcesEqual(prev, pRes) {
+				continue
+			}
+			action = podresourcesv1.WatchPodAction_MODIFIED
+		}
+		if err := stream.Send(&podresourcesv1.WatchPodResourcesResponse{
+			Action:       action,
+			PodResources: pRes,
+		}); err != nil {
+			return err
+		}
+		known[key] = pRes
+	}
+
+	for key, pRes := range known {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		if err := stream.Send(&podresourcesv1.WatchPodResourcesResponse{
+			Action:       podresourcesv1.WatchPodAction_DELETED,
+			PodResources: pRes,
+		}); err != nil {
+			return err
+		}
+		delete(known, key)
+	}
+	return nil
+}
+
+// podResourcesSnapshot returns the resource assignment of every pod on the
+// node, keyed by namespace/name, like an unfiltered List call would report it.
+func (p *v1PodResourcesServer) podResourcesSnapshot() map[string]*podresourcesv1.PodResources {
+	pods := p.podsProvider.GetPods()
+	p.devicesProvider.UpdateAllocatedDevices()
+
+	snapshot := make(map[string]*podresourcesv1.PodResources, len(pods))
+	for _, pod := range pods {
+		pRes := &podresourcesv1.PodResources{
+			Name:       pod.Name,
+			Namespace:  pod.Namespace,
+			Containers: make([]*podresourcesv1.ContainerResources, 0, len(pod.Spec.Containers)),
+		}
+
+		if utilfeature.DefaultFeatureGate.Enabled(kubefeatures.SidecarContainers) {
+			for _, container := range pod.Spec.InitContainers {
+				if !types.IsRestartableInitContainer(&container) {
+					continue
+				}
+
+				pRes.Containers = append(pRes.Containers, normalizeContainerResources(p.getContainerResources(pod, &container)))
+			}
+		}
+
+		for _, container := range pod.Spec.Containers {
+			pRes.Containers = append(pRes.Containers, normalizeContainerResources(p.getContainerResources(pod, &container)))
+		}
+		snapshot[pod.Namespace+"/"+pod.Name] = pRes
+	}
+	return snapshot
+}
+
+// normalizeContainerResources sorts the slices the providers fill from sets
+// and maps, so that comparing consecutive snapshots does not report spurious
+// changes caused only by iteration order.
+func normalizeContainerResources(cRes *podresourcesv1.ContainerResources) *podresourcesv1.ContainerResources {
+	sort.Slice(cRes.CpuIds, func(i, j int) bool {
+		return cRes.CpuIds[i] < cRes.CpuIds[j]
+	})
+	sort.Slice(cRes.Devices, func(i, j int) bool {
+		devI, devJ := cRes.Devices[i], cRes.Devices[j]
+		if devI.ResourceName != devJ.ResourceName {
+			return devI.ResourceName < devJ.ResourceName
+		}
+		return strings.Join(devI.DeviceIds, ",") < strings.Join(devJ.DeviceIds, ",")
+	})
+	return cRes
+}
+
+func podResourcesEqual(resA, resB *podresourcesv1.PodResources) bool {
+	return resA.String() == resB.String()
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podresources
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	podresourcetest "k8s.io/kubernetes/pkg/kubelet/apis/podresources/testing"
+)
+
+// fakeWatchStream is the minimal PodResourcesLister_WatchServer the Watch
+// endpoint needs: a context to honor cancellation and a Send collecting the
+// streamed events.
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *podresourcesapi.WatchPodResourcesResponse
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(resp *podresourcesapi.WatchPodResourcesResponse) error {
+	s.events <- resp
+	return nil
+}
+
+func TestWatchPodResourcesV1(t *testing.T) {
+	oldPollInterval := watchPollInterval
+	watchPollInterval = 10 * time.Millisecond
+	defer func() {
+		watchPollInterval = oldPollInterval
+	}()
+
+	podName := "pod-name"
+	podNamespace := "pod-namespace"
+	podUID := types.UID("pod-uid")
+	containerName := "container-name"
+
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: podNamespace,
+				UID:       podUID,
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: containerName,
+					},
+				},
+			},
+		},
+	}
+
+	// the test drives the watched state through three stages:
+	// 0 - one pod with cpus 0,1 (initial ADDED event)
+	// 1 - the same pod with cpus 0,1,2,3 (MODIFIED event)
+	// 2 - no pods left (DELETED event)
+	var stage atomic.Int64
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDevicesProvider := podresourcetest.NewMockDevicesProvider(mockCtrl)
+	mockPodsProvider := podresourcetest.NewMockPodsProvider(mockCtrl)
+	mockCPUsProvider := podresourcetest.NewMockCPUsProvider(mockCtrl)
+	mockMemoryProvider := podresourcetest.NewMockMemoryProvider(mockCtrl)
+
+	mockPodsProvider.EXPECT().GetPods().DoAndReturn(func() []*v1.Pod {
+		if stage.Load() >= 2 {
+			return []*v1.Pod{}
+		}
+		return pods
+	}).AnyTimes()
+	mockCPUsProvider.EXPECT().GetCPUs(string(podUID), containerName).DoAndReturn(func(_, _ string) []int64 {
+		if stage.Load() >= 1 {
+			return []int64{0, 1, 2, 3}
+		}
+		return []int64{0, 1}
+	}).AnyTimes()
+	mockDevicesProvider.EXPECT().UpdateAllocatedDevices().Return().AnyTimes()
+	mockDevicesProvider.EXPECT().GetDevices(string(podUID), containerName).Return([]*podresourcesapi.ContainerDevices{}).AnyTimes()
+	mockCPUsProvider.EXPECT().GetCPUTopology(string(podUID), containerName).Return([]*podresourcesapi.CPUTopologyInfo{}).AnyTimes()
+	mockMemoryProvider.EXPECT().GetMemory(string(podUID), containerName).Return([]*podresourcesapi.ContainerMemory{}).AnyTimes()
+
+	providers := PodResourcesProviders{
+		Pods:    mockPodsProvider,
+		Devices: mockDevicesProvider,
+		Cpus:    mockCPUsProvider,
+		Memory:  mockMemoryProvider,
+	}
+	server := NewV1PodResourcesServer(providers)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &fakeWatchStream{
+		ctx:    ctx,
+		events: make(chan *podresourcesapi.WatchPodResourcesResponse, 16),
+	}
+
+	watchDone := make(chan error, 1)
+	go func() {
+		watchDone <- server.Watch(&podresourcesapi.WatchPodResourcesRequest{}, stream)
+	}()
+
+	recvEvent := func(desc string) *podresourcesapi.WatchPodResourcesResponse {
+		select {
+		case event := <-stream.events:
+			return event
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for the %s event", desc)
+			return nil
+		}
+	}
+
+	event := recvEvent("initial ADDED")
+	if event.Action != podresourcesapi.WatchPodAction_ADDED {
+		t.Errorf("want action = %v, got %v", podresourcesapi.WatchPodAction_ADDED, event.Action)
+	}
+	if event.PodResources.Name != podName || event.PodResources.Namespace != podNamespace {
+		t.Errorf("want pod %s/%s, got %s/%s", podNamespace, podName, event.PodResources.Namespace, event.PodResources.Name)
+	}
+	if !equalInt64s(event.PodResources.Containers[0].CpuIds, []int64{0, 1}) {
+		t.Errorf("want cpuIds = [0 1], got %v", event.PodResources.Containers[0].CpuIds)
+	}
+
+	stage.Store(1)
+	event = recvEvent("MODIFIED")
+	if event.Action != podresourcesapi.WatchPodAction_MODIFIED {
+		t.Errorf("want action = %v, got %v", podresourcesapi.WatchPodAction_MODIFIED, event.Action)
+	}
+	if !equalInt64s(event.PodResources.Containers[0].CpuIds, []int64{0, 1, 2, 3}) {
+		t.Errorf("want cpuIds = [0 1 2 3], got %v", event.PodResources.Containers[0].CpuIds)
+	}
+
+	stage.Store(2)
+	event = recvEvent("DELETED")
+	if event.Action != podresourcesapi.WatchPodAction_DELETED {
+		t.Errorf("want action = %v, got %v", podresourcesapi.WatchPodAction_DELETED, event.Action)
+	}
+	if event.PodResources.Name != podName {
+		t.Errorf("want pod name = %s, got %s", podName, event.PodResources.Name)
+	}
+
+	cancel()
+	select {
+	case err := <-watchDone:
+		if err != nil {
+			t.Errorf("want err = %v, got %q", nil, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Watch did not return after the stream context was canceled")
+	}
+}
@@ -171,6 +171,20 @@ func (m *MockCPUsProvider) EXPECT() *MockCPUsProviderMockRecorder {
 	return m.recorder
 }
 
+// GetAllocatableCPUTopology mocks base method.
+func (m *MockCPUsProvider) GetAllocatableCPUTopology() []*v10.CPUTopologyInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllocatableCPUTopology")
+	ret0, _ := ret[0].([]*v10.CPUTopologyInfo)
+	return ret0
+}
+
+// GetAllocatableCPUTopology indicates an expected call of GetAllocatableCPUTopology.
+func (mr *MockCPUsProviderMockRecorder) GetAllocatableCPUTopology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllocatableCPUTopology", reflect.TypeOf((*MockCPUsProvider)(nil).GetAllocatableCPUTopology))
+}
+
 // GetAllocatableCPUs mocks base method.
 func (m *MockCPUsProvider) GetAllocatableCPUs() []int64 {
 	m.ctrl.T.Helper()
@@ -185,6 +199,20 @@ func (mr *MockCPUsProviderMockRecorder) GetAllocatableCPUs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllocatableCPUs", reflect.TypeOf((*MockCPUsProvider)(nil).GetAllocatableCPUs))
 }
 
+// GetCPUTopology mocks base method.
+func (m *MockCPUsProvider) GetCPUTopology(podUID, containerName string) []*v10.CPUTopologyInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCPUTopology", podUID, containerName)
+	ret0, _ := ret[0].([]*v10.CPUTopologyInfo)
+	return ret0
+}
+
+// GetCPUTopology indicates an expected call of GetCPUTopology.
+func (mr *MockCPUsProviderMockRecorder) GetCPUTopology(podUID, containerName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCPUTopology", reflect.TypeOf((*MockCPUsProvider)(nil).GetCPUTopology), podUID, containerName)
+}
+
 // GetCPUs mocks base method.
 func (m *MockCPUsProvider) GetCPUs(podUID, containerName string) []int64 {
 	m.ctrl.T.Helper()
@@ -286,3 +314,40 @@ func (mr *MockDynamicResourcesProviderMockRecorder) GetDynamicResources(pod, con
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDynamicResources", reflect.TypeOf((*MockDynamicResourcesProvider)(nil).GetDynamicResources), pod, container)
 }
+
+// MockTopologyAdmissionProvider is a mock of TopologyAdmissionProvider interface.
+type MockTopologyAdmissionProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopologyAdmissionProviderMockRecorder
+}
+
+// MockTopologyAdmissionProviderMockRecorder is the mock recorder for MockTopologyAdmissionProvider.
+type MockTopologyAdmissionProviderMockRecorder struct {
+	mock *MockTopologyAdmissionProvider
+}
+
+// NewMockTopologyAdmissionProvider creates a new mock instance.
+func NewMockTopologyAdmissionProvider(ctrl *gomock.Controller) *MockTopologyAdmissionProvider {
+	mock := &MockTopologyAdmissionProvider{ctrl: ctrl}
+	mock.recorder = &MockTopologyAdmissionProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopologyAdmissionProvider) EXPECT() *MockTopologyAdmissionProviderMockRecorder {
+	return m.recorder
+}
+
+// DryRunTopologyAdmission mocks base method.
+func (m *MockTopologyAdmissionProvider) DryRunTopologyAdmission(pod *v1.Pod) *v10.DryRunAdmissionResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunTopologyAdmission", pod)
+	ret0, _ := ret[0].(*v10.DryRunAdmissionResponse)
+	return ret0
+}
+
+// DryRunTopologyAdmission indicates an expected call of DryRunTopologyAdmission.
+func (mr *MockTopologyAdmissionProviderMockRecorder) DryRunTopologyAdmission(pod any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunTopologyAdmission", reflect.TypeOf((*MockTopologyAdmissionProvider)(nil).DryRunTopologyAdmission), pod)
+}
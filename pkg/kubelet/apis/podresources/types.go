@@ -44,6 +44,11 @@ type CPUsProvider interface {
 	GetCPUs(podUID, containerName string) []int64
 	// GetAllocatableCPUs returns the allocatable (not allocated) CPUs
 	GetAllocatableCPUs() []int64
+	// GetCPUTopology returns the hardware placement of the cpus exclusively
+	// assigned to the given container
+	GetCPUTopology(podUID, containerName string) []*podresourcesapi.CPUTopologyInfo
+	// GetAllocatableCPUTopology returns the hardware placement of the allocatable CPUs
+	GetAllocatableCPUTopology() []*podresourcesapi.CPUTopologyInfo
 }
 
 type MemoryProvider interface {
@@ -58,10 +63,19 @@ type DynamicResourcesProvider interface {
 	GetDynamicResources(pod *v1.Pod, container *v1.Container) []*podresourcesapi.DynamicResource
 }
 
+// TopologyAdmissionProvider predicts the topology manager admission outcome
+// for a pod, without allocating resources or recording state
+type TopologyAdmissionProvider interface {
+	// DryRunTopologyAdmission returns the hint merge outcome the topology
+	// manager would produce if the given pod was admitted now
+	DryRunTopologyAdmission(pod *v1.Pod) *podresourcesapi.DryRunAdmissionResponse
+}
+
 type PodResourcesProviders struct {
-	Pods             PodsProvider
-	Devices          DevicesProvider
-	Cpus             CPUsProvider
-	Memory           MemoryProvider
-	DynamicResources DynamicResourcesProvider
+	Pods              PodsProvider
+	Devices           DevicesProvider
+	Cpus              CPUsProvider
+	Memory            MemoryProvider
+	DynamicResources  DynamicResourcesProvider
+	TopologyAdmission TopologyAdmissionProvider
 }
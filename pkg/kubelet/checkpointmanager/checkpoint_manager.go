@@ -17,7 +17,10 @@ limitations under the License.
 package checkpointmanager
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"sync"
 
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/errors"
@@ -25,6 +28,18 @@ import (
 	utilfs "k8s.io/kubernetes/pkg/util/filesystem"
 )
 
+// DefaultCompressionThreshold is the payload size, in bytes, above which a
+// checkpoint manager created with compression enabled gzips the marshalled
+// checkpoint before persisting it. Checkpoints are JSON documents, so on
+// dense nodes with per-container metadata they compress very well; smaller
+// payloads are stored verbatim since the gzip framing is not worth it.
+const DefaultCompressionThreshold = 1024 * 1024
+
+// gzipMagic is the fixed two-byte header every gzip stream starts with.
+// A marshalled checkpoint is JSON and can never start with these bytes,
+// so they reliably tell compressed payloads apart from plain ones.
+var gzipMagic = []byte{0x1f, 0x8b}
+
 // Checkpoint provides the process checkpoint data
 type Checkpoint interface {
 	MarshalCheckpoint() ([]byte, error)
@@ -50,6 +65,16 @@ type impl struct {
 	path  string
 	store utilstore.Store
 	mutex sync.Mutex
+	// compressionThreshold is the marshalled payload size, in bytes, at or
+	// above which checkpoints are gzipped before being persisted. A zero
+	// value disables compression on write; compressed checkpoints are always
+	// recognized and decompressed on read regardless of this setting.
+	compressionThreshold int
+	// encrypter, when set, seals every checkpoint payload before it is
+	// persisted and unseals encrypted payloads on read. A nil encrypter
+	// writes checkpoints in the clear; reading an encrypted checkpoint then
+	// fails, since there is no key to unseal it with.
+	encrypter Encrypter
 }
 
 // NewCheckpointManager returns a new instance of a checkpoint manager
@@ -62,6 +87,34 @@ func NewCheckpointManager(checkpointDir string) (CheckpointManager, error) {
 	return &impl{path: checkpointDir, store: fstore}, nil
 }
 
+// NewCheckpointManagerWithCompression returns a new instance of a checkpoint
+// manager which gzips checkpoints whose marshalled payload is at least
+// compressionThreshold bytes. Checkpoints written by a manager without
+// compression are read back transparently, and vice versa.
+func NewCheckpointManagerWithCompression(checkpointDir string, compressionThreshold int) (CheckpointManager, error) {
+	fstore, err := utilstore.NewFileStore(checkpointDir, &utilfs.DefaultFs{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &impl{path: checkpointDir, store: fstore, compressionThreshold: compressionThreshold}, nil
+}
+
+// NewCheckpointManagerWithEncryption returns a new instance of a checkpoint
+// manager which seals every checkpoint with the given encrypter before
+// persisting it, for the environments which do not tolerate workload
+// placement metadata stored in cleartext on disk. Checkpoints written in the
+// clear are still read back transparently, so enabling encryption on a node
+// with existing checkpoints is safe.
+func NewCheckpointManagerWithEncryption(checkpointDir string, encrypter Encrypter) (CheckpointManager, error) {
+	fstore, err := utilstore.NewFileStore(checkpointDir, &utilfs.DefaultFs{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &impl{path: checkpointDir, store: fstore, encrypter: encrypter}, nil
+}
+
 // CreateCheckpoint persists checkpoint in CheckpointStore.
 func (manager *impl) CreateCheckpoint(checkpointKey string, checkpoint Checkpoint) error {
 	manager.mutex.Lock()
@@ -70,6 +123,19 @@ func (manager *impl) CreateCheckpoint(checkpointKey string, checkpoint Checkpoin
 	if err != nil {
 		return err
 	}
+	if manager.compressionThreshold > 0 && len(blob) >= manager.compressionThreshold {
+		blob, err = compressCheckpoint(blob)
+		if err != nil {
+			return err
+		}
+	}
+	if manager.encrypter != nil {
+		blob, err = manager.encrypter.Encrypt(blob)
+		if err != nil {
+			return err
+		}
+		blob = append(append([]byte{}, encryptedMagic...), blob...)
+	}
 	return manager.store.Write(checkpointKey, blob)
 }
 
@@ -84,6 +150,21 @@ func (manager *impl) GetCheckpoint(checkpointKey string, checkpoint Checkpoint)
 		}
 		return err
 	}
+	if bytes.HasPrefix(blob, encryptedMagic) {
+		if manager.encrypter == nil {
+			return fmt.Errorf("checkpoint %q is encrypted and no encryption key is configured", checkpointKey)
+		}
+		blob, err = manager.encrypter.Decrypt(blob[len(encryptedMagic):])
+		if err != nil {
+			return err
+		}
+	}
+	if bytes.HasPrefix(blob, gzipMagic) {
+		blob, err = decompressCheckpoint(blob)
+		if err != nil {
+			return err
+		}
+	}
 	err = checkpoint.UnmarshalCheckpoint(blob)
 	if err == nil {
 		err = checkpoint.VerifyChecksum()
@@ -91,6 +172,27 @@ func (manager *impl) GetCheckpoint(checkpointKey string, checkpoint Checkpoint)
 	return err
 }
 
+func compressCheckpoint(blob []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(blob); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressCheckpoint(blob []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(blob))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
 // RemoveCheckpoint will not return error if checkpoint does not exist.
 func (manager *impl) RemoveCheckpoint(checkpointKey string) error {
 	manager.mutex.Lock()
@@ -17,6 +17,7 @@ limitations under the License.
 package checkpointmanager
 
 import (
+	"bytes"
 	"encoding/json"
 	"sort"
 	"testing"
@@ -248,3 +249,116 @@ func TestCheckpointManager(t *testing.T) {
 	err = manager.GetCheckpoint("key1", checkpointNE)
 	assert.Error(t, err)
 }
+
+func TestCheckpointManagerCompression(t *testing.T) {
+	testStore = utilstore.NewMemStore()
+	plainManager := newTestCheckpointManager()
+	compressingManager := &impl{store: testStore, compressionThreshold: 1}
+
+	port80 := int32(80)
+	proto := protocol("tcp")
+	portMappings := []*PortMapping{
+		{
+			&proto,
+			&port80,
+			&port80,
+			"1.2.3.4",
+		},
+	}
+
+	// Any checkpoint written by the compressing manager must be stored as a
+	// gzip stream, and must be readable back through a manager which knows
+	// nothing about compression.
+	err := compressingManager.CreateCheckpoint("compressed", newFakeCheckpointV1("check1", portMappings, true))
+	assert.NoError(t, err)
+	blob, err := testStore.Read("compressed")
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(blob, gzipMagic))
+
+	for _, manager := range []CheckpointManager{plainManager, compressingManager} {
+		checkpointOut := newFakeCheckpointV1("", nil, false)
+		err = manager.GetCheckpoint("compressed", checkpointOut)
+		assert.NoError(t, err)
+		actualPortMappings, actualHostNetwork := checkpointOut.GetData()
+		assert.Equal(t, actualPortMappings, portMappings)
+		assert.True(t, actualHostNetwork)
+	}
+
+	// Payloads below the threshold must be stored verbatim.
+	belowThresholdManager := &impl{store: testStore, compressionThreshold: 1024 * 1024}
+	err = belowThresholdManager.CreateCheckpoint("plain", newFakeCheckpointV1("check2", portMappings, false))
+	assert.NoError(t, err)
+	blob, err = testStore.Read("plain")
+	assert.NoError(t, err)
+	assert.False(t, bytes.HasPrefix(blob, gzipMagic))
+
+	// Plain checkpoints must be readable through a compressing manager.
+	checkpointOut := newFakeCheckpointV1("", nil, false)
+	err = compressingManager.GetCheckpoint("plain", checkpointOut)
+	assert.NoError(t, err)
+
+	// Corrupted compressed payloads must surface a read error.
+	err = testStore.Write("truncated", append([]byte{}, gzipMagic...))
+	assert.NoError(t, err)
+	err = compressingManager.GetCheckpoint("truncated", newFakeCheckpointV1("", nil, false))
+	assert.Error(t, err)
+}
+
+func TestCheckpointManagerEncryption(t *testing.T) {
+	testStore = utilstore.NewMemStore()
+	plainManager := newTestCheckpointManager()
+	encrypter, err := NewAESGCMEncrypter(bytes.Repeat([]byte{0x42}, 32))
+	assert.NoError(t, err)
+	encryptingManager := &impl{store: testStore, encrypter: encrypter}
+
+	port80 := int32(80)
+	proto := protocol("tcp")
+	portMappings := []*PortMapping{
+		{
+			&proto,
+			&port80,
+			&port80,
+			"1.2.3.4",
+		},
+	}
+
+	// Any checkpoint written by the encrypting manager must be stored sealed,
+	// and must round-trip through the same manager.
+	err = encryptingManager.CreateCheckpoint("encrypted", newFakeCheckpointV1("check1", portMappings, true))
+	assert.NoError(t, err)
+	blob, err := testStore.Read("encrypted")
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(blob, encryptedMagic))
+	assert.NotContains(t, string(blob), "1.2.3.4")
+
+	checkpointOut := newFakeCheckpointV1("", nil, false)
+	err = encryptingManager.GetCheckpoint("encrypted", checkpointOut)
+	assert.NoError(t, err)
+	actualPortMappings, actualHostNetwork := checkpointOut.GetData()
+	assert.Equal(t, actualPortMappings, portMappings)
+	assert.True(t, actualHostNetwork)
+
+	// A manager without the key must refuse to read the sealed checkpoint.
+	err = plainManager.GetCheckpoint("encrypted", newFakeCheckpointV1("", nil, false))
+	assert.Error(t, err)
+
+	// So must a manager holding a different key.
+	otherEncrypter, err := NewAESGCMEncrypter(bytes.Repeat([]byte{0x24}, 32))
+	assert.NoError(t, err)
+	otherKeyManager := &impl{store: testStore, encrypter: otherEncrypter}
+	err = otherKeyManager.GetCheckpoint("encrypted", newFakeCheckpointV1("", nil, false))
+	assert.Error(t, err)
+
+	// Checkpoints written in the clear must be readable through an encrypting
+	// manager, so enabling encryption on existing nodes is safe.
+	err = plainManager.CreateCheckpoint("plain", newFakeCheckpointV1("check2", portMappings, false))
+	assert.NoError(t, err)
+	err = encryptingManager.GetCheckpoint("plain", newFakeCheckpointV1("", nil, false))
+	assert.NoError(t, err)
+
+	// Truncated sealed payloads must surface a read error.
+	err = testStore.Write("truncated", append([]byte{}, encryptedMagic...))
+	assert.NoError(t, err)
+	err = encryptingManager.GetCheckpoint("truncated", newFakeCheckpointV1("", nil, false))
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpointmanager
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESGCMEncrypterRoundTrip(t *testing.T) {
+	for _, keySize := range []int{16, 24, 32} {
+		encrypter, err := NewAESGCMEncrypter(bytes.Repeat([]byte{0x42}, keySize))
+		assert.NoError(t, err)
+
+		plaintext := []byte(`{"checksum":1234,"entries":{}}`)
+		ciphertext, err := encrypter.Encrypt(plaintext)
+		assert.NoError(t, err)
+		assert.NotEqual(t, plaintext, ciphertext)
+
+		decrypted, err := encrypter.Decrypt(ciphertext)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+
+		// Sealing the same payload twice must not reuse the nonce.
+		again, err := encrypter.Encrypt(plaintext)
+		assert.NoError(t, err)
+		assert.NotEqual(t, ciphertext, again)
+
+		// Tampered payloads must fail to unseal.
+		tampered := append([]byte{}, ciphertext...)
+		tampered[len(tampered)-1] ^= 0xff
+		_, err = encrypter.Decrypt(tampered)
+		assert.Error(t, err)
+
+		// Truncated payloads must fail to unseal, not panic.
+		_, err = encrypter.Decrypt(ciphertext[:4])
+		assert.Error(t, err)
+	}
+
+	_, err := NewAESGCMEncrypter([]byte("short key"))
+	assert.Error(t, err)
+}
+
+func TestNewAESGCMEncrypterFromKeyFile(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "checkpoint.key")
+
+	_, err := NewAESGCMEncrypterFromKeyFile(keyPath)
+	assert.Error(t, err)
+
+	err = os.WriteFile(keyPath, bytes.Repeat([]byte{0x42}, 32), 0o600)
+	assert.NoError(t, err)
+
+	encrypter, err := NewAESGCMEncrypterFromKeyFile(keyPath)
+	assert.NoError(t, err)
+
+	plaintext := []byte("payload")
+	ciphertext, err := encrypter.Encrypt(plaintext)
+	assert.NoError(t, err)
+	decrypted, err := encrypter.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+}
@@ -25,6 +25,10 @@ import (
 
 const (
 	ErrorReasonUnexpected = "UnexpectedAdmissionError"
+	// ErrorReasonMaintenance is the admission failure reason used while the
+	// resource managers are in maintenance mode and refuse new exclusive
+	// allocations. Existing assignments are preserved.
+	ErrorReasonMaintenance = "ResourceManagersInMaintenance"
 )
 
 type Error interface {
@@ -32,6 +36,16 @@ type Error interface {
 	Type() string
 }
 
+// RemediableError is an admission Error which can additionally suggest an
+// actionable remediation, assembled from the structured data the error
+// carries rather than from free text in each resource manager.
+type RemediableError interface {
+	Error
+	// RemediationHint returns a short actionable suggestion helping to
+	// resolve the failure, or an empty string when none applies.
+	RemediationHint() string
+}
+
 type unexpectedAdmissionError struct{ Err error }
 
 var _ Error = (*unexpectedAdmissionError)(nil)
@@ -54,8 +68,16 @@ func GetPodAdmitResult(err error) lifecycle.PodAdmitResult {
 		admissionErr = &unexpectedAdmissionError{err}
 	}
 
+	message := admissionErr.Error()
+	var remediableErr RemediableError
+	if errors.As(err, &remediableErr) {
+		if hint := remediableErr.RemediationHint(); hint != "" {
+			message = fmt.Sprintf("%s; %s", message, hint)
+		}
+	}
+
 	return lifecycle.PodAdmitResult{
-		Message: admissionErr.Error(),
+		Message: message,
 		Reason:  admissionErr.Type(),
 		Admit:   false,
 	}
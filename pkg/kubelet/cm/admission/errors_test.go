@@ -34,6 +34,15 @@ func (e *TestAdmissionError) Type() string {
 	return e.reason
 }
 
+type TestRemediableAdmissionError struct {
+	TestAdmissionError
+	hint string
+}
+
+func (e *TestRemediableAdmissionError) RemediationHint() string {
+	return e.hint
+}
+
 func TestAdmissionErrors(t *testing.T) {
 	testCases := []struct {
 		Error                  error
@@ -85,3 +94,22 @@ func TestAdmissionErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestAdmissionErrorRemediationHint(t *testing.T) {
+	h := GetPodAdmitResult(&TestRemediableAdmissionError{
+		TestAdmissionError{"allocation failed", "TestAdmissionError"},
+		"reduce the request",
+	})
+	if expected := "allocation failed; reduce the request"; h.Message != expected {
+		t.Errorf("expected PodAdmitResult.Message = %q, got %q", expected, h.Message)
+	}
+
+	// An empty hint must leave the message untouched.
+	h = GetPodAdmitResult(&TestRemediableAdmissionError{
+		TestAdmissionError{"allocation failed", "TestAdmissionError"},
+		"",
+	})
+	if expected := "allocation failed"; h.Message != expected {
+		t.Errorf("expected PodAdmitResult.Message = %q, got %q", expected, h.Message)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"k8s.io/kubernetes/pkg/kubelet/events"
+)
+
+// ContainerAlignment describes the aligned resources a single container was
+// granted during pod admission, collected from the resource managers after
+// the allocation succeeded.
+type ContainerAlignment struct {
+	// Name is the container name.
+	Name string
+	// NUMANodes is the list of NUMA nodes the topology manager aligned the
+	// container resources to. Empty when no affinity was set.
+	NUMANodes []int
+	// CPUs is the set of CPUs exclusively allocated to the container, in
+	// cpuset list format. Empty when the container got no exclusive CPUs.
+	CPUs string
+	// MemoryNodes is the list of NUMA nodes the container memory was
+	// allocated from. Empty when the memory manager pinned no memory.
+	MemoryNodes []int
+	// Devices maps each resource name to the IDs of the devices assigned
+	// to the container from that resource.
+	Devices map[string][]string
+}
+
+// IsEmpty returns true when the container received no aligned resources,
+// hence there is no decision worth reporting for it.
+func (a ContainerAlignment) IsEmpty() bool {
+	return len(a.NUMANodes) == 0 && len(a.CPUs) == 0 && len(a.MemoryNodes) == 0 && len(a.Devices) == 0
+}
+
+// alignmentMessage renders the alignment decisions for the containers of a
+// pod into a single event message, skipping the containers which received no
+// aligned resources. Returns the empty string when no container did.
+func alignmentMessage(containers []ContainerAlignment) string {
+	var parts []string
+	for _, container := range containers {
+		if container.IsEmpty() {
+			continue
+		}
+		var fields []string
+		if len(container.NUMANodes) > 0 {
+			fields = append(fields, "numaNodes="+formatNodeList(container.NUMANodes))
+		}
+		if container.CPUs != "" {
+			fields = append(fields, "cpus="+container.CPUs)
+		}
+		if len(container.MemoryNodes) > 0 {
+			fields = append(fields, "memoryNodes="+formatNodeList(container.MemoryNodes))
+		}
+		resources := make([]string, 0, len(container.Devices))
+		for resource := range container.Devices {
+			resources = append(resources, resource)
+		}
+		sort.Strings(resources)
+		for _, resource := range resources {
+			fields = append(fields, fmt.Sprintf("%s=%s", resource, strings.Join(container.Devices[resource], ",")))
+		}
+		parts = append(parts, fmt.Sprintf("container %s: %s", container.Name, strings.Join(fields, " ")))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Resources aligned: " + strings.Join(parts, "; ")
+}
+
+func formatNodeList(nodes []int) string {
+	items := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		items = append(items, strconv.Itoa(node))
+	}
+	return strings.Join(items, ",")
+}
+
+// RecordResourcesAligned records an event on the given pod summarizing the
+// alignment decisions made for its containers during admission. No event is
+// recorded when no container received aligned resources, so pods which never
+// went through topology-aware allocation produce no noise.
+func RecordResourcesAligned(recorder record.EventRecorder, pod *v1.Pod, containers []ContainerAlignment) {
+	message := alignmentMessage(containers)
+	if message == "" {
+		return
+	}
+	recorder.Event(pod, v1.EventTypeNormal, events.ResourcesAligned, message)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestAlignmentMessage(t *testing.T) {
+	testCases := []struct {
+		name       string
+		containers []ContainerAlignment
+		expected   string
+	}{
+		{
+			name:       "no containers",
+			containers: nil,
+			expected:   "",
+		},
+		{
+			name: "containers without aligned resources",
+			containers: []ContainerAlignment{
+				{Name: "cnt-1"},
+				{Name: "cnt-2"},
+			},
+			expected: "",
+		},
+		{
+			name: "all the resource kinds",
+			containers: []ContainerAlignment{
+				{
+					Name:        "cnt-1",
+					NUMANodes:   []int{0, 1},
+					CPUs:        "0-3",
+					MemoryNodes: []int{0},
+					Devices: map[string][]string{
+						"vendor.example.com/gpu": {"gpu-0", "gpu-1"},
+						"vendor.example.com/nic": {"nic-3"},
+					},
+				},
+			},
+			expected: "Resources aligned: container cnt-1: numaNodes=0,1 cpus=0-3 memoryNodes=0 vendor.example.com/gpu=gpu-0,gpu-1 vendor.example.com/nic=nic-3",
+		},
+		{
+			name: "only the containers with aligned resources are reported",
+			containers: []ContainerAlignment{
+				{Name: "cnt-1"},
+				{Name: "cnt-2", NUMANodes: []int{1}, CPUs: "8-11"},
+			},
+			expected: "Resources aligned: container cnt-2: numaNodes=1 cpus=8-11",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := alignmentMessage(tc.containers)
+			if message != tc.expected {
+				t.Errorf("Expected message %q, got %q", tc.expected, message)
+			}
+		})
+	}
+}
+
+func TestRecordResourcesAligned(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "test-ns",
+			UID:       "test-uid",
+		},
+	}
+
+	recorder := record.NewFakeRecorder(8)
+	RecordResourcesAligned(recorder, pod, []ContainerAlignment{{Name: "cnt-1"}})
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("Expected no event for a pod without aligned resources, got %q", event)
+	default:
+	}
+
+	RecordResourcesAligned(recorder, pod, []ContainerAlignment{{Name: "cnt-1", CPUs: "0-3"}})
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, "ResourcesAligned") || !strings.Contains(event, "cpus=0-3") {
+			t.Errorf("Unexpected event recorded: %q", event)
+		}
+	default:
+		t.Errorf("Expected an event for a pod with aligned resources, got none")
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+
+	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
+)
+
+// SortPodsByAdmissionOrder sorts the given pods, in place, in the order in
+// which they should go through admission: static pods first, then mirror
+// pods, then everything else, each group ordered by ascending creation time.
+// The resource managers hand out exclusive resources at admission time, so
+// at kubelet startup admitting static pods first guarantees they get their
+// checkpointed assignments back before any regular pod can claim them.
+func SortPodsByAdmissionOrder(pods []*v1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		rankI, rankJ := admissionOrderRank(pods[i]), admissionOrderRank(pods[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+	})
+}
+
+func admissionOrderRank(pod *v1.Pod) int {
+	switch {
+	case kubetypes.IsStaticPod(pod):
+		return 0
+	case kubetypes.IsMirrorPod(pod):
+		return 1
+	default:
+		return 2
+	}
+}
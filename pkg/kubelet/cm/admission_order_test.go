@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
+)
+
+func TestSortPodsByAdmissionOrder(t *testing.T) {
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mkPod := func(name string, createdOffset time.Duration, annotations map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Annotations:       annotations,
+				CreationTimestamp: metav1.NewTime(baseTime.Add(createdOffset)),
+			},
+		}
+	}
+	staticAnnotations := map[string]string{
+		kubetypes.ConfigSourceAnnotationKey: kubetypes.FileSource,
+	}
+	mirrorAnnotations := map[string]string{
+		kubetypes.ConfigMirrorAnnotationKey: "mirror",
+	}
+
+	testCases := []struct {
+		description   string
+		pods          []*v1.Pod
+		expectedOrder []string
+	}{
+		{
+			description:   "no pods",
+			pods:          []*v1.Pod{},
+			expectedOrder: []string{},
+		},
+		{
+			description: "regular pods keep creation time order",
+			pods: []*v1.Pod{
+				mkPod("young", 2*time.Minute, nil),
+				mkPod("old", 1*time.Minute, nil),
+			},
+			expectedOrder: []string{"old", "young"},
+		},
+		{
+			description: "static pods first, then mirror pods, then the rest",
+			pods: []*v1.Pod{
+				mkPod("regular-young", 4*time.Minute, nil),
+				mkPod("mirror", 3*time.Minute, mirrorAnnotations),
+				mkPod("static-young", 2*time.Minute, staticAnnotations),
+				mkPod("regular-old", 1*time.Minute, nil),
+				mkPod("static-old", 1*time.Minute, staticAnnotations),
+			},
+			expectedOrder: []string{"static-old", "static-young", "mirror", "regular-old", "regular-young"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			SortPodsByAdmissionOrder(tc.pods)
+
+			order := make([]string, 0, len(tc.pods))
+			for _, pod := range tc.pods {
+				order = append(order, pod.Name)
+			}
+			if !reflect.DeepEqual(order, tc.expectedOrder) {
+				t.Errorf("expected admission order %v, got %v", tc.expectedOrder, order)
+			}
+		})
+	}
+}
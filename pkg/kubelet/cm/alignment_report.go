@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// alignmentReportDirName is the name of the directory under the kubelet
+	// root directory where the per-container alignment reports are stored.
+	alignmentReportDirName = "alignment"
+	// alignmentReportContainerPath is the path at which the alignment report
+	// is mounted inside the container filesystem.
+	alignmentReportContainerPath = "/run/kubelet/alignment.json"
+)
+
+// AlignmentReport describes the exclusive resources assigned to a single
+// container, so workloads can self-configure (e.g. size their thread pools)
+// by reading a file from their own filesystem instead of talking to the
+// podresources API endpoint.
+type AlignmentReport struct {
+	// CPUs is the set of CPUs exclusively allocated to the container,
+	// in cpuset list format (e.g. "0-3,8-11"). Empty if the container
+	// got no exclusive CPUs.
+	CPUs string `json:"cpus,omitempty"`
+	// NUMANodes is the list of NUMA nodes the container resources are
+	// aligned to, as computed by the topology manager. Empty if no
+	// affinity was set.
+	NUMANodes []int `json:"numaNodes,omitempty"`
+	// Devices maps each resource name to the IDs of the devices assigned
+	// to the container from that resource.
+	Devices map[string][]string `json:"devices,omitempty"`
+}
+
+// IsEmpty returns true if the report carries no exclusively allocated
+// resources, hence there is nothing worth exposing to the container.
+// The NUMA affinity alone is intentionally not considered: without
+// exclusive CPUs or devices there is nothing for the workload to act upon.
+func (r *AlignmentReport) IsEmpty() bool {
+	return len(r.CPUs) == 0 && len(r.Devices) == 0
+}
+
+// writeAlignmentReport atomically writes the given report for the given
+// container in the alignment report directory under kubeletRootDir, and
+// returns the host path of the written file.
+func writeAlignmentReport(kubeletRootDir, podUID, containerName string, report *AlignmentReport) (string, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alignment report: %w", err)
+	}
+
+	dir := filepath.Join(kubeletRootDir, alignmentReportDirName, podUID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create alignment report directory: %w", err)
+	}
+
+	path := filepath.Join(dir, containerName+".json")
+	tmpFile, err := os.CreateTemp(dir, containerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary alignment report file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return "", fmt.Errorf("failed to write alignment report: %w", err)
+	}
+	if err := tmpFile.Chmod(0644); err != nil {
+		tmpFile.Close()
+		return "", fmt.Errorf("failed to set alignment report permissions: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", fmt.Errorf("failed to close alignment report file: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to publish alignment report: %w", err)
+	}
+	return path, nil
+}
+
+// removeStaleAlignmentReports deletes the per-pod alignment report
+// directories left behind by pods which are no longer active, the same way
+// the resource managers purge their checkpointed assignments. Without this
+// the report directory would grow without bound over the node lifetime.
+// Failures are only logged: a leftover directory is harmless until the next
+// pass.
+func removeStaleAlignmentReports(kubeletRootDir string, activePods []*v1.Pod) {
+	reportsDir := filepath.Join(kubeletRootDir, alignmentReportDirName)
+	entries, err := os.ReadDir(reportsDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.ErrorS(err, "Failed to list the alignment report directory", "path", reportsDir)
+		}
+		return
+	}
+
+	activeUIDs := make(map[string]bool, len(activePods))
+	for _, pod := range activePods {
+		activeUIDs[string(pod.UID)] = true
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || activeUIDs[entry.Name()] {
+			continue
+		}
+		path := filepath.Join(reportsDir, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			klog.ErrorS(err, "Failed to remove stale alignment reports", "path", path, "podUID", entry.Name())
+			continue
+		}
+		klog.V(4).InfoS("Removed stale alignment reports", "path", path, "podUID", entry.Name())
+	}
+}
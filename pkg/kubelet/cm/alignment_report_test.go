@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAlignmentReportIsEmpty(t *testing.T) {
+	testCases := []struct {
+		description string
+		report      AlignmentReport
+		expected    bool
+	}{
+		{
+			description: "zero report",
+			report:      AlignmentReport{},
+			expected:    true,
+		},
+		{
+			description: "NUMA affinity alone is not worth reporting",
+			report:      AlignmentReport{NUMANodes: []int{0, 1}},
+			expected:    true,
+		},
+		{
+			description: "exclusive cpus",
+			report:      AlignmentReport{CPUs: "0-3"},
+			expected:    false,
+		},
+		{
+			description: "devices",
+			report:      AlignmentReport{Devices: map[string][]string{"vendor.com/dev": {"dev-1"}}},
+			expected:    false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			if got := testCase.report.IsEmpty(); got != testCase.expected {
+				t.Errorf("expected IsEmpty()=%v, got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteAlignmentReport(t *testing.T) {
+	rootDir := t.TempDir()
+	report := &AlignmentReport{
+		CPUs:      "0-3,8-11",
+		NUMANodes: []int{0},
+		Devices: map[string][]string{
+			"vendor.com/dev": {"dev-1", "dev-2"},
+		},
+	}
+
+	path, err := writeAlignmentReport(rootDir, "fakePodUID", "fakeContainer", report)
+	if err != nil {
+		t.Fatalf("failed to write the alignment report: %v", err)
+	}
+	expectedPath := filepath.Join(rootDir, alignmentReportDirName, "fakePodUID", "fakeContainer.json")
+	if path != expectedPath {
+		t.Errorf("expected report path %q, got %q", expectedPath, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read back the alignment report: %v", err)
+	}
+	got := &AlignmentReport{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("failed to unmarshal the alignment report: %v", err)
+	}
+	if !reflect.DeepEqual(report, got) {
+		t.Errorf("expected report %+v, got %+v", report, got)
+	}
+
+	// overwriting an existing report must succeed (container restart).
+	if _, err := writeAlignmentReport(rootDir, "fakePodUID", "fakeContainer", report); err != nil {
+		t.Errorf("failed to overwrite the alignment report: %v", err)
+	}
+}
+
+func TestRemoveStaleAlignmentReports(t *testing.T) {
+	rootDir := t.TempDir()
+	report := &AlignmentReport{CPUs: "0-3"}
+	for _, podUID := range []string{"activePod", "stalePod"} {
+		if _, err := writeAlignmentReport(rootDir, podUID, "fakeContainer", report); err != nil {
+			t.Fatalf("failed to write the alignment report: %v", err)
+		}
+	}
+
+	activePods := []*v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{UID: "activePod"}},
+	}
+	removeStaleAlignmentReports(rootDir, activePods)
+
+	reportsDir := filepath.Join(rootDir, alignmentReportDirName)
+	if _, err := os.Stat(filepath.Join(reportsDir, "activePod", "fakeContainer.json")); err != nil {
+		t.Errorf("expected the reports of the active pod to survive: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(reportsDir, "stalePod")); !os.IsNotExist(err) {
+		t.Errorf("expected the reports of the stale pod to be removed, got %v", err)
+	}
+
+	// a missing report directory (feature just enabled) must be tolerated.
+	removeStaleAlignmentReports(t.TempDir(), activePods)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"fmt"
+	"strings"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+)
+
+// ValidateResourceManagersConfig dry-runs the construction of the CPU,
+// memory and topology managers against the supplied machine info and node
+// configuration, without creating a container manager nor persisting any
+// state. It returns a human-readable report of the computed reserved sets,
+// shared pool and alignment settings, or the first configuration error the
+// managers would hit at kubelet startup.
+func ValidateResourceManagersConfig(machineInfo *cadvisorapi.MachineInfo, nodeConfig NodeConfig) (string, error) {
+	capacity := cadvisor.CapacityFromMachineInfo(machineInfo)
+	reservation := nodeAllocatableReservationFromConfig(nodeConfig, capacity)
+
+	topo, err := topology.Discover(machineInfo)
+	if err != nil {
+		return "", fmt.Errorf("invalid machine topology: %w", err)
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "machine: numaNodes=%d sockets=%d uncoreCaches=%d cores=%d cpus=%d smtEnabled=%v\n",
+		topo.CPUDetails.NUMANodes().Size(), topo.CPUDetails.Sockets().Size(), topo.CPUDetails.UncoreCaches().Size(),
+		topo.NumCores, topo.NumCPUs, topo.CPUsPerCore() > 1)
+
+	reserved, err := cpumanager.ValidateConfig(nodeConfig.CPUManagerPolicy, nodeConfig.CPUManagerPolicyOptions, machineInfo, nodeConfig.NodeAllocatableConfig.ReservedSystemCPUs, reservation)
+	if err != nil {
+		return "", fmt.Errorf("invalid CPU manager configuration: %w", err)
+	}
+	sharedPool := topo.CPUDetails.CPUs().Difference(reserved)
+	fmt.Fprintf(&report, "cpumanager: policy=%q options=%v\n", nodeConfig.CPUManagerPolicy, nodeConfig.CPUManagerPolicyOptions)
+	fmt.Fprintf(&report, "cpumanager: reservedCPUs=%q sharedPoolCPUs=%q\n", reserved, sharedPool)
+
+	if _, err := memorymanager.NewManager(nodeConfig.ExperimentalMemoryManagerPolicy, nodeConfig.MemoryManagerReconcilePeriod, machineInfo, reservation, nodeConfig.ExperimentalMemoryManagerReservedMemory, "", topologymanager.NewFakeManager()); err != nil {
+		return "", fmt.Errorf("invalid memory manager configuration: %w", err)
+	}
+	fmt.Fprintf(&report, "memorymanager: policy=%q reservedMemory=%v\n", nodeConfig.ExperimentalMemoryManagerPolicy, nodeConfig.ExperimentalMemoryManagerReservedMemory)
+
+	if _, err := topologymanager.NewManager(machineInfo.Topology, nodeConfig.TopologyManagerPolicy, nodeConfig.TopologyManagerScope, nodeConfig.TopologyManagerPolicyOptions, ""); err != nil {
+		return "", fmt.Errorf("invalid topology manager configuration: %w", err)
+	}
+	fmt.Fprintf(&report, "topologymanager: policy=%q scope=%q options=%v\n", nodeConfig.TopologyManagerPolicy, nodeConfig.TopologyManagerScope, nodeConfig.TopologyManagerPolicyOptions)
+
+	return report.String(), nil
+}
+
+// nodeAllocatableReservationFromConfig computes the node allocatable
+// reservation the same way GetNodeAllocatableReservation does, from a bare
+// NodeConfig instead of a constructed container manager.
+func nodeAllocatableReservationFromConfig(nodeConfig NodeConfig, capacity v1.ResourceList) v1.ResourceList {
+	evictionReservation := hardEvictionReservation(nodeConfig.HardEvictionThresholds, capacity)
+	result := make(v1.ResourceList)
+	for k := range capacity {
+		value := resource.NewQuantity(0, resource.DecimalSI)
+		if nodeConfig.SystemReserved != nil {
+			value.Add(nodeConfig.SystemReserved[k])
+		}
+		if nodeConfig.KubeReserved != nil {
+			value.Add(nodeConfig.KubeReserved[k])
+		}
+		if evictionReservation != nil {
+			value.Add(evictionReservation[k])
+		}
+		if !value.IsZero() {
+			result[k] = *value
+		}
+	}
+	return result
+}
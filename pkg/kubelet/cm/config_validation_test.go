@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"strings"
+	"testing"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func validationTestMachineInfo() *cadvisorapi.MachineInfo {
+	return &cadvisorapi.MachineInfo{
+		NumCores:       4,
+		MemoryCapacity: 8 * 1024 * 1024 * 1024,
+		Topology: []cadvisorapi.Node{
+			{
+				Id:     0,
+				Memory: 8 * 1024 * 1024 * 1024,
+				Cores: []cadvisorapi.Core{
+					{SocketID: 0, Id: 0, Threads: []int{0, 2}},
+					{SocketID: 0, Id: 1, Threads: []int{1, 3}},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateResourceManagersConfig(t *testing.T) {
+	testCases := []struct {
+		description     string
+		nodeConfig      NodeConfig
+		expectedErr     bool
+		expectedReports []string
+	}{
+		{
+			description: "default policies",
+			nodeConfig: NodeConfig{
+				CPUManagerPolicy:                "none",
+				ExperimentalMemoryManagerPolicy: "None",
+				TopologyManagerPolicy:           "none",
+				TopologyManagerScope:            "container",
+			},
+			expectedReports: []string{
+				`cpumanager: policy="none"`,
+				`reservedCPUs="" sharedPoolCPUs="0-3"`,
+				`memorymanager: policy="None"`,
+				`topologymanager: policy="none" scope="container"`,
+			},
+		},
+		{
+			description: "static CPU manager policy with reservation",
+			nodeConfig: NodeConfig{
+				CPUManagerPolicy: "static",
+				NodeAllocatableConfig: NodeAllocatableConfig{
+					SystemReserved: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("1"),
+					},
+				},
+				ExperimentalMemoryManagerPolicy: "None",
+				TopologyManagerPolicy:           "best-effort",
+				TopologyManagerScope:            "pod",
+			},
+			expectedReports: []string{
+				`cpumanager: policy="static"`,
+				`reservedCPUs="0" sharedPoolCPUs="1-3"`,
+				`topologymanager: policy="best-effort" scope="pod"`,
+			},
+		},
+		{
+			description: "static CPU manager policy without reservation",
+			nodeConfig: NodeConfig{
+				CPUManagerPolicy:                "static",
+				ExperimentalMemoryManagerPolicy: "None",
+				TopologyManagerPolicy:           "none",
+				TopologyManagerScope:            "container",
+			},
+			expectedErr: true,
+		},
+		{
+			description: "unknown topology manager policy",
+			nodeConfig: NodeConfig{
+				CPUManagerPolicy:                "none",
+				ExperimentalMemoryManagerPolicy: "None",
+				TopologyManagerPolicy:           "bogus",
+				TopologyManagerScope:            "container",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			report, err := ValidateResourceManagersConfig(validationTestMachineInfo(), tc.nodeConfig)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected validation error, got report:\n%s", report)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+			for _, expected := range tc.expectedReports {
+				if !strings.Contains(report, expected) {
+					t.Errorf("expected report to contain %q, got:\n%s", expected, report)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
+	"k8s.io/utils/cpuset"
+)
+
+// exclusiveCPUsProvider is the subset of the CPU manager the consistency check
+// needs: the exclusively allocated CPUs of a container and their placement.
+type exclusiveCPUsProvider interface {
+	GetExclusiveCPUs(podUID, containerName string) cpuset.CPUSet
+	GetExclusiveCPUDetails(podUID, containerName string) topology.CPUDetails
+}
+
+// allocatedDevicesProvider is the subset of the device manager the consistency
+// check needs: the devices allocated to a container.
+type allocatedDevicesProvider interface {
+	GetDevices(podUID, containerName string) devicemanager.ResourceDeviceInstances
+}
+
+// checkResourceManagersConsistency cross-checks the state the resource
+// managers recovered from their checkpoints. For every container with
+// exclusive CPUs it verifies that a topology affinity is recorded, that the
+// exclusive CPUs sit inside that affinity, and that every allocated device
+// which reports topology information is attached to one of the affinity NUMA
+// nodes. A crash or an unclean restart can leave the managers silently
+// disagreeing about the placement of a running container; discrepancies are
+// logged and reported through the
+// kubelet_container_manager_startup_inconsistencies_total metric, but are not
+// fatal: the managers keep honoring their own checkpoints either way.
+// Returns the number of discrepancies found.
+func checkResourceManagersConsistency(pods []*v1.Pod, cpus exclusiveCPUsProvider, devices allocatedDevicesProvider, affinities topologymanager.Store) int {
+	inconsistencies := 0
+	for _, pod := range pods {
+		for _, container := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			exclusiveCPUs := cpus.GetExclusiveCPUs(string(pod.UID), container.Name)
+			if exclusiveCPUs.IsEmpty() {
+				continue
+			}
+
+			hint := affinities.GetAffinity(string(pod.UID), container.Name)
+			if hint.NUMANodeAffinity == nil {
+				klog.ErrorS(nil, "Container has exclusive CPUs but no topology affinity recorded", "pod", klog.KObj(pod), "containerName", container.Name, "exclusiveCPUs", exclusiveCPUs)
+				inconsistencies++
+				continue
+			}
+
+			cpuNUMANodes := cpus.GetExclusiveCPUDetails(string(pod.UID), container.Name).NUMANodes()
+			for _, numaNode := range cpuNUMANodes.List() {
+				if !hint.NUMANodeAffinity.IsSet(numaNode) {
+					klog.ErrorS(nil, "Container has exclusive CPUs outside its recorded topology affinity", "pod", klog.KObj(pod), "containerName", container.Name, "exclusiveCPUs", exclusiveCPUs, "numaNode", numaNode, "affinity", hint.NUMANodeAffinity)
+					inconsistencies++
+				}
+			}
+
+			for resourceName, resourceDevs := range devices.GetDevices(string(pod.UID), container.Name) {
+				for devID, dev := range resourceDevs {
+					topo := dev.GetTopology()
+					if topo == nil || len(topo.GetNodes()) == 0 {
+						// Some device plugins do not report topology information. This is
+						// legal, and there is nothing to cross-check in this case.
+						continue
+					}
+					aligned := false
+					for _, node := range topo.GetNodes() {
+						if hint.NUMANodeAffinity.IsSet(int(node.GetID())) {
+							aligned = true
+							break
+						}
+					}
+					if !aligned {
+						klog.ErrorS(nil, "Container has an allocated device outside its recorded topology affinity", "pod", klog.KObj(pod), "containerName", container.Name, "resourceName", resourceName, "deviceID", devID, "affinity", hint.NUMANodeAffinity)
+						inconsistencies++
+					}
+				}
+			}
+		}
+	}
+
+	if inconsistencies > 0 {
+		metrics.ContainerManagerStartupInconsistenciesTotal.Add(float64(inconsistencies))
+	}
+	return inconsistencies
+}
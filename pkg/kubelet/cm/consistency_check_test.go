@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+	"k8s.io/utils/cpuset"
+)
+
+type fakeExclusiveCPUsProvider struct {
+	details topology.CPUDetails
+	cpus    map[string]cpuset.CPUSet
+}
+
+func (f *fakeExclusiveCPUsProvider) GetExclusiveCPUs(podUID, containerName string) cpuset.CPUSet {
+	return f.cpus[podUID+"/"+containerName]
+}
+
+func (f *fakeExclusiveCPUsProvider) GetExclusiveCPUDetails(podUID, containerName string) topology.CPUDetails {
+	return f.details.KeepOnly(f.GetExclusiveCPUs(podUID, containerName))
+}
+
+type fakeAllocatedDevicesProvider struct {
+	devices map[string]devicemanager.ResourceDeviceInstances
+}
+
+func (f *fakeAllocatedDevicesProvider) GetDevices(podUID, containerName string) devicemanager.ResourceDeviceInstances {
+	return f.devices[podUID+"/"+containerName]
+}
+
+func TestCheckResourceManagersConsistency(t *testing.T) {
+	// Two NUMA nodes, four CPUs each.
+	details := topology.CPUDetails{
+		0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+		1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		2: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
+		3: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+		4: {CoreID: 4, SocketID: 1, NUMANodeID: 1},
+		5: {CoreID: 5, SocketID: 1, NUMANodeID: 1},
+		6: {CoreID: 6, SocketID: 1, NUMANodeID: 1},
+		7: {CoreID: 7, SocketID: 1, NUMANodeID: 1},
+	}
+
+	makeNUMADevice := func(id string, numaNode int64) pluginapi.Device {
+		return pluginapi.Device{
+			ID:       id,
+			Health:   pluginapi.Healthy,
+			Topology: &pluginapi.TopologyInfo{Nodes: []*pluginapi.NUMANode{{ID: numaNode}}},
+		}
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fakePod",
+			UID:       types.UID("fakePodUID"),
+			Namespace: "test",
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "fakeContainer"},
+			},
+		},
+	}
+
+	mask := func(bits ...int) bitmask.BitMask {
+		m, err := bitmask.NewBitMask(bits...)
+		if err != nil {
+			t.Fatalf("failed to create bitmask from %v: %v", bits, err)
+		}
+		return m
+	}
+
+	testCases := []struct {
+		description             string
+		exclusiveCPUs           cpuset.CPUSet
+		devices                 devicemanager.ResourceDeviceInstances
+		hint                    *topologymanager.TopologyHint
+		expectedInconsistencies int
+	}{
+		{
+			description:             "no exclusive CPUs, nothing to check",
+			exclusiveCPUs:           cpuset.New(),
+			expectedInconsistencies: 0,
+		},
+		{
+			description:   "exclusive CPUs, devices and affinity all agree",
+			exclusiveCPUs: cpuset.New(0, 1),
+			devices: devicemanager.ResourceDeviceInstances{
+				"vendor.com/device": devicemanager.DeviceInstances{
+					"dev0": makeNUMADevice("dev0", 0),
+				},
+			},
+			hint:                    &topologymanager.TopologyHint{NUMANodeAffinity: mask(0), Preferred: true},
+			expectedInconsistencies: 0,
+		},
+		{
+			description:             "exclusive CPUs but no affinity recorded",
+			exclusiveCPUs:           cpuset.New(0, 1),
+			expectedInconsistencies: 1,
+		},
+		{
+			description:             "exclusive CPU outside the recorded affinity",
+			exclusiveCPUs:           cpuset.New(0, 4),
+			hint:                    &topologymanager.TopologyHint{NUMANodeAffinity: mask(0), Preferred: true},
+			expectedInconsistencies: 1,
+		},
+		{
+			description:   "allocated device outside the recorded affinity",
+			exclusiveCPUs: cpuset.New(0, 1),
+			devices: devicemanager.ResourceDeviceInstances{
+				"vendor.com/device": devicemanager.DeviceInstances{
+					"dev0": makeNUMADevice("dev0", 1),
+				},
+			},
+			hint:                    &topologymanager.TopologyHint{NUMANodeAffinity: mask(0), Preferred: true},
+			expectedInconsistencies: 1,
+		},
+		{
+			description:   "device without topology information is not flagged",
+			exclusiveCPUs: cpuset.New(0, 1),
+			devices: devicemanager.ResourceDeviceInstances{
+				"vendor.com/device": devicemanager.DeviceInstances{
+					"dev0": {ID: "dev0", Health: pluginapi.Healthy},
+				},
+			},
+			hint:                    &topologymanager.TopologyHint{NUMANodeAffinity: mask(0), Preferred: true},
+			expectedInconsistencies: 0,
+		},
+		{
+			description:   "both CPUs and devices misplaced",
+			exclusiveCPUs: cpuset.New(4, 5),
+			devices: devicemanager.ResourceDeviceInstances{
+				"vendor.com/device": devicemanager.DeviceInstances{
+					"dev0": makeNUMADevice("dev0", 1),
+				},
+			},
+			hint:                    &topologymanager.TopologyHint{NUMANodeAffinity: mask(0), Preferred: true},
+			expectedInconsistencies: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			key := string(pod.UID) + "/" + pod.Spec.Containers[0].Name
+			cpus := &fakeExclusiveCPUsProvider{
+				details: details,
+				cpus:    map[string]cpuset.CPUSet{key: tc.exclusiveCPUs},
+			}
+			devs := &fakeAllocatedDevicesProvider{
+				devices: map[string]devicemanager.ResourceDeviceInstances{key: tc.devices},
+			}
+			affinities := topologymanager.NewFakeManager()
+			if tc.hint != nil {
+				affinities = topologymanager.NewFakeManagerWithHint(tc.hint)
+			}
+
+			inconsistencies := checkResourceManagersConsistency([]*v1.Pod{pod}, cpus, devs, affinities)
+			if inconsistencies != tc.expectedInconsistencies {
+				t.Errorf("expected %d inconsistencies, got %d", tc.expectedInconsistencies, inconsistencies)
+			}
+		})
+	}
+}
@@ -30,7 +30,9 @@ import (
 	internalapi "k8s.io/cri-api/pkg/apis"
 	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
 	kubeletconfig "k8s.io/kubernetes/pkg/kubelet/apis/config"
+	"k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
 	"k8s.io/kubernetes/pkg/kubelet/apis/podresources"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
 	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager"
 	"k8s.io/kubernetes/pkg/kubelet/config"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
@@ -58,6 +60,10 @@ type ContainerManager interface {
 	// GetNodeConfig returns a NodeConfig that is being used by the container manager.
 	GetNodeConfig() NodeConfig
 
+	// GetResourceManagersConfigz returns the effective, post-defaulting
+	// configuration of the resource managers, for the /configz endpoint.
+	GetResourceManagersConfigz() ResourceManagersConfigz
+
 	// Status returns internal Status.
 	Status() Status
 
@@ -82,6 +88,15 @@ type ContainerManager interface {
 	// and inactive device plugin resources previously registered on the node.
 	GetDevicePluginResourceCapacity() (v1.ResourceList, v1.ResourceList, []string)
 
+	// GetResourceManagersCapacity returns the extended resources the resource
+	// managers (currently the CPU manager only) advertise on the node.
+	GetResourceManagersCapacity() v1.ResourceList
+
+	// GetNodeResourceTopology returns the per-zone resource accounting of the
+	// resource managers, to be published for topology-aware consumers, or nil
+	// when the node performs no resource alignment.
+	GetNodeResourceTopology() *noderesourcetopology.NodeResourceTopology
+
 	// UpdateQOSCgroups performs housekeeping updates to ensure that the top
 	// level QoS containers have their desired state in a thread-safe way
 	UpdateQOSCgroups() error
@@ -117,6 +132,11 @@ type ContainerManager interface {
 	// GetNodeAllocatableAbsolute returns the absolute value of Node Allocatable which is primarily useful for enforcement.
 	GetNodeAllocatableAbsolute() v1.ResourceList
 
+	// PodTopologyAligned returns whether all the containers of the given pod were
+	// admitted by the topology manager with a preferred resource alignment. The
+	// second return value is false if the topology manager does not track the pod.
+	PodTopologyAligned(podUID string) (aligned bool, tracked bool)
+
 	// PrepareDynamicResource prepares dynamic pod resources
 	PrepareDynamicResources(*v1.Pod) error
 
@@ -132,6 +152,7 @@ type ContainerManager interface {
 	podresources.DevicesProvider
 	podresources.MemoryProvider
 	podresources.DynamicResourcesProvider
+	podresources.TopologyAdmissionProvider
 }
 
 type NodeConfig struct {
@@ -154,11 +175,20 @@ type NodeConfig struct {
 	CPUManagerReconcilePeriod               time.Duration
 	ExperimentalMemoryManagerPolicy         string
 	ExperimentalMemoryManagerReservedMemory []kubeletconfig.MemoryReservation
+	MemoryManagerReconcilePeriod            time.Duration
 	PodPidsLimit                            int64
 	EnforceCPULimits                        bool
 	CPUCFSQuotaPeriod                       time.Duration
 	TopologyManagerPolicy                   string
 	TopologyManagerPolicyOptions            map[string]string
+	DeviceManagerAllocationStrategies       map[string]string
+	DeviceManagerSharingCounts              map[string]string
+	DevicePluginSlowAllocationThreshold     time.Duration
+	// RecordResourceAlignmentEvents makes the kubelet record an event per
+	// admitted pod summarizing the resource alignment decision: the merged
+	// NUMA affinity, the exclusive cpuset, the memory nodes and the device
+	// IDs granted to each container.
+	RecordResourceAlignmentEvents bool
 }
 
 type NodeAllocatableConfig struct {
@@ -210,6 +240,21 @@ func ParseQOSReserved(m map[string]string) (*map[v1.ResourceName]int64, error) {
 	return &reservations, nil
 }
 
+func cpuTopologyInfoFromCPUDetails(details topology.CPUDetails) []*podresourcesapi.CPUTopologyInfo {
+	cpus := details.CPUs().List()
+	respTopo := make([]*podresourcesapi.CPUTopologyInfo, 0, len(cpus))
+	// iterate in CPU ID order so the response is deterministic
+	for _, cpu := range cpus {
+		info := details[cpu]
+		respTopo = append(respTopo, &podresourcesapi.CPUTopologyInfo{
+			CPUID:         int64(cpu),
+			SocketID:      int64(info.SocketID),
+			UncoreCacheID: int64(info.UncoreCacheID),
+		})
+	}
+	return respTopo
+}
+
 func containerDevicesFromResourceDeviceInstances(devs devicemanager.ResourceDeviceInstances) []*podresourcesapi.ContainerDevices {
 	var respDevs []*podresourcesapi.ContainerDevices
 
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
 	"sync"
 	"time"
 
@@ -48,6 +49,7 @@ import (
 	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
 	kubefeatures "k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
+	"k8s.io/kubernetes/pkg/kubelet/cm/admission"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/dra"
@@ -66,6 +68,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/util/swap"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
 	"k8s.io/kubernetes/pkg/util/oom"
+	"k8s.io/utils/cpuset"
 )
 
 // A non-user container tracked by the Kubelet.
@@ -289,6 +292,7 @@ func NewContainerManager(mountUtil mount.Interface, cadvisorInterface cadvisor.I
 		nodeConfig.TopologyManagerPolicy,
 		nodeConfig.TopologyManagerScope,
 		nodeConfig.TopologyManagerPolicyOptions,
+		nodeConfig.KubeletRootDir,
 	)
 
 	if err != nil {
@@ -296,7 +300,7 @@ func NewContainerManager(mountUtil mount.Interface, cadvisorInterface cadvisor.I
 	}
 
 	klog.InfoS("Creating device plugin manager")
-	cm.deviceManager, err = devicemanager.NewManagerImpl(machineInfo.Topology, cm.topologyManager)
+	cm.deviceManager, err = devicemanager.NewManagerImpl(machineInfo.Topology, cm.topologyManager, nodeConfig.DeviceManagerAllocationStrategies, nodeConfig.DeviceManagerSharingCounts, recorder, nodeRefFromNode(string(nodeConfig.NodeName)), nodeConfig.DevicePluginSlowAllocationThreshold)
 	if err != nil {
 		return nil, err
 	}
@@ -321,6 +325,8 @@ func NewContainerManager(mountUtil mount.Interface, cadvisorInterface cadvisor.I
 		cm.GetNodeAllocatableReservation(),
 		nodeConfig.KubeletRootDir,
 		cm.topologyManager,
+		recorder,
+		nodeRefFromNode(string(nodeConfig.NodeName)),
 	)
 	if err != nil {
 		klog.ErrorS(err, "Failed to initialize cpu manager")
@@ -331,6 +337,7 @@ func NewContainerManager(mountUtil mount.Interface, cadvisorInterface cadvisor.I
 	if utilfeature.DefaultFeatureGate.Enabled(kubefeatures.MemoryManager) {
 		cm.memoryManager, err = memorymanager.NewManager(
 			nodeConfig.ExperimentalMemoryManagerPolicy,
+			nodeConfig.MemoryManagerReconcilePeriod,
 			machineInfo,
 			cm.GetNodeAllocatableReservation(),
 			nodeConfig.ExperimentalMemoryManagerReservedMemory,
@@ -518,6 +525,14 @@ func (cm *containerManagerImpl) setupNode(activePods ActivePodsFunc) error {
 		})
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(kubefeatures.ContainerAlignmentReport) {
+		// The reports of a deleted pod have no consumer left, so there is no
+		// rush: purging them with the periodic tasks is enough.
+		cm.periodicTasks = append(cm.periodicTasks, func() {
+			removeStaleAlignmentReports(cm.NodeConfig.KubeletRootDir, activePods())
+		})
+	}
+
 	cm.systemContainers = systemContainers
 	return nil
 }
@@ -528,6 +543,20 @@ func (cm *containerManagerImpl) GetNodeConfig() NodeConfig {
 	return cm.NodeConfig
 }
 
+func (cm *containerManagerImpl) GetResourceManagersConfigz() ResourceManagersConfigz {
+	configz := ResourceManagersConfigz{}
+	if cm.cpuManager != nil {
+		configz.CPUManager = cm.cpuManager.ConfigSnapshot()
+	}
+	if cm.memoryManager != nil {
+		configz.MemoryManager = cm.memoryManager.ConfigSnapshot()
+	}
+	if cm.topologyManager != nil {
+		configz.TopologyManager = cm.topologyManager.ConfigSnapshot()
+	}
+	return configz
+}
+
 // GetPodCgroupRoot returns the literal cgroupfs value for the cgroup containing all pods.
 func (cm *containerManagerImpl) GetPodCgroupRoot() string {
 	return cm.cgroupManager.Name(cm.cgroupRoot)
@@ -645,6 +674,22 @@ func (cm *containerManagerImpl) Start(node *v1.Node,
 		return err
 	}
 
+	// Once all the pod sources are ready, cross-check the state the resource
+	// managers recovered from their checkpoints against each other. With the
+	// none topology manager policy no affinity is ever recorded, so there is
+	// nothing to check against.
+	if cm.NodeConfig.TopologyManagerPolicy != topologymanager.PolicyNone {
+		go func() {
+			_ = wait.PollUntilContextCancel(ctx, time.Second, true, func(context.Context) (bool, error) {
+				if !sourcesReady.AllReady() {
+					return false, nil
+				}
+				checkResourceManagersConsistency(activePods(), cm.cpuManager, cm.deviceManager, cm.topologyManager)
+				return true, nil
+			})
+		}()
+	}
+
 	return nil
 }
 
@@ -671,23 +716,189 @@ func (cm *containerManagerImpl) GetResources(pod *v1.Pod, container *v1.Containe
 	devOpts, err := cm.deviceManager.GetDeviceRunContainerOptions(pod, container)
 	if err != nil {
 		return nil, err
-	} else if devOpts == nil {
-		return opts, nil
-	}
-	opts.Devices = append(opts.Devices, devOpts.Devices...)
-	opts.Mounts = append(opts.Mounts, devOpts.Mounts...)
-	opts.Envs = append(opts.Envs, devOpts.Envs...)
-	opts.Annotations = append(opts.Annotations, devOpts.Annotations...)
-	opts.CDIDevices = append(opts.CDIDevices, devOpts.CDIDevices...)
+	}
+	if devOpts != nil {
+		opts.Devices = append(opts.Devices, devOpts.Devices...)
+		opts.Mounts = append(opts.Mounts, devOpts.Mounts...)
+		opts.Envs = append(opts.Envs, devOpts.Envs...)
+		opts.Annotations = append(opts.Annotations, devOpts.Annotations...)
+		opts.CDIDevices = append(opts.CDIDevices, devOpts.CDIDevices...)
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(kubefeatures.ContainerAlignmentReport) {
+		// Failures to expose the report are intentionally not fatal: the
+		// report is a best-effort optimization aid for the workload, and
+		// must never prevent a container from starting.
+		if mount, err := cm.getAlignmentReportMount(pod, container); err != nil {
+			klog.ErrorS(err, "Failed to expose the alignment report", "pod", klog.KObj(pod), "containerName", container.Name)
+		} else if mount != nil {
+			opts.Mounts = append(opts.Mounts, *mount)
+		}
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(kubefeatures.ContainerTopologyAnnotations) {
+		opts.Annotations = append(opts.Annotations, cm.getTopologyAnnotations(pod, container)...)
+	}
 	return opts, nil
 }
 
+// getTopologyAnnotations returns the annotations describing the placement
+// (uncore caches, NUMA nodes) of the cpus exclusively assigned to the given
+// container, to be forwarded to the runtime on container creation.
+func (cm *containerManagerImpl) getTopologyAnnotations(pod *v1.Pod, container *v1.Container) []kubecontainer.Annotation {
+	var uncoreCacheIDs cpuset.CPUSet
+	var numaNodeIDs []int
+	if cm.cpuManager != nil {
+		uncoreCacheIDs = cm.cpuManager.GetExclusiveCPUDetails(string(pod.UID), container.Name).UncoreCaches()
+	}
+	if cm.topologyManager != nil {
+		if affinity := cm.topologyManager.GetAffinity(string(pod.UID), container.Name); affinity.NUMANodeAffinity != nil {
+			numaNodeIDs = affinity.NUMANodeAffinity.GetBits()
+		}
+	}
+	return topologyAnnotations(uncoreCacheIDs, numaNodeIDs)
+}
+
+// getAlignmentReportMount generates the alignment report for the given
+// container and returns the mount to expose it inside the container
+// filesystem, or nil if the container received no exclusive resources.
+func (cm *containerManagerImpl) getAlignmentReportMount(pod *v1.Pod, container *v1.Container) (*kubecontainer.Mount, error) {
+	report := AlignmentReport{}
+	if cm.cpuManager != nil {
+		if cpus := cm.cpuManager.GetExclusiveCPUs(string(pod.UID), container.Name); cpus.Size() > 0 {
+			report.CPUs = cpus.String()
+		}
+	}
+	if cm.topologyManager != nil {
+		if affinity := cm.topologyManager.GetAffinity(string(pod.UID), container.Name); affinity.NUMANodeAffinity != nil {
+			report.NUMANodes = affinity.NUMANodeAffinity.GetBits()
+		}
+	}
+	if cm.deviceManager != nil {
+		for resourceName, devices := range cm.deviceManager.GetDevices(string(pod.UID), container.Name) {
+			ids := make([]string, 0, len(devices))
+			for deviceID := range devices {
+				ids = append(ids, deviceID)
+			}
+			sort.Strings(ids)
+			if report.Devices == nil {
+				report.Devices = make(map[string][]string)
+			}
+			report.Devices[resourceName] = ids
+		}
+	}
+	if report.IsEmpty() {
+		return nil, nil
+	}
+
+	hostPath, err := writeAlignmentReport(cm.NodeConfig.KubeletRootDir, string(pod.UID), container.Name, &report)
+	if err != nil {
+		return nil, err
+	}
+	return &kubecontainer.Mount{
+		Name:          "kubelet-alignment-report",
+		ContainerPath: alignmentReportContainerPath,
+		HostPath:      hostPath,
+		ReadOnly:      true,
+	}, nil
+}
+
 func (cm *containerManagerImpl) UpdatePluginResources(node *schedulerframework.NodeInfo, attrs *lifecycle.PodAdmitAttributes) error {
 	return cm.deviceManager.UpdatePluginResources(node, attrs)
 }
 
 func (cm *containerManagerImpl) GetAllocateResourcesPodAdmitHandler() lifecycle.PodAdmitHandler {
-	return cm.topologyManager
+	var handler lifecycle.PodAdmitHandler = cm.topologyManager
+	if cm.NodeConfig.RecordResourceAlignmentEvents {
+		handler = &alignmentEventsAdmitHandler{cm: cm}
+	}
+	return &maintenanceModeAdmitHandler{
+		kubeletRootDir: cm.NodeConfig.KubeletRootDir,
+		delegate:       handler,
+	}
+}
+
+// maintenanceModeAdmitHandler refuses the pods which would carve out new
+// exclusive allocations while the resource managers maintenance mode flag
+// file is present, so a node can be safely defragmented or maintained before
+// cordon/drain completes. Pods sharing the common pools, and everything
+// already running, are left alone.
+type maintenanceModeAdmitHandler struct {
+	kubeletRootDir string
+	delegate       lifecycle.PodAdmitHandler
+}
+
+func (h *maintenanceModeAdmitHandler) Admit(attrs *lifecycle.PodAdmitAttributes) lifecycle.PodAdmitResult {
+	if maintenanceModeEnabled(h.kubeletRootDir) && podRequestsExclusiveAllocations(attrs.Pod) {
+		klog.InfoS("Refusing pod requesting exclusive allocations: resource managers are in maintenance mode", "pod", klog.KObj(attrs.Pod))
+		return admission.GetPodAdmitResult(&maintenanceModeError{})
+	}
+	return h.delegate.Admit(attrs)
+}
+
+// alignmentEventsAdmitHandler decorates the topology manager admission with a
+// Kubernetes event per admitted pod summarizing the alignment decision, so
+// the placement each container ended up with can be audited from the API
+// server instead of requiring node access.
+type alignmentEventsAdmitHandler struct {
+	cm *containerManagerImpl
+}
+
+func (h *alignmentEventsAdmitHandler) Admit(attrs *lifecycle.PodAdmitAttributes) lifecycle.PodAdmitResult {
+	result := h.cm.topologyManager.Admit(attrs)
+	if !result.Admit {
+		// The failure is already reported through the admission result
+		// (and the FailedScheduling event the kubelet emits from it).
+		return result
+	}
+
+	pod := attrs.Pod
+	var alignments []admission.ContainerAlignment
+	for i := range pod.Spec.InitContainers {
+		alignments = append(alignments, h.cm.containerAlignment(pod, &pod.Spec.InitContainers[i]))
+	}
+	for i := range pod.Spec.Containers {
+		alignments = append(alignments, h.cm.containerAlignment(pod, &pod.Spec.Containers[i]))
+	}
+	admission.RecordResourcesAligned(h.cm.recorder, pod, alignments)
+	return result
+}
+
+// containerAlignment collects from the resource managers what the given
+// container was granted during admission.
+func (cm *containerManagerImpl) containerAlignment(pod *v1.Pod, container *v1.Container) admission.ContainerAlignment {
+	alignment := admission.ContainerAlignment{Name: container.Name}
+	if cm.topologyManager != nil {
+		if affinity := cm.topologyManager.GetAffinity(string(pod.UID), container.Name); affinity.NUMANodeAffinity != nil {
+			alignment.NUMANodes = affinity.NUMANodeAffinity.GetBits()
+		}
+	}
+	if cm.cpuManager != nil {
+		if cpus := cm.cpuManager.GetExclusiveCPUs(string(pod.UID), container.Name); cpus.Size() > 0 {
+			alignment.CPUs = cpus.String()
+		}
+	}
+	if cm.memoryManager != nil {
+		if nodes := cm.memoryManager.GetMemoryNUMANodes(pod, container); nodes.Len() > 0 {
+			alignment.MemoryNodes = sets.List(nodes)
+		}
+	}
+	if cm.deviceManager != nil {
+		for resourceName, devices := range cm.deviceManager.GetDevices(string(pod.UID), container.Name) {
+			ids := make([]string, 0, len(devices))
+			for deviceID := range devices {
+				ids = append(ids, deviceID)
+			}
+			sort.Strings(ids)
+			if alignment.Devices == nil {
+				alignment.Devices = make(map[string][]string)
+			}
+			alignment.Devices[resourceName] = ids
+		}
+	}
+	return alignment
+}
+
+func (cm *containerManagerImpl) PodTopologyAligned(podUID string) (bool, bool) {
+	return cm.topologyManager.IsPodAligned(podUID)
 }
 
 func (cm *containerManagerImpl) SystemCgroupsLimit() v1.ResourceList {
@@ -905,6 +1116,13 @@ func (cm *containerManagerImpl) GetDevicePluginResourceCapacity() (v1.ResourceLi
 	return cm.deviceManager.GetCapacity()
 }
 
+func (cm *containerManagerImpl) GetResourceManagersCapacity() v1.ResourceList {
+	if cm.cpuManager == nil {
+		return nil
+	}
+	return cm.cpuManager.GetExtendedResourceCapacity()
+}
+
 func (cm *containerManagerImpl) GetDevices(podUID, containerName string) []*podresourcesapi.ContainerDevices {
 	return containerDevicesFromResourceDeviceInstances(cm.deviceManager.GetDevices(podUID, containerName))
 }
@@ -935,6 +1153,20 @@ func (cm *containerManagerImpl) GetAllocatableCPUs() []int64 {
 	return []int64{}
 }
 
+func (cm *containerManagerImpl) GetCPUTopology(podUID, containerName string) []*podresourcesapi.CPUTopologyInfo {
+	if cm.cpuManager != nil {
+		return cpuTopologyInfoFromCPUDetails(cm.cpuManager.GetExclusiveCPUDetails(podUID, containerName))
+	}
+	return []*podresourcesapi.CPUTopologyInfo{}
+}
+
+func (cm *containerManagerImpl) GetAllocatableCPUTopology() []*podresourcesapi.CPUTopologyInfo {
+	if cm.cpuManager != nil {
+		return cpuTopologyInfoFromCPUDetails(cm.cpuManager.GetAllocatableCPUDetails())
+	}
+	return []*podresourcesapi.CPUTopologyInfo{}
+}
+
 func (cm *containerManagerImpl) GetMemory(podUID, containerName string) []*podresourcesapi.ContainerMemory {
 	if cm.memoryManager == nil {
 		return []*podresourcesapi.ContainerMemory{}
@@ -986,6 +1218,33 @@ func (cm *containerManagerImpl) GetDynamicResources(pod *v1.Pod, container *v1.C
 	return containerDynamicResources
 }
 
+func (cm *containerManagerImpl) DryRunTopologyAdmission(pod *v1.Pod) *podresourcesapi.DryRunAdmissionResponse {
+	response := &podresourcesapi.DryRunAdmissionResponse{
+		Admit:          true,
+		TopologyPolicy: cm.NodeConfig.TopologyManagerPolicy,
+		TopologyScope:  cm.NodeConfig.TopologyManagerScope,
+	}
+
+	for _, prediction := range cm.topologyManager.DryRunAdmit(pod) {
+		var numaAffinity []int64
+		if prediction.Affinity.NUMANodeAffinity != nil {
+			for _, node := range prediction.Affinity.NUMANodeAffinity.GetBits() {
+				numaAffinity = append(numaAffinity, int64(node))
+			}
+		}
+		response.Predictions = append(response.Predictions, &podresourcesapi.ContainerAdmissionPrediction{
+			ContainerName: prediction.ContainerName,
+			NumaAffinity:  numaAffinity,
+			Preferred:     prediction.Affinity.Preferred,
+			Admit:         prediction.Admit,
+		})
+		if !prediction.Admit {
+			response.Admit = false
+		}
+	}
+	return response
+}
+
 func (cm *containerManagerImpl) ShouldResetExtendedResourceCapacity() bool {
 	return cm.deviceManager.ShouldResetExtendedResourceCapacity()
 }
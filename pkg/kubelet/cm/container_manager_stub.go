@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	internalapi "k8s.io/cri-api/pkg/apis"
 	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
@@ -57,6 +58,10 @@ func (cm *containerManagerStub) GetNodeConfig() NodeConfig {
 	return NodeConfig{}
 }
 
+func (cm *containerManagerStub) GetResourceManagersConfigz() ResourceManagersConfigz {
+	return ResourceManagersConfigz{}
+}
+
 func (cm *containerManagerStub) GetMountedSubsystems() *CgroupSubsystems {
 	return &CgroupSubsystems{}
 }
@@ -97,6 +102,14 @@ func (cm *containerManagerStub) GetDevicePluginResourceCapacity() (v1.ResourceLi
 	return cm.extendedPluginResources, cm.extendedPluginResources, []string{}
 }
 
+func (cm *containerManagerStub) GetResourceManagersCapacity() v1.ResourceList {
+	return nil
+}
+
+func (cm *containerManagerStub) GetNodeResourceTopology() *noderesourcetopology.NodeResourceTopology {
+	return nil
+}
+
 func (m *podContainerManagerStub) GetPodCgroupConfig(_ *v1.Pod, _ v1.ResourceName) (*ResourceConfig, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -141,6 +154,10 @@ func (cm *containerManagerStub) GetAllocateResourcesPodAdmitHandler() lifecycle.
 	return topologymanager.NewFakeManager()
 }
 
+func (cm *containerManagerStub) PodTopologyAligned(podUID string) (bool, bool) {
+	return false, false
+}
+
 func (cm *containerManagerStub) UpdateAllocatedDevices() {
 	return
 }
@@ -153,6 +170,14 @@ func (cm *containerManagerStub) GetAllocatableCPUs() []int64 {
 	return nil
 }
 
+func (cm *containerManagerStub) GetCPUTopology(_, _ string) []*podresourcesapi.CPUTopologyInfo {
+	return nil
+}
+
+func (cm *containerManagerStub) GetAllocatableCPUTopology() []*podresourcesapi.CPUTopologyInfo {
+	return nil
+}
+
 func (cm *containerManagerStub) GetMemory(_, _ string) []*podresourcesapi.ContainerMemory {
 	return nil
 }
@@ -165,6 +190,10 @@ func (cm *containerManagerStub) GetDynamicResources(pod *v1.Pod, container *v1.C
 	return nil
 }
 
+func (cm *containerManagerStub) DryRunTopologyAdmission(pod *v1.Pod) *podresourcesapi.DryRunAdmissionResponse {
+	return &podresourcesapi.DryRunAdmissionResponse{Admit: true}
+}
+
 func (cm *containerManagerStub) GetNodeAllocatableAbsolute() v1.ResourceList {
 	return nil
 }
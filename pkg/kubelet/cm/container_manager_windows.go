@@ -36,6 +36,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	internalapi "k8s.io/cri-api/pkg/apis"
 	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
 	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
 	"k8s.io/kubernetes/pkg/kubelet/cm/admission"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
@@ -118,7 +119,13 @@ func NewContainerManager(mountUtil mount.Interface, cadvisorInterface cadvisor.I
 	cm.topologyManager = topologymanager.NewFakeManager()
 
 	klog.InfoS("Creating device plugin manager")
-	cm.deviceManager, err = devicemanager.NewManagerImpl(nil, cm.topologyManager)
+	nodeRef := &v1.ObjectReference{
+		Kind:      "Node",
+		Name:      string(nodeConfig.NodeName),
+		UID:       types.UID(nodeConfig.NodeName),
+		Namespace: "",
+	}
+	cm.deviceManager, err = devicemanager.NewManagerImpl(nil, cm.topologyManager, nodeConfig.DeviceManagerAllocationStrategies, nodeConfig.DeviceManagerSharingCounts, recorder, nodeRef, nodeConfig.DevicePluginSlowAllocationThreshold)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +142,10 @@ func (cm *containerManagerImpl) GetNodeConfig() NodeConfig {
 	return NodeConfig{}
 }
 
+func (cm *containerManagerImpl) GetResourceManagersConfigz() ResourceManagersConfigz {
+	return ResourceManagersConfigz{}
+}
+
 func (cm *containerManagerImpl) GetMountedSubsystems() *CgroupSubsystems {
 	return &CgroupSubsystems{}
 }
@@ -184,6 +195,14 @@ func (cm *containerManagerImpl) GetDevicePluginResourceCapacity() (v1.ResourceLi
 	return cm.deviceManager.GetCapacity()
 }
 
+func (cm *containerManagerImpl) GetResourceManagersCapacity() v1.ResourceList {
+	return nil
+}
+
+func (cm *containerManagerImpl) GetNodeResourceTopology() *noderesourcetopology.NodeResourceTopology {
+	return nil
+}
+
 func (cm *containerManagerImpl) NewPodContainerManager() PodContainerManager {
 	return &podContainerManagerStub{}
 }
@@ -233,6 +252,10 @@ func (cm *containerManagerImpl) GetAllocateResourcesPodAdmitHandler() lifecycle.
 	return &noopWindowsResourceAllocator{}
 }
 
+func (cm *containerManagerImpl) PodTopologyAligned(podUID string) (bool, bool) {
+	return cm.topologyManager.IsPodAligned(podUID)
+}
+
 func (cm *containerManagerImpl) UpdateAllocatedDevices() {
 	return
 }
@@ -245,6 +268,14 @@ func (cm *containerManagerImpl) GetAllocatableCPUs() []int64 {
 	return nil
 }
 
+func (cm *containerManagerImpl) GetCPUTopology(_, _ string) []*podresourcesapi.CPUTopologyInfo {
+	return nil
+}
+
+func (cm *containerManagerImpl) GetAllocatableCPUTopology() []*podresourcesapi.CPUTopologyInfo {
+	return nil
+}
+
 func (cm *containerManagerImpl) GetMemory(_, _ string) []*podresourcesapi.ContainerMemory {
 	return nil
 }
@@ -261,6 +292,10 @@ func (cm *containerManagerImpl) GetDynamicResources(pod *v1.Pod, container *v1.C
 	return nil
 }
 
+func (cm *containerManagerImpl) DryRunTopologyAdmission(pod *v1.Pod) *podresourcesapi.DryRunAdmissionResponse {
+	return &podresourcesapi.DryRunAdmissionResponse{Admit: true}
+}
+
 func (cm *containerManagerImpl) PrepareDynamicResources(pod *v1.Pod) error {
 	return nil
 }
@@ -14,7 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package cpumanager
+// Package allocation implements the topology-aware CPU allocation algorithms
+// used by the CPU Manager static policy. It only depends on the CPU topology
+// and cpuset types, so external consumers (secondary schedulers, capacity
+// planners) can run the very same logic the kubelet runs to predict which
+// CPUs a node would hand out, without importing the rest of the kubelet.
+package allocation
 
 import (
 	"fmt"
@@ -46,11 +51,15 @@ func (m mapIntInt) Clone() mapIntInt {
 	return cp
 }
 
+// Keys returns the keys in sorted order. Allocation decisions must never
+// depend on map iteration order, otherwise repeated allocations from the same
+// state could hand out differently ordered cpusets.
 func (m mapIntInt) Keys() []int {
 	var keys []int
 	for k := range m {
 		keys = append(keys, k)
 	}
+	sort.Ints(keys)
 	return keys
 }
 
@@ -251,13 +260,27 @@ func (a *cpuAccumulator) isNUMANodeFree(numaID int) bool {
 // Returns true if the supplied socket is fully available in `a.details`.
 // "fully available" means that all the CPUs in it are free.
 func (a *cpuAccumulator) isSocketFree(socketID int) bool {
-	return a.details.CPUsInSockets(socketID).Size() == a.topo.CPUsPerSocket()
+	return a.details.CPUsInSockets(socketID).Size() == a.topo.CPUDetails.CPUsInSockets(socketID).Size()
+}
+
+// Returns true if the supplied uncore cache is fully available in `a.details`.
+// "fully available" means that all the CPUs in it are free.
+func (a *cpuAccumulator) isUncoreCacheFree(uncoreID int) bool {
+	return a.details.CPUsInUncoreCaches(uncoreID).Size() == a.topo.CPUDetails.CPUsInUncoreCaches(uncoreID).Size()
+}
+
+// Returns true if the supplied cluster is fully available in `a.details`.
+// "fully available" means that all the CPUs in it are free.
+func (a *cpuAccumulator) isClusterFree(clusterID int) bool {
+	return a.details.CPUsInClusters(clusterID).Size() == a.topo.CPUDetails.CPUsInClusters(clusterID).Size()
 }
 
 // Returns true if the supplied core is fully available in `a.details`.
 // "fully available" means that all the CPUs in it are free.
 func (a *cpuAccumulator) isCoreFree(coreID int) bool {
-	return a.details.CPUsInCores(coreID).Size() == a.topo.CPUsPerCore()
+	// Compare against the core's own thread count: with SMT disabled on a
+	// subset of cores, CPUsPerCore would undercount the wide cores.
+	return a.details.CPUsInCores(coreID).Size() == a.topo.ThreadsPerCore(coreID)
 }
 
 // Returns free NUMA Node IDs as a slice sorted by sortAvailableNUMANodes().
@@ -282,6 +305,28 @@ func (a *cpuAccumulator) freeSockets() []int {
 	return free
 }
 
+// Returns free uncore cache IDs as a slice sorted by sortAvailableUncoreCaches().
+func (a *cpuAccumulator) freeUncoreCaches() []int {
+	free := []int{}
+	for _, uncore := range a.sortAvailableUncoreCaches() {
+		if a.isUncoreCacheFree(uncore) {
+			free = append(free, uncore)
+		}
+	}
+	return free
+}
+
+// Returns free cluster IDs as a slice sorted by sortAvailableClusters().
+func (a *cpuAccumulator) freeClusters() []int {
+	free := []int{}
+	for _, cluster := range a.sortAvailableClusters() {
+		if a.isClusterFree(cluster) {
+			free = append(free, cluster)
+		}
+	}
+	return free
+}
+
 // Returns free core IDs as a slice sorted by sortAvailableCores().
 func (a *cpuAccumulator) freeCores() []int {
 	free := []int{}
@@ -357,6 +402,28 @@ func (a *cpuAccumulator) sortAvailableSockets() []int {
 	return a.numaOrSocketsFirst.sortAvailableSockets()
 }
 
+// Sort all uncore caches with at least one free CPU, by ascending number of
+// free CPUs that they contain (ties broken by ascending cache ID). The uncore
+// cache sits between the NUMA node and the core in the memory hierarchy, so
+// no NUMA-vs-sockets ordering question arises here: all the CPUs sharing an
+// uncore cache belong to the same socket and NUMA node.
+func (a *cpuAccumulator) sortAvailableUncoreCaches() []int {
+	uncores := a.details.UncoreCaches().UnsortedList()
+	a.sort(uncores, a.details.CPUsInUncoreCaches)
+	return uncores
+}
+
+// Sort all clusters with at least one free CPU, by ascending number of free
+// CPUs that they contain (ties broken by ascending cluster ID). Like the
+// uncore cache, a cluster sits between the NUMA node and the core in the
+// memory hierarchy: all the CPUs sharing a cluster belong to the same socket
+// and NUMA node.
+func (a *cpuAccumulator) sortAvailableClusters() []int {
+	clusters := a.details.Clusters().UnsortedList()
+	a.sort(clusters, a.details.CPUsInClusters)
+	return clusters
+}
+
 // Sort all cores with at least one free CPU.
 //
 // If sockets are higher in the memory hierarchy than NUMA nodes, meaning that sockets contain a
@@ -442,8 +509,141 @@ func (a *cpuAccumulator) takeFullSockets() {
 	}
 }
 
+func (a *cpuAccumulator) takeFullUncoreCaches() {
+	for _, uncore := range a.freeUncoreCaches() {
+		cpusInUncore := a.topo.CPUDetails.CPUsInUncoreCaches(uncore)
+		if !a.needsAtLeast(cpusInUncore.Size()) {
+			continue
+		}
+		klog.V(4).InfoS("takeFullUncoreCaches: claiming uncore cache", "uncore", uncore)
+		a.take(cpusInUncore)
+	}
+}
+
+// takePartialUncoreCache claims the remaining CPUs from a single uncore cache
+// whenever one can host the whole remainder of the request. Among the
+// candidates, the cache with the largest free share wins: it gives follow-up
+// requests the best chance to land on a single cache too, instead of
+// fragmenting several caches a few cores at a time.
+func (a *cpuAccumulator) takePartialUncoreCache() {
+	bestUncore := -1
+	bestFreeSize := -1
+	for _, uncore := range a.sortAvailableUncoreCaches() {
+		freeSize := a.details.CPUsInUncoreCaches(uncore).Size()
+		if freeSize < a.numCPUsNeeded {
+			continue
+		}
+		if freeSize > bestFreeSize {
+			bestUncore = uncore
+			bestFreeSize = freeSize
+		}
+	}
+	if bestUncore == -1 {
+		return
+	}
+
+	// All the CPUs sharing an uncore cache sit on the same socket and NUMA
+	// node, so the packed algorithm just descends to cores and threads here.
+	cpus, err := TakeByTopologyNUMAPacked(a.topo, a.details.CPUsInUncoreCaches(bestUncore), a.numCPUsNeeded)
+	if err != nil {
+		// Cannot happen, we just verified the cache has enough free CPUs.
+		klog.ErrorS(err, "takePartialUncoreCache: failed to claim CPUs", "uncore", bestUncore)
+		return
+	}
+	klog.V(4).InfoS("takePartialUncoreCache: claiming CPUs", "uncore", bestUncore, "cpus", cpus)
+	a.take(cpus)
+}
+
+func (a *cpuAccumulator) takeFullClusters() {
+	for _, cluster := range a.freeClusters() {
+		cpusInCluster := a.topo.CPUDetails.CPUsInClusters(cluster)
+		if !a.needsAtLeast(cpusInCluster.Size()) {
+			continue
+		}
+		klog.V(4).InfoS("takeFullClusters: claiming cluster", "cluster", cluster)
+		a.take(cpusInCluster)
+	}
+}
+
+// takePartialCluster claims the remaining CPUs from a single cluster whenever
+// one can host the whole remainder of the request, preferring the cluster
+// with the largest free share for the same reason takePartialUncoreCache
+// does: follow-up requests keep the best chance to land on a single cluster.
+func (a *cpuAccumulator) takePartialCluster() {
+	bestCluster := -1
+	bestFreeSize := -1
+	for _, cluster := range a.sortAvailableClusters() {
+		freeSize := a.details.CPUsInClusters(cluster).Size()
+		if freeSize < a.numCPUsNeeded {
+			continue
+		}
+		if freeSize > bestFreeSize {
+			bestCluster = cluster
+			bestFreeSize = freeSize
+		}
+	}
+	if bestCluster == -1 {
+		return
+	}
+
+	// All the CPUs sharing a cluster sit on the same socket and NUMA node, so
+	// the packed algorithm just descends to cores and threads here.
+	cpus, err := TakeByTopologyNUMAPacked(a.topo, a.details.CPUsInClusters(bestCluster), a.numCPUsNeeded)
+	if err != nil {
+		// Cannot happen, we just verified the cluster has enough free CPUs.
+		klog.ErrorS(err, "takePartialCluster: failed to claim CPUs", "cluster", bestCluster)
+		return
+	}
+	klog.V(4).InfoS("takePartialCluster: claiming CPUs", "cluster", bestCluster, "cpus", cpus)
+	a.take(cpus)
+}
+
+// takeDistributedAcrossUncoreCaches spreads the remaining request across the
+// available uncore caches, one CPU group of size `cpuGroupSize` at a time,
+// always claiming the next group from the cache with the most free CPUs. The
+// caches therefore drain at roughly the same rate and the final allocation is
+// as balanced as the available CPUs permit. The method returns early when no
+// cache can supply a whole group anymore, leaving the remainder for the
+// caller to deal with.
+func (a *cpuAccumulator) takeDistributedAcrossUncoreCaches(cpuGroupSize int) {
+	for !a.isSatisfied() {
+		bestUncore := -1
+		bestFreeSize := -1
+		for _, uncore := range a.sortAvailableUncoreCaches() {
+			freeSize := a.details.CPUsInUncoreCaches(uncore).Size()
+			if freeSize < cpuGroupSize {
+				continue
+			}
+			if freeSize > bestFreeSize {
+				bestUncore = uncore
+				bestFreeSize = freeSize
+			}
+		}
+		if bestUncore == -1 {
+			return
+		}
+
+		cpus, err := TakeByTopologyNUMAPacked(a.topo, a.details.CPUsInUncoreCaches(bestUncore), cpuGroupSize)
+		if err != nil {
+			// Cannot happen, we just verified the cache has enough free CPUs.
+			klog.ErrorS(err, "takeDistributedAcrossUncoreCaches: failed to claim CPUs", "uncore", bestUncore)
+			return
+		}
+		klog.V(4).InfoS("takeDistributedAcrossUncoreCaches: claiming CPUs", "uncore", bestUncore, "cpus", cpus)
+		a.take(cpus)
+	}
+}
+
 func (a *cpuAccumulator) takeFullCores() {
-	for _, core := range a.freeCores() {
+	cores := a.freeCores()
+	// On machines where SMT is disabled on a subset of cores, claim the wide
+	// cores first: the narrow cores are the only ones able to absorb an odd
+	// remainder, so they must not be spent while a wider core still fits.
+	// With uniform SMT the stable sort keeps the topological order untouched.
+	sort.SliceStable(cores, func(i, j int) bool {
+		return a.topo.ThreadsPerCore(cores[i]) > a.topo.ThreadsPerCore(cores[j])
+	})
+	for _, core := range cores {
 		cpusInCore := a.topo.CPUDetails.CPUsInCores(core)
 		if !a.needsAtLeast(cpusInCore.Size()) {
 			continue
@@ -539,7 +739,7 @@ func (a *cpuAccumulator) iterateCombinations(n []int, k int, f func([]int) LoopC
 	helper(n, k, 0, []int{}, f)
 }
 
-// takeByTopologyNUMAPacked returns a CPUSet containing `numCPUs` CPUs taken from the CPUs in the
+// TakeByTopologyNUMAPacked returns a CPUSet containing `numCPUs` CPUs taken from the CPUs in the
 // set `availableCPUs`. `topo` describes how the CPUs are arranged between sockets, NUMA nodes
 // and physical cores (if hyperthreading is on a "CPU" is a thread rather than a full physical
 // core).
@@ -581,7 +781,7 @@ func (a *cpuAccumulator) iterateCombinations(n []int, k int, f func([]int) LoopC
 // the least amount of free CPUs to the one with the highest amount of free CPUs (i.e. in ascending
 // order of free CPUs). For any NUMA node, the cores are selected from the ones in the socket with
 // the least amount of free CPUs to the one with the highest amount of free CPUs.
-func takeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error) {
+func TakeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error) {
 	acc := newCPUAccumulator(topo, availableCPUs, numCPUs)
 	if acc.isSatisfied() {
 		return acc.result, nil
@@ -622,7 +822,156 @@ func takeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.C
 	return cpuset.New(), fmt.Errorf("failed to allocate cpus")
 }
 
-// takeByTopologyNUMADistributed returns a CPUSet of size 'numCPUs'.
+// TakeByTopologyUncoreCachePacked works like TakeByTopologyNUMAPacked, with
+// two extra phases between the socket/NUMA level and the core level which keep
+// the allocation aligned to uncore (last-level) caches:
+//
+// After having taken whole sockets and NUMA nodes, the function takes as many
+// entire free uncore caches as possible, by ascending order of free CPUs in
+// them. Then, if a single uncore cache (fully or partially free) can host all
+// the remaining CPUs to take, the remainder is claimed from the cache with the
+// largest free share rather than packed core-by-core across caches. Only when
+// no single cache suffices does the function fall through to the usual whole
+// cores and single threads phases.
+func TakeByTopologyUncoreCachePacked(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error) {
+	acc := newCPUAccumulator(topo, availableCPUs, numCPUs)
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+	if acc.isFailed() {
+		return cpuset.New(), fmt.Errorf("not enough cpus available to satisfy request: requested=%d, available=%d", numCPUs, availableCPUs.Size())
+	}
+
+	// 1. Acquire whole NUMA nodes and sockets, exactly like the packed algorithm.
+	acc.numaOrSocketsFirst.takeFullFirstLevel()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+	acc.numaOrSocketsFirst.takeFullSecondLevel()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 2. Acquire whole uncore caches, if available and the container requires
+	//    at least an uncore cache's-worth of CPUs.
+	acc.takeFullUncoreCaches()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 3. Acquire the remaining CPUs from a single uncore cache, if one has a
+	//    large enough free share.
+	acc.takePartialUncoreCache()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 4. Acquire whole cores, if available and the container requires at least
+	//    a core's-worth of CPUs.
+	acc.takeFullCores()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 5. Acquire single threads, preferring to fill partially-allocated cores
+	//    on the same sockets as the whole cores we have already taken in this
+	//    allocation.
+	acc.takeRemainingCPUs()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	return cpuset.New(), fmt.Errorf("failed to allocate cpus")
+}
+
+// TakeByTopologyClusterPacked works like TakeByTopologyUncoreCachePacked,
+// with the alignment boundary moved from the uncore (last-level) cache to the
+// cluster (DSU/L2 complex): whole free clusters are claimed first, then the
+// remainder is taken from the single cluster with the largest sufficient free
+// share, and only when no single cluster suffices does the function fall
+// through to the usual whole cores and single threads phases. On the ARM
+// platforms this exists for, the cluster rather than the L3 cache is the
+// locality domain that matters.
+func TakeByTopologyClusterPacked(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error) {
+	acc := newCPUAccumulator(topo, availableCPUs, numCPUs)
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+	if acc.isFailed() {
+		return cpuset.New(), fmt.Errorf("not enough cpus available to satisfy request: requested=%d, available=%d", numCPUs, availableCPUs.Size())
+	}
+
+	// 1. Acquire whole NUMA nodes and sockets, exactly like the packed algorithm.
+	acc.numaOrSocketsFirst.takeFullFirstLevel()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+	acc.numaOrSocketsFirst.takeFullSecondLevel()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 2. Acquire whole clusters, if available and the container requires at
+	//    least a cluster's-worth of CPUs.
+	acc.takeFullClusters()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 3. Acquire the remaining CPUs from a single cluster, if one has a large
+	//    enough free share.
+	acc.takePartialCluster()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 4. Acquire whole cores, if available and the container requires at least
+	//    a core's-worth of CPUs.
+	acc.takeFullCores()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// 5. Acquire single threads, preferring to fill partially-allocated cores
+	//    on the same sockets as the whole cores we have already taken in this
+	//    allocation.
+	acc.takeRemainingCPUs()
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	return cpuset.New(), fmt.Errorf("failed to allocate cpus")
+}
+
+// TakeByTopologySMTIsolated returns two CPUSets: the first contains `numCPUs`
+// CPUs, each sitting on a distinct physical core, the second contains the
+// sibling threads of those CPUs. Only fully free cores are considered, taken
+// in the usual topological order, so the caller can withhold the siblings
+// from further allocation and guarantee that the claimed CPUs never share a
+// core with anything else.
+func TakeByTopologySMTIsolated(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, cpuset.CPUSet, error) {
+	acc := newCPUAccumulator(topo, availableCPUs, numCPUs)
+	if acc.isSatisfied() {
+		return acc.result, cpuset.New(), nil
+	}
+
+	freeCores := acc.freeCores()
+	if len(freeCores) < numCPUs {
+		return cpuset.New(), cpuset.New(), fmt.Errorf("not enough free physical cores to satisfy request: requested=%d, free cores=%d", numCPUs, len(freeCores))
+	}
+
+	result := cpuset.New()
+	siblings := cpuset.New()
+	for _, core := range freeCores[:numCPUs] {
+		cpus := acc.topo.CPUDetails.CPUsInCores(core).List()
+		klog.V(4).InfoS("TakeByTopologySMTIsolated: claiming core", "core", core, "cpu", cpus[0], "siblings", cpus[1:])
+		result = result.Union(cpuset.New(cpus[0]))
+		siblings = siblings.Union(cpuset.New(cpus[1:]...))
+	}
+	return result, siblings, nil
+}
+
+// TakeByTopologyNUMADistributed returns a CPUSet of size 'numCPUs'.
 //
 // It generates this CPUset by allocating CPUs from 'availableCPUs' according
 // to the algorithm outlined in KEP-2902:
@@ -631,14 +980,14 @@ func takeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.C
 //
 // This algorithm evenly distribute CPUs across NUMA nodes in cases where more
 // than one NUMA node is required to satisfy the allocation. This is in
-// contrast to the takeByTopologyNUMAPacked algorithm, which attempts to 'pack'
+// contrast to the TakeByTopologyNUMAPacked algorithm, which attempts to 'pack'
 // CPUs onto NUMA nodes and fill them up before moving on to the next one.
 //
 // At a high-level this algorithm can be summarized as:
 //
 // For each NUMA single node:
 //   - If all requested CPUs can be allocated from this NUMA node;
-//     --> Do the allocation by running takeByTopologyNUMAPacked() over the
+//     --> Do the allocation by running TakeByTopologyNUMAPacked() over the
 //     available CPUs in that NUMA node and return
 //
 // Otherwise, for each pair of NUMA nodes:
@@ -646,7 +995,7 @@ func takeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.C
 //     the 2 NUMA nodes; AND
 //   - Any remaining CPUs (after the modulo operation) can be striped across
 //     some subset of the NUMA nodes;
-//     --> Do the allocation by running takeByTopologyNUMAPacked() over the
+//     --> Do the allocation by running TakeByTopologyNUMAPacked() over the
 //     available CPUs in both NUMA nodes and return
 //
 // Otherwise, for each 3-tuple of NUMA nodes:
@@ -654,7 +1003,7 @@ func takeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.C
 //     across the 3 NUMA nodes; AND
 //   - Any remaining CPUs (after the modulo operation) can be striped across
 //     some subset of the NUMA nodes;
-//     --> Do the allocation by running takeByTopologyNUMAPacked() over the
+//     --> Do the allocation by running TakeByTopologyNUMAPacked() over the
 //     available CPUs in all three NUMA nodes and return
 //
 // ...
@@ -664,12 +1013,12 @@ func takeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.C
 //     distributed across all NUMA nodes; AND
 //   - Any remaining CPUs (after the modulo operation) can be striped across
 //     some subset of the NUMA nodes;
-//     --> Do the allocation by running takeByTopologyNUMAPacked() over the
+//     --> Do the allocation by running TakeByTopologyNUMAPacked() over the
 //     available CPUs in all NUMA nodes and return
 //
 // If none of the above conditions can be met, then resort back to a
 // best-effort fit of packing CPUs into NUMA nodes by calling
-// takeByTopologyNUMAPacked() over all available CPUs.
+// TakeByTopologyNUMAPacked() over all available CPUs.
 //
 // NOTE: A "balance score" will be calculated to help find the best subset of
 // NUMA nodes to allocate any 'remainder' CPUs from (in cases where the total
@@ -685,12 +1034,17 @@ func takeByTopologyNUMAPacked(topo *topology.CPUTopology, availableCPUs cpuset.C
 // of size 'cpuGroupSize' according to the algorithm described above. This is
 // important, for example, to ensure that all CPUs (i.e. all hyperthreads) from
 // a single core are allocated together.
-func takeByTopologyNUMADistributed(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int, cpuGroupSize int) (cpuset.CPUSet, error) {
+//
+// NOTE: Once the set of NUMA nodes has been chosen, the share of CPUs taken
+// from each node is claimed with TakeByTopologyUncoreCachePacked() rather than
+// the plain packed algorithm, so that the per-node allocations stay aligned to
+// the uncore (last-level) caches inside that node whenever possible.
+func TakeByTopologyNUMADistributed(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int, cpuGroupSize int) (cpuset.CPUSet, error) {
 	// If the number of CPUs requested cannot be handed out in chunks of
 	// 'cpuGroupSize', then we just call out the packing algorithm since we
 	// can't distribute CPUs in this chunk size.
 	if (numCPUs % cpuGroupSize) != 0 {
-		return takeByTopologyNUMAPacked(topo, availableCPUs, numCPUs)
+		return TakeByTopologyNUMAPacked(topo, availableCPUs, numCPUs)
 	}
 
 	// Otherwise build an accumulator to start allocating CPUs from.
@@ -870,10 +1224,13 @@ func takeByTopologyNUMADistributed(topo *topology.CPUTopology, availableCPUs cpu
 
 		// Otherwise, start allocating CPUs from the NUMA node combination
 		// chosen. First allocate an even distribution of CPUs in groups of
-		// size 'cpuGroupSize' from 'bestCombo'.
+		// size 'cpuGroupSize' from 'bestCombo'. Within each NUMA node the
+		// share is kept aligned to uncore (last-level) caches, so that
+		// distributing across NUMA nodes does not needlessly scatter the
+		// allocation across the caches inside them.
 		distribution := (numCPUs / len(bestCombo) / cpuGroupSize) * cpuGroupSize
 		for _, numa := range bestCombo {
-			cpus, _ := takeByTopologyNUMAPacked(acc.topo, acc.details.CPUsInNUMANodes(numa), distribution)
+			cpus, _ := TakeByTopologyUncoreCachePacked(acc.topo, acc.details.CPUsInNUMANodes(numa), distribution)
 			acc.take(cpus)
 		}
 
@@ -888,7 +1245,7 @@ func takeByTopologyNUMADistributed(topo *topology.CPUTopology, availableCPUs cpu
 				if acc.details.CPUsInNUMANodes(numa).Size() < cpuGroupSize {
 					continue
 				}
-				cpus, _ := takeByTopologyNUMAPacked(acc.topo, acc.details.CPUsInNUMANodes(numa), cpuGroupSize)
+				cpus, _ := TakeByTopologyUncoreCachePacked(acc.topo, acc.details.CPUsInNUMANodes(numa), cpuGroupSize)
 				acc.take(cpus)
 				remainder -= cpuGroupSize
 			}
@@ -912,5 +1269,51 @@ func takeByTopologyNUMADistributed(topo *topology.CPUTopology, availableCPUs cpu
 
 	// If we never found a combination of NUMA nodes that we could properly
 	// distribute CPUs across, fall back to the packing algorithm.
-	return takeByTopologyNUMAPacked(topo, availableCPUs, numCPUs)
+	return TakeByTopologyNUMAPacked(topo, availableCPUs, numCPUs)
+}
+
+// TakeByTopologyUncoreCacheDistributed returns a CPUSet of size `numCPUs`
+// spread as evenly as possible across the uncore (last-level) caches in
+// `availableCPUs`.
+//
+// This is the opposite placement to TakeByTopologyUncoreCachePacked: instead
+// of confining the container to as few caches as possible, every cache the
+// container touches contributes its share of last-level cache capacity and
+// memory bandwidth, which benefits throughput-oriented workloads.
+//
+// CPUs are handed out in groups of size `cpuGroupSize` (so that all the
+// hyperthreads of a core stay together when full-pcpus-only is in effect),
+// one group at a time from the cache with the most free CPUs. Requests which
+// are not a multiple of the group size, and any remainder once no single
+// cache can supply a whole group, fall back to the packing algorithm.
+func TakeByTopologyUncoreCacheDistributed(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int, cpuGroupSize int) (cpuset.CPUSet, error) {
+	if (numCPUs % cpuGroupSize) != 0 {
+		return TakeByTopologyNUMAPacked(topo, availableCPUs, numCPUs)
+	}
+
+	acc := newCPUAccumulator(topo, availableCPUs, numCPUs)
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+	if acc.isFailed() {
+		return cpuset.New(), fmt.Errorf("not enough cpus available to satisfy request: requested=%d, available=%d", numCPUs, availableCPUs.Size())
+	}
+
+	acc.takeDistributedAcrossUncoreCaches(cpuGroupSize)
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	// No cache can supply a whole group anymore; take the remainder
+	// best-effort with the packing algorithm.
+	cpus, err := TakeByTopologyNUMAPacked(acc.topo, acc.details.CPUs(), acc.numCPUsNeeded)
+	if err != nil {
+		return cpuset.New(), err
+	}
+	acc.take(cpus)
+	if acc.isSatisfied() {
+		return acc.result, nil
+	}
+
+	return cpuset.New(), fmt.Errorf("failed to allocate cpus")
 }
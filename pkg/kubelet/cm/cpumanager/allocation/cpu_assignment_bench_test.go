@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocation
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology/fixtures"
+)
+
+// benchTopologies are the machine shapes the allocators are benchmarked on,
+// generated instead of hand-typed; see the fixtures package.
+var benchTopologies = []struct {
+	name string
+	topo *topology.CPUTopology
+}{
+	{"EPYCMilanDualSocket", fixtures.EPYCMilanDualSocket()},
+	{"EPYCGenoaDualSocket", fixtures.EPYCGenoaDualSocket()},
+	{"XeonSapphireRapidsSNC2", fixtures.XeonSapphireRapidsSNC2()},
+	{"XeonSapphireRapidsSNC4", fixtures.XeonSapphireRapidsSNC4()},
+	{"GravitonSingleSocket", fixtures.GravitonSingleSocket()},
+}
+
+func BenchmarkTakeByTopologyNUMAPacked(b *testing.B) {
+	for _, bt := range benchTopologies {
+		available := bt.topo.CPUDetails.CPUs()
+		// Take a quarter of the machine: large enough to span alignment
+		// boundaries, small enough to leave the allocator choices to make.
+		numCPUs := bt.topo.NumCPUs / 4
+		b.Run(fmt.Sprintf("%s/take%d", bt.name, numCPUs), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := TakeByTopologyNUMAPacked(bt.topo, available, numCPUs); err != nil {
+					b.Fatalf("TakeByTopologyNUMAPacked() failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTakeByTopologyUncoreCachePacked(b *testing.B) {
+	for _, bt := range benchTopologies {
+		available := bt.topo.CPUDetails.CPUs()
+		numCPUs := bt.topo.NumCPUs / 4
+		b.Run(fmt.Sprintf("%s/take%d", bt.name, numCPUs), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := TakeByTopologyUncoreCachePacked(bt.topo, available, numCPUs); err != nil {
+					b.Fatalf("TakeByTopologyUncoreCachePacked() failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package cpumanager
+package allocation
 
 import (
 	"reflect"
@@ -655,7 +655,192 @@ func TestTakeByTopologyNUMAPacked(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			result, err := takeByTopologyNUMAPacked(tc.topo, tc.availableCPUs, tc.numCPUs)
+			result, err := TakeByTopologyNUMAPacked(tc.topo, tc.availableCPUs, tc.numCPUs)
+			if tc.expErr != "" && err != nil && err.Error() != tc.expErr {
+				t.Errorf("expected error to be [%v] but it was [%v]", tc.expErr, err)
+			}
+			if !result.Equals(tc.expResult) {
+				t.Errorf("expected result [%s] to equal [%s]", result, tc.expResult)
+			}
+		})
+	}
+}
+
+func TestTakeByTopologyUncoreCachePacked(t *testing.T) {
+	testCases := []takeByTopologyTestCase{
+		{
+			"take a whole free uncore cache",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-15"),
+			4,
+			"",
+			mustParseCPUSet(t, "0-3"),
+		},
+		{
+			"mid-sized request lands on the free uncore cache instead of leftover cores",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "3,4-7"),
+			2,
+			"",
+			mustParseCPUSet(t, "4-5"),
+		},
+		{
+			"whole caches first, then the remainder from the cache with the largest free share",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "1-3,4-7,8-11,13-15"),
+			6,
+			"",
+			mustParseCPUSet(t, "4-9"),
+		},
+		{
+			"no single cache can host the remainder, fall through to core packing",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-1,4-5,8-9"),
+			5,
+			"",
+			mustParseCPUSet(t, "0-1,4-5,8"),
+		},
+		{
+			"not enough cpus available",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-3"),
+			6,
+			"not enough cpus available to satisfy request: requested=6, available=4",
+			cpuset.New(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := TakeByTopologyUncoreCachePacked(tc.topo, tc.availableCPUs, tc.numCPUs)
+			if tc.expErr != "" && err != nil && err.Error() != tc.expErr {
+				t.Errorf("expected error to be [%v] but it was [%v]", tc.expErr, err)
+			}
+			if !result.Equals(tc.expResult) {
+				t.Errorf("expected result [%s] to equal [%s]", result, tc.expResult)
+			}
+		})
+	}
+}
+
+func TestTakeByTopologyClusterPacked(t *testing.T) {
+	testCases := []takeByTopologyTestCase{
+		{
+			"take a whole free cluster",
+			topoClusterSingleSocketNoHT,
+			mustParseCPUSet(t, "0-15"),
+			4,
+			"",
+			mustParseCPUSet(t, "0-3"),
+		},
+		{
+			"mid-sized request lands on the free cluster instead of leftover cores",
+			topoClusterSingleSocketNoHT,
+			mustParseCPUSet(t, "3,4-7"),
+			2,
+			"",
+			mustParseCPUSet(t, "4-5"),
+		},
+		{
+			"whole clusters first, then the remainder from the cluster with the largest free share",
+			topoClusterSingleSocketNoHT,
+			mustParseCPUSet(t, "1-3,4-7,8-11,13-15"),
+			6,
+			"",
+			mustParseCPUSet(t, "4-9"),
+		},
+		{
+			"no single cluster can host the remainder, fall through to core packing",
+			topoClusterSingleSocketNoHT,
+			mustParseCPUSet(t, "0-1,4-5,8-9"),
+			5,
+			"",
+			mustParseCPUSet(t, "0-1,4-5,8"),
+		},
+		{
+			"not enough cpus available",
+			topoClusterSingleSocketNoHT,
+			mustParseCPUSet(t, "0-3"),
+			6,
+			"not enough cpus available to satisfy request: requested=6, available=4",
+			cpuset.New(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := TakeByTopologyClusterPacked(tc.topo, tc.availableCPUs, tc.numCPUs)
+			if tc.expErr != "" && err != nil && err.Error() != tc.expErr {
+				t.Errorf("expected error to be [%v] but it was [%v]", tc.expErr, err)
+			}
+			if !result.Equals(tc.expResult) {
+				t.Errorf("expected result [%s] to equal [%s]", result, tc.expResult)
+			}
+		})
+	}
+}
+
+func TestTakeByTopologyUncoreCacheDistributed(t *testing.T) {
+	testCases := []takeByTopologyExtendedTestCase{
+		{
+			"spread one CPU on each uncore cache",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-15"),
+			4,
+			1,
+			"",
+			mustParseCPUSet(t, "0,4,8,12"),
+		},
+		{
+			"spread two CPUs on each uncore cache",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-15"),
+			8,
+			1,
+			"",
+			mustParseCPUSet(t, "0-1,4-5,8-9,12-13"),
+		},
+		{
+			"uneven availability, caches drain at the same rate",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-3,4-5,8-11"),
+			6,
+			1,
+			"",
+			mustParseCPUSet(t, "0-2,4,8-9"),
+		},
+		{
+			"no cache can supply a whole group, remainder taken packed",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-1,4,8"),
+			4,
+			2,
+			"",
+			mustParseCPUSet(t, "0-1,4,8"),
+		},
+		{
+			"request not a multiple of the group size falls back to packing",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-15"),
+			3,
+			2,
+			"",
+			mustParseCPUSet(t, "0-2"),
+		},
+		{
+			"not enough cpus available",
+			topoUncoreSingleSocketNoHT,
+			mustParseCPUSet(t, "0-3"),
+			6,
+			1,
+			"not enough cpus available to satisfy request: requested=6, available=4",
+			cpuset.New(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := TakeByTopologyUncoreCacheDistributed(tc.topo, tc.availableCPUs, tc.numCPUs, tc.cpuGroupSize)
 			if tc.expErr != "" && err != nil && err.Error() != tc.expErr {
 				t.Errorf("expected error to be [%v] but it was [%v]", tc.expErr, err)
 			}
@@ -845,6 +1030,15 @@ func TestTakeByTopologyNUMADistributed(t *testing.T) {
 			"",
 			mustParseCPUSet(t, "0-3,10-13,20-23,30-34,40-43,50-53,60-63,70-74"),
 		},
+		{
+			"distribute across NUMA nodes while preferring a whole uncore cache within each node",
+			topoUncoreDualSocketNoHT,
+			mustParseCPUSet(t, "0,1,4-9,12-15"),
+			8,
+			1,
+			"",
+			mustParseCPUSet(t, "4-7,12-15"),
+		},
 		{
 			"ensure previous failure encountered on live machine has been fixed (1/1)",
 			topoDualSocketMultiNumaPerSocketHTLarge,
@@ -858,7 +1052,7 @@ func TestTakeByTopologyNUMADistributed(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			result, err := takeByTopologyNUMADistributed(tc.topo, tc.availableCPUs, tc.numCPUs, tc.cpuGroupSize)
+			result, err := TakeByTopologyNUMADistributed(tc.topo, tc.availableCPUs, tc.numCPUs, tc.cpuGroupSize)
 			if err != nil {
 				if tc.expErr == "" {
 					t.Errorf("unexpected error [%v]", err)
@@ -882,3 +1076,131 @@ func mustParseCPUSet(t *testing.T, s string) cpuset.CPUSet {
 	}
 	return cpus
 }
+
+// TestTakeByTopologyDeterminism verifies that repeated allocations from the
+// same state always hand out the same cpuset, regardless of map iteration
+// order in the internal bookkeeping. Users fingerprint the assigned cpusets
+// (e.g. for cache warm-up), so any non-determinism here is a regression even
+// when all the possible outcomes are equally good placements.
+func TestTakeByTopologyDeterminism(t *testing.T) {
+	testCases := []struct {
+		description   string
+		topo          *topology.CPUTopology
+		availableCPUs cpuset.CPUSet
+		numCPUs       int
+		take          func(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error)
+	}{
+		{
+			description:   "packed allocation",
+			topo:          topoDualSocketHT,
+			availableCPUs: mustParseCPUSet(t, "0-11"),
+			numCPUs:       3,
+			take:          TakeByTopologyNUMAPacked,
+		},
+		{
+			description:   "packed allocation from a fragmented state",
+			topo:          topoDualSocketHT,
+			availableCPUs: mustParseCPUSet(t, "1-3,5,7-9,11"),
+			numCPUs:       5,
+			take:          TakeByTopologyNUMAPacked,
+		},
+		{
+			description:   "uncore cache aligned allocation",
+			topo:          topoUncoreSingleSocketNoHT,
+			availableCPUs: mustParseCPUSet(t, "1-3,4-7,8-11,13-15"),
+			numCPUs:       6,
+			take:          TakeByTopologyUncoreCachePacked,
+		},
+		{
+			description:   "distributed allocation across NUMA nodes",
+			topo:          topoDualSocketMultiNumaPerSocketHT,
+			availableCPUs: mustParseCPUSet(t, "0-79"),
+			numCPUs:       24,
+			take: func(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error) {
+				return TakeByTopologyNUMADistributed(topo, availableCPUs, numCPUs, 2)
+			},
+		},
+		{
+			description:   "distributed allocation with a remainder",
+			topo:          topoDualSocketMultiNumaPerSocketHT,
+			availableCPUs: mustParseCPUSet(t, "0-79"),
+			numCPUs:       28,
+			take: func(topo *topology.CPUTopology, availableCPUs cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error) {
+				return TakeByTopologyNUMADistributed(topo, availableCPUs, numCPUs, 2)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			reference, err := tc.take(tc.topo, tc.availableCPUs, tc.numCPUs)
+			if err != nil {
+				t.Fatalf("expected no error [%v]", err)
+			}
+			for i := 0; i < 100; i++ {
+				result, err := tc.take(tc.topo, tc.availableCPUs, tc.numCPUs)
+				if err != nil {
+					t.Fatalf("expected no error [%v]", err)
+				}
+				if !result.Equals(reference) {
+					t.Fatalf("expected result [%s] to equal [%s] on repetition %d", result, reference, i)
+				}
+			}
+		})
+	}
+}
+
+func TestTakeByTopologySMTIsolated(t *testing.T) {
+	testCases := []struct {
+		description   string
+		topo          *topology.CPUTopology
+		availableCPUs cpuset.CPUSet
+		numCPUs       int
+		expErr        string
+		expResult     cpuset.CPUSet
+		expSiblings   cpuset.CPUSet
+	}{
+		{
+			"one thread per core, siblings withheld",
+			topoSingleSocketHT,
+			mustParseCPUSet(t, "0-7"),
+			2,
+			"",
+			cpuset.New(0, 1),
+			cpuset.New(4, 5),
+		},
+		{
+			"partially allocated cores are skipped",
+			topoSingleSocketHT,
+			mustParseCPUSet(t, "1,2,4,5,6"),
+			2,
+			"",
+			cpuset.New(1, 2),
+			cpuset.New(5, 6),
+		},
+		{
+			"not enough free physical cores",
+			topoSingleSocketHT,
+			mustParseCPUSet(t, "0,1,4"),
+			2,
+			"not enough free physical cores to satisfy request: requested=2, free cores=1",
+			cpuset.New(),
+			cpuset.New(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, siblings, err := TakeByTopologySMTIsolated(tc.topo, tc.availableCPUs, tc.numCPUs)
+			if tc.expErr != "" && err != nil && err.Error() != tc.expErr {
+				t.Errorf("expected error to be [%v] but it was [%v]", tc.expErr, err)
+			}
+			if !result.Equals(tc.expResult) {
+				t.Errorf("expected result [%s] to equal [%s]", result, tc.expResult)
+			}
+			if !siblings.Equals(tc.expSiblings) {
+				t.Errorf("expected siblings [%s] to equal [%s]", siblings, tc.expSiblings)
+			}
+		})
+	}
+}
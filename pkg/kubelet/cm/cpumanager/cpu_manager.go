@@ -20,22 +20,32 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	cadvisorapi "github.com/google/cadvisor/info/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 	"k8s.io/klog/v2"
 
+	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
 	"k8s.io/kubernetes/pkg/kubelet/config"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/events"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/status"
+	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/utils/cpuset"
 )
 
@@ -44,6 +54,7 @@ type ActivePodsFunc func() []*v1.Pod
 
 type runtimeService interface {
 	UpdateContainerResources(ctx context.Context, id string, resources *runtimeapi.ContainerResources) error
+	ContainerStatus(ctx context.Context, containerID string, verbose bool) (*runtimeapi.ContainerStatusResponse, error)
 }
 
 type policyName string
@@ -51,6 +62,18 @@ type policyName string
 // cpuManagerStateFileName is the file name where cpu manager stores its state
 const cpuManagerStateFileName = "cpu_manager_state"
 
+// PhysicalCoresFreeResourceName is the extended resource through which the
+// static policy advertises how many whole physical cores are still free for
+// exclusive allocation, so schedulers enforcing full-pcpus-only semantics can
+// filter out nodes whose logical allocatable looks sufficient but whose free
+// CPUs are scattered across partially allocated cores.
+const PhysicalCoresFreeResourceName v1.ResourceName = "kubelet.kubernetes.io/physical-cores-free"
+
+// cpuManagerTopologyFingerprintFileName is the file, sitting next to the state
+// checkpoint, which holds the fingerprint of the topology the checkpoint was
+// written against.
+const cpuManagerTopologyFingerprintFileName = "cpu_manager_topology_fingerprint"
+
 // Manager interface provides methods for Kubelet to manage pod cpus.
 type Manager interface {
 	// Start is called during Kubelet initialization.
@@ -93,6 +116,31 @@ type Manager interface {
 	// GetCPUAffinity returns cpuset which includes cpus from shared pools
 	// as well as exclusively allocated cpus
 	GetCPUAffinity(podUID, containerName string) cpuset.CPUSet
+
+	// GetExclusiveCPUDetails returns the topology details (socket, NUMA node,
+	// uncore cache) of the cpus exclusively allocated to the container, if any.
+	GetExclusiveCPUDetails(podUID, containerName string) topology.CPUDetails
+
+	// GetAllocatableCPUDetails returns the topology details of the cpus
+	// available for allocation.
+	GetAllocatableCPUDetails() topology.CPUDetails
+
+	// CheckpointFile returns the path of the checkpoint file the manager
+	// uses to persist the CPU assignments across kubelet restarts.
+	CheckpointFile() string
+
+	// StateSnapshot returns a point-in-time, JSON-serializable copy of the
+	// CPU assignments of the manager, for instrumentation and debugging.
+	StateSnapshot() interface{}
+
+	// ConfigSnapshot returns a JSON-serializable copy of the effective,
+	// post-defaulting configuration the manager is running with.
+	ConfigSnapshot() interface{}
+
+	// GetExtendedResourceCapacity returns the extended resources the manager
+	// advertises on the node, derived from its current allocation state.
+	// Policies which do not track exclusive assignments return nil.
+	GetExtendedResourceCapacity() v1.ResourceList
 }
 
 type manager struct {
@@ -139,8 +187,58 @@ type manager struct {
 	// allocatableCPUs is the set of online CPUs as reported by the system
 	allocatableCPUs cpuset.CPUSet
 
+	// burstSiblings reflects the homonymous static policy option: containers
+	// with exclusively allocated CPUs also get soft access to the SMT siblings
+	// of their CPUs for as long as the siblings sit in the shared pool.
+	burstSiblings bool
+
+	// uncoreSharedPools reflects the homonymous static policy option: each
+	// Burstable pod is confined to the shared CPUs of a single uncore cache,
+	// assigned round-robin among the caches.
+	uncoreSharedPools bool
+
+	// exclusiveUncoreCPUs reflects the exclusive-only-uncore-caches static
+	// policy option: these CPUs never serve the shared pool, keeping their
+	// caches free for full-cache exclusive allocation.
+	exclusiveUncoreCPUs cpuset.CPUSet
+
+	// irqExclusion mirrors the exclusively assigned CPUs into the irqbalance
+	// ban-list file configured through the irq-ban-list-file static policy
+	// option. Nil when the option is unset.
+	irqExclusion *irqExclusion
+
+	// admissionFailures is the bounded history of failed CPU allocations
+	// during pod admission, surfaced through the status file. Guarded by
+	// the manager lock.
+	admissionFailures []admissionFailure
+
+	// recoverCorruptCheckpoint reflects the homonymous static policy option:
+	// when the state checkpoint cannot be restored, the assignment state is
+	// rebuilt from the actual cpuset cgroups of the running containers
+	// instead of refusing to start.
+	recoverCorruptCheckpoint bool
+
+	// sharedUncoreByPod maps each Burstable pod to the uncore cache backing
+	// its shared sub-pool. The assignments live only in memory: after a
+	// kubelet restart the pods are simply assigned again.
+	sharedUncoreByPod map[string]int
+	// nextSharedUncore is the round-robin cursor over the uncore caches.
+	nextSharedUncore int
+
 	// pendingAdmissionPod contain the pod during the admission phase
 	pendingAdmissionPod *v1.Pod
+
+	// recorder and nodeRef are used to surface node events when the online
+	// CPU validation finds the state out of sync with the machine. Both may
+	// be nil, in which case no events are emitted.
+	recorder record.EventRecorder
+	nodeRef  *v1.ObjectReference
+
+	// onlineCPUs returns the CPUs currently online, re-read from sysfs so
+	// that runtime changes are seen. It is a field only to be overridable
+	// from the tests; when nil the validation falls back to the
+	// discovery-time snapshot.
+	onlineCPUs func() (cpuset.CPUSet, error)
 }
 
 var _ Manager = &manager{}
@@ -151,7 +249,7 @@ func (s *sourcesReadyStub) AddSource(source string) {}
 func (s *sourcesReadyStub) AllReady() bool          { return true }
 
 // NewManager creates new cpu manager based on provided policy
-func NewManager(cpuPolicyName string, cpuPolicyOptions map[string]string, reconcilePeriod time.Duration, machineInfo *cadvisorapi.MachineInfo, specificCPUs cpuset.CPUSet, nodeAllocatableReservation v1.ResourceList, stateFileDirectory string, affinity topologymanager.Store) (Manager, error) {
+func NewManager(cpuPolicyName string, cpuPolicyOptions map[string]string, reconcilePeriod time.Duration, machineInfo *cadvisorapi.MachineInfo, specificCPUs cpuset.CPUSet, nodeAllocatableReservation v1.ResourceList, stateFileDirectory string, affinity topologymanager.Store, recorder record.EventRecorder, nodeRef *v1.ObjectReference) (Manager, error) {
 	var topo *topology.CPUTopology
 	var policy Policy
 	var err error
@@ -165,7 +263,11 @@ func NewManager(cpuPolicyName string, cpuPolicyOptions map[string]string, reconc
 		}
 
 	case PolicyStatic:
-		topo, err = topology.Discover(machineInfo)
+		if sysFSTopologyDiscoveryEnabled(cpuPolicyOptions) {
+			topo, err = topology.DiscoverFromSysFS(machineInfo)
+		} else {
+			topo, err = topology.Discover(machineInfo)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -205,11 +307,44 @@ func NewManager(cpuPolicyName string, cpuPolicyOptions map[string]string, reconc
 		topology:                   topo,
 		nodeAllocatableReservation: nodeAllocatableReservation,
 		stateFileDirectory:         stateFileDirectory,
+		recorder:                   recorder,
+		nodeRef:                    nodeRef,
+		onlineCPUs:                 topology.OnlineCPUs,
+	}
+	if staticPolicy, ok := policy.(*staticPolicy); ok {
+		manager.burstSiblings = staticPolicy.options.BurstSiblings
+		manager.uncoreSharedPools = staticPolicy.options.UncoreSharedPools
+		manager.exclusiveUncoreCPUs = staticPolicy.exclusiveOnlyUncoreCPUs()
+		manager.recoverCorruptCheckpoint = staticPolicy.options.RecoverCorruptCheckpoint
+		// ValidateConfig dry-runs with no state directory; like the topology
+		// fingerprint, the irq exclusion only works with a place to checkpoint.
+		if staticPolicy.options.IRQBanListFile != "" && stateFileDirectory != "" {
+			manager.irqExclusion, err = newIRQExclusion(staticPolicy.options.IRQBanListFile, stateFileDirectory)
+			if err != nil {
+				return nil, fmt.Errorf("irq exclusion error: %w", err)
+			}
+		}
 	}
+	manager.sharedUncoreByPod = make(map[string]int)
 	manager.sourcesReady = &sourcesReadyStub{}
 	return manager, nil
 }
 
+// ValidateConfig dry-runs the construction steps NewManager performs for the
+// given configuration and returns the reserved CPU set it would yield,
+// without creating a manager nor touching any state on disk. The returned
+// set is empty for policies which do not reserve exclusive CPUs.
+func ValidateConfig(cpuPolicyName string, cpuPolicyOptions map[string]string, machineInfo *cadvisorapi.MachineInfo, specificCPUs cpuset.CPUSet, nodeAllocatableReservation v1.ResourceList) (cpuset.CPUSet, error) {
+	mgr, err := NewManager(cpuPolicyName, cpuPolicyOptions, time.Second, machineInfo, specificCPUs, nodeAllocatableReservation, "", topologymanager.NewFakeManager(), nil, nil)
+	if err != nil {
+		return cpuset.New(), err
+	}
+	if staticPolicy, ok := mgr.(*manager).policy.(*staticPolicy); ok {
+		return staticPolicy.reservedCPUs.Clone(), nil
+	}
+	return cpuset.New(), nil
+}
+
 func (m *manager) Start(activePods ActivePodsFunc, sourcesReady config.SourcesReady, podStatusProvider status.PodStatusProvider, containerRuntime runtimeService, initialContainers containermap.ContainerMap) error {
 	klog.InfoS("Starting CPU manager", "policy", m.policy.Name())
 	klog.InfoS("Reconciling", "reconcilePeriod", m.reconcilePeriod)
@@ -221,28 +356,170 @@ func (m *manager) Start(activePods ActivePodsFunc, sourcesReady config.SourcesRe
 
 	stateImpl, err := state.NewCheckpointState(m.stateFileDirectory, cpuManagerStateFileName, m.policy.Name(), m.containerMap)
 	if err != nil {
-		klog.ErrorS(err, "Could not initialize checkpoint manager, please drain node and remove policy state file")
-		return err
+		if !m.recoverCorruptCheckpoint {
+			klog.ErrorS(err, "Could not initialize checkpoint manager, please drain node and remove policy state file")
+			return err
+		}
+		klog.ErrorS(err, "Could not restore the state checkpoint, rebuilding the state from the container runtime")
+		stateImpl, err = m.recoverStateFromRuntime()
+		if err != nil {
+			klog.ErrorS(err, "Could not rebuild the state from the container runtime, please drain node and remove policy state file")
+			return err
+		}
+	}
+	// Observe every state mutation so the capacity gauges track the shared
+	// pool and the exclusive allocations without polling.
+	m.state = state.NewObservedState(stateImpl, m.updateStateMetrics)
+
+	if m.checkTopologyFingerprint() {
+		// The machine topology is the very same the checkpoint was written
+		// against, so the state cannot disagree with it: let the policy skip
+		// the expensive part of its validation.
+		klog.V(2).InfoS("Machine topology unchanged since the last run")
+		if staticPolicy, ok := m.policy.(*staticPolicy); ok {
+			staticPolicy.topologyUnchanged = true
+		}
 	}
-	m.state = stateImpl
 
 	err = m.policy.Start(m.state)
 	if err != nil {
 		klog.ErrorS(err, "Policy start error")
 		return err
 	}
+	// Seed the gauges with the restored state: the policy start may not
+	// mutate anything when the checkpoint is already consistent.
+	m.updateStateMetrics(m.state)
 
 	m.allocatableCPUs = m.policy.GetAllocatableCPUs(m.state)
 
+	if m.irqExclusion != nil {
+		if err := m.irqExclusion.restore(); err != nil {
+			// Not fatal: the first sync below rewrites the current ban-list
+			// file anyway, only the cleanup of a relocated file may be lost.
+			klog.ErrorS(err, "Failed to restore the IRQ exclusion checkpoint")
+		}
+		m.syncIRQExclusion()
+	}
+
+	// Write the status summary once up front, so debug sessions find the
+	// file even before the first reconcile pass.
+	m.writeStatusFile()
+
 	if m.policy.Name() == string(PolicyNone) {
 		return nil
 	}
 	// Periodically call m.reconcileState() to continue to keep the CPU sets of
-	// all pods in sync with and guaranteed CPUs handed out among them.
-	go wait.Until(func() { m.reconcileState() }, m.reconcilePeriod, wait.NeverStop)
+	// all pods in sync with and guaranteed CPUs handed out among them. The
+	// status summary is refreshed on the same cadence.
+	go wait.Until(func() {
+		m.reconcileState()
+		m.writeStatusFile()
+	}, m.reconcilePeriod, wait.NeverStop)
 	return nil
 }
 
+// checkTopologyFingerprint compares the fingerprint of the discovered
+// topology against the one persisted alongside the state checkpoint, and
+// persists the current one when they differ. It returns true when the
+// fingerprints match, meaning the machine topology did not change since the
+// checkpoint was written. A missing or unreadable fingerprint file counts as
+// a change, forcing the full validation path.
+func (m *manager) checkTopologyFingerprint() bool {
+	if m.topology == nil || m.stateFileDirectory == "" {
+		return false
+	}
+	fingerprint := m.topology.Fingerprint()
+	fingerprintPath := filepath.Join(m.stateFileDirectory, cpuManagerTopologyFingerprintFileName)
+	saved, err := os.ReadFile(fingerprintPath)
+	if err == nil && strings.TrimSpace(string(saved)) == fingerprint {
+		return true
+	}
+	if err := os.WriteFile(fingerprintPath, []byte(fingerprint+"\n"), 0644); err != nil {
+		// Not fatal: the next restart just takes the full validation path again.
+		klog.ErrorS(err, "Failed to persist the topology fingerprint", "path", fingerprintPath)
+	}
+	return false
+}
+
+// recoverStateFromRuntime rebuilds the assignment state after a failed
+// checkpoint restore, when the recover-corrupt-checkpoint policy option is
+// set. The unreadable checkpoint file is deleted and the actual cpuset of
+// every container known to the runtime is read back through the CRI: a
+// cpuset disjoint from the reserved CPUs can only result from an exclusive
+// allocation and is recorded as an assignment, everything else ran on the
+// shared pool. The default cpuset is what remains, and writing the rebuilt
+// state produces a fresh checkpoint. The policy then validates the result
+// on start like any restored state.
+func (m *manager) recoverStateFromRuntime() (state.State, error) {
+	checkpointPath := filepath.Join(m.stateFileDirectory, cpuManagerStateFileName)
+	if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not remove checkpoint file %q: %w", checkpointPath, err)
+	}
+	stateImpl, err := state.NewCheckpointState(m.stateFileDirectory, cpuManagerStateFileName, m.policy.Name(), m.containerMap)
+	if err != nil {
+		return nil, err
+	}
+
+	staticPolicy, ok := m.policy.(*staticPolicy)
+	if !ok {
+		// Only the static policy tracks assignments: the empty state is complete.
+		return stateImpl, nil
+	}
+
+	reservedCPUs := staticPolicy.reservedCPUs
+	assignments := state.ContainerCPUAssignments{}
+	exclusiveCPUs := cpuset.New()
+	numExclusiveContainers := 0
+	var recoverErr error
+	m.containerMap.Visit(func(podUID, containerName, containerID string) {
+		if recoverErr != nil {
+			return
+		}
+		resp, err := m.containerRuntime.ContainerStatus(context.TODO(), containerID, false)
+		if err != nil {
+			// The container may be gone already; its CPUs end up in the default set.
+			klog.InfoS("State recovery: could not read container status, assuming no exclusive CPUs", "err", err, "podUID", podUID, "containerName", containerName, "containerID", containerID)
+			return
+		}
+		cpus := resp.GetStatus().GetResources().GetLinux().GetCpusetCpus()
+		if cpus == "" {
+			// No cpuset pinning at all: the container ran on the shared pool.
+			return
+		}
+		cset, err := cpuset.Parse(cpus)
+		if err != nil {
+			recoverErr = fmt.Errorf("could not parse cpuset %q of container %q in pod %q: %w", cpus, containerName, podUID, err)
+			return
+		}
+		if !cset.Intersection(reservedCPUs).IsEmpty() {
+			// Exclusive allocations never contain reserved CPUs: the
+			// container ran on the shared pool, or on a sub-pool of it.
+			klog.V(4).InfoS("State recovery: container ran on the shared pool", "podUID", podUID, "containerName", containerName, "cpus", cset.String())
+			return
+		}
+		if overlap := cset.Intersection(exclusiveCPUs); !overlap.IsEmpty() {
+			recoverErr = fmt.Errorf("cpuset %q of container %q in pod %q overlaps another exclusive assignment on CPUs %q", cset.String(), containerName, podUID, overlap.String())
+			return
+		}
+		klog.InfoS("State recovery: recovered exclusive CPU assignment", "podUID", podUID, "containerName", containerName, "cpus", cset.String())
+		if assignments[podUID] == nil {
+			assignments[podUID] = make(map[string]cpuset.CPUSet)
+		}
+		assignments[podUID][containerName] = cset
+		exclusiveCPUs = exclusiveCPUs.Union(cset)
+		numExclusiveContainers++
+	})
+	if recoverErr != nil {
+		return nil, recoverErr
+	}
+
+	defaultCPUSet := m.topology.CPUDetails.CPUs().Difference(exclusiveCPUs)
+	stateImpl.SetDefaultCPUSet(defaultCPUSet)
+	stateImpl.SetCPUAssignments(assignments)
+	klog.InfoS("State recovery: rebuilt state from the container runtime", "numExclusiveContainers", numExclusiveContainers, "defaultCpuSet", defaultCPUSet.String())
+	return stateImpl, nil
+}
+
 func (m *manager) Allocate(p *v1.Pod, c *v1.Container) error {
 	// The pod is during the admission phase. We need to save the pod to avoid it
 	// being cleaned before the admission ended
@@ -258,9 +535,22 @@ func (m *manager) Allocate(p *v1.Pod, c *v1.Container) error {
 	err := m.policy.Allocate(m.state, p, c)
 	if err != nil {
 		klog.ErrorS(err, "Allocate error")
+		m.recordAdmissionFailure(p, c, err)
 		return err
 	}
 
+	// Flag static and mirror pods which got exclusive CPUs, so after a restart
+	// their assignments can be told apart even before the API server is
+	// reachable. Static pods are re-admitted first on startup and must find
+	// their CPUs where they left them.
+	if kubetypes.IsStaticPod(p) || kubetypes.IsMirrorPod(p) {
+		if _, exclusive := m.state.GetCPUSet(string(p.UID), c.Name); exclusive {
+			m.state.SetStaticPod(string(p.UID))
+		}
+	}
+
+	m.syncIRQExclusion()
+
 	return nil
 }
 
@@ -283,6 +573,8 @@ func (m *manager) RemoveContainer(containerID string) error {
 		return err
 	}
 
+	m.syncIRQExclusion()
+
 	return nil
 }
 
@@ -315,6 +607,67 @@ func (m *manager) State() state.Reader {
 	return m.state
 }
 
+func (m *manager) CheckpointFile() string {
+	return filepath.Join(m.stateFileDirectory, cpuManagerStateFileName)
+}
+
+// StateSnapshot returns the current CPU assignments in the same shape the
+// state checkpoint uses, with the cpusets rendered in canonical string form.
+func (m *manager) StateSnapshot() interface{} {
+	assignments := m.state.GetCPUAssignments()
+	entries := make(map[string]map[string]string, len(assignments))
+	for podUID, containers := range assignments {
+		entries[podUID] = make(map[string]string, len(containers))
+		for containerName, cset := range containers {
+			entries[podUID][containerName] = cset.String()
+		}
+	}
+	return struct {
+		PolicyName    string                       `json:"policyName"`
+		DefaultCPUSet string                       `json:"defaultCpuSet"`
+		Entries       map[string]map[string]string `json:"entries,omitempty"`
+	}{
+		PolicyName:    m.policy.Name(),
+		DefaultCPUSet: m.state.GetDefaultCPUSet().String(),
+		Entries:       entries,
+	}
+}
+
+// ConfigSnapshot returns the effective configuration the manager is running
+// with: the policy picked at startup and, for the static policy, the computed
+// reserved set and the options left after parsing and feature gate pruning.
+func (m *manager) ConfigSnapshot() interface{} {
+	snapshot := struct {
+		PolicyName      string               `json:"policyName"`
+		ReconcilePeriod string               `json:"reconcilePeriod"`
+		ReservedCPUs    string               `json:"reservedCpus,omitempty"`
+		PolicyOptions   *StaticPolicyOptions `json:"policyOptions,omitempty"`
+	}{
+		PolicyName:      m.policy.Name(),
+		ReconcilePeriod: m.reconcilePeriod.String(),
+	}
+	if staticPolicy, ok := m.policy.(*staticPolicy); ok {
+		snapshot.ReservedCPUs = staticPolicy.reservedCPUs.String()
+		options := staticPolicy.options
+		snapshot.PolicyOptions = &options
+	}
+	return snapshot
+}
+
+// GetExtendedResourceCapacity reports the number of free whole physical cores
+// as the PhysicalCoresFreeResourceName extended resource. Only the static
+// policy performs exclusive allocations, so other policies advertise nothing.
+func (m *manager) GetExtendedResourceCapacity() v1.ResourceList {
+	staticPolicy, ok := m.policy.(*staticPolicy)
+	if !ok || m.state == nil {
+		return nil
+	}
+	freeCores := staticPolicy.GetFreePhysicalCores(m.state)
+	return v1.ResourceList{
+		PhysicalCoresFreeResourceName: *resource.NewQuantity(int64(freeCores.Size()), resource.DecimalSI),
+	}
+}
+
 func (m *manager) GetTopologyHints(pod *v1.Pod, container *v1.Container) map[string][]topologymanager.TopologyHint {
 	// The pod is during the admission phase. We need to save the pod to avoid it
 	// being cleaned before the admission ended
@@ -399,6 +752,31 @@ func (m *manager) removeStaleState() {
 			}
 		}
 	})
+
+	// Drop the uncore cache sub-pool assignments of the pods which went away.
+	for podUID := range m.sharedUncoreByPod {
+		if _, ok := activeContainers[podUID]; !ok {
+			delete(m.sharedUncoreByPod, podUID)
+		}
+	}
+
+	m.syncIRQExclusion()
+}
+
+// syncIRQExclusion recomputes the set of exclusively assigned CPUs from the
+// state and mirrors it into the IRQ ban-list file. The caller must hold the
+// manager lock.
+func (m *manager) syncIRQExclusion() {
+	if m.irqExclusion == nil {
+		return
+	}
+	banned := cpuset.New()
+	for _, containers := range m.state.GetCPUAssignments() {
+		for _, cset := range containers {
+			banned = banned.Union(cset)
+		}
+	}
+	m.irqExclusion.sync(banned)
 }
 
 func (m *manager) reconcileState() (success []reconciledContainer, failure []reconciledContainer) {
@@ -407,6 +785,7 @@ func (m *manager) reconcileState() (success []reconciledContainer, failure []rec
 	failure = []reconciledContainer{}
 
 	m.removeStaleState()
+	m.validateOnlineCPUs()
 	for _, pod := range m.activePods() {
 		pstatus, ok := m.podStatusProvider.GetPodStatus(pod.UID)
 		if !ok {
@@ -468,6 +847,18 @@ func (m *manager) reconcileState() (success []reconciledContainer, failure []rec
 				continue
 			}
 
+			if !m.exclusiveUncoreCPUs.IsEmpty() {
+				cset = m.withoutExclusiveUncore(string(pod.UID), container.Name, cset)
+			}
+
+			if m.burstSiblings {
+				cset = m.withBurstSiblings(string(pod.UID), container.Name, cset)
+			}
+
+			if m.uncoreSharedPools {
+				cset = m.sharedUncorePool(pod, container.Name, cset)
+			}
+
 			lcset := m.lastUpdateState.GetCPUSetOrDefault(string(pod.UID), container.Name)
 			if !cset.Equals(lcset) {
 				klog.V(4).InfoS("ReconcileState: updating container", "pod", klog.KObj(pod), "containerName", container.Name, "containerID", containerID, "cpuSet", cset)
@@ -485,6 +876,159 @@ func (m *manager) reconcileState() (success []reconciledContainer, failure []rec
 	return success, failure
 }
 
+// withBurstSiblings returns the given container cpuset extended with the SMT
+// siblings of its exclusively allocated CPUs which currently sit in the
+// shared pool. The extension is soft and recomputed on every reconcile: once
+// a sibling gets exclusively allocated to another container it leaves the
+// shared pool, and the access is revoked with the next update. Containers
+// running in the shared pool are returned unmodified.
+func (m *manager) withBurstSiblings(podUID, containerName string, cset cpuset.CPUSet) cpuset.CPUSet {
+	exclusiveCPUs, ok := m.state.GetCPUSet(podUID, containerName)
+	if !ok {
+		return cset
+	}
+	cores := m.topology.CPUDetails.KeepOnly(exclusiveCPUs).Cores()
+	siblings := m.topology.CPUDetails.CPUsInCores(cores.List()...).Difference(exclusiveCPUs)
+	return cset.Union(siblings.Intersection(m.state.GetDefaultCPUSet()))
+}
+
+// withoutExclusiveUncore removes the CPUs of the exclusive-only uncore caches
+// from the cpuset of a container running in the shared pool. Containers with
+// exclusively allocated CPUs are returned unmodified: full-cache allocations
+// are exactly what these caches are kept free for. Should the trimming leave
+// no CPU - the whole shared pool living on exclusive-only caches is a
+// misconfiguration - the container keeps the untrimmed set, since a container
+// must always have CPUs to run on.
+func (m *manager) withoutExclusiveUncore(podUID, containerName string, cset cpuset.CPUSet) cpuset.CPUSet {
+	if _, ok := m.state.GetCPUSet(podUID, containerName); ok {
+		return cset
+	}
+	trimmed := cset.Difference(m.exclusiveUncoreCPUs)
+	if trimmed.IsEmpty() {
+		return cset
+	}
+	return trimmed
+}
+
+// sharedUncorePool confines a Burstable container running in the shared pool
+// to the shared CPUs of the uncore cache assigned to its pod. Pods get their
+// cache round-robin on first sight and keep it for their whole lifetime. The
+// confinement is soft and recomputed on every reconcile: the sub-pool shrinks
+// and grows with the exclusive allocations on its cache, and once no shared
+// CPU is left there the container falls back to the whole shared pool.
+// Containers with exclusively allocated CPUs and non-Burstable pods are
+// returned unmodified.
+func (m *manager) sharedUncorePool(pod *v1.Pod, containerName string, cset cpuset.CPUSet) cpuset.CPUSet {
+	if _, ok := m.state.GetCPUSet(string(pod.UID), containerName); ok {
+		// exclusively allocated, not running in the shared pool
+		return cset
+	}
+	if v1qos.GetPodQOS(pod) != v1.PodQOSBurstable {
+		return cset
+	}
+
+	m.Lock()
+	cacheID, ok := m.sharedUncoreByPod[string(pod.UID)]
+	if !ok {
+		cacheIDs := m.topology.CPUDetails.UncoreCaches().List()
+		cacheID = cacheIDs[m.nextSharedUncore%len(cacheIDs)]
+		m.nextSharedUncore++
+		m.sharedUncoreByPod[string(pod.UID)] = cacheID
+		klog.V(3).InfoS("Assigned pod to uncore cache shared sub-pool", "pod", klog.KObj(pod), "uncoreCacheID", cacheID)
+	}
+	m.Unlock()
+
+	subPool := m.topology.CPUDetails.CPUsInUncoreCaches(cacheID).Intersection(cset)
+	if subPool.IsEmpty() {
+		// the cache is fully exclusively allocated, fall back to the whole shared pool
+		return cset
+	}
+	return subPool
+}
+
+// validateOnlineCPUs cross-checks the CPU accounting in the state against the
+// CPUs currently online, re-read from sysfs on every pass so that runtime
+// changes are seen. The union of the shared pool and of all the exclusive
+// assignments must be equal to the online CPU set at all times; any
+// difference means CPUs vanished or appeared behind the kubelet back (e.g.
+// hotplug, BIOS or SMT setting changes), and the node likely needs
+// remediation.
+func (m *manager) validateOnlineCPUs() {
+	if m.topology == nil {
+		// not running the static policy, nothing to check.
+		return
+	}
+	onlineCPUs := m.topology.CPUDetails.CPUs()
+	if m.onlineCPUs != nil {
+		if current, err := m.onlineCPUs(); err != nil {
+			// sysfs is unreadable (e.g. on exotic setups): fall back to the
+			// discovery-time snapshot, which is better than no check.
+			klog.V(4).InfoS("ReconcileState: could not read the online CPUs, using the discovery-time snapshot", "err", err)
+		} else {
+			onlineCPUs = current
+		}
+	}
+
+	m.Lock()
+	knownCPUs := m.state.GetDefaultCPUSet()
+	assignments := m.state.GetCPUAssignments()
+	m.Unlock()
+
+	vanishedCPUs, appearedCPUs := onlineCPUsDelta(onlineCPUs, knownCPUs, assignments)
+	if vanishedCPUs.IsEmpty() && appearedCPUs.IsEmpty() {
+		return
+	}
+	metrics.CPUManagerOnlineCheckErrorsTotal.Inc()
+	klog.ErrorS(nil, "ReconcileState: state inconsistent with the online CPU set", "onlineCPUs", onlineCPUs, "vanishedCPUs", vanishedCPUs, "appearedCPUs", appearedCPUs)
+	if m.recorder != nil {
+		m.recorder.Eventf(m.nodeRef, v1.EventTypeWarning, events.CPUManagerOnlineCPUsMismatch,
+			"CPU manager state inconsistent with the online CPU set %v: vanished CPUs %v, appeared CPUs %v", onlineCPUs, vanishedCPUs, appearedCPUs)
+	}
+}
+
+// updateStateMetrics refreshes the shared pool and exclusive allocation
+// gauges from the given state. It runs after every state mutation, through
+// the observed state wrapper, so the gauges never go stale between scrapes.
+func (m *manager) updateStateMetrics(reader state.Reader) {
+	if m.topology == nil {
+		// not running the static policy, the whole machine is shared.
+		return
+	}
+	sharedPool := reader.GetDefaultCPUSet()
+	for _, nodeID := range m.topology.CPUDetails.NUMANodes().List() {
+		numaCPUs := m.topology.CPUDetails.CPUsInNUMANodes(nodeID).Intersection(sharedPool)
+		metrics.CPUManagerSharedPoolNUMACPUs.WithLabelValues(strconv.Itoa(nodeID)).Set(float64(numaCPUs.Size()))
+	}
+	for _, cacheID := range m.topology.CPUDetails.UncoreCaches().List() {
+		cacheCPUs := m.topology.CPUDetails.CPUsInUncoreCaches(cacheID).Intersection(sharedPool)
+		metrics.CPUManagerSharedPoolUncoreCPUs.WithLabelValues(strconv.Itoa(cacheID)).Set(float64(cacheCPUs.Size()))
+	}
+
+	exclusiveCPUs := 0
+	assignments := reader.GetCPUAssignments()
+	for podUID := range assignments {
+		for containerName := range assignments[podUID] {
+			exclusiveCPUs += assignments[podUID][containerName].Size()
+		}
+	}
+	metrics.CPUManagerExclusiveCPUsCount.Set(float64(exclusiveCPUs))
+}
+
+// onlineCPUsDelta compares the online CPU set against the CPUs the cpu manager
+// is accounting for (shared pool plus exclusive assignments), and returns the
+// CPUs tracked in the state but no longer online (vanished) and the online
+// CPUs the state knows nothing about (appeared). Both sets are empty when the
+// accounting is consistent.
+func onlineCPUsDelta(onlineCPUs, defaultCPUSet cpuset.CPUSet, assignments state.ContainerCPUAssignments) (vanished, appeared cpuset.CPUSet) {
+	knownCPUs := defaultCPUSet
+	for podUID := range assignments {
+		for containerName := range assignments[podUID] {
+			knownCPUs = knownCPUs.Union(assignments[podUID][containerName])
+		}
+	}
+	return knownCPUs.Difference(onlineCPUs), onlineCPUs.Difference(knownCPUs)
+}
+
 func findContainerIDByName(status *v1.PodStatus, name string) (string, error) {
 	allStatuses := status.InitContainerStatuses
 	allStatuses = append(allStatuses, status.ContainerStatuses...)
@@ -537,6 +1081,22 @@ func (m *manager) GetCPUAffinity(podUID, containerName string) cpuset.CPUSet {
 	return m.state.GetCPUSetOrDefault(podUID, containerName)
 }
 
+func (m *manager) GetExclusiveCPUDetails(podUID, containerName string) topology.CPUDetails {
+	if m.topology == nil {
+		// not running the static policy, so no exclusive allocation happened
+		return topology.CPUDetails{}
+	}
+	return m.topology.CPUDetails.KeepOnly(m.GetExclusiveCPUs(podUID, containerName))
+}
+
+func (m *manager) GetAllocatableCPUDetails() topology.CPUDetails {
+	if m.topology == nil {
+		// not running the static policy, so no cpus are set aside for exclusive allocation
+		return topology.CPUDetails{}
+	}
+	return m.topology.CPUDetails.KeepOnly(m.GetAllocatableCPUs())
+}
+
 func (m *manager) setPodPendingAdmission(pod *v1.Pod) {
 	m.Lock()
 	defer m.Unlock()
@@ -18,9 +18,12 @@ package cpumanager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -31,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
@@ -43,6 +47,29 @@ import (
 type mockState struct {
 	assignments   state.ContainerCPUAssignments
 	defaultCPUSet cpuset.CPUSet
+	staticPods    map[string]bool
+	alignments    state.ContainerCPUAlignments
+	sharedPools   state.PodSharedCPUSets
+}
+
+func (s *mockState) IsStaticPod(podUID string) bool {
+	return s.staticPods[podUID]
+}
+
+func (s *mockState) GetStaticPods() []string {
+	var podUIDs []string
+	for podUID := range s.staticPods {
+		podUIDs = append(podUIDs, podUID)
+	}
+	sort.Strings(podUIDs)
+	return podUIDs
+}
+
+func (s *mockState) SetStaticPod(podUID string) {
+	if s.staticPods == nil {
+		s.staticPods = make(map[string]bool)
+	}
+	s.staticPods[podUID] = true
 }
 
 func (s *mockState) GetCPUSet(podUID string, containerName string) (cpuset.CPUSet, bool) {
@@ -92,6 +119,41 @@ func (s *mockState) GetCPUAssignments() state.ContainerCPUAssignments {
 	return s.assignments.Clone()
 }
 
+func (s *mockState) GetAlignment(podUID string, containerName string) (state.ContainerAlignment, bool) {
+	res, ok := s.alignments[podUID][containerName]
+	return res, ok
+}
+
+func (s *mockState) GetCPUAlignments() state.ContainerCPUAlignments {
+	return s.alignments.Clone()
+}
+
+func (s *mockState) SetAlignment(podUID string, containerName string, alignment state.ContainerAlignment) {
+	if s.alignments == nil {
+		s.alignments = state.ContainerCPUAlignments{}
+	}
+	if _, exists := s.alignments[podUID]; !exists {
+		s.alignments[podUID] = make(map[string]state.ContainerAlignment)
+	}
+	s.alignments[podUID][containerName] = alignment
+}
+
+func (s *mockState) GetPodSharedCPUSet(podUID string) (cpuset.CPUSet, bool) {
+	res, ok := s.sharedPools[podUID]
+	return res, ok
+}
+
+func (s *mockState) GetPodSharedCPUSets() state.PodSharedCPUSets {
+	return s.sharedPools.Clone()
+}
+
+func (s *mockState) SetPodSharedCPUSet(podUID string, cset cpuset.CPUSet) {
+	if s.sharedPools == nil {
+		s.sharedPools = state.PodSharedCPUSets{}
+	}
+	s.sharedPools[podUID] = cset
+}
+
 type mockPolicy struct {
 	err error
 }
@@ -125,13 +187,21 @@ func (p *mockPolicy) GetAllocatableCPUs(m state.State) cpuset.CPUSet {
 }
 
 type mockRuntimeService struct {
-	err error
+	err      error
+	statuses map[string]*runtimeapi.ContainerStatusResponse
 }
 
 func (rt mockRuntimeService) UpdateContainerResources(_ context.Context, id string, resources *runtimeapi.ContainerResources) error {
 	return rt.err
 }
 
+func (rt mockRuntimeService) ContainerStatus(_ context.Context, containerID string, _ bool) (*runtimeapi.ContainerStatusResponse, error) {
+	if resp, ok := rt.statuses[containerID]; ok {
+		return resp, nil
+	}
+	return nil, fmt.Errorf("container %q not found", containerID)
+}
+
 type mockPodStatusProvider struct {
 	podStatus v1.PodStatus
 	found     bool
@@ -683,7 +753,7 @@ func TestCPUManagerGenerate(t *testing.T) {
 			}
 			defer os.RemoveAll(sDir)
 
-			mgr, err := NewManager(testCase.cpuPolicyName, nil, 5*time.Second, machineInfo, cpuset.New(), testCase.nodeAllocatableReservation, sDir, topologymanager.NewFakeManager())
+			mgr, err := NewManager(testCase.cpuPolicyName, nil, 5*time.Second, machineInfo, cpuset.New(), testCase.nodeAllocatableReservation, sDir, topologymanager.NewFakeManager(), nil, nil)
 			if testCase.expectedError != nil {
 				if !strings.Contains(err.Error(), testCase.expectedError.Error()) {
 					t.Errorf("Unexpected error message. Have: %s wants %s", err.Error(), testCase.expectedError.Error())
@@ -752,6 +822,128 @@ func TestCPUManagerRemove(t *testing.T) {
 	}
 }
 
+func TestCPUManagerStateSnapshot(t *testing.T) {
+	mgr := &manager{
+		policy: &mockPolicy{},
+		state: &mockState{
+			assignments: state.ContainerCPUAssignments{
+				"fakePodUID": map[string]cpuset.CPUSet{
+					"fakeContainerName": cpuset.New(1, 2),
+				},
+			},
+			defaultCPUSet: cpuset.New(0, 3),
+		},
+		stateFileDirectory: "/var/lib/kubelet",
+	}
+
+	expectedCheckpoint := filepath.Join("/var/lib/kubelet", cpuManagerStateFileName)
+	if mgr.CheckpointFile() != expectedCheckpoint {
+		t.Errorf("Unexpected checkpoint file. Have: %s wants %s", mgr.CheckpointFile(), expectedCheckpoint)
+	}
+
+	snapshot, err := json.Marshal(mgr.StateSnapshot())
+	if err != nil {
+		t.Fatalf("could not serialize the state snapshot: %v", err)
+	}
+	expectedSnapshot := `{"policyName":"mock","defaultCpuSet":"0,3","entries":{"fakePodUID":{"fakeContainerName":"1-2"}}}`
+	if string(snapshot) != expectedSnapshot {
+		t.Errorf("Unexpected state snapshot. Have: %s wants %s", snapshot, expectedSnapshot)
+	}
+}
+
+func TestCPUManagerConfigSnapshot(t *testing.T) {
+	mgr := &manager{
+		policy:          &mockPolicy{},
+		reconcilePeriod: 10 * time.Second,
+	}
+
+	snapshot, err := json.Marshal(mgr.ConfigSnapshot())
+	if err != nil {
+		t.Fatalf("could not serialize the config snapshot: %v", err)
+	}
+	expectedSnapshot := `{"policyName":"mock","reconcilePeriod":"10s"}`
+	if string(snapshot) != expectedSnapshot {
+		t.Errorf("Unexpected config snapshot. Have: %s wants %s", snapshot, expectedSnapshot)
+	}
+}
+
+func TestCPUManagerGetExtendedResourceCapacity(t *testing.T) {
+	testPolicy, _ := NewStaticPolicy(
+		&topology.CPUTopology{
+			NumCPUs:    4,
+			NumSockets: 1,
+			NumCores:   2,
+			CPUDetails: map[int]topology.CPUInfo{
+				0: {CoreID: 0, SocketID: 0},
+				1: {CoreID: 1, SocketID: 0},
+				2: {CoreID: 0, SocketID: 0},
+				3: {CoreID: 1, SocketID: 0},
+			},
+		},
+		0,
+		cpuset.New(),
+		topologymanager.NewFakeManager(),
+		nil)
+
+	testCases := []struct {
+		description     string
+		policy          Policy
+		stDefaultCPUSet cpuset.CPUSet
+		expFreeCores    int64
+		expNil          bool
+	}{
+		{
+			description:     "all cores free",
+			policy:          testPolicy,
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3),
+			expFreeCores:    2,
+		},
+		{
+			description:     "partially allocated core is not free",
+			policy:          testPolicy,
+			stDefaultCPUSet: cpuset.New(0, 1, 3),
+			expFreeCores:    1,
+		},
+		{
+			description:     "no cores free",
+			policy:          testPolicy,
+			stDefaultCPUSet: cpuset.New(),
+			expFreeCores:    0,
+		},
+		{
+			description: "non-static policy advertises nothing",
+			policy:      &mockPolicy{},
+			expNil:      true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			mgr := &manager{
+				policy: testCase.policy,
+				state: &mockState{
+					assignments:   state.ContainerCPUAssignments{},
+					defaultCPUSet: testCase.stDefaultCPUSet,
+				},
+			}
+
+			capacity := mgr.GetExtendedResourceCapacity()
+			if testCase.expNil {
+				if capacity != nil {
+					t.Fatalf("expected no extended resources, got: %v", capacity)
+				}
+				return
+			}
+			quantity, ok := capacity[PhysicalCoresFreeResourceName]
+			if !ok {
+				t.Fatalf("expected %q in capacity, got: %v", PhysicalCoresFreeResourceName, capacity)
+			}
+			if quantity.Value() != testCase.expFreeCores {
+				t.Errorf("Unexpected free physical cores. Have: %d wants %d", quantity.Value(), testCase.expFreeCores)
+			}
+		})
+	}
+}
+
 func TestReconcileState(t *testing.T) {
 	testPolicy, _ := NewStaticPolicy(
 		&topology.CPUTopology{
@@ -1403,7 +1595,7 @@ func TestCPUManagerHandlePolicyOptions(t *testing.T) {
 			}
 			defer os.RemoveAll(sDir)
 
-			_, err = NewManager(testCase.cpuPolicyName, testCase.cpuPolicyOptions, 5*time.Second, machineInfo, cpuset.New(), nodeAllocatableReservation, sDir, topologymanager.NewFakeManager())
+			_, err = NewManager(testCase.cpuPolicyName, testCase.cpuPolicyOptions, 5*time.Second, machineInfo, cpuset.New(), nodeAllocatableReservation, sDir, topologymanager.NewFakeManager(), nil, nil)
 			if err == nil {
 				t.Errorf("Expected error, but NewManager succeeded")
 			}
@@ -1477,3 +1669,445 @@ func TestCPUManagerGetAllocatableCPUs(t *testing.T) {
 		}
 	}
 }
+
+func TestOnlineCPUsDelta(t *testing.T) {
+	testCases := []struct {
+		description   string
+		onlineCPUs    cpuset.CPUSet
+		defaultCPUSet cpuset.CPUSet
+		assignments   state.ContainerCPUAssignments
+		expVanished   cpuset.CPUSet
+		expAppeared   cpuset.CPUSet
+	}{
+		{
+			description:   "consistent state - shared pool only",
+			onlineCPUs:    cpuset.New(0, 1, 2, 3),
+			defaultCPUSet: cpuset.New(0, 1, 2, 3),
+			assignments:   state.ContainerCPUAssignments{},
+			expVanished:   cpuset.New(),
+			expAppeared:   cpuset.New(),
+		},
+		{
+			description:   "consistent state - shared pool and assignments",
+			onlineCPUs:    cpuset.New(0, 1, 2, 3),
+			defaultCPUSet: cpuset.New(0, 1),
+			assignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(2, 3),
+				},
+			},
+			expVanished: cpuset.New(),
+			expAppeared: cpuset.New(),
+		},
+		{
+			description:   "CPUs vanished",
+			onlineCPUs:    cpuset.New(0, 1),
+			defaultCPUSet: cpuset.New(0, 1),
+			assignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(2, 3),
+				},
+			},
+			expVanished: cpuset.New(2, 3),
+			expAppeared: cpuset.New(),
+		},
+		{
+			description:   "CPUs appeared",
+			onlineCPUs:    cpuset.New(0, 1, 2, 3, 4, 5),
+			defaultCPUSet: cpuset.New(0, 1, 2, 3),
+			assignments:   state.ContainerCPUAssignments{},
+			expVanished:   cpuset.New(),
+			expAppeared:   cpuset.New(4, 5),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			vanished, appeared := onlineCPUsDelta(testCase.onlineCPUs, testCase.defaultCPUSet, testCase.assignments)
+			if !vanished.Equals(testCase.expVanished) {
+				t.Errorf("expected vanished CPUs %v but got %v", testCase.expVanished, vanished)
+			}
+			if !appeared.Equals(testCase.expAppeared) {
+				t.Errorf("expected appeared CPUs %v but got %v", testCase.expAppeared, appeared)
+			}
+		})
+	}
+}
+
+func TestValidateOnlineCPUsEvent(t *testing.T) {
+	testCases := []struct {
+		description string
+		onlineCPUs  cpuset.CPUSet
+		expEvent    string
+	}{
+		{
+			description: "consistent state",
+			onlineCPUs:  cpuset.New(0, 1, 2, 3, 4, 5, 6, 7),
+		},
+		{
+			description: "CPUs vanished at runtime",
+			onlineCPUs:  cpuset.New(0, 1, 2, 3),
+			expEvent:    "vanished CPUs 4-7",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			mgr := &manager{
+				topology: topoSingleSocketHT,
+				state: &mockState{
+					assignments:   state.ContainerCPUAssignments{},
+					defaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7),
+				},
+				recorder: recorder,
+				nodeRef:  &v1.ObjectReference{Kind: "Node", Name: "fakeNode"},
+				onlineCPUs: func() (cpuset.CPUSet, error) {
+					return testCase.onlineCPUs, nil
+				},
+			}
+
+			mgr.validateOnlineCPUs()
+
+			var events []string
+			for done := false; !done; {
+				select {
+				case event := <-recorder.Events:
+					events = append(events, event)
+				default:
+					done = true
+				}
+			}
+			if testCase.expEvent == "" {
+				if len(events) > 0 {
+					t.Fatalf("expected no events, got %v", events)
+				}
+				return
+			}
+			if len(events) != 1 || !strings.Contains(events[0], testCase.expEvent) {
+				t.Fatalf("expected a single event containing %q, got %v", testCase.expEvent, events)
+			}
+		})
+	}
+}
+
+func TestWithBurstSiblings(t *testing.T) {
+	// topoSingleSocketHT: 4 cores, thread siblings (0,4), (1,5), (2,6), (3,7)
+	testCases := []struct {
+		description   string
+		defaultCPUSet cpuset.CPUSet
+		assignments   state.ContainerCPUAssignments
+		expCSet       cpuset.CPUSet
+	}{
+		{
+			description:   "shared pool container is not extended",
+			defaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7),
+			assignments:   state.ContainerCPUAssignments{},
+			expCSet:       cpuset.New(0, 1, 2, 3, 4, 5, 6, 7),
+		},
+		{
+			description:   "idle siblings granted",
+			defaultCPUSet: cpuset.New(0, 2, 3, 4, 6, 7),
+			assignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(1, 5),
+				},
+			},
+			expCSet: cpuset.New(1, 5),
+		},
+		{
+			description:   "sibling in the shared pool granted",
+			defaultCPUSet: cpuset.New(0, 3, 4, 5, 7),
+			assignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(1, 2, 6),
+				},
+			},
+			expCSet: cpuset.New(1, 2, 5, 6),
+		},
+		{
+			description:   "sibling exclusively allocated elsewhere not granted",
+			defaultCPUSet: cpuset.New(0, 3, 4, 7),
+			assignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(1, 2),
+				},
+				"otherFakePod": map[string]cpuset.CPUSet{
+					"otherFakeContainer": cpuset.New(5, 6),
+				},
+			},
+			expCSet: cpuset.New(1, 2),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			mgr := &manager{
+				topology: topoSingleSocketHT,
+				state: &mockState{
+					assignments:   testCase.assignments,
+					defaultCPUSet: testCase.defaultCPUSet,
+				},
+				burstSiblings: true,
+			}
+
+			cset := mgr.state.GetCPUSetOrDefault("fakePod", "fakeContainer")
+			cset = mgr.withBurstSiblings("fakePod", "fakeContainer", cset)
+			if !cset.Equals(testCase.expCSet) {
+				t.Errorf("expected cpuset %v but got %v", testCase.expCSet, cset)
+			}
+		})
+	}
+}
+
+func TestSharedUncorePool(t *testing.T) {
+	// topoUncoreSingleSocketNoHT: 16 CPUs, 4 uncore caches, cache N holds CPUs [4N, 4N+3].
+	// Cache 2 is partially and cache 3 fully taken by exclusive allocations.
+	defaultCPUSet := cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 10, 11)
+	mgr := &manager{
+		topology: topoUncoreSingleSocketNoHT,
+		state: &mockState{
+			assignments: state.ContainerCPUAssignments{
+				"guPod": map[string]cpuset.CPUSet{
+					"guContainer": cpuset.New(8, 9, 12, 13, 14, 15),
+				},
+			},
+			defaultCPUSet: defaultCPUSet,
+		},
+		uncoreSharedPools: true,
+		sharedUncoreByPod: make(map[string]int),
+	}
+
+	buPod1 := makePod("buPod1", "buContainer1", "1000m", "2000m")
+	buPod2 := makePod("buPod2", "buContainer2", "1000m", "2000m")
+	guPod := makePod("guPod", "guContainer", "6000m", "6000m")
+	bePod := &v1.Pod{}
+	bePod.UID = "bePod"
+
+	testCases := []struct {
+		description   string
+		pod           *v1.Pod
+		containerName string
+		expCSet       cpuset.CPUSet
+	}{
+		{
+			description:   "first burstable pod gets the first uncore cache",
+			pod:           buPod1,
+			containerName: "buContainer1",
+			expCSet:       cpuset.New(0, 1, 2, 3),
+		},
+		{
+			description:   "second burstable pod gets the next uncore cache",
+			pod:           buPod2,
+			containerName: "buContainer2",
+			expCSet:       cpuset.New(4, 5, 6, 7),
+		},
+		{
+			description:   "pods stick to their sub-pool",
+			pod:           buPod1,
+			containerName: "buContainer1",
+			expCSet:       cpuset.New(0, 1, 2, 3),
+		},
+		{
+			description:   "exclusively allocated container is not confined",
+			pod:           guPod,
+			containerName: "guContainer",
+			expCSet:       defaultCPUSet,
+		},
+		{
+			description:   "best-effort pod keeps the whole shared pool",
+			pod:           bePod,
+			containerName: "beContainer",
+			expCSet:       defaultCPUSet,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			cset := mgr.sharedUncorePool(testCase.pod, testCase.containerName, defaultCPUSet)
+			if !cset.Equals(testCase.expCSet) {
+				t.Errorf("expected cpuset %v but got %v", testCase.expCSet, cset)
+			}
+		})
+	}
+
+	// A pod assigned to a cache with no shared CPUs left falls back to the whole shared pool.
+	buPod3 := makePod("buPod3", "buContainer3", "1000m", "2000m")
+	mgr.sharedUncoreByPod["buPod3"] = 3
+	cset := mgr.sharedUncorePool(buPod3, "buContainer3", defaultCPUSet)
+	if !cset.Equals(defaultCPUSet) {
+		t.Errorf("expected fallback to the shared pool %v but got %v", defaultCPUSet, cset)
+	}
+}
+
+func TestWithoutExclusiveUncore(t *testing.T) {
+	// topoUncoreSingleSocketNoHT: 16 CPUs, 4 uncore caches, cache N holds CPUs [4N, 4N+3].
+	// Cache 3 is exclusive-only; the guaranteed container holds it.
+	defaultCPUSet := cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11)
+	mgr := &manager{
+		topology: topoUncoreSingleSocketNoHT,
+		state: &mockState{
+			assignments: state.ContainerCPUAssignments{
+				"guPod": map[string]cpuset.CPUSet{
+					"guContainer": cpuset.New(12, 13, 14, 15),
+				},
+			},
+			defaultCPUSet: defaultCPUSet,
+		},
+		exclusiveUncoreCPUs: cpuset.New(12, 13, 14, 15),
+	}
+
+	testCases := []struct {
+		description   string
+		podUID        string
+		containerName string
+		cset          cpuset.CPUSet
+		expCSet       cpuset.CPUSet
+	}{
+		{
+			description:   "shared container is trimmed of the exclusive-only cache CPUs",
+			podUID:        "buPod",
+			containerName: "buContainer",
+			cset:          defaultCPUSet.Union(cpuset.New(12, 13)),
+			expCSet:       defaultCPUSet,
+		},
+		{
+			description:   "shared container outside the exclusive-only cache is untouched",
+			podUID:        "buPod",
+			containerName: "buContainer",
+			cset:          defaultCPUSet,
+			expCSet:       defaultCPUSet,
+		},
+		{
+			description:   "exclusively allocated container keeps its CPUs",
+			podUID:        "guPod",
+			containerName: "guContainer",
+			cset:          cpuset.New(12, 13, 14, 15),
+			expCSet:       cpuset.New(12, 13, 14, 15),
+		},
+		{
+			description:   "trimming to an empty set falls back to the untrimmed one",
+			podUID:        "buPod",
+			containerName: "buContainer",
+			cset:          cpuset.New(14, 15),
+			expCSet:       cpuset.New(14, 15),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			cset := mgr.withoutExclusiveUncore(testCase.podUID, testCase.containerName, testCase.cset)
+			if !cset.Equals(testCase.expCSet) {
+				t.Errorf("expected cpuset %v but got %v", testCase.expCSet, cset)
+			}
+		})
+	}
+}
+
+func TestCheckTopologyFingerprint(t *testing.T) {
+	mgr := &manager{
+		topology:           topoUncoreSingleSocketNoHT,
+		stateFileDirectory: t.TempDir(),
+	}
+
+	if mgr.checkTopologyFingerprint() {
+		t.Errorf("expected a mismatch when no fingerprint was persisted yet")
+	}
+	if !mgr.checkTopologyFingerprint() {
+		t.Errorf("expected a match against the just persisted fingerprint")
+	}
+
+	mgr.topology = topoSingleSocketHT
+	if mgr.checkTopologyFingerprint() {
+		t.Errorf("expected a mismatch after the topology changed")
+	}
+	if !mgr.checkTopologyFingerprint() {
+		t.Errorf("expected a match after the new fingerprint was persisted")
+	}
+
+	mgr.stateFileDirectory = ""
+	if mgr.checkTopologyFingerprint() {
+		t.Errorf("expected a mismatch without a state directory")
+	}
+}
+
+func TestCPUManagerRecoverStateFromRuntime(t *testing.T) {
+	testPolicy, err := NewStaticPolicy(topoSingleSocketHT, 1, cpuset.New(0), topologymanager.NewFakeManager(), nil)
+	if err != nil {
+		t.Fatalf("could not create static policy: %v", err)
+	}
+
+	containerStatus := func(cpus string) *runtimeapi.ContainerStatusResponse {
+		return &runtimeapi.ContainerStatusResponse{
+			Status: &runtimeapi.ContainerStatus{
+				Resources: &runtimeapi.ContainerResources{
+					Linux: &runtimeapi.LinuxContainerResources{
+						CpusetCpus: cpus,
+					},
+				},
+			},
+		}
+	}
+
+	containerMap := containermap.NewContainerMap()
+	containerMap.Add("guPod", "guContainer", "guID")
+	containerMap.Add("buPod", "buContainer", "buID")
+	containerMap.Add("gonePod", "goneContainer", "goneID")
+
+	stateDir := t.TempDir()
+	checkpointPath := filepath.Join(stateDir, cpuManagerStateFileName)
+	if err := os.WriteFile(checkpointPath, []byte("{definitely not a checkpoint"), 0644); err != nil {
+		t.Fatalf("could not write the corrupt checkpoint: %v", err)
+	}
+
+	mgr := &manager{
+		policy:             testPolicy,
+		topology:           topoSingleSocketHT,
+		stateFileDirectory: stateDir,
+		containerMap:       containerMap,
+		containerRuntime: mockRuntimeService{
+			statuses: map[string]*runtimeapi.ContainerStatusResponse{
+				// disjoint from the reserved CPU 0: an exclusive allocation
+				"guID": containerStatus("1,5"),
+				// contains the reserved CPU 0: ran on the shared pool
+				"buID": containerStatus("0,2-4,6,7"),
+				// goneID has no status anymore: ignored
+			},
+		},
+		recoverCorruptCheckpoint: true,
+	}
+
+	recovered, err := mgr.recoverStateFromRuntime()
+	if err != nil {
+		t.Fatalf("could not recover the state: %v", err)
+	}
+
+	expDefaultCPUSet := cpuset.New(0, 2, 3, 4, 6, 7)
+	if !recovered.GetDefaultCPUSet().Equals(expDefaultCPUSet) {
+		t.Errorf("expected default cpuset %v but got %v", expDefaultCPUSet, recovered.GetDefaultCPUSet())
+	}
+	cset, ok := recovered.GetCPUSet("guPod", "guContainer")
+	if !ok || !cset.Equals(cpuset.New(1, 5)) {
+		t.Errorf("expected recovered exclusive cpuset %v but got %v (found=%v)", cpuset.New(1, 5), cset, ok)
+	}
+	if _, ok := recovered.GetCPUSet("buPod", "buContainer"); ok {
+		t.Errorf("did not expect an exclusive cpuset for the shared pool container")
+	}
+
+	// the rebuilt state must have replaced the corrupt checkpoint on disk
+	restored, err := state.NewCheckpointState(stateDir, cpuManagerStateFileName, testPolicy.Name(), containerMap)
+	if err != nil {
+		t.Fatalf("could not restore the rewritten checkpoint: %v", err)
+	}
+	if !restored.GetDefaultCPUSet().Equals(expDefaultCPUSet) {
+		t.Errorf("expected restored default cpuset %v but got %v", expDefaultCPUSet, restored.GetDefaultCPUSet())
+	}
+
+	// two containers claiming the same CPUs mean the runtime state itself
+	// is inconsistent, so the recovery must give up
+	mgr.containerRuntime = mockRuntimeService{
+		statuses: map[string]*runtimeapi.ContainerStatusResponse{
+			"guID":   containerStatus("1,5"),
+			"buID":   containerStatus("1,2"),
+			"goneID": containerStatus("2,6"),
+		},
+	}
+	if _, err := mgr.recoverStateFromRuntime(); err == nil {
+		t.Errorf("expected an error recovering overlapping exclusive assignments")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/state"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
 	"k8s.io/kubernetes/pkg/kubelet/config"
 	"k8s.io/kubernetes/pkg/kubelet/status"
@@ -85,6 +86,36 @@ func (m *fakeManager) GetCPUAffinity(podUID, containerName string) cpuset.CPUSet
 	return cpuset.CPUSet{}
 }
 
+func (m *fakeManager) GetExclusiveCPUDetails(podUID, containerName string) topology.CPUDetails {
+	klog.InfoS("GetExclusiveCPUDetails", "podUID", podUID, "containerName", containerName)
+	return topology.CPUDetails{}
+}
+
+func (m *fakeManager) GetAllocatableCPUDetails() topology.CPUDetails {
+	klog.InfoS("GetAllocatableCPUDetails")
+	return topology.CPUDetails{}
+}
+
+func (m *fakeManager) CheckpointFile() string {
+	klog.InfoS("CheckpointFile")
+	return ""
+}
+
+func (m *fakeManager) StateSnapshot() interface{} {
+	klog.InfoS("StateSnapshot")
+	return nil
+}
+
+func (m *fakeManager) ConfigSnapshot() interface{} {
+	klog.InfoS("ConfigSnapshot")
+	return nil
+}
+
+func (m *fakeManager) GetExtendedResourceCapacity() v1.ResourceList {
+	klog.InfoS("GetExtendedResourceCapacity")
+	return nil
+}
+
 // NewFakeManager creates empty/fake cpu manager
 func NewFakeManager() Manager {
 	return &fakeManager{
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpumanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/checksum"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/errors"
+	"k8s.io/utils/cpuset"
+)
+
+// irqExclusionCheckpointName is the file name, next to the cpu manager state
+// checkpoint, where the irq exclusion state is stored.
+const irqExclusionCheckpointName = "cpu_manager_irq_exclusion"
+
+// irqExclusionCheckpoint records which ban-list file the manager wrote and
+// with which content, so after a kubelet crash the next run can rewrite or
+// remove the file instead of leaving stale bans behind.
+type irqExclusionCheckpoint struct {
+	BanListFile string            `json:"banListFile"`
+	BannedCPUs  string            `json:"bannedCpus"`
+	Checksum    checksum.Checksum `json:"checksum"`
+}
+
+var _ checkpointmanager.Checkpoint = &irqExclusionCheckpoint{}
+
+// MarshalCheckpoint returns marshalled checkpoint
+func (cp *irqExclusionCheckpoint) MarshalCheckpoint() ([]byte, error) {
+	// make sure checksum wasn't set before so it doesn't affect output checksum
+	cp.Checksum = 0
+	cp.Checksum = checksum.New(cp)
+	return json.Marshal(*cp)
+}
+
+// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint
+func (cp *irqExclusionCheckpoint) UnmarshalCheckpoint(blob []byte) error {
+	return json.Unmarshal(blob, cp)
+}
+
+// VerifyChecksum verifies that current checksum of checkpoint is valid
+func (cp *irqExclusionCheckpoint) VerifyChecksum() error {
+	ck := cp.Checksum
+	cp.Checksum = 0
+	err := ck.Verify(cp)
+	cp.Checksum = ck
+	return err
+}
+
+// irqExclusion keeps a ban-list file in sync with the CPUs exclusively
+// assigned to containers: irqbalance, pointed at the file through its
+// policyscript hook (or any equivalent wrapper), then steers device
+// interrupts away from the CPUs running the pinned workloads. The file
+// holds a single cpulist line and is removed when no CPU is exclusively
+// assigned. What was written is checkpointed alongside the cpu manager
+// state, so a crashed kubelet cleans up after itself on the next start.
+type irqExclusion struct {
+	banListFile       string
+	checkpointManager checkpointmanager.CheckpointManager
+
+	// bannedCPUs is the set last written to the ban-list file. synced stays
+	// false until the first write of this run, forcing a rewrite even when
+	// the recomputed set matches: the file content cannot be trusted after
+	// a restart.
+	bannedCPUs cpuset.CPUSet
+	synced     bool
+}
+
+func newIRQExclusion(banListFile string, stateFileDirectory string) (*irqExclusion, error) {
+	checkpointManager, err := checkpointmanager.NewCheckpointManager(stateFileDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize checkpoint manager: %w", err)
+	}
+	return &irqExclusion{
+		banListFile:       banListFile,
+		checkpointManager: checkpointManager,
+	}, nil
+}
+
+// restore reads the checkpoint of the previous run and cleans up after it:
+// when the configured ban-list file moved, the file the previous run wrote is
+// removed, since nobody would ever update it again. The current file needs no
+// such care because the first sync call always rewrites it, crash or not.
+func (ie *irqExclusion) restore() error {
+	cp := &irqExclusionCheckpoint{}
+	if err := ie.checkpointManager.GetCheckpoint(irqExclusionCheckpointName, cp); err != nil {
+		if err == errors.ErrCheckpointNotFound {
+			return nil
+		}
+		return err
+	}
+	if cp.BanListFile != ie.banListFile && cp.BannedCPUs != "" {
+		if err := os.Remove(cp.BanListFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		klog.InfoS("Removed the IRQ ban-list file the previous run left behind", "path", cp.BanListFile)
+	}
+	return nil
+}
+
+// sync brings the ban-list file in line with the given set of exclusively
+// assigned CPUs: the cpulist is rewritten when the set changed and the file
+// is removed when the set became empty. The checkpoint is written before the
+// file, so a crash between the two writes leaves enough on disk for the next
+// run to converge. Errors are logged, not returned: failing to steer IRQs
+// away must not fail the container admission.
+func (ie *irqExclusion) sync(banned cpuset.CPUSet) {
+	if ie.synced && banned.Equals(ie.bannedCPUs) {
+		return
+	}
+	cp := &irqExclusionCheckpoint{
+		BanListFile: ie.banListFile,
+		BannedCPUs:  banned.String(),
+	}
+	if err := ie.checkpointManager.CreateCheckpoint(irqExclusionCheckpointName, cp); err != nil {
+		klog.ErrorS(err, "Failed to checkpoint the IRQ exclusion state", "bannedCPUs", banned)
+		return
+	}
+	if banned.IsEmpty() {
+		if err := os.Remove(ie.banListFile); err != nil && !os.IsNotExist(err) {
+			klog.ErrorS(err, "Failed to remove the IRQ ban-list file", "path", ie.banListFile)
+			return
+		}
+	} else {
+		if err := os.WriteFile(ie.banListFile, []byte(banned.String()+"\n"), 0644); err != nil {
+			klog.ErrorS(err, "Failed to write the IRQ ban-list file", "path", ie.banListFile)
+			return
+		}
+	}
+	klog.V(4).InfoS("Synced the IRQ ban-list file", "path", ie.banListFile, "bannedCPUs", banned)
+	ie.bannedCPUs = banned
+	ie.synced = true
+}
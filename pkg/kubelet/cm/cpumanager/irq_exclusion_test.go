@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpumanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/utils/cpuset"
+)
+
+func assertBanListFileContent(t *testing.T, path string, expected string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read the ban-list file: %v", err)
+	}
+	if string(content) != expected {
+		t.Fatalf("expected ban-list file content %q, got %q", expected, string(content))
+	}
+}
+
+func TestIRQExclusionSync(t *testing.T) {
+	stateDir := t.TempDir()
+	banListFile := filepath.Join(t.TempDir(), "banned-cpus")
+
+	ie, err := newIRQExclusion(banListFile, stateDir)
+	if err != nil {
+		t.Fatalf("could not create the irq exclusion: %v", err)
+	}
+
+	ie.sync(cpuset.New(1, 2, 3))
+	assertBanListFileContent(t, banListFile, "1-3\n")
+
+	ie.sync(cpuset.New(1, 2, 3, 7))
+	assertBanListFileContent(t, banListFile, "1-3,7\n")
+
+	// an unchanged set must not touch the file again
+	if err := os.Remove(banListFile); err != nil {
+		t.Fatalf("could not remove the ban-list file: %v", err)
+	}
+	ie.sync(cpuset.New(1, 2, 3, 7))
+	if _, err := os.Stat(banListFile); !os.IsNotExist(err) {
+		t.Fatalf("expected no write for an unchanged set, stat returned: %v", err)
+	}
+
+	ie.sync(cpuset.New(5))
+	assertBanListFileContent(t, banListFile, "5\n")
+
+	// releasing the last exclusive CPU removes the file
+	ie.sync(cpuset.New())
+	if _, err := os.Stat(banListFile); !os.IsNotExist(err) {
+		t.Fatalf("expected the ban-list file to be removed, stat returned: %v", err)
+	}
+}
+
+func TestIRQExclusionRestore(t *testing.T) {
+	t.Run("rewrites the ban-list file after a restart", func(t *testing.T) {
+		stateDir := t.TempDir()
+		banListFile := filepath.Join(t.TempDir(), "banned-cpus")
+
+		ie, err := newIRQExclusion(banListFile, stateDir)
+		if err != nil {
+			t.Fatalf("could not create the irq exclusion: %v", err)
+		}
+		ie.sync(cpuset.New(1, 2))
+
+		// simulate a crash which left the file behind with stale content
+		if err := os.WriteFile(banListFile, []byte("0-63\n"), 0644); err != nil {
+			t.Fatalf("could not overwrite the ban-list file: %v", err)
+		}
+
+		restarted, err := newIRQExclusion(banListFile, stateDir)
+		if err != nil {
+			t.Fatalf("could not create the irq exclusion: %v", err)
+		}
+		if err := restarted.restore(); err != nil {
+			t.Fatalf("could not restore the irq exclusion: %v", err)
+		}
+		restarted.sync(cpuset.New(1, 2))
+		assertBanListFileContent(t, banListFile, "1-2\n")
+	})
+
+	t.Run("removes the file of the previous run when the ban-list moved", func(t *testing.T) {
+		stateDir := t.TempDir()
+		banListDir := t.TempDir()
+		oldBanListFile := filepath.Join(banListDir, "banned-cpus")
+		newBanListFile := filepath.Join(banListDir, "banned-cpus-moved")
+
+		ie, err := newIRQExclusion(oldBanListFile, stateDir)
+		if err != nil {
+			t.Fatalf("could not create the irq exclusion: %v", err)
+		}
+		ie.sync(cpuset.New(1, 2))
+
+		restarted, err := newIRQExclusion(newBanListFile, stateDir)
+		if err != nil {
+			t.Fatalf("could not create the irq exclusion: %v", err)
+		}
+		if err := restarted.restore(); err != nil {
+			t.Fatalf("could not restore the irq exclusion: %v", err)
+		}
+		if _, err := os.Stat(oldBanListFile); !os.IsNotExist(err) {
+			t.Fatalf("expected the old ban-list file to be removed, stat returned: %v", err)
+		}
+		restarted.sync(cpuset.New(1, 2))
+		assertBanListFileContent(t, newBanListFile, "1-2\n")
+	})
+
+	t.Run("no checkpoint means nothing to clean up", func(t *testing.T) {
+		stateDir := t.TempDir()
+		banListFile := filepath.Join(t.TempDir(), "banned-cpus")
+
+		ie, err := newIRQExclusion(banListFile, stateDir)
+		if err != nil {
+			t.Fatalf("could not create the irq exclusion: %v", err)
+		}
+		if err := ie.restore(); err != nil {
+			t.Fatalf("could not restore the irq exclusion: %v", err)
+		}
+	})
+}
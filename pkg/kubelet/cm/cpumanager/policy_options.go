@@ -18,49 +18,82 @@ package cpumanager
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
-	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	kubefeatures "k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/policyoptions"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/utils/cpuset"
 )
 
 // Names of the options, as part of the user interface.
 const (
-	FullPCPUsOnlyOption            string = "full-pcpus-only"
-	DistributeCPUsAcrossNUMAOption string = "distribute-cpus-across-numa"
-	AlignBySocketOption            string = "align-by-socket"
+	FullPCPUsOnlyOption                 string = "full-pcpus-only"
+	DistributeCPUsAcrossNUMAOption      string = "distribute-cpus-across-numa"
+	AlignBySocketOption                 string = "align-by-socket"
+	BurstSiblingsOption                 string = "burst-siblings"
+	PerSocketExclusiveCapOption         string = "per-socket-exclusive-cap"
+	PreferAlignByUncoreCacheOption      string = "prefer-align-cpus-by-uncorecache"
+	StrictReservedUncoreAlignmentOption string = "strict-reserved-uncore-alignment"
+	ReservedUncoreCacheOption           string = "reserved-cpus-uncore-cache"
+	UncoreSharedPoolsOption             string = "uncore-cache-shared-pools"
+	ExclusiveUncoreCachesOption         string = "exclusive-only-uncore-caches"
+	DistributeCPUsAcrossUncoreOption    string = "distribute-cpus-across-uncore-caches"
+	FullPCPUsStrictOption               string = "full-pcpus-strict"
+	PreferPerformanceCoresOption        string = "prefer-performance-cores"
+	ExclusiveNUMAIslandsOption          string = "exclusive-numa-islands"
+	IRQBanListFileOption                string = "irq-ban-list-file"
+	RecoverCorruptCheckpointOption      string = "recover-corrupt-checkpoint"
+	ReservedCPUsConstraintsOption       string = "reserved-cpus-constraints"
+	SysFSTopologyDiscoveryOption        string = "sysfs-topology-discovery"
+	AlignByClusterOption                string = "align-by-cluster"
 )
 
 var (
 	alphaOptions = sets.New[string](
 		DistributeCPUsAcrossNUMAOption,
 		AlignBySocketOption,
+		BurstSiblingsOption,
+		PerSocketExclusiveCapOption,
+		PreferAlignByUncoreCacheOption,
+		StrictReservedUncoreAlignmentOption,
+		ReservedUncoreCacheOption,
+		UncoreSharedPoolsOption,
+		ExclusiveUncoreCachesOption,
+		DistributeCPUsAcrossUncoreOption,
+		FullPCPUsStrictOption,
+		PreferPerformanceCoresOption,
+		ExclusiveNUMAIslandsOption,
+		IRQBanListFileOption,
+		RecoverCorruptCheckpointOption,
+		ReservedCPUsConstraintsOption,
+		SysFSTopologyDiscoveryOption,
+		AlignByClusterOption,
 	)
 	betaOptions = sets.New[string](
 		FullPCPUsOnlyOption,
 	)
 	stableOptions = sets.New[string]()
+
+	// optionsRegistry binds the option tiers to the CPU manager feature gates.
+	optionsRegistry = policyoptions.Registry{
+		Component: "CPU Manager",
+		AlphaGate: kubefeatures.CPUManagerPolicyAlphaOptions,
+		BetaGate:  kubefeatures.CPUManagerPolicyBetaOptions,
+		Alpha:     alphaOptions,
+		Beta:      betaOptions,
+		Stable:    stableOptions,
+	}
 )
 
 // CheckPolicyOptionAvailable verifies if the given option can be used depending on the Feature Gate Settings.
 // returns nil on success, or an error describing the failure on error.
 func CheckPolicyOptionAvailable(option string) error {
-	if !alphaOptions.Has(option) && !betaOptions.Has(option) && !stableOptions.Has(option) {
-		return fmt.Errorf("unknown CPU Manager Policy option: %q", option)
-	}
-
-	if alphaOptions.Has(option) && !utilfeature.DefaultFeatureGate.Enabled(kubefeatures.CPUManagerPolicyAlphaOptions) {
-		return fmt.Errorf("CPU Manager Policy Alpha-level Options not enabled, but option %q provided", option)
-	}
-
-	if betaOptions.Has(option) && !utilfeature.DefaultFeatureGate.Enabled(kubefeatures.CPUManagerPolicyBetaOptions) {
-		return fmt.Errorf("CPU Manager Policy Beta-level Options not enabled, but option %q provided", option)
-	}
-
-	return nil
+	return optionsRegistry.CheckAvailable(option)
 }
 
 // StaticPolicyOptions holds the parsed value of the policy options, ready to be consumed internally.
@@ -80,6 +113,173 @@ type StaticPolicyOptions struct {
 	// Flag to ensure CPUs are considered aligned at socket boundary rather than
 	// NUMA boundary
 	AlignBySocket bool
+	// Flag to let containers with exclusively allocated CPUs burst on the SMT
+	// siblings of their CPUs while these siblings sit in the shared pool.
+	// The extra access is soft: it is granted and revoked by the reconcile
+	// loop as the siblings enter and leave the shared pool, and the cgroup
+	// cpu weight keeps arbitrating under contention.
+	BurstSiblings bool
+	// Percentage of each socket's CPUs which may be exclusively allocated.
+	// Once the cap is reached on a socket, further exclusive allocations
+	// spill over to the other sockets, keeping per-socket memory bandwidth
+	// headroom for the workloads left in the shared pool.
+	// Zero (the default) means no cap.
+	PerSocketExclusiveCapPercentage int
+	// Flag to prefer allocating CPUs which share the same uncore cache
+	// (last-level cache). Whole free uncore caches are claimed first; when
+	// none can host the remaining request, the partially free cache with
+	// the largest sufficient free share is used before descending to
+	// core-by-core packing.
+	PreferAlignByUncoreCache bool
+	// Flag to require the reserved CPUs to occupy the smallest possible
+	// number of uncore caches (last-level caches). A reservation straddling
+	// more caches than necessary leaves every one of them partially busy,
+	// hence unusable for full-cache exclusive allocation; without this
+	// option such a reservation is only logged, with it the kubelet fails
+	// to start.
+	StrictReservedUncoreAlignment bool
+	// ReservedUncoreCacheID, when set, pins the reserved CPUs to one
+	// specific uncore cache: the kubelet fails to start if any reserved
+	// CPU lives outside that cache.
+	ReservedUncoreCacheID *int
+	// Flag to partition the shared pool into per-uncore-cache sub-pools and
+	// to confine each Burstable pod to one of them, assigned round-robin.
+	// The confinement is soft: it is recomputed by the reconcile loop as
+	// exclusive allocations come and go, and a pod falls back to the whole
+	// shared pool when its sub-pool has no shared CPUs left. This trims
+	// cross-cache interference for non-guaranteed workloads without the
+	// rigidity of full static pinning.
+	UncoreSharedPools bool
+	// IDs of the uncore caches reserved for exclusive allocation: neither
+	// the shared pool nor the reserved CPUs ever use them, so full-cache
+	// exclusive allocations stay possible no matter how the shared-pool
+	// load fluctuates. The kubelet fails to start if the reserved CPUs
+	// overlap these caches; the shared pool is kept off them by the
+	// reconcile loop.
+	ExclusiveUncoreCacheIDs []int
+	// Flag to evenly spread a container's exclusive CPUs across the uncore
+	// caches (last-level caches) instead of packing them into as few caches
+	// as possible. Every cache the container touches contributes its slice
+	// of last-level cache capacity and bandwidth, which favours
+	// throughput-oriented workloads over the interference isolation the
+	// packed placement provides.
+	DistributeCPUsAcrossUncore bool
+	// Flag to give containers with exclusively allocated CPUs a whole
+	// physical core per requested CPU, handing out a single thread per core
+	// and withholding the sibling threads from the shared pool. The
+	// siblings stay idle for the lifetime of the allocation, so the
+	// container never shares a core with anybody, not even with itself.
+	// The exclusion shows up in the checkpoint as the siblings being absent
+	// from both the default cpuset and the assignments.
+	FullPhysicalCPUsStrict bool
+	// Flag to steer exclusive allocations to the performance (P) cores of
+	// hybrid CPUs, leaving the efficiency (E) cores to the shared pool.
+	// The preference is soft: when the performance cores alone cannot
+	// satisfy a request, the allocation falls back to any available CPU.
+	PreferPerformanceCores bool
+	// Flag to let a Guaranteed pod claim a whole NUMA node for itself
+	// through the cpumanager.kubernetes.io/exclusive-numa-island pod
+	// annotation. The claiming container is handed every CPU of a fully
+	// free NUMA node, which leaves the shared pool for as long as the pod
+	// runs.
+	ExclusiveNUMAIslands bool
+	// Path of the irqbalance ban-list file to keep in sync with the
+	// exclusively allocated CPUs. Whenever an exclusive allocation is made
+	// or released, the file is rewritten with the cpulist of all the CPUs
+	// currently assigned exclusively, so irqbalance (through its
+	// policyscript or a wrapper reading the file) steers device interrupts
+	// away from them. Empty (the default) disables the synchronization.
+	IRQBanListFile string
+	// Flag to rebuild the assignment state from the container runtime when
+	// the state checkpoint cannot be restored, instead of refusing to start.
+	// The actual cpuset of every container known to the runtime is read back
+	// through the CRI: cpusets disjoint from the reserved CPUs can only
+	// result from exclusive allocations and are recorded as assignments,
+	// everything else ran on the shared pool. A fresh checkpoint is written
+	// with the rebuilt state. Without this option a corrupt checkpoint
+	// requires draining the node and deleting the file by hand.
+	RecoverCorruptCheckpoint bool
+	// Constraints describing how to choose the reserved CPUs from the
+	// discovered topology, instead of naming them one by one through the
+	// reservedSystemCPUs list. The concrete reserved cpuset is computed at
+	// startup, so the same configuration works across machine models. Nil
+	// when the option is unset.
+	ReservedCPUsConstraints *ReservedCPUsConstraints
+	// Flag to discover the CPU topology straight from sysfs instead of the
+	// cadvisor MachineInfo, for the kernels and platforms where cadvisor
+	// misreports parts of it, the uncore caches most notably. The cadvisor
+	// data is still cross-validated against the sysfs view, and discovery
+	// fails when the two disagree on the basic counts.
+	SysFSTopologyDiscovery bool
+	// Flag to prefer allocating CPUs which share the same cluster (DSU/L2
+	// complex). On ARM Neoverse-class platforms locality is governed by
+	// these clusters rather than by the L3 uncore caches the
+	// prefer-align-cpus-by-uncorecache option works with. Whole free
+	// clusters are claimed first; when none can host the remaining request,
+	// the partially free cluster with the largest sufficient free share is
+	// used before descending to core-by-core packing.
+	AlignByCluster bool
+}
+
+// ReservedCPUsConstraints holds the parsed value of the
+// reserved-cpus-constraints policy option. The clauses combine: the
+// reservation is spread as requested, avoiding the listed caches, preferring
+// the given core type.
+type ReservedCPUsConstraints struct {
+	// CoresPerNUMANode reserves this many whole physical cores on every
+	// NUMA node. The resulting set must still match the CPU count derived
+	// from the system and kube reservations. Zero disables the spreading.
+	CoresPerNUMANode int
+	// AvoidUncoreCacheIDs lists the uncore caches whose CPUs must not be
+	// reserved, keeping them free for full-cache exclusive allocation.
+	AvoidUncoreCacheIDs []int
+	// PreferCoreType steers the reservation to the performance or the
+	// efficiency cores of hybrid CPUs, falling back to the other type when
+	// the preferred one cannot cover the whole reservation. Empty means no
+	// preference.
+	PreferCoreType topology.CoreType
+}
+
+// parseReservedCPUsConstraints parses the reserved-cpus-constraints option
+// value, a semicolon-separated list of key=value clauses, for example
+// "cores-per-numa=1;avoid-uncore-caches=0;prefer-core-type=efficiency".
+func parseReservedCPUsConstraints(value string) (*ReservedCPUsConstraints, error) {
+	constraints := &ReservedCPUsConstraints{}
+	for _, clause := range strings.Split(value, ";") {
+		key, clauseValue, found := strings.Cut(strings.TrimSpace(clause), "=")
+		if !found {
+			return nil, fmt.Errorf("malformed constraint %q: expected key=value", clause)
+		}
+		switch key {
+		case "cores-per-numa":
+			cores, err := strconv.Atoi(clauseValue)
+			if err != nil {
+				return nil, fmt.Errorf("bad constraint %q: %w", clause, err)
+			}
+			if cores < 1 {
+				return nil, fmt.Errorf("bad constraint %q: core count must be positive", clause)
+			}
+			constraints.CoresPerNUMANode = cores
+		case "avoid-uncore-caches":
+			cacheIDs, err := cpuset.Parse(clauseValue)
+			if err != nil {
+				return nil, fmt.Errorf("bad constraint %q: %w", clause, err)
+			}
+			if cacheIDs.IsEmpty() {
+				return nil, fmt.Errorf("bad constraint %q: no uncore cache ids given", clause)
+			}
+			constraints.AvoidUncoreCacheIDs = cacheIDs.List()
+		case "prefer-core-type":
+			coreType := topology.CoreType(clauseValue)
+			if coreType != topology.CoreTypePerformance && coreType != topology.CoreTypeEfficiency {
+				return nil, fmt.Errorf("bad constraint %q: core type must be %q or %q", clause, topology.CoreTypePerformance, topology.CoreTypeEfficiency)
+			}
+			constraints.PreferCoreType = coreType
+		default:
+			return nil, fmt.Errorf("unknown constraint %q", clause)
+		}
+	}
+	return constraints, nil
 }
 
 // NewStaticPolicyOptions creates a StaticPolicyOptions struct from the user configuration.
@@ -92,23 +292,127 @@ func NewStaticPolicyOptions(policyOptions map[string]string) (StaticPolicyOption
 
 		switch name {
 		case FullPCPUsOnlyOption:
-			optValue, err := strconv.ParseBool(value)
+			optValue, err := policyoptions.Bool(name, value)
 			if err != nil {
-				return opts, fmt.Errorf("bad value for option %q: %w", name, err)
+				return opts, err
 			}
 			opts.FullPhysicalCPUsOnly = optValue
 		case DistributeCPUsAcrossNUMAOption:
-			optValue, err := strconv.ParseBool(value)
+			optValue, err := policyoptions.Bool(name, value)
 			if err != nil {
-				return opts, fmt.Errorf("bad value for option %q: %w", name, err)
+				return opts, err
 			}
 			opts.DistributeCPUsAcrossNUMA = optValue
 		case AlignBySocketOption:
-			optValue, err := strconv.ParseBool(value)
+			optValue, err := policyoptions.Bool(name, value)
 			if err != nil {
-				return opts, fmt.Errorf("bad value for option %q: %w", name, err)
+				return opts, err
 			}
 			opts.AlignBySocket = optValue
+		case BurstSiblingsOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.BurstSiblings = optValue
+		case PerSocketExclusiveCapOption:
+			optValue, err := policyoptions.Int(name, value)
+			if err != nil {
+				return opts, err
+			}
+			if optValue < 1 || optValue > 100 {
+				return opts, policyoptions.BadValue(name, fmt.Errorf("percentage must be between 1 and 100, got %d", optValue))
+			}
+			opts.PerSocketExclusiveCapPercentage = optValue
+		case PreferAlignByUncoreCacheOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.PreferAlignByUncoreCache = optValue
+		case StrictReservedUncoreAlignmentOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.StrictReservedUncoreAlignment = optValue
+		case ReservedUncoreCacheOption:
+			optValue, err := policyoptions.Int(name, value)
+			if err != nil {
+				return opts, err
+			}
+			if optValue < 0 {
+				return opts, policyoptions.BadValue(name, fmt.Errorf("uncore cache id must not be negative, got %d", optValue))
+			}
+			opts.ReservedUncoreCacheID = &optValue
+		case UncoreSharedPoolsOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.UncoreSharedPools = optValue
+		case ExclusiveUncoreCachesOption:
+			cacheIDs, err := cpuset.Parse(value)
+			if err != nil {
+				return opts, policyoptions.BadValue(name, err)
+			}
+			if cacheIDs.IsEmpty() {
+				return opts, policyoptions.BadValue(name, fmt.Errorf("no uncore cache ids given"))
+			}
+			opts.ExclusiveUncoreCacheIDs = cacheIDs.List()
+		case DistributeCPUsAcrossUncoreOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.DistributeCPUsAcrossUncore = optValue
+		case FullPCPUsStrictOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.FullPhysicalCPUsStrict = optValue
+		case PreferPerformanceCoresOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.PreferPerformanceCores = optValue
+		case ExclusiveNUMAIslandsOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.ExclusiveNUMAIslands = optValue
+		case IRQBanListFileOption:
+			if !filepath.IsAbs(value) {
+				return opts, policyoptions.BadValue(name, fmt.Errorf("path must be absolute, got %q", value))
+			}
+			opts.IRQBanListFile = value
+		case RecoverCorruptCheckpointOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.RecoverCorruptCheckpoint = optValue
+		case ReservedCPUsConstraintsOption:
+			constraints, err := parseReservedCPUsConstraints(value)
+			if err != nil {
+				return opts, policyoptions.BadValue(name, err)
+			}
+			opts.ReservedCPUsConstraints = constraints
+		case SysFSTopologyDiscoveryOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.SysFSTopologyDiscovery = optValue
+		case AlignByClusterOption:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.AlignByCluster = optValue
 		default:
 			// this should never be reached, we already detect unknown options,
 			// but we keep it as further safety.
@@ -118,6 +422,16 @@ func NewStaticPolicyOptions(policyOptions map[string]string) (StaticPolicyOption
 	return opts, nil
 }
 
+// sysFSTopologyDiscoveryEnabled reports whether the raw policy options ask
+// for sysfs based topology discovery. Discovery runs before the options are
+// parsed as a whole, so this one option is peeked at up front; availability
+// and malformed values are still reported by NewStaticPolicyOptions together
+// with every other option error.
+func sysFSTopologyDiscoveryEnabled(policyOptions map[string]string) bool {
+	enabled, err := strconv.ParseBool(policyOptions[SysFSTopologyDiscoveryOption])
+	return err == nil && enabled
+}
+
 // ValidateStaticPolicyOptions ensures that the requested policy options are compatible with the machine on which the CPUManager is running.
 func ValidateStaticPolicyOptions(opts StaticPolicyOptions, topology *topology.CPUTopology, topologyManager topologymanager.Store) error {
 	if opts.AlignBySocket {
@@ -130,5 +444,93 @@ func ValidateStaticPolicyOptions(opts StaticPolicyOptions, topology *topology.CP
 			return fmt.Errorf("Align by socket is not compatible with hardware where number of sockets are more than number of NUMA")
 		}
 	}
+	if opts.BurstSiblings && topology.MaxThreadsPerCore() < 2 {
+		return fmt.Errorf("Burst siblings requires hardware with SMT enabled")
+	}
+	if opts.FullPhysicalCPUsStrict {
+		if topology.MaxThreadsPerCore() < 2 {
+			return fmt.Errorf("SMT isolation requires hardware with SMT enabled")
+		}
+		if opts.FullPhysicalCPUsOnly {
+			return fmt.Errorf("SMT isolation and full physical CPUs options are mutually exclusive")
+		}
+		if opts.BurstSiblings {
+			return fmt.Errorf("SMT isolation and burst siblings options are mutually exclusive")
+		}
+	}
+	if opts.PreferAlignByUncoreCache && opts.DistributeCPUsAcrossNUMA {
+		return fmt.Errorf("Uncore cache alignment and NUMA distribution options are mutually exclusive")
+	}
+	if opts.AlignByCluster {
+		if opts.PreferAlignByUncoreCache {
+			return fmt.Errorf("Cluster alignment and uncore cache alignment options are mutually exclusive")
+		}
+		if opts.DistributeCPUsAcrossNUMA {
+			return fmt.Errorf("Cluster alignment and NUMA distribution options are mutually exclusive")
+		}
+		if opts.DistributeCPUsAcrossUncore {
+			return fmt.Errorf("Cluster alignment and uncore cache distribution options are mutually exclusive")
+		}
+		if topology.CPUDetails.Clusters().Size() < 2 {
+			return fmt.Errorf("Cluster alignment requires a machine with more than one cluster")
+		}
+	}
+	if opts.DistributeCPUsAcrossUncore {
+		if opts.PreferAlignByUncoreCache {
+			return fmt.Errorf("Uncore cache alignment and uncore cache distribution options are mutually exclusive")
+		}
+		if opts.DistributeCPUsAcrossNUMA {
+			return fmt.Errorf("NUMA distribution and uncore cache distribution options are mutually exclusive")
+		}
+		if topology.CPUDetails.UncoreCaches().Size() < 2 {
+			return fmt.Errorf("Uncore cache distribution requires a machine with more than one uncore cache")
+		}
+	}
+	if opts.ReservedUncoreCacheID != nil && !topology.CPUDetails.UncoreCaches().Contains(*opts.ReservedUncoreCacheID) {
+		return fmt.Errorf("Reserved uncore cache %d does not exist on this machine", *opts.ReservedUncoreCacheID)
+	}
+	if opts.UncoreSharedPools && topology.CPUDetails.UncoreCaches().Size() < 2 {
+		return fmt.Errorf("Uncore cache shared pools require a machine with more than one uncore cache")
+	}
+	if opts.ExclusiveNUMAIslands && topology.CPUDetails.NUMANodes().Size() < 2 {
+		return fmt.Errorf("Exclusive NUMA islands require a machine with more than one NUMA node")
+	}
+	if len(opts.ExclusiveUncoreCacheIDs) > 0 {
+		uncoreCaches := topology.CPUDetails.UncoreCaches()
+		for _, cacheID := range opts.ExclusiveUncoreCacheIDs {
+			if !uncoreCaches.Contains(cacheID) {
+				return fmt.Errorf("Exclusive-only uncore cache %d does not exist on this machine", cacheID)
+			}
+		}
+		if len(opts.ExclusiveUncoreCacheIDs) >= uncoreCaches.Size() {
+			return fmt.Errorf("Exclusive-only uncore caches %v leave no uncore cache for the shared pool", opts.ExclusiveUncoreCacheIDs)
+		}
+	}
+	if opts.PreferPerformanceCores && !topology.HasHybridCoreTypes() {
+		return fmt.Errorf("Performance core preference requires a hybrid CPU exposing core types")
+	}
+	if constraints := opts.ReservedCPUsConstraints; constraints != nil {
+		uncoreCaches := topology.CPUDetails.UncoreCaches()
+		for _, cacheID := range constraints.AvoidUncoreCacheIDs {
+			if !uncoreCaches.Contains(cacheID) {
+				return fmt.Errorf("Avoided uncore cache %d does not exist on this machine", cacheID)
+			}
+		}
+		if len(constraints.AvoidUncoreCacheIDs) >= uncoreCaches.Size() {
+			return fmt.Errorf("Avoided uncore caches %v leave no uncore cache to reserve CPUs from", constraints.AvoidUncoreCacheIDs)
+		}
+		if constraints.PreferCoreType != "" && !topology.HasHybridCoreTypes() {
+			return fmt.Errorf("Reserved core type preference requires a hybrid CPU exposing core types")
+		}
+	}
+	if opts.PerSocketExclusiveCapPercentage > 0 {
+		// A cap which rounds down to zero CPUs would forbid exclusive allocation entirely.
+		for _, socketID := range topology.CPUDetails.Sockets().List() {
+			socketSize := topology.CPUDetails.CPUsInSockets(socketID).Size()
+			if socketSize*opts.PerSocketExclusiveCapPercentage/100 < 1 {
+				return fmt.Errorf("Per-socket exclusive cap of %d%% allows no exclusive CPUs on socket %d (%d CPUs)", opts.PerSocketExclusiveCapPercentage, socketID, socketSize)
+			}
+		}
+	}
 	return nil
 }
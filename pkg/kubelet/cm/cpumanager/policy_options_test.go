@@ -17,6 +17,7 @@ limitations under the License.
 package cpumanager
 
 import (
+	"reflect"
 	"testing"
 
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
@@ -94,6 +95,30 @@ func TestPolicyOptionsAvailable(t *testing.T) {
 			featureGateEnable: true,
 			expectedAvailable: false,
 		},
+		{
+			option:            BurstSiblingsOption,
+			featureGate:       pkgfeatures.CPUManagerPolicyAlphaOptions,
+			featureGateEnable: true,
+			expectedAvailable: true,
+		},
+		{
+			option:            BurstSiblingsOption,
+			featureGate:       pkgfeatures.CPUManagerPolicyBetaOptions,
+			featureGateEnable: true,
+			expectedAvailable: false,
+		},
+		{
+			option:            PerSocketExclusiveCapOption,
+			featureGate:       pkgfeatures.CPUManagerPolicyAlphaOptions,
+			featureGateEnable: true,
+			expectedAvailable: true,
+		},
+		{
+			option:            PerSocketExclusiveCapOption,
+			featureGate:       pkgfeatures.CPUManagerPolicyBetaOptions,
+			featureGateEnable: true,
+			expectedAvailable: false,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.option, func(t *testing.T) {
@@ -157,6 +182,195 @@ func TestValidateStaticPolicyOptions(t *testing.T) {
 			topoMgrPolicy: topologymanager.PolicyRestricted,
 			expectedErr:   false,
 		},
+		{
+			description:   "Burst siblings enabled with SMT",
+			policyOption:  map[string]string{BurstSiblingsOption: "true"},
+			topology:      topoDualSocketMultiNumaPerSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Burst siblings enabled without SMT",
+			policyOption:  map[string]string{BurstSiblingsOption: "true"},
+			topology:      topoDualSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Per-socket exclusive cap leaves room on every socket",
+			policyOption:  map[string]string{PerSocketExclusiveCapOption: "50"},
+			topology:      topoDualSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Per-socket exclusive cap rounds down to zero CPUs",
+			policyOption:  map[string]string{PerSocketExclusiveCapOption: "5"},
+			topology:      topoDualSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Uncore cache alignment enabled",
+			policyOption:  map[string]string{PreferAlignByUncoreCacheOption: "true"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Uncore cache alignment enabled with NUMA distribution",
+			policyOption:  map[string]string{PreferAlignByUncoreCacheOption: "true", DistributeCPUsAcrossNUMAOption: "true"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Reserved uncore cache exists on the machine",
+			policyOption:  map[string]string{ReservedUncoreCacheOption: "1"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Reserved uncore cache does not exist on the machine",
+			policyOption:  map[string]string{ReservedUncoreCacheOption: "7"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Uncore shared pools on a machine with multiple uncore caches",
+			policyOption:  map[string]string{UncoreSharedPoolsOption: "true"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Uncore shared pools on a machine with a single uncore cache",
+			policyOption:  map[string]string{UncoreSharedPoolsOption: "true"},
+			topology:      topoSingleSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Exclusive-only uncore caches exist on the machine",
+			policyOption:  map[string]string{ExclusiveUncoreCachesOption: "1,2"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Exclusive-only uncore cache does not exist on the machine",
+			policyOption:  map[string]string{ExclusiveUncoreCachesOption: "7"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Exclusive-only uncore caches leave no cache for the shared pool",
+			policyOption:  map[string]string{ExclusiveUncoreCachesOption: "0-3"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Uncore cache distribution on a machine with multiple uncore caches",
+			policyOption:  map[string]string{DistributeCPUsAcrossUncoreOption: "true"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Uncore cache distribution on a machine with a single uncore cache",
+			policyOption:  map[string]string{DistributeCPUsAcrossUncoreOption: "true"},
+			topology:      topoSingleSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Uncore cache distribution with uncore cache alignment",
+			policyOption:  map[string]string{DistributeCPUsAcrossUncoreOption: "true", PreferAlignByUncoreCacheOption: "true"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Uncore cache distribution with NUMA distribution",
+			policyOption:  map[string]string{DistributeCPUsAcrossUncoreOption: "true", DistributeCPUsAcrossNUMAOption: "true"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "SMT isolation with SMT",
+			policyOption:  map[string]string{FullPCPUsStrictOption: "true"},
+			topology:      topoDualSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "SMT isolation without SMT",
+			policyOption:  map[string]string{FullPCPUsStrictOption: "true"},
+			topology:      topoDualSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "SMT isolation with full physical CPUs",
+			policyOption:  map[string]string{FullPCPUsStrictOption: "true", FullPCPUsOnlyOption: "true"},
+			topology:      topoDualSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "SMT isolation with burst siblings",
+			policyOption:  map[string]string{FullPCPUsStrictOption: "true", BurstSiblingsOption: "true"},
+			topology:      topoDualSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Performance core preference on a hybrid CPU",
+			policyOption:  map[string]string{PreferPerformanceCoresOption: "true"},
+			topology:      topoHybridSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Performance core preference on a homogeneous CPU",
+			policyOption:  map[string]string{PreferPerformanceCoresOption: "true"},
+			topology:      topoSingleSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Reserved constraints avoiding an existing uncore cache",
+			policyOption:  map[string]string{ReservedCPUsConstraintsOption: "avoid-uncore-caches=0"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   false,
+		},
+		{
+			description:   "Reserved constraints avoiding a nonexistent uncore cache",
+			policyOption:  map[string]string{ReservedCPUsConstraintsOption: "avoid-uncore-caches=42"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Reserved constraints avoiding every uncore cache",
+			policyOption:  map[string]string{ReservedCPUsConstraintsOption: "avoid-uncore-caches=0-3"},
+			topology:      topoUncoreSingleSocketNoHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
+		{
+			description:   "Reserved constraints preferring a core type on a homogeneous CPU",
+			policyOption:  map[string]string{ReservedCPUsConstraintsOption: "prefer-core-type=efficiency"},
+			topology:      topoSingleSocketHT,
+			topoMgrPolicy: topologymanager.PolicyNone,
+			expectedErr:   true,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.description, func(t *testing.T) {
@@ -177,3 +391,75 @@ func TestValidateStaticPolicyOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReservedCPUsConstraints(t *testing.T) {
+	testCases := []struct {
+		description string
+		value       string
+		expected    *ReservedCPUsConstraints
+		expectedErr bool
+	}{
+		{
+			description: "single clause",
+			value:       "cores-per-numa=1",
+			expected:    &ReservedCPUsConstraints{CoresPerNUMANode: 1},
+		},
+		{
+			description: "all the clauses combined",
+			value:       "cores-per-numa=2;avoid-uncore-caches=0,3;prefer-core-type=efficiency",
+			expected: &ReservedCPUsConstraints{
+				CoresPerNUMANode:    2,
+				AvoidUncoreCacheIDs: []int{0, 3},
+				PreferCoreType:      topology.CoreTypeEfficiency,
+			},
+		},
+		{
+			description: "clauses tolerate surrounding spaces",
+			value:       "avoid-uncore-caches=1; prefer-core-type=performance",
+			expected: &ReservedCPUsConstraints{
+				AvoidUncoreCacheIDs: []int{1},
+				PreferCoreType:      topology.CoreTypePerformance,
+			},
+		},
+		{
+			description: "missing value",
+			value:       "cores-per-numa",
+			expectedErr: true,
+		},
+		{
+			description: "zero cores per numa node",
+			value:       "cores-per-numa=0",
+			expectedErr: true,
+		},
+		{
+			description: "empty avoided cache list",
+			value:       "avoid-uncore-caches=",
+			expectedErr: true,
+		},
+		{
+			description: "unknown core type",
+			value:       "prefer-core-type=fancy",
+			expectedErr: true,
+		},
+		{
+			description: "unknown clause",
+			value:       "cores-per-socket=1",
+			expectedErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			constraints, err := parseReservedCPUsConstraints(testCase.value)
+			gotError := (err != nil)
+			if gotError != testCase.expectedErr {
+				t.Fatalf("unexpected error result: got %v expected %v (err=%v)", gotError, testCase.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(constraints, testCase.expected) {
+				t.Errorf("expected constraints %+v but got %+v", testCase.expected, constraints)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package cpumanager
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 
 	v1 "k8s.io/api/core/v1"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
@@ -25,6 +27,7 @@ import (
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
 	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/allocation"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
@@ -42,6 +45,19 @@ const (
 	PolicyStatic policyName = "static"
 	// ErrorSMTAlignment represents the type of an SMTAlignmentError
 	ErrorSMTAlignment = "SMTAlignmentError"
+	// ErrorNUMAIsland represents the type of a NUMAIslandError
+	ErrorNUMAIsland = "NUMAIslandError"
+
+	// ExclusiveNUMAIslandAnnotation is the pod annotation through which a
+	// Guaranteed pod claims a whole NUMA node for itself, when the static
+	// policy runs with the exclusive-numa-islands option.
+	ExclusiveNUMAIslandAnnotation = "cpumanager.kubernetes.io/exclusive-numa-island"
+
+	// SkipExclusiveCPUsAnnotation is the pod annotation through which a
+	// Guaranteed pod with integral CPU requests opts out of exclusive CPU
+	// pinning. Its containers run on the shared pool like any burstable
+	// workload, and the shared pool is not shrunk on their behalf.
+	SkipExclusiveCPUsAnnotation = "cpumanager.kubernetes.io/skip-exclusive-cpus"
 )
 
 // SMTAlignmentError represents an error due to SMT alignment
@@ -63,6 +79,35 @@ func (e SMTAlignmentError) Type() string {
 	return ErrorSMTAlignment
 }
 
+// RemediationHint assembles an actionable suggestion from the structured data
+// the error carries.
+func (e SMTAlignmentError) RemediationHint() string {
+	if e.AvailablePhysicalCPUs > 0 {
+		return fmt.Sprintf("reduce the CPU request to at most %d CPUs, or wait for pods holding exclusive CPUs to terminate", e.AvailablePhysicalCPUs)
+	}
+	return fmt.Sprintf("request a multiple of %d CPUs to get whole physical cores", e.CpusPerCore)
+}
+
+// NUMAIslandError represents an error allocating a whole NUMA node to a pod
+// which requested an exclusive island.
+type NUMAIslandError struct {
+	RequestedCPUs int
+}
+
+func (e NUMAIslandError) Error() string {
+	return fmt.Sprintf("NUMA Island Error: no fully free NUMA node with at least %d CPUs available", e.RequestedCPUs)
+}
+
+// Type returns human-readable type of this error. Used in the admission control to populate Admission Failure reason.
+func (e NUMAIslandError) Type() string {
+	return ErrorNUMAIsland
+}
+
+// RemediationHint suggests how to resolve the allocation failure.
+func (e NUMAIslandError) RemediationHint() string {
+	return "wait for a pod owning a whole NUMA node to terminate, or remove the exclusive island request from the pod"
+}
+
 // staticPolicy is a CPU manager policy that does not change CPU
 // assignments for exclusively pinned guaranteed containers after the main
 // container process starts.
@@ -118,6 +163,10 @@ type staticPolicy struct {
 	cpusToReuse map[string]cpuset.CPUSet
 	// options allow to fine-tune the behaviour of the policy
 	options StaticPolicyOptions
+	// topologyUnchanged is set before Start when the fingerprint of the
+	// machine topology matches the one persisted alongside the checkpoint,
+	// letting validateState skip the per-CPU consistency walk.
+	topologyUnchanged bool
 }
 
 // Ensure staticPolicy implements Policy interface
@@ -147,7 +196,15 @@ func NewStaticPolicy(topology *topology.CPUTopology, numReservedCPUs int, reserv
 
 	allCPUs := topology.CPUDetails.CPUs()
 	var reserved cpuset.CPUSet
-	if reservedCPUs.Size() > 0 {
+	if opts.ReservedCPUsConstraints != nil {
+		if reservedCPUs.Size() > 0 {
+			return nil, fmt.Errorf("[cpumanager] the %s policy option and an explicit reserved CPU list are mutually exclusive", ReservedCPUsConstraintsOption)
+		}
+		reserved, err = policy.takeReservedCPUsByConstraints(numReservedCPUs)
+		if err != nil {
+			return nil, fmt.Errorf("[cpumanager] unable to reserve CPUs by constraints: %w", err)
+		}
+	} else if reservedCPUs.Size() > 0 {
 		reserved = reservedCPUs
 	} else {
 		// takeByTopology allocates CPUs associated with low-numbered cores from
@@ -172,6 +229,14 @@ func NewStaticPolicy(topology *topology.CPUTopology, numReservedCPUs int, reserv
 		reservedPhysicalCPUs = reservedPhysicalCPUs.Union(topology.CPUDetails.CPUsInCores(core))
 	}
 
+	if err := checkReservedUncoreAlignment(topology, reserved, opts); err != nil {
+		return nil, err
+	}
+
+	if excl := policy.exclusiveOnlyUncoreCPUs(); excl.Intersection(reserved).Size() > 0 {
+		return nil, fmt.Errorf("[cpumanager] reserved CPUs %s overlap the exclusive-only uncore caches %v", reserved, opts.ExclusiveUncoreCacheIDs)
+	}
+
 	klog.InfoS("Reserved CPUs not available for exclusive assignment", "reservedSize", reserved.Size(), "reserved", reserved, "reservedPhysicalCPUs", reservedPhysicalCPUs)
 	policy.reservedCPUs = reserved
 	policy.reservedPhysicalCPUs = reservedPhysicalCPUs
@@ -179,6 +244,116 @@ func NewStaticPolicy(topology *topology.CPUTopology, numReservedCPUs int, reserv
 	return policy, nil
 }
 
+// takeReservedCPUsByConstraints computes the concrete reserved CPU set from
+// the discovered topology, according to the reserved-cpus-constraints policy
+// option. With cores-per-numa the lowest-numbered eligible physical cores of
+// every NUMA node are reserved whole; otherwise the CPUs are taken by
+// topology from the eligible set, trying the preferred core type first. In
+// both cases the avoided uncore caches are never eligible. The resulting set
+// must still match numReservedCPUs, which the caller verifies like for any
+// other reservation.
+func (p *staticPolicy) takeReservedCPUsByConstraints(numReservedCPUs int) (cpuset.CPUSet, error) {
+	constraints := p.options.ReservedCPUsConstraints
+	details := p.topology.CPUDetails
+	candidates := details.CPUs()
+	for _, cacheID := range constraints.AvoidUncoreCacheIDs {
+		candidates = candidates.Difference(details.CPUsInUncoreCaches(cacheID))
+	}
+
+	if constraints.CoresPerNUMANode > 0 {
+		reserved := cpuset.New()
+		for _, numaID := range details.NUMANodes().List() {
+			eligibleCores := details.KeepOnly(details.CPUsInNUMANodes(numaID).Intersection(candidates)).Cores()
+			if eligibleCores.Size() < constraints.CoresPerNUMANode {
+				return cpuset.New(), fmt.Errorf("NUMA node %d has %d eligible cores, %d requested", numaID, eligibleCores.Size(), constraints.CoresPerNUMANode)
+			}
+			for _, coreID := range eligibleCores.List()[:constraints.CoresPerNUMANode] {
+				reserved = reserved.Union(details.CPUsInCores(coreID))
+			}
+		}
+		return reserved, nil
+	}
+
+	preferred := candidates
+	if constraints.PreferCoreType != "" {
+		preferred = candidates.Intersection(details.CPUsWithCoreType(constraints.PreferCoreType))
+	}
+	numPreferred := numReservedCPUs
+	if preferred.Size() < numPreferred {
+		numPreferred = preferred.Size()
+	}
+	reserved, err := p.takeByTopology(preferred, numPreferred)
+	if err != nil {
+		return cpuset.New(), err
+	}
+	if remaining := numReservedCPUs - reserved.Size(); remaining > 0 {
+		// The preferred core type alone cannot cover the reservation:
+		// fall back to the rest of the eligible CPUs.
+		fallback, err := p.takeByTopology(candidates.Difference(preferred), remaining)
+		if err != nil {
+			return cpuset.New(), err
+		}
+		reserved = reserved.Union(fallback)
+	}
+	return reserved, nil
+}
+
+// checkReservedUncoreAlignment verifies how the reserved CPUs spread across
+// the uncore caches. Every cache the reservation touches is left partially
+// busy, hence unusable for full-cache exclusive allocation, so a reservation
+// straddling more caches than its size requires is always logged; with the
+// strict-reserved-uncore-alignment option it becomes a startup error, and
+// with the reserved-cpus-uncore-cache option the reserved CPUs must all live
+// in the given cache.
+func checkReservedUncoreAlignment(topo *topology.CPUTopology, reserved cpuset.CPUSet, opts StaticPolicyOptions) error {
+	details := topo.CPUDetails
+	var occupied []int
+	var cacheSizes []int
+	for _, cacheID := range details.UncoreCaches().List() {
+		cpusInCache := details.CPUsInUncoreCaches(cacheID)
+		cacheSizes = append(cacheSizes, cpusInCache.Size())
+		if cpusInCache.Intersection(reserved).Size() > 0 {
+			occupied = append(occupied, cacheID)
+		}
+	}
+
+	if opts.ReservedUncoreCacheID != nil {
+		wantCacheID := *opts.ReservedUncoreCacheID
+		if len(occupied) != 1 || occupied[0] != wantCacheID {
+			return fmt.Errorf("[cpumanager] reserved CPUs %s must all belong to uncore cache %d, found them on caches %v", reserved, wantCacheID, occupied)
+		}
+		return nil
+	}
+
+	// The smallest number of caches which could host the reservation, filling
+	// the largest caches first.
+	sort.Sort(sort.Reverse(sort.IntSlice(cacheSizes)))
+	neededCaches := 0
+	remaining := reserved.Size()
+	for _, size := range cacheSizes {
+		if remaining <= 0 {
+			break
+		}
+		remaining -= size
+		neededCaches++
+	}
+
+	if len(occupied) > neededCaches {
+		if opts.StrictReservedUncoreAlignment {
+			return fmt.Errorf("[cpumanager] reserved CPUs %s straddle %d uncore caches %v, while %d would suffice", reserved, len(occupied), occupied, neededCaches)
+		}
+		klog.InfoS("Reserved CPUs straddle more uncore caches than necessary, the partially reserved caches cannot host full-cache exclusive allocations", "reserved", reserved, "uncoreCaches", occupied, "minimumUncoreCaches", neededCaches)
+	}
+	return nil
+}
+
+// exclusiveOnlyUncoreCPUs returns the CPUs of the uncore caches designated
+// exclusive-only by the homonymous policy option. The set is empty when the
+// option is unset.
+func (p *staticPolicy) exclusiveOnlyUncoreCPUs() cpuset.CPUSet {
+	return p.topology.CPUDetails.CPUsInUncoreCaches(p.options.ExclusiveUncoreCacheIDs...)
+}
+
 func (p *staticPolicy) Name() string {
 	return string(PolicyStatic)
 }
@@ -233,6 +408,11 @@ func (p *staticPolicy) validateState(s state.State) error {
 	// assign non-existent CPUs to containers. Validate that the
 	// topology that was received during CPU manager startup matches with
 	// the set of CPUs stored in the state.
+	if p.topologyUnchanged {
+		// The state was written against the very same topology, so the
+		// check below cannot fail: skip the walk on warm restarts.
+		return nil
+	}
 	totalKnownCPUs := tmpDefaultCPUset.Clone()
 	tmpCPUSets := []cpuset.CPUSet{}
 	for pod := range tmpAssignments {
@@ -241,6 +421,12 @@ func (p *staticPolicy) validateState(s state.State) error {
 		}
 	}
 	totalKnownCPUs = totalKnownCPUs.Union(tmpCPUSets...)
+	if p.options.FullPhysicalCPUsStrict {
+		// The sibling threads of assigned CPUs are withheld from the shared
+		// pool, so they belong to no set in the state; they are still known,
+		// since the assignments pin down which cores they live on.
+		totalKnownCPUs = totalKnownCPUs.Union(p.smtSiblings(cpuset.New().Union(tmpCPUSets...)))
+	}
 	if !totalKnownCPUs.Equals(p.topology.CPUDetails.CPUs()) {
 		return fmt.Errorf("current set of available CPUs \"%s\" doesn't match with CPUs in state \"%s\"",
 			p.topology.CPUDetails.CPUs().String(), totalKnownCPUs.String())
@@ -263,6 +449,20 @@ func (p *staticPolicy) GetAvailablePhysicalCPUs(s state.State) cpuset.CPUSet {
 	return s.GetDefaultCPUSet().Difference(p.reservedPhysicalCPUs)
 }
 
+// GetFreePhysicalCores returns the set of core IDs whose SMT siblings are all
+// still available for exclusive allocation. Partially allocated cores are
+// excluded: they can never satisfy a full-pcpus-only request.
+func (p *staticPolicy) GetFreePhysicalCores(s state.State) cpuset.CPUSet {
+	availableCPUs := p.GetAvailablePhysicalCPUs(s)
+	freeCores := []int{}
+	for _, coreID := range p.topology.CPUDetails.Cores().List() {
+		if p.topology.CPUDetails.CPUsInCores(coreID).IsSubsetOf(availableCPUs) {
+			freeCores = append(freeCores, coreID)
+		}
+	}
+	return cpuset.New(freeCores...)
+}
+
 func (p *staticPolicy) updateCPUsToReuse(pod *v1.Pod, container *v1.Container, cset cpuset.CPUSet) {
 	// If pod entries to m.cpusToReuse other than the current pod exist, delete them.
 	for podUID := range p.cpusToReuse {
@@ -294,6 +494,9 @@ func (p *staticPolicy) updateCPUsToReuse(pod *v1.Pod, container *v1.Container, c
 }
 
 func (p *staticPolicy) Allocate(s state.State, pod *v1.Pod, container *v1.Container) (rerr error) {
+	if err := validateSkipExclusiveCPUsAnnotation(pod); err != nil {
+		return err
+	}
 	numCPUs := p.guaranteedCPUs(pod, container)
 	if numCPUs == 0 {
 		// container belongs in the shared pool (nothing to do; use default cpuset)
@@ -311,9 +514,12 @@ func (p *staticPolicy) Allocate(s state.State, pod *v1.Pod, container *v1.Contai
 
 	if p.options.FullPhysicalCPUsOnly {
 		CPUsPerCore := p.topology.CPUsPerCore()
-		if (numCPUs % CPUsPerCore) != 0 {
+		if !p.topology.SMTAlignableCPUCount(numCPUs) {
 			// Since CPU Manager has been enabled requesting strict SMT alignment, it means a guaranteed pod can only be admitted
 			// if the CPU requested is a multiple of the number of virtual cpus per physical cores.
+			// On machines where SMT is disabled on only a subset of cores, the narrower cores can absorb
+			// what would otherwise be a misaligned remainder, so the request is checked against the actual
+			// core inventory rather than a flat modulus.
 			// In case CPU request is not a multiple of the number of virtual cpus per physical cores the Pod will be put
 			// in Failed state, with SMTAlignmentError as reason. Since the allocation happens in terms of physical cores
 			// and the scheduler is responsible for ensuring that the workload goes to a node that has enough CPUs,
@@ -340,10 +546,41 @@ func (p *staticPolicy) Allocate(s state.State, pod *v1.Pod, container *v1.Contai
 				AvailablePhysicalCPUs: availablePhysicalCPUs,
 			}
 		}
+
+		// On heterogeneous SMT machines passing the inventory check is not
+		// enough: the cores able to absorb the remainder must be free right
+		// now, otherwise the allocation below would split an SMT core.
+		if p.topology.HasHeterogeneousSMT() {
+			freeCores := p.GetFreePhysicalCores(s)
+			freeDetails := p.topology.CPUDetails.KeepOnly(p.topology.CPUDetails.CPUsInCores(freeCores.List()...))
+			if !freeDetails.SMTAlignableCPUCount(numCPUs) {
+				return SMTAlignmentError{
+					RequestedCPUs:         numCPUs,
+					CpusPerCore:           CPUsPerCore,
+					AvailablePhysicalCPUs: availablePhysicalCPUs,
+				}
+			}
+		}
 	}
-	if cpuset, ok := s.GetCPUSet(string(pod.UID), container.Name); ok {
-		p.updateCPUsToReuse(pod, container, cpuset)
-		klog.InfoS("Static policy: container already present in state, skipping", "pod", klog.KObj(pod), "containerName", container.Name)
+	if cset, ok := s.GetCPUSet(string(pod.UID), container.Name); ok {
+		// An island spans a whole NUMA node no matter the exact request, so
+		// a size mismatch there is expected and not an in-place resize.
+		if !utilfeature.DefaultFeatureGate.Enabled(features.InPlacePodVerticalScaling) || cset.Size() == numCPUs || p.podRequestsExclusiveNUMAIsland(pod) {
+			p.updateCPUsToReuse(pod, container, cset)
+			klog.InfoS("Static policy: container already present in state, skipping", "pod", klog.KObj(pod), "containerName", container.Name)
+			return nil
+		}
+
+		// The container is being resized in place: adjust the exclusive
+		// assignment to the new request instead of leaving it stale.
+		cset, err := p.reallocateCPUs(s, cset, numCPUs)
+		if err != nil {
+			klog.ErrorS(err, "Unable to reallocate CPUs", "pod", klog.KObj(pod), "containerName", container.Name, "numCPUs", numCPUs)
+			return err
+		}
+		s.SetCPUSet(string(pod.UID), container.Name, cset)
+		p.updateCPUsToReuse(pod, container, cset)
+		klog.InfoS("Static policy: reallocated exclusive CPUs for resized container", "pod", klog.KObj(pod), "containerName", container.Name, "cpus", cset)
 		return nil
 	}
 
@@ -351,6 +588,17 @@ func (p *staticPolicy) Allocate(s state.State, pod *v1.Pod, container *v1.Contai
 	hint := p.affinity.GetAffinity(string(pod.UID), container.Name)
 	klog.InfoS("Topology Affinity", "pod", klog.KObj(pod), "containerName", container.Name, "affinity", hint)
 
+	if p.podRequestsExclusiveNUMAIsland(pod) {
+		cset, err := p.allocateNUMAIsland(s, numCPUs, hint.NUMANodeAffinity)
+		if err != nil {
+			klog.ErrorS(err, "Unable to allocate an exclusive NUMA island", "pod", klog.KObj(pod), "containerName", container.Name, "numCPUs", numCPUs)
+			return err
+		}
+		s.SetCPUSet(string(pod.UID), container.Name, cset)
+		klog.InfoS("Static policy: handed a whole NUMA node to the container", "pod", klog.KObj(pod), "containerName", container.Name, "cpus", cset)
+		return nil
+	}
+
 	// Allocate CPUs according to the NUMA affinity contained in the hint.
 	cpuset, err := p.allocateCPUs(s, numCPUs, hint.NUMANodeAffinity, p.cpusToReuse[string(pod.UID)])
 	if err != nil {
@@ -382,17 +630,60 @@ func (p *staticPolicy) RemoveContainer(s state.State, podUID string, containerNa
 	if toRelease, ok := s.GetCPUSet(podUID, containerName); ok {
 		s.Delete(podUID, containerName)
 		// Mutate the shared pool, adding released cpus.
+		if p.options.FullPhysicalCPUsStrict {
+			// The sibling threads were withheld from the shared pool when
+			// the CPUs were allocated; release them as well.
+			toRelease = p.smtSiblings(toRelease)
+		}
 		toRelease = toRelease.Difference(cpusInUse)
 		s.SetDefaultCPUSet(s.GetDefaultCPUSet().Union(toRelease))
 	}
 	return nil
 }
 
+// smtSiblings returns the given CPUs plus all the threads sharing a physical
+// core with them.
+func (p *staticPolicy) smtSiblings(cpus cpuset.CPUSet) cpuset.CPUSet {
+	cores := p.topology.CPUDetails.KeepOnly(cpus).Cores()
+	return p.topology.CPUDetails.CPUsInCores(cores.List()...)
+}
+
 func (p *staticPolicy) allocateCPUs(s state.State, numCPUs int, numaAffinity bitmask.BitMask, reusableCPUs cpuset.CPUSet) (cpuset.CPUSet, error) {
 	klog.InfoS("AllocateCPUs", "numCPUs", numCPUs, "socket", numaAffinity)
 
 	allocatableCPUs := p.GetAvailableCPUs(s).Union(reusableCPUs)
 
+	// Enforce the per-socket exclusive allocation cap, if one is configured.
+	// Sockets which already hit the cap contribute no CPUs, so the request
+	// spills over to the remaining sockets (or fails if they cannot satisfy it).
+	if p.options.PerSocketExclusiveCapPercentage > 0 {
+		allocatableCPUs = p.capAllocatableCPUsBySocket(s, allocatableCPUs, reusableCPUs)
+	}
+
+	// On hybrid CPUs steer exclusive allocations to the performance cores,
+	// which leaves the efficiency cores to the shared pool. The preference
+	// is dropped when the performance cores alone cannot satisfy the
+	// request.
+	if p.options.PreferPerformanceCores {
+		pcoreCPUs := allocatableCPUs.Intersection(p.topology.CPUDetails.CPUsWithCoreType(topology.CoreTypePerformance))
+		if pcoreCPUs.Size() >= numCPUs {
+			allocatableCPUs = pcoreCPUs
+		}
+	}
+
+	// With SMT isolation each requested CPU claims a whole physical core:
+	// the container gets one thread per core and the sibling threads leave
+	// the shared pool without being assigned to anybody.
+	if p.options.FullPhysicalCPUsStrict {
+		result, siblings, err := allocation.TakeByTopologySMTIsolated(p.topology, allocatableCPUs, numCPUs)
+		if err != nil {
+			return cpuset.New(), err
+		}
+		s.SetDefaultCPUSet(s.GetDefaultCPUSet().Difference(result).Difference(siblings))
+		klog.InfoS("AllocateCPUs", "result", result, "isolatedSiblings", siblings)
+		return result, nil
+	}
+
 	// If there are aligned CPUs in numaAffinity, attempt to take those first.
 	result := cpuset.New()
 	if numaAffinity != nil {
@@ -425,10 +716,207 @@ func (p *staticPolicy) allocateCPUs(s state.State, numCPUs int, numaAffinity bit
 	return result, nil
 }
 
+// podRequestsExclusiveNUMAIsland returns whether the given pod asked to have
+// a whole NUMA node for itself, and the policy runs with the option honoring
+// the request. The QoS class is not checked here: only containers with an
+// integral exclusive CPU request get as far as asking for an island.
+func (p *staticPolicy) podRequestsExclusiveNUMAIsland(pod *v1.Pod) bool {
+	if !p.options.ExclusiveNUMAIslands {
+		return false
+	}
+	requested, err := strconv.ParseBool(pod.Annotations[ExclusiveNUMAIslandAnnotation])
+	return err == nil && requested
+}
+
+// podSkipsExclusiveCPUs returns whether the given pod opted out of exclusive
+// CPU pinning through the annotation. The annotation value is validated at
+// admission time, so a parse failure here can only mean the pod never asked.
+func podSkipsExclusiveCPUs(pod *v1.Pod) bool {
+	skip, err := strconv.ParseBool(pod.Annotations[SkipExclusiveCPUsAnnotation])
+	return err == nil && skip
+}
+
+// validateSkipExclusiveCPUsAnnotation rejects at admission pods carrying a
+// malformed opt-out annotation, rather than silently pinning them.
+func validateSkipExclusiveCPUsAnnotation(pod *v1.Pod) error {
+	value, ok := pod.Annotations[SkipExclusiveCPUsAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("bad value %q for annotation %q: %w", value, SkipExclusiveCPUsAnnotation, err)
+	}
+	return nil
+}
+
+// freeNUMAIslands returns the NUMA nodes whose every CPU is free: neither
+// reserved nor exclusively assigned. Only such nodes can be handed out whole
+// as an exclusive island.
+func (p *staticPolicy) freeNUMAIslands(s state.State) []int {
+	available := p.GetAvailableCPUs(s)
+	var islands []int
+	for _, node := range p.topology.CPUDetails.NUMANodes().List() {
+		nodeCPUs := p.topology.CPUDetails.CPUsInNUMANodes(node)
+		if !nodeCPUs.IsEmpty() && nodeCPUs.IsSubsetOf(available) {
+			islands = append(islands, node)
+		}
+	}
+	return islands
+}
+
+// allocateNUMAIsland picks a fully free NUMA node, preferring the ones in the
+// given affinity, and hands every CPU of it to the requesting container. The
+// island ownership is recorded in the state checkpoint like any other
+// exclusive assignment, so it survives a kubelet restart, and removing the
+// container returns the whole node to the shared pool.
+func (p *staticPolicy) allocateNUMAIsland(s state.State, numCPUs int, numaAffinity bitmask.BitMask) (cpuset.CPUSet, error) {
+	island := -1
+	fallback := -1
+	for _, node := range p.freeNUMAIslands(s) {
+		if numCPUs > p.topology.CPUDetails.CPUsInNUMANodes(node).Size() {
+			continue
+		}
+		if numaAffinity == nil || numaAffinity.IsSet(node) {
+			island = node
+			break
+		}
+		if fallback == -1 {
+			fallback = node
+		}
+	}
+	if island == -1 {
+		// The affinity is a preference, not a constraint: any fully free
+		// node large enough beats failing the admission.
+		island = fallback
+	}
+	if island == -1 {
+		return cpuset.New(), NUMAIslandError{RequestedCPUs: numCPUs}
+	}
+
+	result := p.topology.CPUDetails.CPUsInNUMANodes(island)
+	// Remove the whole island from the shared CPUSet.
+	s.SetDefaultCPUSet(s.GetDefaultCPUSet().Difference(result))
+
+	klog.InfoS("AllocateNUMAIsland", "island", island, "result", result)
+	return result, nil
+}
+
+// islandTopologyHints generates the hints for a pod claiming a whole NUMA
+// node: one preferred single-node hint per fully free node large enough for
+// the request. A node already handed out to the pod (after a kubelet restart,
+// for example) is reported back as the only hint.
+func (p *staticPolicy) islandTopologyHints(s state.State, assigned cpuset.CPUSet, request int) []topologymanager.TopologyHint {
+	if !assigned.IsEmpty() {
+		nodes := p.topology.CPUDetails.KeepOnly(assigned).NUMANodes()
+		mask, _ := bitmask.NewBitMask(nodes.List()...)
+		return []topologymanager.TopologyHint{{NUMANodeAffinity: mask, Preferred: true}}
+	}
+
+	hints := []topologymanager.TopologyHint{}
+	for _, node := range p.freeNUMAIslands(s) {
+		if p.topology.CPUDetails.CPUsInNUMANodes(node).Size() < request {
+			continue
+		}
+		mask, _ := bitmask.NewBitMask(node)
+		hints = append(hints, topologymanager.TopologyHint{NUMANodeAffinity: mask, Preferred: true})
+	}
+	return hints
+}
+
+// reallocateCPUs adjusts an existing exclusive assignment to the new
+// requested size, as needed when a container is resized in place. The updated
+// assignment is stored by the caller, so it ends up in the checkpoint like
+// any other allocation.
+//
+// When shrinking, the CPUs to keep are picked topologically from the current
+// assignment, so the surviving set stays as aligned as the original one, and
+// the released CPUs return to the shared pool.
+//
+// When growing, the extra CPUs are taken first from the NUMA nodes (or, with
+// uncore cache alignment enabled, the uncore caches) the assignment already
+// spans, extending it without crossing new boundaries. Only when those cannot
+// supply the extra CPUs is the whole request re-taken from scratch, with the
+// current CPUs back in the pool so the new assignment can retain as many of
+// them as possible.
+func (p *staticPolicy) reallocateCPUs(s state.State, current cpuset.CPUSet, numCPUs int) (cpuset.CPUSet, error) {
+	klog.InfoS("ReallocateCPUs", "numCPUs", numCPUs, "current", current)
+
+	if numCPUs < current.Size() {
+		keep, err := p.takeByTopology(current, numCPUs)
+		if err != nil {
+			return cpuset.New(), err
+		}
+		s.SetDefaultCPUSet(s.GetDefaultCPUSet().Union(current.Difference(keep)))
+		return keep, nil
+	}
+
+	candidates := p.GetAvailableCPUs(s)
+	if p.options.PreferAlignByUncoreCache {
+		uncores := p.topology.CPUDetails.KeepOnly(current).UncoreCaches()
+		candidates = candidates.Intersection(p.topology.CPUDetails.CPUsInUncoreCaches(uncores.List()...))
+	} else if p.options.AlignByCluster {
+		clusters := p.topology.CPUDetails.KeepOnly(current).Clusters()
+		candidates = candidates.Intersection(p.topology.CPUDetails.CPUsInClusters(clusters.List()...))
+	} else {
+		numas := p.topology.CPUDetails.KeepOnly(current).NUMANodes()
+		candidates = candidates.Intersection(p.topology.CPUDetails.CPUsInNUMANodes(numas.List()...))
+	}
+	if extra, err := p.takeByTopology(candidates, numCPUs-current.Size()); err == nil {
+		s.SetDefaultCPUSet(s.GetDefaultCPUSet().Difference(extra))
+		return current.Union(extra), nil
+	}
+
+	// The assignment cannot grow in place. Return its CPUs to the pool and
+	// re-take the whole request; offering the current CPUs for reuse keeps
+	// the disruption to the running container as small as possible.
+	s.SetDefaultCPUSet(s.GetDefaultCPUSet().Union(current))
+	cset, err := p.allocateCPUs(s, numCPUs, nil, current)
+	if err != nil {
+		// Put the state back the way we found it: the container keeps
+		// running with its pre-resize assignment.
+		s.SetDefaultCPUSet(s.GetDefaultCPUSet().Difference(current))
+		return cpuset.New(), err
+	}
+	return cset, nil
+}
+
+// capAllocatableCPUsBySocket trims the allocatable set so that no socket can
+// exceed its exclusive allocation cap. For each socket the headroom is the
+// configured percentage of the socket's CPUs minus the CPUs already
+// exclusively assigned there; CPUs offered for reuse are not counted as
+// assigned, since taking them again does not grow the exclusive footprint.
+// When a socket has more free CPUs than headroom, the surviving subset is
+// picked topologically, like any other allocation.
+func (p *staticPolicy) capAllocatableCPUsBySocket(s state.State, allocatableCPUs cpuset.CPUSet, reusableCPUs cpuset.CPUSet) cpuset.CPUSet {
+	cappedCPUs := cpuset.New()
+	for _, socketID := range p.topology.CPUDetails.Sockets().List() {
+		socketCPUs := p.topology.CPUDetails.CPUsInSockets(socketID)
+		capSize := socketCPUs.Size() * p.options.PerSocketExclusiveCapPercentage / 100
+		exclusiveInUse := socketCPUs.Difference(s.GetDefaultCPUSet()).Difference(reusableCPUs).Size()
+		headroom := capSize - exclusiveInUse
+		if headroom <= 0 {
+			klog.V(4).InfoS("Socket reached its exclusive allocation cap", "socketID", socketID, "capSize", capSize, "exclusiveInUse", exclusiveInUse)
+			continue
+		}
+		freeCPUs := allocatableCPUs.Intersection(socketCPUs)
+		if freeCPUs.Size() > headroom {
+			// takeByTopology cannot fail here: headroom is smaller than the set size.
+			freeCPUs, _ = p.takeByTopology(freeCPUs, headroom)
+		}
+		cappedCPUs = cappedCPUs.Union(freeCPUs)
+	}
+	return cappedCPUs
+}
+
 func (p *staticPolicy) guaranteedCPUs(pod *v1.Pod, container *v1.Container) int {
 	if v1qos.GetPodQOS(pod) != v1.PodQOSGuaranteed {
 		return 0
 	}
+	if podSkipsExclusiveCPUs(pod) {
+		// The pod opted out of exclusive pinning: its containers stay on the
+		// shared pool, and no CPUs are accounted to it anywhere else.
+		return 0
+	}
 	cpuQuantity := container.Resources.Requests[v1.ResourceCPU]
 	// In-place pod resize feature makes Container.Resources field mutable for CPU & memory.
 	// AllocatedResources holds the value of Container.Resources.Requests when the pod was admitted.
@@ -486,11 +974,26 @@ func (p *staticPolicy) takeByTopology(availableCPUs cpuset.CPUSet, numCPUs int)
 	if p.options.DistributeCPUsAcrossNUMA {
 		cpuGroupSize := 1
 		if p.options.FullPhysicalCPUsOnly {
-			cpuGroupSize = p.topology.CPUsPerCore()
+			// The widest core dictates the group size, so a group never
+			// splits an SMT core even when SMT is disabled on some cores.
+			cpuGroupSize = p.topology.MaxThreadsPerCore()
+		}
+		return allocation.TakeByTopologyNUMADistributed(p.topology, availableCPUs, numCPUs, cpuGroupSize)
+	}
+	if p.options.PreferAlignByUncoreCache {
+		return allocation.TakeByTopologyUncoreCachePacked(p.topology, availableCPUs, numCPUs)
+	}
+	if p.options.AlignByCluster {
+		return allocation.TakeByTopologyClusterPacked(p.topology, availableCPUs, numCPUs)
+	}
+	if p.options.DistributeCPUsAcrossUncore {
+		cpuGroupSize := 1
+		if p.options.FullPhysicalCPUsOnly {
+			cpuGroupSize = p.topology.MaxThreadsPerCore()
 		}
-		return takeByTopologyNUMADistributed(p.topology, availableCPUs, numCPUs, cpuGroupSize)
+		return allocation.TakeByTopologyUncoreCacheDistributed(p.topology, availableCPUs, numCPUs, cpuGroupSize)
 	}
-	return takeByTopologyNUMAPacked(p.topology, availableCPUs, numCPUs)
+	return allocation.TakeByTopologyNUMAPacked(p.topology, availableCPUs, numCPUs)
 }
 
 func (p *staticPolicy) GetTopologyHints(s state.State, pod *v1.Pod, container *v1.Container) map[string][]topologymanager.TopologyHint {
@@ -505,6 +1008,18 @@ func (p *staticPolicy) GetTopologyHints(s state.State, pod *v1.Pod, container *v
 		return nil
 	}
 
+	// A container claiming a whole NUMA node only ever fits on a fully free
+	// node, so the regular hint generation does not apply. The size checks
+	// below do not either: the island is larger than the request by design.
+	if p.podRequestsExclusiveNUMAIsland(pod) {
+		assigned, _ := s.GetCPUSet(string(pod.UID), container.Name)
+		islandHints := p.islandTopologyHints(s, assigned, requested)
+		klog.InfoS("TopologyHints generated for an exclusive NUMA island", "pod", klog.KObj(pod), "containerName", container.Name, "cpuHints", islandHints)
+		return map[string][]topologymanager.TopologyHint{
+			string(v1.ResourceCPU): islandHints,
+		}
+	}
+
 	// Short circuit to regenerate the same hints if there are already
 	// guaranteed CPUs allocated to the Container. This might happen after a
 	// kubelet restart, for example.
@@ -552,6 +1067,22 @@ func (p *staticPolicy) GetPodTopologyHints(s state.State, pod *v1.Pod) map[strin
 		return nil
 	}
 
+	// A pod claiming a whole NUMA node only ever fits on a fully free node;
+	// see GetTopologyHints above.
+	if p.podRequestsExclusiveNUMAIsland(pod) {
+		assigned := cpuset.New()
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if allocated, exists := s.GetCPUSet(string(pod.UID), container.Name); exists {
+				assigned = assigned.Union(allocated)
+			}
+		}
+		islandHints := p.islandTopologyHints(s, assigned, requested)
+		klog.InfoS("TopologyHints generated for an exclusive NUMA island", "pod", klog.KObj(pod), "cpuHints", islandHints)
+		return map[string][]topologymanager.TopologyHint{
+			string(v1.ResourceCPU): islandHints,
+		}
+	}
+
 	assignedCPUs := cpuset.New()
 	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
 		requestedByContainer := p.guaranteedCPUs(pod, &container)
@@ -660,6 +660,63 @@ func runStaticPolicyTestCaseWithFeatureGate(t *testing.T, testCase staticPolicyT
 	runStaticPolicyTestCase(t, testCase)
 }
 
+func TestStaticPolicyAddWithPerSocketExclusiveCap(t *testing.T) {
+	// topoDualSocketHT has 6 CPUs per socket, so a 34% cap allows
+	// 2 exclusive CPUs per socket.
+	testCases := []staticPolicyTest{
+		{
+			description: "request exceeding the cap on one socket spills over to the other",
+			topo:        topoDualSocketHT,
+			options: map[string]string{
+				PerSocketExclusiveCapOption: "34",
+			},
+			numReservedCPUs: 1,
+			stAssignments:   state.ContainerCPUAssignments{},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11),
+			pod:             makePod("fakePod", "fakeContainer2", "4000m", "4000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(1, 2, 7, 8),
+		},
+		{
+			description: "socket already at the cap contributes no CPUs",
+			topo:        topoDualSocketHT,
+			options: map[string]string{
+				PerSocketExclusiveCapOption: "34",
+			},
+			numReservedCPUs: 1,
+			stAssignments: state.ContainerCPUAssignments{
+				"fakePod2": map[string]cpuset.CPUSet{
+					"fakeContainer100": cpuset.New(2, 8),
+				},
+			},
+			stDefaultCPUSet: cpuset.New(0, 1, 3, 4, 5, 6, 7, 9, 10, 11),
+			pod:             makePod("fakePod", "fakeContainer2", "2000m", "2000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(1, 7),
+		},
+		{
+			description: "request larger than the combined caps fails even with free CPUs",
+			topo:        topoDualSocketHT,
+			options: map[string]string{
+				PerSocketExclusiveCapOption: "34",
+			},
+			numReservedCPUs: 1,
+			stAssignments:   state.ContainerCPUAssignments{},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11),
+			pod:             makePod("fakePod", "fakeContainer2", "5000m", "5000m"),
+			expErr:          fmt.Errorf("not enough cpus available to satisfy request: requested=5, available=4"),
+			expCPUAlloc:     false,
+			expCSet:         cpuset.New(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		runStaticPolicyTestCaseWithFeatureGate(t, testCase)
+	}
+}
+
 func TestStaticPolicyReuseCPUs(t *testing.T) {
 	testCases := []struct {
 		staticPolicyTest
@@ -1015,6 +1072,78 @@ func TestStaticPolicyStartWithResvList(t *testing.T) {
 	}
 }
 
+func TestStaticPolicyReservedUncoreAlignment(t *testing.T) {
+	testCases := []struct {
+		description     string
+		numReservedCPUs int
+		reserved        cpuset.CPUSet
+		options         map[string]string
+		expNewErr       bool
+	}{
+		{
+			description:     "strict alignment, reservation fills exactly one cache",
+			numReservedCPUs: 4,
+			reserved:        cpuset.New(0, 1, 2, 3),
+			options:         map[string]string{StrictReservedUncoreAlignmentOption: "true"},
+			expNewErr:       false,
+		},
+		{
+			description:     "strict alignment, reservation straddles two caches",
+			numReservedCPUs: 2,
+			reserved:        cpuset.New(3, 4),
+			options:         map[string]string{StrictReservedUncoreAlignmentOption: "true"},
+			expNewErr:       true,
+		},
+		{
+			description:     "no options, straddling reservation is only logged",
+			numReservedCPUs: 2,
+			reserved:        cpuset.New(3, 4),
+			options:         nil,
+			expNewErr:       false,
+		},
+		{
+			description:     "pinned cache, reservation inside it",
+			numReservedCPUs: 2,
+			reserved:        cpuset.New(4, 5),
+			options:         map[string]string{ReservedUncoreCacheOption: "1"},
+			expNewErr:       false,
+		},
+		{
+			description:     "pinned cache, reservation on another cache",
+			numReservedCPUs: 2,
+			reserved:        cpuset.New(0, 1),
+			options:         map[string]string{ReservedUncoreCacheOption: "1"},
+			expNewErr:       true,
+		},
+		{
+			description:     "exclusive-only cache, reservation elsewhere",
+			numReservedCPUs: 2,
+			reserved:        cpuset.New(0, 1),
+			options:         map[string]string{ExclusiveUncoreCachesOption: "2"},
+			expNewErr:       false,
+		},
+		{
+			description:     "exclusive-only cache, reservation overlaps it",
+			numReservedCPUs: 2,
+			reserved:        cpuset.New(8, 9),
+			options:         map[string]string{ExclusiveUncoreCachesOption: "2"},
+			expNewErr:       true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.CPUManagerPolicyAlphaOptions, true)
+
+			_, err := NewStaticPolicy(topoUncoreSingleSocketNoHT, testCase.numReservedCPUs, testCase.reserved, topologymanager.NewFakeManager(), testCase.options)
+			gotError := (err != nil)
+			if gotError != testCase.expNewErr {
+				t.Errorf("NewStaticPolicy() error (%v). expected error: %v but got: %v",
+					testCase.description, testCase.expNewErr, err)
+			}
+		})
+	}
+}
+
 func TestStaticPolicyAddWithResvList(t *testing.T) {
 
 	testCases := []staticPolicyTestWithResvList{
@@ -1192,3 +1321,434 @@ func newCPUSetPtr(cpus ...int) *cpuset.CPUSet {
 	ret := cpuset.New(cpus...)
 	return &ret
 }
+
+func TestStaticPolicyReallocateOnResize(t *testing.T) {
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.InPlacePodVerticalScaling, true)
+
+	testCases := []staticPolicyTest{
+		{
+			description:     "grow within the NUMA node the assignment already spans",
+			topo:            topoSingleSocketHT,
+			numReservedCPUs: 1,
+			reservedCPUs:    newCPUSetPtr(0),
+			stAssignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(1, 5),
+				},
+			},
+			stDefaultCPUSet: cpuset.New(0, 2, 3, 4, 6, 7),
+			pod:             makePod("fakePod", "fakeContainer", "4000m", "4000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(1, 2, 5, 6),
+		},
+		{
+			description:     "shrink keeps a topologically picked subset",
+			topo:            topoSingleSocketHT,
+			numReservedCPUs: 1,
+			reservedCPUs:    newCPUSetPtr(0),
+			stAssignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(1, 2, 5, 6),
+				},
+			},
+			stDefaultCPUSet: cpuset.New(0, 3, 4, 7),
+			pod:             makePod("fakePod", "fakeContainer", "2000m", "2000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(1, 5),
+		},
+		{
+			description:     "grow falls back to a full re-take when the NUMA node is exhausted",
+			topo:            topoDualSocketHT,
+			numReservedCPUs: 1,
+			reservedCPUs:    newCPUSetPtr(1),
+			stAssignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(0),
+				},
+				"otherPod": map[string]cpuset.CPUSet{
+					"otherContainer": cpuset.New(2, 4, 6, 8, 10),
+				},
+			},
+			stDefaultCPUSet: cpuset.New(1, 3, 5, 7, 9, 11),
+			pod:             makePod("fakePod", "fakeContainer", "4000m", "4000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(3, 5, 9, 11),
+		},
+		{
+			description:     "grow fails and the pre-resize assignment survives",
+			topo:            topoSingleSocketHT,
+			numReservedCPUs: 1,
+			reservedCPUs:    newCPUSetPtr(0),
+			stAssignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(1, 5),
+				},
+				"otherPod": map[string]cpuset.CPUSet{
+					"otherContainer": cpuset.New(2, 3, 6, 7),
+				},
+			},
+			stDefaultCPUSet: cpuset.New(0, 4),
+			pod:             makePod("fakePod", "fakeContainer", "6000m", "6000m"),
+			expErr:          fmt.Errorf("not enough cpus available to satisfy request: requested=6, available=3"),
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(1, 5),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			runStaticPolicyTestCase(t, testCase)
+		})
+	}
+}
+
+func TestStaticPolicySMTIsolation(t *testing.T) {
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.CPUManagerPolicyAlphaOptions, true)
+
+	options := map[string]string{FullPCPUsStrictOption: "true"}
+	policy, err := NewStaticPolicy(topoSingleSocketHT, 1, cpuset.New(0), topologymanager.NewFakeManager(), options)
+	if err != nil {
+		t.Fatalf("NewStaticPolicy() failed: %v", err)
+	}
+
+	st := &mockState{
+		assignments:   state.ContainerCPUAssignments{},
+		defaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7),
+	}
+
+	pod := makePod("fakePod", "fakeContainer", "2000m", "2000m")
+	container := &pod.Spec.Containers[0]
+	if err := policy.Allocate(st, pod, container); err != nil {
+		t.Fatalf("Allocate() failed: %v", err)
+	}
+
+	cset, found := st.assignments["fakePod"]["fakeContainer"]
+	if !found {
+		t.Fatalf("expected container to be present in assignments %v", st.assignments)
+	}
+	// One thread per core; core 0 holds the reserved CPU, so cores 1 and 2 are used.
+	if expected := cpuset.New(1, 2); !cset.Equals(expected) {
+		t.Errorf("expected cpuset %v but got %v", expected, cset)
+	}
+	// The siblings of the allocated CPUs must leave the shared pool as well.
+	if expected := cpuset.New(0, 3, 4, 7); !st.defaultCPUSet.Equals(expected) {
+		t.Errorf("expected default cpuset %v but got %v", expected, st.defaultCPUSet)
+	}
+
+	// A request no free cores can satisfy must be rejected.
+	pod2 := makePod("fakePod2", "fakeContainer2", "2000m", "2000m")
+	if err := policy.Allocate(st, pod2, &pod2.Spec.Containers[0]); err == nil {
+		t.Errorf("expected Allocate() to fail, got assignments %v", st.assignments)
+	}
+
+	// Removing the container must return the withheld siblings too.
+	if err := policy.RemoveContainer(st, "fakePod", "fakeContainer"); err != nil {
+		t.Fatalf("RemoveContainer() failed: %v", err)
+	}
+	if expected := cpuset.New(0, 1, 2, 3, 4, 5, 6, 7); !st.defaultCPUSet.Equals(expected) {
+		t.Errorf("expected default cpuset %v but got %v", expected, st.defaultCPUSet)
+	}
+}
+
+func TestStaticPolicyPreferPerformanceCores(t *testing.T) {
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.CPUManagerPolicyAlphaOptions, true)
+
+	options := map[string]string{PreferPerformanceCoresOption: "true"}
+	policy, err := NewStaticPolicy(topoHybridSingleSocketNoHT, 1, cpuset.New(0), topologymanager.NewFakeManager(), options)
+	if err != nil {
+		t.Fatalf("NewStaticPolicy() failed: %v", err)
+	}
+
+	st := &mockState{
+		assignments:   state.ContainerCPUAssignments{},
+		defaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7),
+	}
+
+	pod := makePod("fakePod", "fakeContainer", "2000m", "2000m")
+	if err := policy.Allocate(st, pod, &pod.Spec.Containers[0]); err != nil {
+		t.Fatalf("Allocate() failed: %v", err)
+	}
+
+	cset, found := st.assignments["fakePod"]["fakeContainer"]
+	if !found {
+		t.Fatalf("expected container to be present in assignments %v", st.assignments)
+	}
+	// CPU 0 is reserved, so the allocation lands on the remaining P-cores.
+	if expected := cpuset.New(1, 2); !cset.Equals(expected) {
+		t.Errorf("expected cpuset %v but got %v", expected, cset)
+	}
+
+	// A request larger than the free P-cores falls back to any available CPU.
+	pod2 := makePod("fakePod2", "fakeContainer2", "3000m", "3000m")
+	if err := policy.Allocate(st, pod2, &pod2.Spec.Containers[0]); err != nil {
+		t.Fatalf("Allocate() failed: %v", err)
+	}
+	cset2 := st.assignments["fakePod2"]["fakeContainer2"]
+	if expected := cpuset.New(3, 4, 5); !cset2.Equals(expected) {
+		t.Errorf("expected cpuset %v but got %v", expected, cset2)
+	}
+	if expected := cpuset.New(0, 6, 7); !st.defaultCPUSet.Equals(expected) {
+		t.Errorf("expected default cpuset %v but got %v", expected, st.defaultCPUSet)
+	}
+}
+
+func TestStaticPolicyAllocateExclusiveNUMAIsland(t *testing.T) {
+	islandOptions := map[string]string{
+		ExclusiveNUMAIslandsOption: "true",
+	}
+	reserved := cpuset.New(0)
+	node0Mask, _ := bitmask.NewBitMask(0)
+
+	makeIslandPod := func(podUID, containerName, cpus string) *v1.Pod {
+		pod := makePod(podUID, containerName, cpus, cpus)
+		pod.Annotations = map[string]string{ExclusiveNUMAIslandAnnotation: "true"}
+		return pod
+	}
+
+	testCases := []staticPolicyTest{
+		{
+			description:     "claim a whole NUMA node; the reserved CPU rules out node 0",
+			topo:            topoDualSocketHT,
+			options:         islandOptions,
+			numReservedCPUs: 1,
+			reservedCPUs:    &reserved,
+			stAssignments:   state.ContainerCPUAssignments{},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11),
+			pod:             makeIslandPod("fakePod", "fakeContainer", "2000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(1, 3, 5, 7, 9, 11),
+		},
+		{
+			description:     "no fully free NUMA node left",
+			topo:            topoDualSocketHT,
+			options:         islandOptions,
+			numReservedCPUs: 1,
+			reservedCPUs:    &reserved,
+			stAssignments: state.ContainerCPUAssignments{
+				"fakePod2": map[string]cpuset.CPUSet{
+					"fakeContainer2": cpuset.New(3),
+				},
+			},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 4, 5, 6, 7, 8, 9, 10, 11),
+			pod:             makeIslandPod("fakePod", "fakeContainer", "2000m"),
+			expErr:          NUMAIslandError{RequestedCPUs: 2},
+			expCPUAlloc:     false,
+		},
+		{
+			description:     "the topology hint steers the island choice",
+			topo:            topoDualSocketHT,
+			options:         islandOptions,
+			stAssignments:   state.ContainerCPUAssignments{},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11),
+			pod:             makeIslandPod("fakePod", "fakeContainer", "2000m"),
+			topologyHint:    &topologymanager.TopologyHint{NUMANodeAffinity: node0Mask, Preferred: true},
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(0, 2, 4, 6, 8, 10),
+		},
+	}
+
+	for _, testCase := range testCases {
+		runStaticPolicyTestCaseWithFeatureGate(t, testCase)
+	}
+
+	// The option makes no sense on a machine with a single NUMA node.
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.CPUManagerPolicyAlphaOptions, true)
+	if _, err := NewStaticPolicy(topoUncoreSingleSocketNoHT, 1, cpuset.New(), topologymanager.NewFakeManager(), islandOptions); err == nil {
+		t.Errorf("expected an error creating the policy on a single NUMA node machine")
+	}
+}
+
+func TestStaticPolicyReservedCPUsByConstraints(t *testing.T) {
+	testCases := []struct {
+		description     string
+		topo            *topology.CPUTopology
+		numReservedCPUs int
+		reserved        cpuset.CPUSet
+		constraints     string
+		expReserved     cpuset.CPUSet
+		expNewErr       bool
+	}{
+		{
+			description:     "one core per NUMA node",
+			topo:            topoDualSocketHT,
+			numReservedCPUs: 4,
+			constraints:     "cores-per-numa=1",
+			expReserved:     cpuset.New(0, 1, 6, 7),
+		},
+		{
+			description:     "one core per NUMA node, reservation count does not match",
+			topo:            topoDualSocketHT,
+			numReservedCPUs: 2,
+			constraints:     "cores-per-numa=1",
+			expNewErr:       true,
+		},
+		{
+			description:     "avoided uncore cache is left alone",
+			topo:            topoUncoreSingleSocketNoHT,
+			numReservedCPUs: 2,
+			constraints:     "avoid-uncore-caches=0",
+			expReserved:     cpuset.New(4, 5),
+		},
+		{
+			description:     "efficiency cores are preferred",
+			topo:            topoHybridSingleSocketNoHT,
+			numReservedCPUs: 2,
+			constraints:     "prefer-core-type=efficiency",
+			expReserved:     cpuset.New(4, 5),
+		},
+		{
+			description:     "preferred core type falls back when exhausted",
+			topo:            topoHybridSingleSocketNoHT,
+			numReservedCPUs: 6,
+			constraints:     "prefer-core-type=efficiency",
+			expReserved:     cpuset.New(0, 1, 4, 5, 6, 7),
+		},
+		{
+			description:     "constraints and an explicit reserved list are mutually exclusive",
+			topo:            topoDualSocketHT,
+			numReservedCPUs: 2,
+			reserved:        cpuset.New(0, 6),
+			constraints:     "cores-per-numa=1",
+			expNewErr:       true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.CPUManagerPolicyAlphaOptions, true)
+
+			options := map[string]string{ReservedCPUsConstraintsOption: testCase.constraints}
+			policy, err := NewStaticPolicy(testCase.topo, testCase.numReservedCPUs, testCase.reserved, topologymanager.NewFakeManager(), options)
+			gotError := (err != nil)
+			if gotError != testCase.expNewErr {
+				t.Fatalf("NewStaticPolicy() expected error: %v but got: %v", testCase.expNewErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if reserved := policy.(*staticPolicy).reservedCPUs; !reserved.Equals(testCase.expReserved) {
+				t.Errorf("expected reserved cpuset %v but got %v", testCase.expReserved, reserved)
+			}
+		})
+	}
+}
+
+func TestStaticPolicySkipExclusiveCPUs(t *testing.T) {
+	policy, err := NewStaticPolicy(topoSingleSocketHT, 1, cpuset.New(0), topologymanager.NewFakeManager(), nil)
+	if err != nil {
+		t.Fatalf("could not create static policy: %v", err)
+	}
+
+	sharedCPUs := cpuset.New(0, 1, 2, 3, 4, 5, 6, 7)
+	st := &mockState{
+		assignments:   state.ContainerCPUAssignments{},
+		defaultCPUSet: sharedCPUs.Clone(),
+	}
+
+	pod := makePod("fakePod", "fakeContainer", "2000m", "2000m")
+	pod.Annotations = map[string]string{SkipExclusiveCPUsAnnotation: "true"}
+
+	if err := policy.Allocate(st, pod, &pod.Spec.Containers[0]); err != nil {
+		t.Fatalf("Allocate() expected no error but got: %v", err)
+	}
+	if len(st.assignments) != 0 {
+		t.Errorf("expected no exclusive assignment but got %v", st.assignments)
+	}
+	if !st.defaultCPUSet.Equals(sharedCPUs) {
+		t.Errorf("expected the shared pool %v to be untouched but got %v", sharedCPUs, st.defaultCPUSet)
+	}
+	if numCPUs := policy.(*staticPolicy).podGuaranteedCPUs(pod); numCPUs != 0 {
+		t.Errorf("expected no CPUs to be accounted to the pod but got %d", numCPUs)
+	}
+
+	// Without the annotation the very same pod gets pinned.
+	pod.Annotations = nil
+	if err := policy.Allocate(st, pod, &pod.Spec.Containers[0]); err != nil {
+		t.Fatalf("Allocate() expected no error but got: %v", err)
+	}
+	if _, found := st.assignments[string(pod.UID)]["fakeContainer"]; !found {
+		t.Errorf("expected an exclusive assignment but got %v", st.assignments)
+	}
+
+	// A malformed annotation value is rejected at admission.
+	pod2 := makePod("fakePod2", "fakeContainer2", "2000m", "2000m")
+	pod2.Annotations = map[string]string{SkipExclusiveCPUsAnnotation: "maybe"}
+	if err := policy.Allocate(st, pod2, &pod2.Spec.Containers[0]); err == nil {
+		t.Errorf("expected an admission error for the malformed annotation value")
+	}
+}
+
+func TestStaticPolicyFullPCPUsPartialSMT(t *testing.T) {
+	// topoPartialSMTSingleSocketHT: cores 0 (CPUs 0,4) and 1 (CPUs 1,5) have
+	// SMT enabled, cores 2 (CPU 2) and 3 (CPU 3) do not. CPU 2 is reserved.
+	options := map[string]string{
+		FullPCPUsOnlyOption: "true",
+	}
+	reserved := cpuset.New(2)
+
+	testCases := []staticPolicyTest{
+		{
+			description:     "an odd request lands on whole cores, absorbed by a narrow one",
+			topo:            topoPartialSMTSingleSocketHT,
+			options:         options,
+			numReservedCPUs: 1,
+			reservedCPUs:    &reserved,
+			stAssignments:   state.ContainerCPUAssignments{},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5),
+			pod:             makePod("fakePod", "fakeContainer", "3000m", "3000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(0, 3, 4),
+		},
+		{
+			description:     "a single CPU fits on a narrow core without splitting an SMT core",
+			topo:            topoPartialSMTSingleSocketHT,
+			options:         options,
+			numReservedCPUs: 1,
+			reservedCPUs:    &reserved,
+			stAssignments:   state.ContainerCPUAssignments{},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5),
+			pod:             makePod("fakePod", "fakeContainer", "1000m", "1000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(3),
+		},
+		{
+			description:     "an even request takes the SMT cores and leaves the narrow one alone",
+			topo:            topoPartialSMTSingleSocketHT,
+			options:         options,
+			numReservedCPUs: 1,
+			reservedCPUs:    &reserved,
+			stAssignments:   state.ContainerCPUAssignments{},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5),
+			pod:             makePod("fakePod", "fakeContainer", "4000m", "4000m"),
+			expErr:          nil,
+			expCPUAlloc:     true,
+			expCSet:         cpuset.New(0, 1, 4, 5),
+		},
+		{
+			description:     "an odd remainder with no narrow core free is rejected",
+			topo:            topoPartialSMTSingleSocketHT,
+			options:         options,
+			numReservedCPUs: 1,
+			reservedCPUs:    &reserved,
+			stAssignments: state.ContainerCPUAssignments{
+				"fakePod2": map[string]cpuset.CPUSet{
+					"fakeContainer2": cpuset.New(3),
+				},
+			},
+			stDefaultCPUSet: cpuset.New(0, 1, 2, 4, 5),
+			pod:             makePod("fakePod", "fakeContainer", "3000m", "3000m"),
+			expErr:          SMTAlignmentError{RequestedCPUs: 3, CpusPerCore: 1, AvailablePhysicalCPUs: 4},
+			expCPUAlloc:     false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		runStaticPolicyTestCaseWithFeatureGate(t, testCase)
+	}
+}
@@ -37,6 +37,36 @@ var (
 		},
 	}
 
+	topoHybridSingleSocketNoHT = &topology.CPUTopology{
+		NumCPUs:    8,
+		NumSockets: 1,
+		NumCores:   8,
+		CPUDetails: map[int]topology.CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypePerformance},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypePerformance},
+			2: {CoreID: 2, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypePerformance},
+			3: {CoreID: 3, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypePerformance},
+			4: {CoreID: 4, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypeEfficiency},
+			5: {CoreID: 5, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypeEfficiency},
+			6: {CoreID: 6, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypeEfficiency},
+			7: {CoreID: 7, SocketID: 0, NUMANodeID: 0, CoreType: topology.CoreTypeEfficiency},
+		},
+	}
+
+	topoPartialSMTSingleSocketHT = &topology.CPUTopology{
+		NumCPUs:    6,
+		NumSockets: 1,
+		NumCores:   4,
+		CPUDetails: map[int]topology.CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			2: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
+			3: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+			4: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			5: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		},
+	}
+
 	topoDualSocketHT = &topology.CPUTopology{
 		NumCPUs:    12,
 		NumSockets: 2,
@@ -73,6 +103,31 @@ var (
 		},
 	}
 
+	topoUncoreSingleSocketNoHT = &topology.CPUTopology{
+		NumCPUs:      16,
+		NumSockets:   1,
+		NumCores:     16,
+		NumNUMANodes: 1,
+		CPUDetails: map[int]topology.CPUInfo{
+			0:  {CoreID: 0, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 0},
+			1:  {CoreID: 1, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 0},
+			2:  {CoreID: 2, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 0},
+			3:  {CoreID: 3, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 0},
+			4:  {CoreID: 4, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 1},
+			5:  {CoreID: 5, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 1},
+			6:  {CoreID: 6, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 1},
+			7:  {CoreID: 7, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 1},
+			8:  {CoreID: 8, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 2},
+			9:  {CoreID: 9, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 2},
+			10: {CoreID: 10, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 2},
+			11: {CoreID: 11, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 2},
+			12: {CoreID: 12, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 3},
+			13: {CoreID: 13, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 3},
+			14: {CoreID: 14, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 3},
+			15: {CoreID: 15, SocketID: 0, NUMANodeID: 0, UncoreCacheID: 3},
+		},
+	}
+
 	// fake topology for testing purposes only
 	topoTripleSocketHT = &topology.CPUTopology{
 		NumCPUs:    18,
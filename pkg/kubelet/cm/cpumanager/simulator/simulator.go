@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator replays a sequence of exclusive CPU requests against the
+// static CPU manager policy, without a kubelet and without a real machine.
+// Operators feed it a machine topology and the policy options they intend to
+// run with, and get back the assignments the policy would make, so placement
+// behavior can be predicted for capacity planning instead of discovered on
+// live nodes.
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/state"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/utils/cpuset"
+)
+
+// Request describes one container asking for exclusive CPUs. The simulated
+// pods are Guaranteed QoS with integral CPU requests by construction: nothing
+// else gets exclusive CPUs from the static policy.
+type Request struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	CPUs      int    `json:"cpus"`
+}
+
+// Config is the input of a simulation run.
+type Config struct {
+	// Topology is the machine to simulate, in the CPUTopology layout
+	// discovered by the kubelet.
+	Topology *topology.CPUTopology `json:"topology"`
+	// Policy is the CPU manager policy to simulate. Only the static policy
+	// makes exclusive assignments, so it is the only supported value and the
+	// default when empty.
+	Policy string `json:"policy,omitempty"`
+	// PolicyOptions are the static policy options, in the same key=value form
+	// the kubelet configuration accepts.
+	PolicyOptions map[string]string `json:"policyOptions,omitempty"`
+	// ReservedCPUs is the cpuset held back for system and kube daemons.
+	ReservedCPUs string `json:"reservedCPUs,omitempty"`
+	// NumReservedCPUs is the size of the reservation when ReservedCPUs does
+	// not spell out an explicit set. Derived from ReservedCPUs when zero.
+	NumReservedCPUs int `json:"numReservedCPUs,omitempty"`
+	// Requests are replayed in order against the policy.
+	Requests []Request `json:"requests"`
+}
+
+// Result records the outcome of a single request.
+type Result struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	CPUs      string `json:"cpus,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the outcome of a whole simulation run.
+type Report struct {
+	Results []Result `json:"results"`
+	// SharedPool is what is left for the shared pool once every successful
+	// request has been carved out.
+	SharedPool string `json:"sharedPool"`
+}
+
+// LoadConfig parses a simulation config from its JSON serialization.
+func LoadConfig(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("could not parse the simulation config: %w", err)
+	}
+	return config, nil
+}
+
+// Run replays the configured requests against a fresh static policy instance
+// and reports the assignments it made. Individual requests failing admission
+// is a finding, not an error: the failure is recorded in the report and the
+// run continues, like the kubelet would keep admitting other pods.
+func Run(config *Config) (*Report, error) {
+	if config.Topology == nil {
+		return nil, fmt.Errorf("no topology provided")
+	}
+	if config.Policy != "" && config.Policy != string(cpumanager.PolicyStatic) {
+		return nil, fmt.Errorf("unsupported policy %q: only the %q policy makes exclusive assignments", config.Policy, cpumanager.PolicyStatic)
+	}
+
+	reserved := cpuset.New()
+	if config.ReservedCPUs != "" {
+		var err error
+		reserved, err = cpuset.Parse(config.ReservedCPUs)
+		if err != nil {
+			return nil, fmt.Errorf("bad reserved cpuset %q: %w", config.ReservedCPUs, err)
+		}
+	}
+	numReserved := config.NumReservedCPUs
+	if numReserved == 0 {
+		numReserved = reserved.Size()
+	}
+
+	policy, err := cpumanager.NewStaticPolicy(config.Topology, numReserved, reserved, topologymanager.NewFakeManager(), config.PolicyOptions)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the policy: %w", err)
+	}
+
+	st := state.NewMemoryState()
+	if err := policy.Start(st); err != nil {
+		return nil, fmt.Errorf("could not start the policy: %w", err)
+	}
+
+	report := &Report{}
+	for _, request := range config.Requests {
+		pod := guaranteedPod(request)
+		result := Result{Pod: request.Pod, Container: request.Container}
+		if err := policy.Allocate(st, pod, &pod.Spec.Containers[0]); err != nil {
+			result.Error = err.Error()
+		} else if cset, ok := st.GetCPUSet(request.Pod, request.Container); ok {
+			result.CPUs = cset.String()
+		}
+		report.Results = append(report.Results, result)
+	}
+	report.SharedPool = st.GetDefaultCPUSet().String()
+
+	return report, nil
+}
+
+// guaranteedPod builds the minimal Guaranteed pod carrying the request: the
+// static policy only looks at the QoS class and the container resources.
+func guaranteedPod(request Request) *v1.Pod {
+	resources := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(strconv.Itoa(request.CPUs)),
+		v1.ResourceMemory: resource.MustParse("1G"),
+	}
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: request.Container,
+					Resources: v1.ResourceRequirements{
+						Requests: resources,
+						Limits:   resources,
+					},
+				},
+			},
+		},
+	}
+	pod.Name = request.Pod
+	pod.UID = types.UID(request.Pod)
+	return pod
+}
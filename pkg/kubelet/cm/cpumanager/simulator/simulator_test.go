@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+)
+
+// topoSingleSocketHT is the usual 4 core, 8 thread test machine: core N holds
+// CPUs N and N+4.
+var topoSingleSocketHT = &topology.CPUTopology{
+	NumCPUs:    8,
+	NumSockets: 1,
+	NumCores:   4,
+	CPUDetails: map[int]topology.CPUInfo{
+		0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+		1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		2: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
+		3: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+		4: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+		5: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		6: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
+		7: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+	},
+}
+
+func TestRun(t *testing.T) {
+	config := &Config{
+		Topology:     topoSingleSocketHT,
+		ReservedCPUs: "0",
+		Requests: []Request{
+			{Pod: "fakePod", Container: "fakeContainer", CPUs: 2},
+			{Pod: "fakePod2", Container: "fakeContainer2", CPUs: 1},
+			{Pod: "fakePod3", Container: "fakeContainer3", CPUs: 10},
+		},
+	}
+
+	report, err := Run(config)
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if len(report.Results) != 3 {
+		t.Fatalf("expected 3 results but got %v", report.Results)
+	}
+	// The first request takes a whole free core.
+	if report.Results[0].CPUs != "1,5" || report.Results[0].Error != "" {
+		t.Errorf("expected the first request to get CPUs 1,5 but got %+v", report.Results[0])
+	}
+	if report.Results[1].CPUs == "" || report.Results[1].Error != "" {
+		t.Errorf("expected the second request to be satisfied but got %+v", report.Results[1])
+	}
+	// More CPUs than the machine has must be reported, not abort the run.
+	if report.Results[2].Error == "" {
+		t.Errorf("expected the third request to fail but got %+v", report.Results[2])
+	}
+	if report.SharedPool == "" {
+		t.Errorf("expected a non-empty shared pool")
+	}
+}
+
+func TestRunRejectsUnsupportedPolicy(t *testing.T) {
+	config := &Config{
+		Topology: topoSingleSocketHT,
+		Policy:   "none",
+	}
+	if _, err := Run(config); err == nil {
+		t.Errorf("expected an error for the unsupported policy")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	data := []byte(`{
+		"topology": {"NumCPUs": 8, "NumSockets": 1, "NumCores": 4},
+		"policyOptions": {"full-pcpus-only": "true"},
+		"reservedCPUs": "0-1",
+		"requests": [{"pod": "fakePod", "container": "fakeContainer", "cpus": 2}]
+	}`)
+	config, err := LoadConfig(data)
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+	if config.Topology == nil || config.Topology.NumCPUs != 8 {
+		t.Errorf("expected the topology to be parsed, got %+v", config.Topology)
+	}
+	if config.PolicyOptions["full-pcpus-only"] != "true" {
+		t.Errorf("expected the policy options to be parsed, got %v", config.PolicyOptions)
+	}
+	if len(config.Requests) != 1 || config.Requests[0].CPUs != 2 {
+		t.Errorf("expected the requests to be parsed, got %v", config.Requests)
+	}
+
+	if _, err := LoadConfig([]byte("not json")); err == nil {
+		t.Errorf("expected an error for malformed input")
+	}
+}
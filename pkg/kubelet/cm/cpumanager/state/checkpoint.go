@@ -30,14 +30,27 @@ import (
 
 var _ checkpointmanager.Checkpoint = &CPUManagerCheckpointV1{}
 var _ checkpointmanager.Checkpoint = &CPUManagerCheckpointV2{}
+var _ checkpointmanager.Checkpoint = &CPUManagerCheckpointV3{}
 var _ checkpointmanager.Checkpoint = &CPUManagerCheckpoint{}
 
-// CPUManagerCheckpoint struct is used to store cpu/pod assignments in a checkpoint in v2 format
+// CPUManagerCheckpointAlignment records, in a checkpoint, the alignment
+// achieved for a single container: which NUMA nodes and which uncore caches
+// its CPUs span, and whether the allocation covers only full physical cores
+type CPUManagerCheckpointAlignment struct {
+	NUMANodes    string `json:"numaNodes,omitempty"`
+	UncoreCaches string `json:"uncoreCaches,omitempty"`
+	FullCore     bool   `json:"fullCore,omitempty"`
+}
+
+// CPUManagerCheckpoint struct is used to store cpu/pod assignments in a checkpoint in v4 format
 type CPUManagerCheckpoint struct {
-	PolicyName    string                       `json:"policyName"`
-	DefaultCPUSet string                       `json:"defaultCpuSet"`
-	Entries       map[string]map[string]string `json:"entries,omitempty"`
-	Checksum      checksum.Checksum            `json:"checksum"`
+	PolicyName    string                                              `json:"policyName"`
+	DefaultCPUSet string                                              `json:"defaultCpuSet"`
+	Entries       map[string]map[string]string                        `json:"entries,omitempty"`
+	Alignments    map[string]map[string]CPUManagerCheckpointAlignment `json:"alignments,omitempty"`
+	SharedPools   map[string]string                                   `json:"sharedPools,omitempty"`
+	StaticPods    []string                                            `json:"staticPods,omitempty"`
+	Checksum      checksum.Checksum                                   `json:"checksum"`
 }
 
 // CPUManagerCheckpointV1 struct is used to store cpu/pod assignments in a checkpoint in v1 format
@@ -49,12 +62,29 @@ type CPUManagerCheckpointV1 struct {
 }
 
 // CPUManagerCheckpointV2 struct is used to store cpu/pod assignments in a checkpoint in v2 format
-type CPUManagerCheckpointV2 = CPUManagerCheckpoint
+type CPUManagerCheckpointV2 struct {
+	PolicyName    string                       `json:"policyName"`
+	DefaultCPUSet string                       `json:"defaultCpuSet"`
+	Entries       map[string]map[string]string `json:"entries,omitempty"`
+	Checksum      checksum.Checksum            `json:"checksum"`
+}
+
+// CPUManagerCheckpointV3 struct is used to store cpu/pod assignments in a checkpoint in v3 format
+type CPUManagerCheckpointV3 struct {
+	PolicyName    string                       `json:"policyName"`
+	DefaultCPUSet string                       `json:"defaultCpuSet"`
+	Entries       map[string]map[string]string `json:"entries,omitempty"`
+	StaticPods    []string                     `json:"staticPods,omitempty"`
+	Checksum      checksum.Checksum            `json:"checksum"`
+}
+
+// CPUManagerCheckpointV4 struct is used to store cpu/pod assignments in a checkpoint in v4 format
+type CPUManagerCheckpointV4 = CPUManagerCheckpoint
 
 // NewCPUManagerCheckpoint returns an instance of Checkpoint
 func NewCPUManagerCheckpoint() *CPUManagerCheckpoint {
 	//nolint:staticcheck // unexported-type-in-api user-facing error message
-	return newCPUManagerCheckpointV2()
+	return newCPUManagerCheckpointV4()
 }
 
 func newCPUManagerCheckpointV1() *CPUManagerCheckpointV1 {
@@ -69,6 +99,18 @@ func newCPUManagerCheckpointV2() *CPUManagerCheckpointV2 {
 	}
 }
 
+func newCPUManagerCheckpointV3() *CPUManagerCheckpointV3 {
+	return &CPUManagerCheckpointV3{
+		Entries: make(map[string]map[string]string),
+	}
+}
+
+func newCPUManagerCheckpointV4() *CPUManagerCheckpointV4 {
+	return &CPUManagerCheckpointV4{
+		Entries: make(map[string]map[string]string),
+	}
+}
+
 // MarshalCheckpoint returns marshalled checkpoint in v1 format
 func (cp *CPUManagerCheckpointV1) MarshalCheckpoint() ([]byte, error) {
 	// make sure checksum wasn't set before so it doesn't affect output checksum
@@ -85,6 +127,22 @@ func (cp *CPUManagerCheckpointV2) MarshalCheckpoint() ([]byte, error) {
 	return json.Marshal(*cp)
 }
 
+// MarshalCheckpoint returns marshalled checkpoint in v3 format
+func (cp *CPUManagerCheckpointV3) MarshalCheckpoint() ([]byte, error) {
+	// make sure checksum wasn't set before so it doesn't affect output checksum
+	cp.Checksum = 0
+	cp.Checksum = checksum.New(cp)
+	return json.Marshal(*cp)
+}
+
+// MarshalCheckpoint returns marshalled checkpoint in v4 format
+func (cp *CPUManagerCheckpointV4) MarshalCheckpoint() ([]byte, error) {
+	// make sure checksum wasn't set before so it doesn't affect output checksum
+	cp.Checksum = 0
+	cp.Checksum = checksum.New(cp)
+	return json.Marshal(*cp)
+}
+
 // UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint in v1 format
 func (cp *CPUManagerCheckpointV1) UnmarshalCheckpoint(blob []byte) error {
 	return json.Unmarshal(blob, cp)
@@ -95,6 +153,16 @@ func (cp *CPUManagerCheckpointV2) UnmarshalCheckpoint(blob []byte) error {
 	return json.Unmarshal(blob, cp)
 }
 
+// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint in v3 format
+func (cp *CPUManagerCheckpointV3) UnmarshalCheckpoint(blob []byte) error {
+	return json.Unmarshal(blob, cp)
+}
+
+// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint in v4 format
+func (cp *CPUManagerCheckpointV4) UnmarshalCheckpoint(blob []byte) error {
+	return json.Unmarshal(blob, cp)
+}
+
 // VerifyChecksum verifies that current checksum of checkpoint is valid in v1 format
 func (cp *CPUManagerCheckpointV1) VerifyChecksum() error {
 	if cp.Checksum == 0 {
@@ -123,6 +191,54 @@ func (cp *CPUManagerCheckpointV2) VerifyChecksum() error {
 		// accept empty checksum for compatibility with old file backend
 		return nil
 	}
+
+	// v2 checksums were computed when this type was the one named
+	// CPUManagerCheckpoint, before the v3 format was introduced
+	ck := cp.Checksum
+	cp.Checksum = 0
+	object := dump.ForHash(cp)
+	object = strings.Replace(object, "CPUManagerCheckpointV2", "CPUManagerCheckpoint", 1)
+	cp.Checksum = ck
+
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%v", object)
+	if cp.Checksum != checksum.Checksum(hash.Sum32()) {
+		return errors.ErrCorruptCheckpoint
+	}
+
+	return nil
+}
+
+// VerifyChecksum verifies that current checksum of checkpoint is valid in v3 format
+func (cp *CPUManagerCheckpointV3) VerifyChecksum() error {
+	if cp.Checksum == 0 {
+		// accept empty checksum for compatibility with old file backend
+		return nil
+	}
+
+	// v3 checksums were computed when this type was the one named
+	// CPUManagerCheckpoint, before the v4 format was introduced
+	ck := cp.Checksum
+	cp.Checksum = 0
+	object := dump.ForHash(cp)
+	object = strings.Replace(object, "CPUManagerCheckpointV3", "CPUManagerCheckpoint", 1)
+	cp.Checksum = ck
+
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%v", object)
+	if cp.Checksum != checksum.Checksum(hash.Sum32()) {
+		return errors.ErrCorruptCheckpoint
+	}
+
+	return nil
+}
+
+// VerifyChecksum verifies that current checksum of checkpoint is valid in v4 format
+func (cp *CPUManagerCheckpointV4) VerifyChecksum() error {
+	if cp.Checksum == 0 {
+		// accept empty checksum for compatibility with old file backend
+		return nil
+	}
 	ck := cp.Checksum
 	cp.Checksum = 0
 	err := ck.Verify(cp)
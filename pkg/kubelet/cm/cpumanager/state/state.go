@@ -35,18 +35,87 @@ func (as ContainerCPUAssignments) Clone() ContainerCPUAssignments {
 	return ret
 }
 
+// ContainerAlignment records the alignment achieved when the CPUs of a
+// container were allocated: which NUMA nodes and which uncore caches the
+// CPUs span, and whether the allocation covers only full physical cores.
+type ContainerAlignment struct {
+	NUMANodes    cpuset.CPUSet
+	UncoreCaches cpuset.CPUSet
+	FullCore     bool
+}
+
+// ContainerCPUAlignments type used in cpu manager state
+type ContainerCPUAlignments map[string]map[string]ContainerAlignment
+
+// Clone returns a copy of ContainerCPUAlignments
+func (al ContainerCPUAlignments) Clone() ContainerCPUAlignments {
+	ret := make(ContainerCPUAlignments, len(al))
+	for pod := range al {
+		ret[pod] = make(map[string]ContainerAlignment, len(al[pod]))
+		for container, alignment := range al[pod] {
+			ret[pod][container] = alignment
+		}
+	}
+	return ret
+}
+
+// PodSharedCPUSets maps pod UIDs to the shared cpuset carved out for that
+// pod, for pods which get their own shared pool instead of the node one
+type PodSharedCPUSets map[string]cpuset.CPUSet
+
+// Clone returns a copy of PodSharedCPUSets
+func (sp PodSharedCPUSets) Clone() PodSharedCPUSets {
+	ret := make(PodSharedCPUSets, len(sp))
+	for pod, cset := range sp {
+		ret[pod] = cset
+	}
+	return ret
+}
+
 // Reader interface used to read current cpu/pod assignment state
 type Reader interface {
 	GetCPUSet(podUID string, containerName string) (cpuset.CPUSet, bool)
 	GetDefaultCPUSet() cpuset.CPUSet
 	GetCPUSetOrDefault(podUID string, containerName string) cpuset.CPUSet
+	// GetCPUAssignments returns a point-in-time snapshot of the assignments.
+	// The returned map may be shared with the state and must be treated as
+	// read-only by the callers.
 	GetCPUAssignments() ContainerCPUAssignments
+	// IsStaticPod tells if the given pod was flagged as a static (or mirror)
+	// pod when its assignment was recorded.
+	IsStaticPod(podUID string) bool
+	// GetStaticPods returns the sorted UIDs of all the flagged pods.
+	GetStaticPods() []string
+	// GetAlignment returns the alignment recorded for the given container,
+	// if any was.
+	GetAlignment(podUID string, containerName string) (ContainerAlignment, bool)
+	// GetCPUAlignments returns a point-in-time snapshot of all the recorded
+	// alignments. Like GetCPUAssignments, the returned map may be shared
+	// with the state and must be treated as read-only by the callers.
+	GetCPUAlignments() ContainerCPUAlignments
+	// GetPodSharedCPUSet returns the shared cpuset carved out for the given
+	// pod, if any was.
+	GetPodSharedCPUSet(podUID string) (cpuset.CPUSet, bool)
+	// GetPodSharedCPUSets returns a point-in-time snapshot of all the
+	// per-pod shared cpusets, with the same sharing caveat as above.
+	GetPodSharedCPUSets() PodSharedCPUSets
 }
 
 type writer interface {
 	SetCPUSet(podUID string, containerName string, cpuset cpuset.CPUSet)
 	SetDefaultCPUSet(cpuset cpuset.CPUSet)
 	SetCPUAssignments(ContainerCPUAssignments)
+	// SetStaticPod flags the given pod as a static (or mirror) pod. The flag
+	// is persisted alongside the assignments and dropped with them, so after
+	// a restart the kubelet can tell which assignments belong to static pods
+	// even before the API server is reachable.
+	SetStaticPod(podUID string)
+	// SetAlignment records the alignment achieved for the given container.
+	// The record lives as long as the container assignment does.
+	SetAlignment(podUID string, containerName string, alignment ContainerAlignment)
+	// SetPodSharedCPUSet records the shared cpuset carved out for the given
+	// pod. The record lives as long as the pod has assignments.
+	SetPodSharedCPUSet(podUID string, cset cpuset.CPUSet)
 	Delete(podUID string, containerName string)
 	ClearState()
 }
@@ -62,8 +62,8 @@ func NewCheckpointState(stateDir, checkpointName, policyName string, initialCont
 	return stateCheckpoint, nil
 }
 
-// migrateV1CheckpointToV2Checkpoint() converts checkpoints from the v1 format to the v2 format
-func (sc *stateCheckpoint) migrateV1CheckpointToV2Checkpoint(src *CPUManagerCheckpointV1, dst *CPUManagerCheckpointV2) error {
+// migrateV1CheckpointToV3Checkpoint() converts checkpoints from the v1 format to the v3 format
+func (sc *stateCheckpoint) migrateV1CheckpointToV3Checkpoint(src *CPUManagerCheckpointV1, dst *CPUManagerCheckpointV3) error {
 	if src.PolicyName != "" {
 		dst.PolicyName = src.PolicyName
 	}
@@ -86,6 +86,38 @@ func (sc *stateCheckpoint) migrateV1CheckpointToV2Checkpoint(src *CPUManagerChec
 	return nil
 }
 
+// migrateV2CheckpointToV3Checkpoint() converts checkpoints from the v2 format to the v3 format
+func (sc *stateCheckpoint) migrateV2CheckpointToV3Checkpoint(src *CPUManagerCheckpointV2, dst *CPUManagerCheckpointV3) {
+	// v3 only adds the static pods flags, which v2 checkpoints do not carry
+	if src.PolicyName != "" {
+		dst.PolicyName = src.PolicyName
+	}
+	if src.DefaultCPUSet != "" {
+		dst.DefaultCPUSet = src.DefaultCPUSet
+	}
+	if len(src.Entries) > 0 {
+		dst.Entries = src.Entries
+	}
+}
+
+// migrateV3CheckpointToV4Checkpoint() converts checkpoints from the v3 format to the v4 format
+func (sc *stateCheckpoint) migrateV3CheckpointToV4Checkpoint(src *CPUManagerCheckpointV3, dst *CPUManagerCheckpointV4) {
+	// v4 only adds the per-container alignment metadata and the per-pod
+	// shared pools, which v3 checkpoints do not carry
+	if src.PolicyName != "" {
+		dst.PolicyName = src.PolicyName
+	}
+	if src.DefaultCPUSet != "" {
+		dst.DefaultCPUSet = src.DefaultCPUSet
+	}
+	if len(src.Entries) > 0 {
+		dst.Entries = src.Entries
+	}
+	if len(src.StaticPods) > 0 {
+		dst.StaticPods = src.StaticPods
+	}
+}
+
 // restores state from a checkpoint and creates it if it doesn't exist
 func (sc *stateCheckpoint) restoreState() error {
 	sc.mux.Lock()
@@ -94,35 +126,45 @@ func (sc *stateCheckpoint) restoreState() error {
 
 	checkpointV1 := newCPUManagerCheckpointV1()
 	checkpointV2 := newCPUManagerCheckpointV2()
+	checkpointV3 := newCPUManagerCheckpointV3()
+	checkpointV4 := newCPUManagerCheckpointV4()
 
 	if err = sc.checkpointManager.GetCheckpoint(sc.checkpointName, checkpointV1); err != nil {
 		checkpointV1 = &CPUManagerCheckpointV1{} // reset it back to 0
 		if err = sc.checkpointManager.GetCheckpoint(sc.checkpointName, checkpointV2); err != nil {
-			if err == errors.ErrCheckpointNotFound {
-				return sc.storeState()
+			checkpointV2 = &CPUManagerCheckpointV2{} // reset it back to 0
+			if err = sc.checkpointManager.GetCheckpoint(sc.checkpointName, checkpointV3); err != nil {
+				checkpointV3 = &CPUManagerCheckpointV3{} // reset it back to 0
+				if err = sc.checkpointManager.GetCheckpoint(sc.checkpointName, checkpointV4); err != nil {
+					if err == errors.ErrCheckpointNotFound {
+						return sc.storeState()
+					}
+					return err
+				}
 			}
-			return err
 		}
 	}
 
-	if err = sc.migrateV1CheckpointToV2Checkpoint(checkpointV1, checkpointV2); err != nil {
-		return fmt.Errorf("error migrating v1 checkpoint state to v2 checkpoint state: %s", err)
+	if err = sc.migrateV1CheckpointToV3Checkpoint(checkpointV1, checkpointV3); err != nil {
+		return fmt.Errorf("error migrating v1 checkpoint state to v3 checkpoint state: %s", err)
 	}
+	sc.migrateV2CheckpointToV3Checkpoint(checkpointV2, checkpointV3)
+	sc.migrateV3CheckpointToV4Checkpoint(checkpointV3, checkpointV4)
 
-	if sc.policyName != checkpointV2.PolicyName {
-		return fmt.Errorf("configured policy %q differs from state checkpoint policy %q", sc.policyName, checkpointV2.PolicyName)
+	if sc.policyName != checkpointV4.PolicyName {
+		return fmt.Errorf("configured policy %q differs from state checkpoint policy %q", sc.policyName, checkpointV4.PolicyName)
 	}
 
 	var tmpDefaultCPUSet cpuset.CPUSet
-	if tmpDefaultCPUSet, err = cpuset.Parse(checkpointV2.DefaultCPUSet); err != nil {
-		return fmt.Errorf("could not parse default cpu set %q: %v", checkpointV2.DefaultCPUSet, err)
+	if tmpDefaultCPUSet, err = cpuset.Parse(checkpointV4.DefaultCPUSet); err != nil {
+		return fmt.Errorf("could not parse default cpu set %q: %v", checkpointV4.DefaultCPUSet, err)
 	}
 
 	var tmpContainerCPUSet cpuset.CPUSet
 	tmpAssignments := ContainerCPUAssignments{}
-	for pod := range checkpointV2.Entries {
-		tmpAssignments[pod] = make(map[string]cpuset.CPUSet, len(checkpointV2.Entries[pod]))
-		for container, cpuString := range checkpointV2.Entries[pod] {
+	for pod := range checkpointV4.Entries {
+		tmpAssignments[pod] = make(map[string]cpuset.CPUSet, len(checkpointV4.Entries[pod]))
+		for container, cpuString := range checkpointV4.Entries[pod] {
 			if tmpContainerCPUSet, err = cpuset.Parse(cpuString); err != nil {
 				return fmt.Errorf("could not parse cpuset %q for container %q in pod %q: %v", cpuString, container, pod, err)
 			}
@@ -130,8 +172,42 @@ func (sc *stateCheckpoint) restoreState() error {
 		}
 	}
 
+	tmpAlignments := ContainerCPUAlignments{}
+	for pod := range checkpointV4.Alignments {
+		tmpAlignments[pod] = make(map[string]ContainerAlignment, len(checkpointV4.Alignments[pod]))
+		for container, alignment := range checkpointV4.Alignments[pod] {
+			var tmpAlignment ContainerAlignment
+			if tmpAlignment.NUMANodes, err = cpuset.Parse(alignment.NUMANodes); err != nil {
+				return fmt.Errorf("could not parse NUMA nodes %q for container %q in pod %q: %v", alignment.NUMANodes, container, pod, err)
+			}
+			if tmpAlignment.UncoreCaches, err = cpuset.Parse(alignment.UncoreCaches); err != nil {
+				return fmt.Errorf("could not parse uncore caches %q for container %q in pod %q: %v", alignment.UncoreCaches, container, pod, err)
+			}
+			tmpAlignment.FullCore = alignment.FullCore
+			tmpAlignments[pod][container] = tmpAlignment
+		}
+	}
+
+	tmpSharedPools := PodSharedCPUSets{}
+	for pod, cpuString := range checkpointV4.SharedPools {
+		if tmpSharedPools[pod], err = cpuset.Parse(cpuString); err != nil {
+			return fmt.Errorf("could not parse shared cpuset %q for pod %q: %v", cpuString, pod, err)
+		}
+	}
+
 	sc.cache.SetDefaultCPUSet(tmpDefaultCPUSet)
 	sc.cache.SetCPUAssignments(tmpAssignments)
+	for _, podUID := range checkpointV4.StaticPods {
+		sc.cache.SetStaticPod(podUID)
+	}
+	for pod := range tmpAlignments {
+		for container, alignment := range tmpAlignments[pod] {
+			sc.cache.SetAlignment(pod, container, alignment)
+		}
+	}
+	for pod, cset := range tmpSharedPools {
+		sc.cache.SetPodSharedCPUSet(pod, cset)
+	}
 
 	klog.V(2).InfoS("State checkpoint: restored state from checkpoint")
 	klog.V(2).InfoS("State checkpoint: defaultCPUSet", "defaultCpuSet", tmpDefaultCPUSet.String())
@@ -152,6 +228,31 @@ func (sc *stateCheckpoint) storeState() error {
 			checkpoint.Entries[pod][container] = cset.String()
 		}
 	}
+	// leave the fields unset when there is nothing to record: the checksum
+	// covers the fields, and restored checkpoints carry nil, not empty
+	// slices or maps
+	if staticPods := sc.cache.GetStaticPods(); len(staticPods) > 0 {
+		checkpoint.StaticPods = staticPods
+	}
+	if alignments := sc.cache.GetCPUAlignments(); len(alignments) > 0 {
+		checkpoint.Alignments = make(map[string]map[string]CPUManagerCheckpointAlignment, len(alignments))
+		for pod := range alignments {
+			checkpoint.Alignments[pod] = make(map[string]CPUManagerCheckpointAlignment, len(alignments[pod]))
+			for container, alignment := range alignments[pod] {
+				checkpoint.Alignments[pod][container] = CPUManagerCheckpointAlignment{
+					NUMANodes:    alignment.NUMANodes.String(),
+					UncoreCaches: alignment.UncoreCaches.String(),
+					FullCore:     alignment.FullCore,
+				}
+			}
+		}
+	}
+	if sharedPools := sc.cache.GetPodSharedCPUSets(); len(sharedPools) > 0 {
+		checkpoint.SharedPools = make(map[string]string, len(sharedPools))
+		for pod, cset := range sharedPools {
+			checkpoint.SharedPools[pod] = cset.String()
+		}
+	}
 
 	err := sc.checkpointManager.CreateCheckpoint(sc.checkpointName, checkpoint)
 	if err != nil {
@@ -194,6 +295,87 @@ func (sc *stateCheckpoint) GetCPUAssignments() ContainerCPUAssignments {
 	return sc.cache.GetCPUAssignments()
 }
 
+// IsStaticPod tells if the given pod was flagged as a static (or mirror) pod
+func (sc *stateCheckpoint) IsStaticPod(podUID string) bool {
+	sc.mux.RLock()
+	defer sc.mux.RUnlock()
+
+	return sc.cache.IsStaticPod(podUID)
+}
+
+// GetStaticPods returns the sorted UIDs of all the flagged pods
+func (sc *stateCheckpoint) GetStaticPods() []string {
+	sc.mux.RLock()
+	defer sc.mux.RUnlock()
+
+	return sc.cache.GetStaticPods()
+}
+
+// GetAlignment returns the alignment recorded for the given container, if any was
+func (sc *stateCheckpoint) GetAlignment(podUID string, containerName string) (ContainerAlignment, bool) {
+	sc.mux.RLock()
+	defer sc.mux.RUnlock()
+
+	return sc.cache.GetAlignment(podUID, containerName)
+}
+
+// GetCPUAlignments returns a snapshot of all the recorded alignments
+func (sc *stateCheckpoint) GetCPUAlignments() ContainerCPUAlignments {
+	sc.mux.RLock()
+	defer sc.mux.RUnlock()
+
+	return sc.cache.GetCPUAlignments()
+}
+
+// GetPodSharedCPUSet returns the shared cpuset carved out for the given pod, if any was
+func (sc *stateCheckpoint) GetPodSharedCPUSet(podUID string) (cpuset.CPUSet, bool) {
+	sc.mux.RLock()
+	defer sc.mux.RUnlock()
+
+	return sc.cache.GetPodSharedCPUSet(podUID)
+}
+
+// GetPodSharedCPUSets returns a snapshot of all the per-pod shared cpusets
+func (sc *stateCheckpoint) GetPodSharedCPUSets() PodSharedCPUSets {
+	sc.mux.RLock()
+	defer sc.mux.RUnlock()
+
+	return sc.cache.GetPodSharedCPUSets()
+}
+
+// SetAlignment records the alignment achieved for the given container
+func (sc *stateCheckpoint) SetAlignment(podUID string, containerName string, alignment ContainerAlignment) {
+	sc.mux.Lock()
+	defer sc.mux.Unlock()
+	sc.cache.SetAlignment(podUID, containerName, alignment)
+	err := sc.storeState()
+	if err != nil {
+		klog.InfoS("Store state to checkpoint error", "err", err)
+	}
+}
+
+// SetPodSharedCPUSet records the shared cpuset carved out for the given pod
+func (sc *stateCheckpoint) SetPodSharedCPUSet(podUID string, cset cpuset.CPUSet) {
+	sc.mux.Lock()
+	defer sc.mux.Unlock()
+	sc.cache.SetPodSharedCPUSet(podUID, cset)
+	err := sc.storeState()
+	if err != nil {
+		klog.InfoS("Store state to checkpoint error", "err", err)
+	}
+}
+
+// SetStaticPod flags the given pod as a static (or mirror) pod
+func (sc *stateCheckpoint) SetStaticPod(podUID string) {
+	sc.mux.Lock()
+	defer sc.mux.Unlock()
+	sc.cache.SetStaticPod(podUID)
+	err := sc.storeState()
+	if err != nil {
+		klog.InfoS("Store state to checkpoint error", "err", err)
+	}
+}
+
 // SetCPUSet sets CPU set
 func (sc *stateCheckpoint) SetCPUSet(podUID string, containerName string, cset cpuset.CPUSet) {
 	sc.mux.Lock()
@@ -18,11 +18,13 @@ package state
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	testutil "k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/state/testing"
@@ -235,6 +237,107 @@ func TestCheckpointStateRestore(t *testing.T) {
 	}
 }
 
+func TestCheckpointStateGoldenFiles(t *testing.T) {
+	// The testdata directory holds verbatim checkpoints written by past
+	// kubelet versions, checksums included. Every file must load through
+	// NewCheckpointState: this is what guarantees that format changes keep
+	// the upgrade path working for every format the kubelet ever wrote.
+	testCases := []struct {
+		goldenFile        string
+		policyName        string
+		initialContainers containermap.ContainerMap
+		expectedState     *stateMemory
+	}{
+		{
+			"cpu_manager_state-v1-none-kubelet-1.17",
+			"none",
+			func() containermap.ContainerMap {
+				cm := containermap.NewContainerMap()
+				cm.Add("pod", "container1", "containerID1")
+				cm.Add("pod", "container2", "containerID2")
+				return cm
+			}(),
+			&stateMemory{
+				assignments: ContainerCPUAssignments{
+					"pod": map[string]cpuset.CPUSet{
+						"container1": cpuset.New(4, 5, 6),
+						"container2": cpuset.New(1, 2, 3),
+					},
+				},
+				defaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7),
+			},
+		},
+		{
+			"cpu_manager_state-v2-none-kubelet-1.18",
+			"none",
+			containermap.ContainerMap{},
+			&stateMemory{
+				defaultCPUSet: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11),
+			},
+		},
+		{
+			"cpu_manager_state-v2-static-kubelet-1.26",
+			"static",
+			containermap.ContainerMap{},
+			&stateMemory{
+				assignments: ContainerCPUAssignments{
+					"pod-a": map[string]cpuset.CPUSet{
+						"container1": cpuset.New(2, 3),
+						"container2": cpuset.New(4, 5),
+					},
+				},
+				defaultCPUSet: cpuset.New(0, 1, 6, 7, 8, 9, 10, 11),
+			},
+		},
+		{
+			"cpu_manager_state-v3-static-kubelet-1.31",
+			"static",
+			containermap.ContainerMap{},
+			&stateMemory{
+				assignments: ContainerCPUAssignments{
+					"pod-b": map[string]cpuset.CPUSet{
+						"container1": cpuset.New(2, 3, 4, 5),
+					},
+				},
+				defaultCPUSet: cpuset.New(0, 1, 6, 7, 8, 9, 10, 11),
+				staticPods:    sets.New[string]("pod-b"),
+			},
+		},
+	}
+
+	// every file in the corpus must be exercised by a test case
+	covered := make(map[string]bool)
+	for _, tc := range testCases {
+		covered[tc.goldenFile] = true
+	}
+	goldenFiles, err := os.ReadDir("testdata")
+	require.NoError(t, err)
+	for _, goldenFile := range goldenFiles {
+		if !covered[goldenFile.Name()] {
+			t.Errorf("golden checkpoint %q has no test case", goldenFile.Name())
+		}
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.goldenFile, func(t *testing.T) {
+			blob, err := os.ReadFile(filepath.Join("testdata", tc.goldenFile))
+			require.NoError(t, err)
+
+			testingDir, err := os.MkdirTemp("", "cpumanager_state_test")
+			require.NoError(t, err)
+			defer os.RemoveAll(testingDir)
+
+			err = os.WriteFile(filepath.Join(testingDir, testingCheckpoint), blob, 0644)
+			require.NoError(t, err)
+
+			restoredState, err := NewCheckpointState(testingDir, testingCheckpoint, tc.policyName, tc.initialContainers)
+			require.NoError(t, err)
+
+			AssertStateEqual(t, restoredState, tc.expectedState)
+		})
+	}
+}
+
 func TestCheckpointStateStore(t *testing.T) {
 	testCases := []struct {
 		description   string
@@ -293,6 +396,40 @@ func TestCheckpointStateStore(t *testing.T) {
 	}
 }
 
+func TestCheckpointStateStaticPods(t *testing.T) {
+	// create temp dir
+	testingDir, err := os.MkdirTemp("", "cpumanager_state_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(testingDir)
+
+	cs1, err := NewCheckpointState(testingDir, testingCheckpoint, "static", nil)
+	require.NoError(t, err)
+
+	cs1.SetCPUSet("staticPod", "container1", cpuset.New(4, 5, 6))
+	cs1.SetStaticPod("staticPod")
+	cs1.SetCPUSet("regularPod", "container1", cpuset.New(1, 2, 3))
+
+	// the flag must survive a restart
+	cs2, err := NewCheckpointState(testingDir, testingCheckpoint, "static", nil)
+	require.NoError(t, err)
+
+	if !cs2.IsStaticPod("staticPod") {
+		t.Error("static pod flag lost across checkpoint restore")
+	}
+	if cs2.IsStaticPod("regularPod") {
+		t.Error("regular pod wrongly flagged as static after checkpoint restore")
+	}
+
+	// the flag goes away with the last assignment of the pod
+	cs2.Delete("staticPod", "container1")
+	cs3, err := NewCheckpointState(testingDir, testingCheckpoint, "static", nil)
+	require.NoError(t, err)
+
+	if cs3.IsStaticPod("staticPod") {
+		t.Error("static pod flag outlived the pod assignments")
+	}
+}
+
 func TestCheckpointStateHelpers(t *testing.T) {
 	testCases := []struct {
 		description   string
@@ -430,4 +567,121 @@ func AssertStateEqual(t *testing.T, sf State, sm State) {
 	if !reflect.DeepEqual(cpuassignmentSf, cpuassignmentSm) {
 		t.Errorf("State CPU assignments mismatch. Have %s, want %s", cpuassignmentSf, cpuassignmentSm)
 	}
+
+	staticPodsSf := sf.GetStaticPods()
+	staticPodsSm := sm.GetStaticPods()
+	if !reflect.DeepEqual(staticPodsSf, staticPodsSm) {
+		t.Errorf("State static pods mismatch. Have %v, want %v", staticPodsSf, staticPodsSm)
+	}
+
+	alignmentsSf := sf.GetCPUAlignments()
+	alignmentsSm := sm.GetCPUAlignments()
+	if !reflect.DeepEqual(alignmentsSf, alignmentsSm) {
+		t.Errorf("State CPU alignments mismatch. Have %v, want %v", alignmentsSf, alignmentsSm)
+	}
+
+	sharedPoolsSf := sf.GetPodSharedCPUSets()
+	sharedPoolsSm := sm.GetPodSharedCPUSets()
+	if !reflect.DeepEqual(sharedPoolsSf, sharedPoolsSm) {
+		t.Errorf("State pod shared cpusets mismatch. Have %v, want %v", sharedPoolsSf, sharedPoolsSm)
+	}
+}
+
+func TestCheckpointStateAlignments(t *testing.T) {
+	// create temp dir
+	testingDir, err := os.MkdirTemp("", "cpumanager_state_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(testingDir)
+
+	cs1, err := NewCheckpointState(testingDir, testingCheckpoint, "static", nil)
+	require.NoError(t, err)
+
+	alignment := ContainerAlignment{
+		NUMANodes:    cpuset.New(0),
+		UncoreCaches: cpuset.New(0, 1),
+		FullCore:     true,
+	}
+	cs1.SetCPUSet("pod", "container1", cpuset.New(4, 5, 6))
+	cs1.SetAlignment("pod", "container1", alignment)
+	cs1.SetPodSharedCPUSet("pod", cpuset.New(10, 11))
+
+	// the records must survive a restart
+	cs2, err := NewCheckpointState(testingDir, testingCheckpoint, "static", nil)
+	require.NoError(t, err)
+
+	restoredAlignment, ok := cs2.GetAlignment("pod", "container1")
+	if !ok {
+		t.Fatal("container alignment lost across checkpoint restore")
+	}
+	if !reflect.DeepEqual(restoredAlignment, alignment) {
+		t.Errorf("container alignment mismatch after restore. Have %v, want %v", restoredAlignment, alignment)
+	}
+	sharedPool, ok := cs2.GetPodSharedCPUSet("pod")
+	if !ok {
+		t.Fatal("pod shared cpuset lost across checkpoint restore")
+	}
+	if !sharedPool.Equals(cpuset.New(10, 11)) {
+		t.Errorf("pod shared cpuset mismatch after restore. Have %v, want %v", sharedPool, cpuset.New(10, 11))
+	}
+
+	// the records go away with the last assignment of the pod
+	cs2.Delete("pod", "container1")
+	cs3, err := NewCheckpointState(testingDir, testingCheckpoint, "static", nil)
+	require.NoError(t, err)
+
+	if _, ok := cs3.GetAlignment("pod", "container1"); ok {
+		t.Error("container alignment outlived the pod assignments")
+	}
+	if _, ok := cs3.GetPodSharedCPUSet("pod"); ok {
+		t.Error("pod shared cpuset outlived the pod assignments")
+	}
+}
+
+func TestCheckpointStateMigrateV3ToV4(t *testing.T) {
+	// create temp dir
+	testingDir, err := os.MkdirTemp("", "cpumanager_state_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(testingDir)
+	cpm, err := checkpointmanager.NewCheckpointManager(testingDir)
+	require.NoErrorf(t, err, "could not create testing checkpoint manager: %v", err)
+
+	// a verbatim v3 checkpoint, checksum included
+	checkpoint := &testutil.MockCheckpoint{Content: `{
+		"policyName": "none",
+		"defaultCPUSet": "1-3",
+		"entries": {
+			"pod": {
+				"container1": "4-6",
+				"container2": "1-3"
+			}
+		},
+		"checksum": 3610638499
+	}`}
+	err = cpm.CreateCheckpoint(testingCheckpoint, checkpoint)
+	require.NoErrorf(t, err, "could not create testing checkpoint: %v", err)
+
+	restoredState, err := NewCheckpointState(testingDir, testingCheckpoint, "none", nil)
+	require.NoError(t, err)
+
+	AssertStateEqual(t, restoredState, &stateMemory{
+		assignments: ContainerCPUAssignments{
+			"pod": map[string]cpuset.CPUSet{
+				"container1": cpuset.New(4, 5, 6),
+				"container2": cpuset.New(1, 2, 3),
+			},
+		},
+		defaultCPUSet: cpuset.New(1, 2, 3),
+	})
+
+	// any write after the migration stores the checkpoint in v4 format
+	restoredState.SetAlignment("pod", "container1", ContainerAlignment{
+		NUMANodes:    cpuset.New(0),
+		UncoreCaches: cpuset.New(0),
+	})
+	migratedState, err := NewCheckpointState(testingDir, testingCheckpoint, "none", nil)
+	require.NoError(t, err)
+
+	if _, ok := migratedState.GetAlignment("pod", "container1"); !ok {
+		t.Error("container alignment lost across the v3 to v4 migration")
+	}
 }
@@ -19,14 +19,27 @@ package state
 import (
 	"sync"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/cpuset"
 )
 
 type stateMemory struct {
 	sync.RWMutex
+	// assignments is treated as immutable once published: every mutation
+	// builds a fresh copy and swaps it in under the write lock (copy-on-write).
+	// This way readers can hand out the current map as a snapshot without
+	// deep-copying it under the read lock, which matters on the hot read
+	// paths (reconcile loop, podresources, metrics) on nodes running
+	// thousands of containers. Writes (admission, container removal) are
+	// much rarer, so they pay the copy cost.
 	assignments   ContainerCPUAssignments
 	defaultCPUSet cpuset.CPUSet
+	staticPods    sets.Set[string]
+	// alignments and sharedPools follow the same copy-on-write discipline
+	// as assignments.
+	alignments  ContainerCPUAlignments
+	sharedPools PodSharedCPUSets
 }
 
 var _ State = &stateMemory{}
@@ -37,6 +50,9 @@ func NewMemoryState() State {
 	return &stateMemory{
 		assignments:   ContainerCPUAssignments{},
 		defaultCPUSet: cpuset.New(),
+		staticPods:    sets.New[string](),
+		alignments:    ContainerCPUAlignments{},
+		sharedPools:   PodSharedCPUSets{},
 	}
 }
 
@@ -65,18 +81,75 @@ func (s *stateMemory) GetCPUSetOrDefault(podUID string, containerName string) cp
 func (s *stateMemory) GetCPUAssignments() ContainerCPUAssignments {
 	s.RLock()
 	defer s.RUnlock()
-	return s.assignments.Clone()
+	// The assignments map is copy-on-write, so the current value is an
+	// immutable point-in-time snapshot which can be returned as it is.
+	if s.assignments == nil {
+		return ContainerCPUAssignments{}
+	}
+	return s.assignments
+}
+
+func (s *stateMemory) IsStaticPod(podUID string) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.staticPods.Has(podUID)
+}
+
+func (s *stateMemory) GetStaticPods() []string {
+	s.RLock()
+	defer s.RUnlock()
+
+	return sets.List(s.staticPods)
+}
+
+func (s *stateMemory) GetAlignment(podUID string, containerName string) (ContainerAlignment, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	res, ok := s.alignments[podUID][containerName]
+	return res, ok
+}
+
+func (s *stateMemory) GetCPUAlignments() ContainerCPUAlignments {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.alignments == nil {
+		return ContainerCPUAlignments{}
+	}
+	return s.alignments
+}
+
+func (s *stateMemory) GetPodSharedCPUSet(podUID string) (cpuset.CPUSet, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	res, ok := s.sharedPools[podUID]
+	return res, ok
+}
+
+func (s *stateMemory) GetPodSharedCPUSets() PodSharedCPUSets {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.sharedPools == nil {
+		return PodSharedCPUSets{}
+	}
+	return s.sharedPools
 }
 
 func (s *stateMemory) SetCPUSet(podUID string, containerName string, cset cpuset.CPUSet) {
 	s.Lock()
 	defer s.Unlock()
 
-	if _, ok := s.assignments[podUID]; !ok {
-		s.assignments[podUID] = make(map[string]cpuset.CPUSet)
+	assignments := s.assignments.Clone()
+	if _, ok := assignments[podUID]; !ok {
+		assignments[podUID] = make(map[string]cpuset.CPUSet)
 	}
 
-	s.assignments[podUID][containerName] = cset
+	assignments[podUID][containerName] = cset
+	s.assignments = assignments
 	klog.InfoS("Updated desired CPUSet", "podUID", podUID, "containerName", containerName, "cpuSet", cset)
 }
 
@@ -96,14 +169,69 @@ func (s *stateMemory) SetCPUAssignments(a ContainerCPUAssignments) {
 	klog.InfoS("Updated CPUSet assignments", "assignments", a)
 }
 
+func (s *stateMemory) SetStaticPod(podUID string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.staticPods == nil {
+		s.staticPods = sets.New[string]()
+	}
+	s.staticPods.Insert(podUID)
+	klog.V(2).InfoS("Flagged pod as static", "podUID", podUID)
+}
+
+func (s *stateMemory) SetAlignment(podUID string, containerName string, alignment ContainerAlignment) {
+	s.Lock()
+	defer s.Unlock()
+
+	alignments := s.alignments.Clone()
+	if _, ok := alignments[podUID]; !ok {
+		alignments[podUID] = make(map[string]ContainerAlignment)
+	}
+
+	alignments[podUID][containerName] = alignment
+	s.alignments = alignments
+	klog.V(2).InfoS("Recorded container alignment", "podUID", podUID, "containerName", containerName, "numaNodes", alignment.NUMANodes, "uncoreCaches", alignment.UncoreCaches, "fullCore", alignment.FullCore)
+}
+
+func (s *stateMemory) SetPodSharedCPUSet(podUID string, cset cpuset.CPUSet) {
+	s.Lock()
+	defer s.Unlock()
+
+	sharedPools := s.sharedPools.Clone()
+	sharedPools[podUID] = cset
+	s.sharedPools = sharedPools
+	klog.V(2).InfoS("Recorded pod shared CPUSet", "podUID", podUID, "cpuSet", cset)
+}
+
 func (s *stateMemory) Delete(podUID string, containerName string) {
 	s.Lock()
 	defer s.Unlock()
 
-	delete(s.assignments[podUID], containerName)
-	if len(s.assignments[podUID]) == 0 {
-		delete(s.assignments, podUID)
+	if _, ok := s.assignments[podUID][containerName]; !ok {
+		return
+	}
+	assignments := s.assignments.Clone()
+	delete(assignments[podUID], containerName)
+	if _, ok := s.alignments[podUID][containerName]; ok {
+		alignments := s.alignments.Clone()
+		delete(alignments[podUID], containerName)
+		if len(alignments[podUID]) == 0 {
+			delete(alignments, podUID)
+		}
+		s.alignments = alignments
+	}
+	if len(assignments[podUID]) == 0 {
+		delete(assignments, podUID)
+		// the flag and the shared pool live as long as the pod has assignments
+		s.staticPods.Delete(podUID)
+		if _, ok := s.sharedPools[podUID]; ok {
+			sharedPools := s.sharedPools.Clone()
+			delete(sharedPools, podUID)
+			s.sharedPools = sharedPools
+		}
 	}
+	s.assignments = assignments
 	klog.V(2).InfoS("Deleted CPUSet assignment", "podUID", podUID, "containerName", containerName)
 }
 
@@ -113,5 +241,8 @@ func (s *stateMemory) ClearState() {
 
 	s.defaultCPUSet = cpuset.CPUSet{}
 	s.assignments = make(ContainerCPUAssignments)
+	s.staticPods = sets.New[string]()
+	s.alignments = make(ContainerCPUAlignments)
+	s.sharedPools = make(PodSharedCPUSets)
 	klog.V(2).InfoS("Cleared state")
 }
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"k8s.io/utils/cpuset"
+)
+
+// observedState wraps a State and invokes a callback after every mutation,
+// giving the owner a single place to react to assignment changes (e.g. to
+// refresh metrics) without instrumenting each call site.
+type observedState struct {
+	inner    State
+	onChange func(Reader)
+}
+
+var _ State = &observedState{}
+
+// NewObservedState returns a State delegating to inner which additionally
+// calls onChange, with the inner state as a Reader, after every mutating
+// operation completes. The callback runs outside the inner state locks, on
+// the goroutine performing the mutation, so it must be cheap and must not
+// mutate the state itself.
+func NewObservedState(inner State, onChange func(Reader)) State {
+	return &observedState{
+		inner:    inner,
+		onChange: onChange,
+	}
+}
+
+func (s *observedState) GetCPUSet(podUID string, containerName string) (cpuset.CPUSet, bool) {
+	return s.inner.GetCPUSet(podUID, containerName)
+}
+
+func (s *observedState) GetDefaultCPUSet() cpuset.CPUSet {
+	return s.inner.GetDefaultCPUSet()
+}
+
+func (s *observedState) GetCPUSetOrDefault(podUID string, containerName string) cpuset.CPUSet {
+	return s.inner.GetCPUSetOrDefault(podUID, containerName)
+}
+
+func (s *observedState) GetCPUAssignments() ContainerCPUAssignments {
+	return s.inner.GetCPUAssignments()
+}
+
+func (s *observedState) IsStaticPod(podUID string) bool {
+	return s.inner.IsStaticPod(podUID)
+}
+
+func (s *observedState) GetStaticPods() []string {
+	return s.inner.GetStaticPods()
+}
+
+func (s *observedState) GetAlignment(podUID string, containerName string) (ContainerAlignment, bool) {
+	return s.inner.GetAlignment(podUID, containerName)
+}
+
+func (s *observedState) GetCPUAlignments() ContainerCPUAlignments {
+	return s.inner.GetCPUAlignments()
+}
+
+func (s *observedState) GetPodSharedCPUSet(podUID string) (cpuset.CPUSet, bool) {
+	return s.inner.GetPodSharedCPUSet(podUID)
+}
+
+func (s *observedState) GetPodSharedCPUSets() PodSharedCPUSets {
+	return s.inner.GetPodSharedCPUSets()
+}
+
+func (s *observedState) SetCPUSet(podUID string, containerName string, cset cpuset.CPUSet) {
+	s.inner.SetCPUSet(podUID, containerName, cset)
+	s.onChange(s.inner)
+}
+
+func (s *observedState) SetDefaultCPUSet(cset cpuset.CPUSet) {
+	s.inner.SetDefaultCPUSet(cset)
+	s.onChange(s.inner)
+}
+
+func (s *observedState) SetCPUAssignments(assignments ContainerCPUAssignments) {
+	s.inner.SetCPUAssignments(assignments)
+	s.onChange(s.inner)
+}
+
+func (s *observedState) SetStaticPod(podUID string) {
+	s.inner.SetStaticPod(podUID)
+	s.onChange(s.inner)
+}
+
+func (s *observedState) SetAlignment(podUID string, containerName string, alignment ContainerAlignment) {
+	s.inner.SetAlignment(podUID, containerName, alignment)
+	s.onChange(s.inner)
+}
+
+func (s *observedState) SetPodSharedCPUSet(podUID string, cset cpuset.CPUSet) {
+	s.inner.SetPodSharedCPUSet(podUID, cset)
+	s.onChange(s.inner)
+}
+
+func (s *observedState) Delete(podUID string, containerName string) {
+	s.inner.Delete(podUID, containerName)
+	s.onChange(s.inner)
+}
+
+func (s *observedState) ClearState() {
+	s.inner.ClearState()
+	s.onChange(s.inner)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"k8s.io/utils/cpuset"
+)
+
+func TestObservedStateCallback(t *testing.T) {
+	changes := 0
+	var observed Reader
+	st := NewObservedState(NewMemoryState(), func(reader Reader) {
+		changes++
+		observed = reader
+	})
+
+	st.SetDefaultCPUSet(cpuset.New(0, 1, 2, 3))
+	if changes != 1 {
+		t.Errorf("expected 1 change after SetDefaultCPUSet, got %d", changes)
+	}
+	if !observed.GetDefaultCPUSet().Equals(cpuset.New(0, 1, 2, 3)) {
+		t.Errorf("expected the callback reader to see the mutation, got %v", observed.GetDefaultCPUSet())
+	}
+
+	st.SetCPUSet("pod", "container", cpuset.New(4, 5))
+	st.SetCPUAssignments(ContainerCPUAssignments{})
+	st.SetStaticPod("pod")
+	st.Delete("pod", "container")
+	st.ClearState()
+	if changes != 6 {
+		t.Errorf("expected 6 changes after all the mutations, got %d", changes)
+	}
+
+	// reads must not trigger the callback
+	st.GetDefaultCPUSet()
+	st.GetCPUSet("pod", "container")
+	st.GetCPUSetOrDefault("pod", "container")
+	st.GetCPUAssignments()
+	st.IsStaticPod("pod")
+	st.GetStaticPods()
+	if changes != 6 {
+		t.Errorf("expected no changes after reads, got %d", changes)
+	}
+}
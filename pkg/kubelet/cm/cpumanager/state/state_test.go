@@ -35,3 +35,35 @@ func TestClone(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMemoryStateAssignmentsSnapshot(t *testing.T) {
+	st := NewMemoryState()
+	st.SetCPUAssignments(ContainerCPUAssignments{
+		"pod": map[string]cpuset.CPUSet{
+			"container1": cpuset.New(4, 5, 6),
+		},
+	})
+
+	// the returned snapshot must not be affected by later updates.
+	snapshot := st.GetCPUAssignments()
+	st.SetCPUSet("pod", "container2", cpuset.New(1, 2, 3))
+	st.Delete("pod", "container1")
+
+	expect := ContainerCPUAssignments{
+		"pod": map[string]cpuset.CPUSet{
+			"container1": cpuset.New(4, 5, 6),
+		},
+	}
+	if !reflect.DeepEqual(expect, snapshot) {
+		t.Errorf("expected snapshot %v, got %v", expect, snapshot)
+	}
+
+	expect = ContainerCPUAssignments{
+		"pod": map[string]cpuset.CPUSet{
+			"container2": cpuset.New(1, 2, 3),
+		},
+	}
+	if actual := st.GetCPUAssignments(); !reflect.DeepEqual(expect, actual) {
+		t.Errorf("expected assignments %v, got %v", expect, actual)
+	}
+}
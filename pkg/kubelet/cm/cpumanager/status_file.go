@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpumanager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// cpuManagerStatusFileName is the file, sitting next to the state checkpoint,
+// which holds the periodically refreshed status summary.
+const cpuManagerStatusFileName = "cpu_manager_status"
+
+// maxTrackedAdmissionFailures bounds the admission failure history surfaced
+// through the status file; older failures are dropped.
+const maxTrackedAdmissionFailures = 10
+
+// admissionFailure records one failed CPU allocation during pod admission.
+type admissionFailure struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Pod           string    `json:"pod"`
+	ContainerName string    `json:"containerName"`
+	Error         string    `json:"error"`
+}
+
+// statusTopology summarizes the discovered CPU topology.
+type statusTopology struct {
+	NumCPUs      int `json:"numCPUs"`
+	NumCores     int `json:"numCores"`
+	NumSockets   int `json:"numSockets"`
+	NumDies      int `json:"numDies,omitempty"`
+	NumNUMANodes int `json:"numNUMANodes,omitempty"`
+}
+
+// cpuManagerStatus is the JSON layout of the status file. It repeats data
+// available through the checkpoint and the podresources API on purpose: the
+// file is meant to be read as-is from a `kubectl debug node` session, with no
+// extra endpoints and no knowledge of the checkpoint format.
+type cpuManagerStatus struct {
+	Timestamp               time.Time                    `json:"timestamp"`
+	PolicyName              string                       `json:"policyName"`
+	Topology                *statusTopology              `json:"topology,omitempty"`
+	SharedPool              string                       `json:"sharedPool"`
+	Assignments             map[string]map[string]string `json:"assignments,omitempty"`
+	RecentAdmissionFailures []admissionFailure           `json:"recentAdmissionFailures,omitempty"`
+}
+
+// recordAdmissionFailure appends the failed allocation to the bounded history
+// surfaced through the status file. Must be called with m.Lock held.
+func (m *manager) recordAdmissionFailure(pod *v1.Pod, container *v1.Container, err error) {
+	m.admissionFailures = append(m.admissionFailures, admissionFailure{
+		Timestamp:     time.Now(),
+		Pod:           klog.KObj(pod).String(),
+		ContainerName: container.Name,
+		Error:         err.Error(),
+	})
+	if len(m.admissionFailures) > maxTrackedAdmissionFailures {
+		m.admissionFailures = m.admissionFailures[len(m.admissionFailures)-maxTrackedAdmissionFailures:]
+	}
+}
+
+// currentStatus assembles the status summary from the manager state.
+func (m *manager) currentStatus() cpuManagerStatus {
+	status := cpuManagerStatus{
+		Timestamp:  time.Now(),
+		PolicyName: m.policy.Name(),
+		SharedPool: m.state.GetDefaultCPUSet().String(),
+	}
+	if m.topology != nil {
+		status.Topology = &statusTopology{
+			NumCPUs:      m.topology.NumCPUs,
+			NumCores:     m.topology.NumCores,
+			NumSockets:   m.topology.NumSockets,
+			NumDies:      m.topology.NumDies,
+			NumNUMANodes: m.topology.NumNUMANodes,
+		}
+	}
+	assignments := m.state.GetCPUAssignments()
+	if len(assignments) > 0 {
+		status.Assignments = make(map[string]map[string]string, len(assignments))
+		for podUID, containers := range assignments {
+			status.Assignments[podUID] = make(map[string]string, len(containers))
+			for containerName, cset := range containers {
+				status.Assignments[podUID][containerName] = cset.String()
+			}
+		}
+	}
+
+	m.Lock()
+	status.RecentAdmissionFailures = append([]admissionFailure(nil), m.admissionFailures...)
+	m.Unlock()
+
+	return status
+}
+
+// writeStatusFile refreshes the status file. Failures are only logged: the
+// file is purely informational and the next refresh tries again.
+func (m *manager) writeStatusFile() {
+	if m.stateFileDirectory == "" {
+		return
+	}
+	statusPath := filepath.Join(m.stateFileDirectory, cpuManagerStatusFileName)
+	data, err := json.MarshalIndent(m.currentStatus(), "", "  ")
+	if err != nil {
+		klog.ErrorS(err, "Failed to serialize the status summary")
+		return
+	}
+	if err := os.WriteFile(statusPath, append(data, '\n'), 0644); err != nil {
+		klog.ErrorS(err, "Failed to write the status file", "path", statusPath)
+	}
+}
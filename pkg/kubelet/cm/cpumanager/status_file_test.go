@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpumanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/state"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/utils/cpuset"
+)
+
+func TestWriteStatusFile(t *testing.T) {
+	testPolicy, err := NewStaticPolicy(topoSingleSocketHT, 1, cpuset.New(0), topologymanager.NewFakeManager(), nil)
+	if err != nil {
+		t.Fatalf("could not create static policy: %v", err)
+	}
+
+	mgr := &manager{
+		policy:   testPolicy,
+		topology: topoSingleSocketHT,
+		state: &mockState{
+			assignments: state.ContainerCPUAssignments{
+				"fakePod": map[string]cpuset.CPUSet{
+					"fakeContainer": cpuset.New(1, 5),
+				},
+			},
+			defaultCPUSet: cpuset.New(0, 2, 3, 4, 6, 7),
+		},
+		stateFileDirectory: t.TempDir(),
+	}
+
+	pod := makePod("fakePod", "fakeContainer2", "2000m", "2000m")
+	mgr.Lock()
+	mgr.recordAdmissionFailure(pod, &pod.Spec.Containers[0], fmt.Errorf("not enough cpus available"))
+	mgr.Unlock()
+
+	mgr.writeStatusFile()
+
+	data, err := os.ReadFile(filepath.Join(mgr.stateFileDirectory, cpuManagerStatusFileName))
+	if err != nil {
+		t.Fatalf("could not read the status file: %v", err)
+	}
+	status := cpuManagerStatus{}
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatalf("could not parse the status file: %v", err)
+	}
+
+	if status.PolicyName != string(PolicyStatic) {
+		t.Errorf("expected policy name %q but got %q", PolicyStatic, status.PolicyName)
+	}
+	if status.SharedPool != "0,2-4,6-7" {
+		t.Errorf("expected shared pool %q but got %q", "0,2-4,6-7", status.SharedPool)
+	}
+	if status.Assignments["fakePod"]["fakeContainer"] != "1,5" {
+		t.Errorf("expected assignment %q but got %v", "1,5", status.Assignments)
+	}
+	if status.Topology == nil || status.Topology.NumCPUs != topoSingleSocketHT.NumCPUs {
+		t.Errorf("expected topology summary of %d CPUs but got %+v", topoSingleSocketHT.NumCPUs, status.Topology)
+	}
+	if len(status.RecentAdmissionFailures) != 1 || status.RecentAdmissionFailures[0].ContainerName != "fakeContainer2" {
+		t.Errorf("expected the recorded admission failure, got %+v", status.RecentAdmissionFailures)
+	}
+	if status.Timestamp.IsZero() {
+		t.Errorf("expected a non-zero timestamp")
+	}
+}
+
+func TestRecordAdmissionFailureBounded(t *testing.T) {
+	mgr := &manager{}
+	pod := makePod("fakePod", "fakeContainer", "2000m", "2000m")
+	for i := 0; i < maxTrackedAdmissionFailures+5; i++ {
+		mgr.Lock()
+		mgr.recordAdmissionFailure(pod, &pod.Spec.Containers[0], fmt.Errorf("failure %d", i))
+		mgr.Unlock()
+	}
+	if len(mgr.admissionFailures) != maxTrackedAdmissionFailures {
+		t.Fatalf("expected the history to be trimmed to %d entries, have %d", maxTrackedAdmissionFailures, len(mgr.admissionFailures))
+	}
+	if expected := fmt.Sprintf("failure %d", maxTrackedAdmissionFailures+4); mgr.admissionFailures[maxTrackedAdmissionFailures-1].Error != expected {
+		t.Errorf("expected the newest failure %q to be kept, have %q", expected, mgr.admissionFailures[maxTrackedAdmissionFailures-1].Error)
+	}
+}
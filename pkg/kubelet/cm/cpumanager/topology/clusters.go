@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/cpuset"
+)
+
+// On ARM Neoverse-class platforms locality is governed by clusters (DSU/L2
+// complexes): small groups of cores sharing an L2 cache, several per
+// last-level cache. cadvisor does not report clusters, so like the dies they
+// are read straight from sysfs, where the kernel exposes the cluster each
+// logical CPU belongs to.
+
+// readClusterID returns the cluster ID the kernel exposes for the given
+// logical CPU under `devicesDir`, or false when the platform does not expose
+// cluster enumeration. The kernel cluster IDs are only unique within their
+// socket, and are negative on platforms which enumerate the file without
+// knowing the cluster.
+func readClusterID(devicesDir string, cpu int) (int, bool) {
+	path := filepath.Join(devicesDir, "system", "cpu", fmt.Sprintf("cpu%d", cpu), "topology", "cluster_id")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// The file is absent on kernels predating cluster enumeration.
+		return 0, false
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		klog.ErrorS(err, "Could not parse the cluster id", "path", path)
+		return 0, false
+	}
+	if id < 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// clusterIDsByCPU returns a platform-unique cluster ID for each of the given
+// logical CPUs, with `cpuSockets` mapping each CPU to its socket. Like the
+// kernel die IDs, the kernel cluster IDs are only unique within their socket,
+// so each cluster is identified by the lowest logical CPU ID it contains. The
+// returned map is empty when the platform does not expose cluster
+// enumeration.
+func clusterIDsByCPU(devicesDir string, cpuSockets map[int]int) map[int]int {
+	type socketCluster struct {
+		socketID  int
+		clusterID int
+	}
+	members := map[socketCluster][]int{}
+	for cpu, socketID := range cpuSockets {
+		clusterID, ok := readClusterID(devicesDir, cpu)
+		if !ok {
+			return map[int]int{}
+		}
+		key := socketCluster{socketID: socketID, clusterID: clusterID}
+		members[key] = append(members[key], cpu)
+	}
+
+	clusters := map[int]int{}
+	for _, cpus := range members {
+		uniqueID := cpuset.New(cpus...).List()[0]
+		for _, cpu := range cpus {
+			clusters[cpu] = uniqueID
+		}
+	}
+	return clusters
+}
+
+// cpuClusterID returns the cluster ID for the given logical CPU out of the
+// clusterIDsByCPU mapping, falling back to the uncore cache ID when the
+// platform does not expose cluster enumeration: without it, the last-level
+// cache is the closest thing to a cluster the topology offers.
+func cpuClusterID(clusterIDs map[int]int, cpu int, uncoreCacheID int) int {
+	if clusterID, ok := clusterIDs[cpu]; ok {
+		return clusterID
+	}
+	return uncoreCacheID
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeClusterID(t *testing.T, devicesDir string, cpu int, clusterID int) {
+	t.Helper()
+	dir := filepath.Join(devicesDir, "system", "cpu", fmt.Sprintf("cpu%d", cpu), "topology")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cluster_id"), []byte(fmt.Sprintf("%d\n", clusterID)), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestClusterIDsByCPU(t *testing.T) {
+	testCases := []struct {
+		description string
+		cpuSockets  map[int]int
+		clusterIDs  map[int]int
+		expected    map[int]int
+	}{
+		{
+			description: "two clusters in a single socket",
+			cpuSockets:  map[int]int{0: 0, 1: 0, 2: 0, 3: 0},
+			clusterIDs:  map[int]int{0: 0, 1: 0, 2: 1, 3: 1},
+			expected:    map[int]int{0: 0, 1: 0, 2: 2, 3: 2},
+		},
+		{
+			description: "socket-relative cluster IDs are made platform unique",
+			cpuSockets:  map[int]int{0: 0, 1: 0, 2: 1, 3: 1},
+			clusterIDs:  map[int]int{0: 0, 1: 0, 2: 0, 3: 0},
+			expected:    map[int]int{0: 0, 1: 0, 2: 2, 3: 2},
+		},
+		{
+			description: "no cluster enumeration exposed",
+			cpuSockets:  map[int]int{0: 0, 1: 0},
+			clusterIDs:  map[int]int{},
+			expected:    map[int]int{},
+		},
+		{
+			description: "negative cluster IDs are treated as absent",
+			cpuSockets:  map[int]int{0: 0, 1: 0},
+			clusterIDs:  map[int]int{0: -1, 1: -1},
+			expected:    map[int]int{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			devicesDir := t.TempDir()
+			for cpu, clusterID := range testCase.clusterIDs {
+				writeClusterID(t, devicesDir, cpu, clusterID)
+			}
+
+			clusterIDs := clusterIDsByCPU(devicesDir, testCase.cpuSockets)
+			if !reflect.DeepEqual(clusterIDs, testCase.expected) {
+				t.Errorf("expected cluster IDs %v, but got %v", testCase.expected, clusterIDs)
+			}
+		})
+	}
+}
+
+func TestCPUClusterID(t *testing.T) {
+	clusterIDs := map[int]int{0: 0, 1: 0, 2: 2, 3: 2}
+	if id := cpuClusterID(clusterIDs, 2, 7); id != 2 {
+		t.Errorf("expected cluster ID 2, but got %d", id)
+	}
+	// Without cluster enumeration the uncore cache acts as the cluster.
+	if id := cpuClusterID(map[int]int{}, 2, 7); id != 7 {
+		t.Errorf("expected cluster ID 7, but got %d", id)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/cpuset"
+)
+
+// CoreType identifies the microarchitectural class of a physical core on
+// hybrid CPUs, like the P-cores and E-cores of Intel hybrid processors.
+// Homogeneous hardware does not expose core types, so every core reports
+// CoreTypeUnknown there.
+type CoreType string
+
+const (
+	// CoreTypeUnknown is used when the platform does not expose core types.
+	CoreTypeUnknown CoreType = ""
+	// CoreTypePerformance identifies a performance (P) core.
+	CoreTypePerformance CoreType = "performance"
+	// CoreTypeEfficiency identifies an efficiency (E) core.
+	CoreTypeEfficiency CoreType = "efficiency"
+)
+
+// HasHybridCoreTypes returns true when the topology exposes core types, that
+// is when the machine has a hybrid CPU.
+func (topo *CPUTopology) HasHybridCoreTypes() bool {
+	return !topo.CPUDetails.CPUsWithCoreType(CoreTypePerformance).IsEmpty()
+}
+
+// sysDevicesDir is where the kernel exposes the CPU details cadvisor does
+// not report: on hybrid CPUs, the cpu_core/cpus and cpu_atom/cpus files list
+// the logical CPUs backed by performance and efficiency cores respectively,
+// and the per-CPU topology directories hold the die enumeration. It is a
+// variable only to be overridable from the tests.
+var sysDevicesDir = "/sys/devices"
+
+// readCoreTypes returns the logical CPU IDs of each core type exposed by the
+// kernel under `devicesDir`. The returned map is empty on homogeneous
+// hardware, where no core enumeration files exist; cadvisor does not report
+// core types, which is why they are read straight from sysfs.
+func readCoreTypes(devicesDir string) map[CoreType]cpuset.CPUSet {
+	coreTypeFiles := map[CoreType]string{
+		CoreTypePerformance: "cpu_core",
+		CoreTypeEfficiency:  "cpu_atom",
+	}
+
+	coreTypes := map[CoreType]cpuset.CPUSet{}
+	for coreType, dir := range coreTypeFiles {
+		path := filepath.Join(devicesDir, dir, "cpus")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			// The file is absent on non-hybrid hardware, nothing to report.
+			continue
+		}
+		// The cpus file uses the canonical cpu list format, e.g. "0-7,16".
+		cpus, err := cpuset.Parse(strings.TrimSpace(string(data)))
+		if err != nil {
+			klog.ErrorS(err, "Could not parse the core type cpu list", "path", path)
+			continue
+		}
+		coreTypes[coreType] = cpus
+	}
+	return coreTypes
+}
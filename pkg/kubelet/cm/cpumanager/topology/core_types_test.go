@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/cpuset"
+)
+
+func writeCoreTypeCPUs(t *testing.T, devicesDir string, dir string, cpus string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(devicesDir, dir), 0o755); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(devicesDir, dir, "cpus"), []byte(cpus+"\n"), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestReadCoreTypes(t *testing.T) {
+	testCases := []struct {
+		description       string
+		coreTypeCPUs      map[string]string
+		expectedCoreTypes map[CoreType]cpuset.CPUSet
+	}{
+		{
+			description: "hybrid CPU exposing both core types",
+			coreTypeCPUs: map[string]string{
+				"cpu_core": "0-7,16-19",
+				"cpu_atom": "8-15",
+			},
+			expectedCoreTypes: map[CoreType]cpuset.CPUSet{
+				CoreTypePerformance: cpuset.New(0, 1, 2, 3, 4, 5, 6, 7, 16, 17, 18, 19),
+				CoreTypeEfficiency:  cpuset.New(8, 9, 10, 11, 12, 13, 14, 15),
+			},
+		},
+		{
+			description:       "homogeneous CPU without core enumeration files",
+			coreTypeCPUs:      map[string]string{},
+			expectedCoreTypes: map[CoreType]cpuset.CPUSet{},
+		},
+		{
+			description: "malformed core enumeration file is skipped",
+			coreTypeCPUs: map[string]string{
+				"cpu_core": "not-a-cpu-list",
+				"cpu_atom": "8-15",
+			},
+			expectedCoreTypes: map[CoreType]cpuset.CPUSet{
+				CoreTypeEfficiency: cpuset.New(8, 9, 10, 11, 12, 13, 14, 15),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			devicesDir := t.TempDir()
+			for dir, cpus := range testCase.coreTypeCPUs {
+				writeCoreTypeCPUs(t, devicesDir, dir, cpus)
+			}
+
+			coreTypes := readCoreTypes(devicesDir)
+			if !reflect.DeepEqual(coreTypes, testCase.expectedCoreTypes) {
+				t.Errorf("expected core types %v, but got %v", testCase.expectedCoreTypes, coreTypes)
+			}
+		})
+	}
+}
+
+func TestCPUsWithCoreType(t *testing.T) {
+	details := CPUDetails{
+		0: CPUInfo{CoreID: 0, SocketID: 0, NUMANodeID: 0, CoreType: CoreTypePerformance},
+		1: CPUInfo{CoreID: 0, SocketID: 0, NUMANodeID: 0, CoreType: CoreTypePerformance},
+		2: CPUInfo{CoreID: 2, SocketID: 0, NUMANodeID: 0, CoreType: CoreTypeEfficiency},
+		3: CPUInfo{CoreID: 3, SocketID: 0, NUMANodeID: 0, CoreType: CoreTypeEfficiency},
+	}
+
+	if pcores := details.CPUsWithCoreType(CoreTypePerformance); !pcores.Equals(cpuset.New(0, 1)) {
+		t.Errorf("expected performance core CPUs 0-1, but got %v", pcores)
+	}
+	if ecores := details.CPUsWithCoreType(CoreTypeEfficiency); !ecores.Equals(cpuset.New(2, 3)) {
+		t.Errorf("expected efficiency core CPUs 2-3, but got %v", ecores)
+	}
+	if unknown := details.CPUsWithCoreType(CoreTypeUnknown); !unknown.IsEmpty() {
+		t.Errorf("expected no CPUs with an unknown core type, but got %v", unknown)
+	}
+}
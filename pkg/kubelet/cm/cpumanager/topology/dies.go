@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/cpuset"
+)
+
+// Modern multi-chip-module processors package several dies per socket, each
+// with its own memory and IO latency profile. cadvisor does not report dies,
+// so like the core types they are read straight from sysfs, where the kernel
+// exposes the die each logical CPU belongs to.
+
+// readDieID returns the die ID the kernel exposes for the given logical CPU
+// under `devicesDir`, or false when the platform does not expose die
+// enumeration. The kernel die IDs are only unique within their socket.
+func readDieID(devicesDir string, cpu int) (int, bool) {
+	path := filepath.Join(devicesDir, "system", "cpu", fmt.Sprintf("cpu%d", cpu), "topology", "die_id")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// The file is absent on kernels predating die enumeration.
+		return 0, false
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		klog.ErrorS(err, "Could not parse the die id", "path", path)
+		return 0, false
+	}
+	return id, true
+}
+
+// dieIDsByCPU returns a platform-unique die ID for each of the given logical
+// CPUs, with `cpuSockets` mapping each CPU to its socket. The kernel die IDs
+// are only unique within their socket, so following the same approach
+// getUniqueCoreID takes for cores, each die is identified by the lowest
+// logical CPU ID it contains. The returned map is empty when the platform
+// does not expose die enumeration.
+func dieIDsByCPU(devicesDir string, cpuSockets map[int]int) map[int]int {
+	type socketDie struct {
+		socketID int
+		dieID    int
+	}
+	members := map[socketDie][]int{}
+	for cpu, socketID := range cpuSockets {
+		dieID, ok := readDieID(devicesDir, cpu)
+		if !ok {
+			return map[int]int{}
+		}
+		key := socketDie{socketID: socketID, dieID: dieID}
+		members[key] = append(members[key], cpu)
+	}
+
+	dies := map[int]int{}
+	for _, cpus := range members {
+		uniqueID := cpuset.New(cpus...).List()[0]
+		for _, cpu := range cpus {
+			dies[cpu] = uniqueID
+		}
+	}
+	return dies
+}
+
+// cpuDieID returns the die ID for the given logical CPU out of the
+// dieIDsByCPU mapping, falling back to the socket ID when the platform does
+// not expose die enumeration: every socket holds at least one die, and
+// single-die sockets are the norm on the hardware which predates it.
+func cpuDieID(dieIDs map[int]int, cpu int, socketID int) int {
+	if dieID, ok := dieIDs[cpu]; ok {
+		return dieID
+	}
+	return socketID
+}
+
+// cpuSockets returns the socket each logical CPU in the given cadvisor
+// topology belongs to.
+func cpuSockets(nodes []cadvisorapi.Node) map[int]int {
+	sockets := map[int]int{}
+	for _, node := range nodes {
+		for _, core := range node.Cores {
+			for _, cpu := range core.Threads {
+				sockets[cpu] = core.SocketID
+			}
+		}
+	}
+	return sockets
+}
+
+// NUMANodesPerDie returns the NUMA node IDs backed by each die on the
+// machine, for the components, like the topology manager, which work with
+// the cadvisor NUMA topology and do not need the full CPUTopology.
+func NUMANodesPerDie(nodes []cadvisorapi.Node) map[int]cpuset.CPUSet {
+	return numaNodesPerDie(sysDevicesDir, nodes)
+}
+
+func numaNodesPerDie(devicesDir string, nodes []cadvisorapi.Node) map[int]cpuset.CPUSet {
+	dieIDs := dieIDsByCPU(devicesDir, cpuSockets(nodes))
+
+	numaNodes := map[int][]int{}
+	for _, node := range nodes {
+		for _, core := range node.Cores {
+			for _, cpu := range core.Threads {
+				dieID := cpuDieID(dieIDs, cpu, core.SocketID)
+				numaNodes[dieID] = append(numaNodes[dieID], node.Id)
+			}
+		}
+	}
+
+	dies := map[int]cpuset.CPUSet{}
+	for dieID, ids := range numaNodes {
+		dies[dieID] = cpuset.New(ids...)
+	}
+	return dies
+}
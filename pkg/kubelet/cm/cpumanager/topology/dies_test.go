@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+	"k8s.io/utils/cpuset"
+)
+
+func writeDieID(t *testing.T, devicesDir string, cpu int, dieID int) {
+	t.Helper()
+	dir := filepath.Join(devicesDir, "system", "cpu", fmt.Sprintf("cpu%d", cpu), "topology")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "die_id"), []byte(fmt.Sprintf("%d\n", dieID)), 0o644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestDieIDsByCPU(t *testing.T) {
+	testCases := []struct {
+		description string
+		cpuSockets  map[int]int
+		dieIDs      map[int]int
+		expected    map[int]int
+	}{
+		{
+			description: "two dies in a single socket",
+			cpuSockets:  map[int]int{0: 0, 1: 0, 2: 0, 3: 0},
+			dieIDs:      map[int]int{0: 0, 1: 0, 2: 1, 3: 1},
+			expected:    map[int]int{0: 0, 1: 0, 2: 2, 3: 2},
+		},
+		{
+			description: "socket-relative die IDs are made platform unique",
+			cpuSockets:  map[int]int{0: 0, 1: 0, 2: 1, 3: 1},
+			dieIDs:      map[int]int{0: 0, 1: 0, 2: 0, 3: 0},
+			expected:    map[int]int{0: 0, 1: 0, 2: 2, 3: 2},
+		},
+		{
+			description: "no die enumeration exposed",
+			cpuSockets:  map[int]int{0: 0, 1: 0},
+			dieIDs:      map[int]int{},
+			expected:    map[int]int{},
+		},
+		{
+			description: "partial die enumeration is treated as absent",
+			cpuSockets:  map[int]int{0: 0, 1: 0},
+			dieIDs:      map[int]int{0: 0},
+			expected:    map[int]int{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			devicesDir := t.TempDir()
+			for cpu, dieID := range testCase.dieIDs {
+				writeDieID(t, devicesDir, cpu, dieID)
+			}
+
+			dieIDs := dieIDsByCPU(devicesDir, testCase.cpuSockets)
+			if !reflect.DeepEqual(dieIDs, testCase.expected) {
+				t.Errorf("expected die IDs %v, but got %v", testCase.expected, dieIDs)
+			}
+		})
+	}
+}
+
+func TestNUMANodesPerDie(t *testing.T) {
+	nodes := []cadvisorapi.Node{
+		{
+			Id: 0,
+			Cores: []cadvisorapi.Core{
+				{SocketID: 0, Threads: []int{0, 1}},
+			},
+		},
+		{
+			Id: 1,
+			Cores: []cadvisorapi.Core{
+				{SocketID: 0, Threads: []int{2, 3}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		description string
+		dieIDs      map[int]int
+		expected    map[int]cpuset.CPUSet
+	}{
+		{
+			description: "one NUMA node per die",
+			dieIDs:      map[int]int{0: 0, 1: 0, 2: 1, 3: 1},
+			expected: map[int]cpuset.CPUSet{
+				0: cpuset.New(0),
+				2: cpuset.New(1),
+			},
+		},
+		{
+			description: "without die enumeration the socket acts as the die",
+			dieIDs:      map[int]int{},
+			expected: map[int]cpuset.CPUSet{
+				0: cpuset.New(0, 1),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			devicesDir := t.TempDir()
+			for cpu, dieID := range testCase.dieIDs {
+				writeDieID(t, devicesDir, cpu, dieID)
+			}
+
+			dies := numaNodesPerDie(devicesDir, nodes)
+			if !reflect.DeepEqual(dies, testCase.expected) {
+				t.Errorf("expected NUMA nodes per die %v, but got %v", testCase.expected, dies)
+			}
+		})
+	}
+}
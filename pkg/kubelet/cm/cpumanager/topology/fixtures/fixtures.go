@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures generates CPU topologies for tests and benchmarks.
+//
+// Hand-typing a CPUDetails literal is fine for a handful of CPUs, but the
+// machines where the interesting allocation behaviors show up (uncore cache
+// alignment, sub-NUMA clustering) have hundreds of logical CPUs, and literal
+// fixtures for them run into thousands of lines nobody can review. This
+// package builds the same topologies programmatically from their shape, and
+// ships presets for the machine families the CPU manager is commonly tuned
+// for.
+package fixtures
+
+import (
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+)
+
+// Config describes the shape of a machine, from which a full CPU topology is
+// generated. The zero values of the optional fields give the simplest layout:
+// no SMT, one NUMA node per socket, one uncore cache per NUMA node.
+type Config struct {
+	// Sockets is the number of physical sockets.
+	Sockets int
+	// NUMANodesPerSocket is the number of NUMA nodes each socket exposes.
+	// More than one models sub-NUMA clustering (Intel SNC) or NPS modes
+	// (AMD). Defaults to 1.
+	NUMANodesPerSocket int
+	// CoresPerNUMANode is the number of physical cores in each NUMA node.
+	CoresPerNUMANode int
+	// ThreadsPerCore is the number of hardware threads per physical core.
+	// Defaults to 1 (no SMT).
+	ThreadsPerCore int
+	// CoresPerUncoreCache is the number of cores sharing a last-level
+	// cache, as on the AMD CCX/CCD designs. Defaults to all the cores of a
+	// NUMA node sharing one cache, as on the monolithic Intel designs.
+	CoresPerUncoreCache int
+}
+
+// New generates the CPU topology of a machine with the given shape. The CPU
+// numbering follows the common Linux enumeration, which the hand-written
+// fixtures use as well: the first thread of every core first, then the
+// sibling threads, so CPU n and CPU n+NumCores are core siblings.
+func New(cfg Config) *topology.CPUTopology {
+	if cfg.NUMANodesPerSocket == 0 {
+		cfg.NUMANodesPerSocket = 1
+	}
+	if cfg.ThreadsPerCore == 0 {
+		cfg.ThreadsPerCore = 1
+	}
+	if cfg.CoresPerUncoreCache == 0 {
+		cfg.CoresPerUncoreCache = cfg.CoresPerNUMANode
+	}
+
+	numCores := cfg.Sockets * cfg.NUMANodesPerSocket * cfg.CoresPerNUMANode
+	details := make(map[int]topology.CPUInfo, numCores*cfg.ThreadsPerCore)
+	for core := 0; core < numCores; core++ {
+		numaNode := core / cfg.CoresPerNUMANode
+		socket := numaNode / cfg.NUMANodesPerSocket
+		uncoreCache := core / cfg.CoresPerUncoreCache
+		for thread := 0; thread < cfg.ThreadsPerCore; thread++ {
+			details[core+thread*numCores] = topology.CPUInfo{
+				CoreID:        core,
+				SocketID:      socket,
+				DieID:         socket,
+				NUMANodeID:    numaNode,
+				UncoreCacheID: uncoreCache,
+			}
+		}
+	}
+
+	return &topology.CPUTopology{
+		NumCPUs:      numCores * cfg.ThreadsPerCore,
+		NumCores:     numCores,
+		NumSockets:   cfg.Sockets,
+		NumDies:      cfg.Sockets,
+		NumNUMANodes: cfg.Sockets * cfg.NUMANodesPerSocket,
+		CPUDetails:   details,
+	}
+}
+
+// EPYCMilanDualSocket returns the topology of a two socket AMD EPYC 7763
+// machine: 2x64 cores with SMT, eight cores per CCX sharing a L3 cache, one
+// NUMA node per socket (NPS1).
+func EPYCMilanDualSocket() *topology.CPUTopology {
+	return New(Config{
+		Sockets:             2,
+		CoresPerNUMANode:    64,
+		ThreadsPerCore:      2,
+		CoresPerUncoreCache: 8,
+	})
+}
+
+// EPYCGenoaDualSocket returns the topology of a two socket AMD EPYC 9654
+// machine: 2x96 cores with SMT, eight cores per CCD sharing a L3 cache, four
+// NUMA nodes per socket (NPS4).
+func EPYCGenoaDualSocket() *topology.CPUTopology {
+	return New(Config{
+		Sockets:             2,
+		NUMANodesPerSocket:  4,
+		CoresPerNUMANode:    24,
+		ThreadsPerCore:      2,
+		CoresPerUncoreCache: 8,
+	})
+}
+
+// XeonSapphireRapidsSNC2 returns the topology of a two socket Intel Xeon
+// 8480+ machine with sub-NUMA clustering: 2x56 cores with SMT, two NUMA
+// nodes per socket, one monolithic L3 cache per NUMA node.
+func XeonSapphireRapidsSNC2() *topology.CPUTopology {
+	return New(Config{
+		Sockets:            2,
+		NUMANodesPerSocket: 2,
+		CoresPerNUMANode:   28,
+		ThreadsPerCore:     2,
+	})
+}
+
+// XeonSapphireRapidsSNC4 returns the topology of the same machine as
+// XeonSapphireRapidsSNC2, configured with four sub-NUMA clusters per socket.
+func XeonSapphireRapidsSNC4() *topology.CPUTopology {
+	return New(Config{
+		Sockets:            2,
+		NUMANodesPerSocket: 4,
+		CoresPerNUMANode:   14,
+		ThreadsPerCore:     2,
+	})
+}
+
+// GravitonSingleSocket returns the topology of an AWS Graviton3 metal
+// machine: 64 cores without SMT on a single socket and NUMA node.
+func GravitonSingleSocket() *topology.CPUTopology {
+	return New(Config{
+		Sockets:          1,
+		CoresPerNUMANode: 64,
+	})
+}
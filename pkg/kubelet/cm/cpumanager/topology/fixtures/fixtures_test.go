@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+)
+
+func TestNewMatchesHandWrittenFixture(t *testing.T) {
+	got := New(Config{
+		Sockets:          1,
+		CoresPerNUMANode: 4,
+		ThreadsPerCore:   2,
+	})
+
+	want := &topology.CPUTopology{
+		NumCPUs:      8,
+		NumCores:     4,
+		NumSockets:   1,
+		NumDies:      1,
+		NumNUMANodes: 1,
+		CPUDetails: map[int]topology.CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			2: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
+			3: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+			4: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			5: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			6: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
+			7: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generated topology differs from the hand-written one. Have: %+v wants %+v", got, want)
+	}
+}
+
+func TestPresetShapes(t *testing.T) {
+	testCases := []struct {
+		name            string
+		topo            *topology.CPUTopology
+		expCPUs         int
+		expCores        int
+		expSockets      int
+		expNUMANodes    int
+		expUncoreCaches int
+	}{
+		{
+			name:            "EPYC Milan dual socket",
+			topo:            EPYCMilanDualSocket(),
+			expCPUs:         256,
+			expCores:        128,
+			expSockets:      2,
+			expNUMANodes:    2,
+			expUncoreCaches: 16,
+		},
+		{
+			name:            "EPYC Genoa dual socket",
+			topo:            EPYCGenoaDualSocket(),
+			expCPUs:         384,
+			expCores:        192,
+			expSockets:      2,
+			expNUMANodes:    8,
+			expUncoreCaches: 24,
+		},
+		{
+			name:            "Xeon Sapphire Rapids SNC-2",
+			topo:            XeonSapphireRapidsSNC2(),
+			expCPUs:         224,
+			expCores:        112,
+			expSockets:      2,
+			expNUMANodes:    4,
+			expUncoreCaches: 4,
+		},
+		{
+			name:            "Xeon Sapphire Rapids SNC-4",
+			topo:            XeonSapphireRapidsSNC4(),
+			expCPUs:         224,
+			expCores:        112,
+			expSockets:      2,
+			expNUMANodes:    8,
+			expUncoreCaches: 8,
+		},
+		{
+			name:            "Graviton single socket",
+			topo:            GravitonSingleSocket(),
+			expCPUs:         64,
+			expCores:        64,
+			expSockets:      1,
+			expNUMANodes:    1,
+			expUncoreCaches: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.topo.NumCPUs != tc.expCPUs || tc.topo.CPUDetails.CPUs().Size() != tc.expCPUs {
+				t.Errorf("expected %d CPUs, have %d (%d in the details)", tc.expCPUs, tc.topo.NumCPUs, tc.topo.CPUDetails.CPUs().Size())
+			}
+			if tc.topo.NumCores != tc.expCores || tc.topo.CPUDetails.Cores().Size() != tc.expCores {
+				t.Errorf("expected %d cores, have %d (%d in the details)", tc.expCores, tc.topo.NumCores, tc.topo.CPUDetails.Cores().Size())
+			}
+			if tc.topo.NumSockets != tc.expSockets || tc.topo.CPUDetails.Sockets().Size() != tc.expSockets {
+				t.Errorf("expected %d sockets, have %d (%d in the details)", tc.expSockets, tc.topo.NumSockets, tc.topo.CPUDetails.Sockets().Size())
+			}
+			if tc.topo.NumNUMANodes != tc.expNUMANodes || tc.topo.CPUDetails.NUMANodes().Size() != tc.expNUMANodes {
+				t.Errorf("expected %d NUMA nodes, have %d (%d in the details)", tc.expNUMANodes, tc.topo.NumNUMANodes, tc.topo.CPUDetails.NUMANodes().Size())
+			}
+			if uncoreCaches := tc.topo.CPUDetails.UncoreCaches().Size(); uncoreCaches != tc.expUncoreCaches {
+				t.Errorf("expected %d uncore caches, have %d", tc.expUncoreCaches, uncoreCaches)
+			}
+
+			// Every core must hold the same number of sibling threads, and
+			// the siblings must share the socket, NUMA node and uncore cache.
+			threadsPerCore := tc.topo.NumCPUs / tc.topo.NumCores
+			for _, core := range tc.topo.CPUDetails.Cores().List() {
+				siblings := tc.topo.CPUDetails.CPUsInCores(core)
+				if siblings.Size() != threadsPerCore {
+					t.Errorf("expected %d threads in core %d, have %d", threadsPerCore, core, siblings.Size())
+				}
+				var info *topology.CPUInfo
+				for _, cpu := range siblings.List() {
+					cpuInfo := tc.topo.CPUDetails[cpu]
+					if info == nil {
+						info = &cpuInfo
+						continue
+					}
+					if cpuInfo != *info {
+						t.Errorf("core %d siblings disagree on the placement: %+v vs %+v", core, cpuInfo, *info)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/cpuset"
+)
+
+// DiscoverFromSysFS builds the CPUTopology straight from sysfs, as an
+// alternative to the cadvisor MachineInfo based Discover. cadvisor misreports
+// or omits parts of the topology on some kernels and platforms, the uncore
+// caches most notably; the kernel files are the source cadvisor itself reads
+// from, so going to them directly cuts out the middleman. The cadvisor
+// MachineInfo is still used to cross-validate the result: a disagreement on
+// the basic counts means one of the two views is wrong, and rather than
+// guessing which, discovery fails.
+func DiscoverFromSysFS(machineInfo *cadvisorapi.MachineInfo) (*CPUTopology, error) {
+	return discoverFromSysFS(sysDevicesDir, machineInfo)
+}
+
+func discoverFromSysFS(devicesDir string, machineInfo *cadvisorapi.MachineInfo) (*CPUTopology, error) {
+	cpuDir := filepath.Join(devicesDir, "system", "cpu")
+	cpus, err := readCPUList(filepath.Join(cpuDir, "online"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the online CPUs: %w", err)
+	}
+
+	numaNodes, err := numaNodesByCPU(devicesDir, cpus)
+	if err != nil {
+		return nil, err
+	}
+
+	// The kernel core and uncore cache IDs are not platform unique, so both
+	// are identified by the lowest logical CPU they span, the same way
+	// getUniqueCoreID handles the cadvisor core IDs.
+	cpuSockets := map[int]int{}
+	coreMembers := map[string][]int{}
+	uncoreMembers := map[string][]int{}
+	for _, cpu := range cpus.List() {
+		topologyDir := filepath.Join(cpuDir, fmt.Sprintf("cpu%d", cpu), "topology")
+		socketID, err := readIntFile(filepath.Join(topologyDir, "physical_package_id"))
+		if err != nil {
+			return nil, fmt.Errorf("could not read the package of CPU %d: %w", cpu, err)
+		}
+		coreID, err := readIntFile(filepath.Join(topologyDir, "core_id"))
+		if err != nil {
+			return nil, fmt.Errorf("could not read the core of CPU %d: %w", cpu, err)
+		}
+		cpuSockets[cpu] = socketID
+		coreKey := fmt.Sprintf("%d/%d", socketID, coreID)
+		coreMembers[coreKey] = append(coreMembers[coreKey], cpu)
+
+		// The last-level cache is described by its shared_cpu_list: CPUs with
+		// the same list sit behind the same uncore cache. Platforms without a
+		// level 3 cache fall back to the socket, like Discover does.
+		uncoreKey := fmt.Sprintf("socket%d", socketID)
+		if sharedCPUs, err := os.ReadFile(filepath.Join(cpuDir, fmt.Sprintf("cpu%d", cpu), "cache", "index3", "shared_cpu_list")); err == nil {
+			uncoreKey = strings.TrimSpace(string(sharedCPUs))
+		}
+		uncoreMembers[uncoreKey] = append(uncoreMembers[uncoreKey], cpu)
+	}
+
+	coreIDs := uniqueIDsByMember(coreMembers)
+	uncoreIDs := uniqueIDsByMember(uncoreMembers)
+	dieIDs := dieIDsByCPU(devicesDir, cpuSockets)
+	clusterIDs := clusterIDsByCPU(devicesDir, cpuSockets)
+	coreTypes := readCoreTypes(devicesDir)
+
+	details := CPUDetails{}
+	for _, cpu := range cpus.List() {
+		details[cpu] = CPUInfo{
+			NUMANodeID:    numaNodes[cpu],
+			SocketID:      cpuSockets[cpu],
+			DieID:         cpuDieID(dieIDs, cpu, cpuSockets[cpu]),
+			ClusterID:     cpuClusterID(clusterIDs, cpu, uncoreIDs[cpu]),
+			CoreID:        coreIDs[cpu],
+			UncoreCacheID: uncoreIDs[cpu],
+			CoreType:      cpuCoreType(coreTypes, cpu),
+		}
+	}
+
+	topo := &CPUTopology{
+		NumCPUs:      cpus.Size(),
+		NumCores:     details.Cores().Size(),
+		NumSockets:   details.Sockets().Size(),
+		NumDies:      details.Dies().Size(),
+		NumNUMANodes: details.NUMANodes().Size(),
+		CPUDetails:   details,
+	}
+
+	if err := crossValidate(topo, machineInfo); err != nil {
+		return nil, err
+	}
+	return topo, nil
+}
+
+// crossValidate checks the sysfs view of the machine against the cadvisor
+// one. Only the counts both sides are expected to get right are compared:
+// finer grained data like the uncore caches is exactly what cadvisor gets
+// wrong on the platforms this discoverer exists for.
+func crossValidate(topo *CPUTopology, machineInfo *cadvisorapi.MachineInfo) error {
+	if machineInfo == nil {
+		return nil
+	}
+	if topo.NumCPUs != machineInfo.NumCores {
+		return fmt.Errorf("sysfs reports %d CPUs but cadvisor reports %d", topo.NumCPUs, machineInfo.NumCores)
+	}
+	if topo.NumSockets != machineInfo.NumSockets {
+		return fmt.Errorf("sysfs reports %d sockets but cadvisor reports %d", topo.NumSockets, machineInfo.NumSockets)
+	}
+	return nil
+}
+
+// numaNodesByCPU maps each of the given logical CPUs to its NUMA node, read
+// from the node cpulist files. Machines without NUMA enumeration place every
+// CPU on node 0.
+func numaNodesByCPU(devicesDir string, cpus cpuset.CPUSet) (map[int]int, error) {
+	numaNodes := map[int]int{}
+	nodeDirs, err := filepath.Glob(filepath.Join(devicesDir, "system", "node", "node*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeDir := range nodeDirs {
+		nodeID, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(nodeDir), "node"))
+		if err != nil {
+			// Not a node directory, e.g. node memory statistics files.
+			continue
+		}
+		nodeCPUs, err := readCPUList(filepath.Join(nodeDir, "cpulist"))
+		if err != nil {
+			return nil, fmt.Errorf("could not read the CPUs of NUMA node %d: %w", nodeID, err)
+		}
+		for _, cpu := range nodeCPUs.List() {
+			numaNodes[cpu] = nodeID
+		}
+	}
+	if len(numaNodes) == 0 {
+		klog.InfoS("No NUMA enumeration in sysfs, assuming a single node")
+	}
+	return numaNodes, nil
+}
+
+// uniqueIDsByMember flattens groups of logical CPUs into a per-CPU ID map,
+// identifying each group by the lowest CPU it contains.
+func uniqueIDsByMember(members map[string][]int) map[int]int {
+	ids := map[int]int{}
+	for _, cpus := range members {
+		uniqueID := cpuset.New(cpus...).List()[0]
+		for _, cpu := range cpus {
+			ids[cpu] = uniqueID
+		}
+	}
+	return ids
+}
+
+// OnlineCPUs returns the set of CPUs currently online per the kernel. Unlike
+// the discovery-time topology, which is a snapshot, this reflects runtime
+// changes such as CPU hotplug or SMT toggling and so can be polled.
+func OnlineCPUs() (cpuset.CPUSet, error) {
+	return readCPUList(filepath.Join(sysDevicesDir, "system", "cpu", "online"))
+}
+
+// readIntFile reads a sysfs file holding a single integer.
+func readIntFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// readCPUList parses a file in the canonical cpu list format, e.g. "0-7,16".
+func readCPUList(path string) (cpuset.CPUSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cpuset.New(), err
+	}
+	return cpuset.Parse(strings.TrimSpace(string(data)))
+}
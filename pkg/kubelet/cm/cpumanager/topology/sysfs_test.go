@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+)
+
+// sysFSCPU describes one logical CPU of a fake sysfs tree.
+type sysFSCPU struct {
+	socketID int
+	coreID   int
+	// llcCPUs is the shared_cpu_list of the level 3 cache; empty means the
+	// cache enumeration is absent.
+	llcCPUs string
+}
+
+func writeFakeSysFS(t *testing.T, devicesDir string, cpus map[int]sysFSCPU, online string, numaNodes map[int]string) {
+	t.Helper()
+	writeFile := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cpuDir := filepath.Join(devicesDir, "system", "cpu")
+	writeFile(filepath.Join(cpuDir, "online"), online)
+	for cpu, info := range cpus {
+		topologyDir := filepath.Join(cpuDir, fmt.Sprintf("cpu%d", cpu), "topology")
+		writeFile(filepath.Join(topologyDir, "physical_package_id"), fmt.Sprintf("%d", info.socketID))
+		writeFile(filepath.Join(topologyDir, "core_id"), fmt.Sprintf("%d", info.coreID))
+		if info.llcCPUs != "" {
+			writeFile(filepath.Join(cpuDir, fmt.Sprintf("cpu%d", cpu), "cache", "index3", "shared_cpu_list"), info.llcCPUs)
+		}
+	}
+	for nodeID, cpulist := range numaNodes {
+		writeFile(filepath.Join(devicesDir, "system", "node", fmt.Sprintf("node%d", nodeID), "cpulist"), cpulist)
+	}
+}
+
+func Test_discoverFromSysFS(t *testing.T) {
+	// A dual socket HT machine: socket 0 holds CPUs 0-3, socket 1 CPUs 4-7,
+	// the kernel core IDs repeating across sockets, one uncore cache and one
+	// NUMA node per socket.
+	devicesDir := t.TempDir()
+	cpus := map[int]sysFSCPU{
+		0: {socketID: 0, coreID: 0, llcCPUs: "0-3"},
+		1: {socketID: 0, coreID: 1, llcCPUs: "0-3"},
+		2: {socketID: 0, coreID: 0, llcCPUs: "0-3"},
+		3: {socketID: 0, coreID: 1, llcCPUs: "0-3"},
+		4: {socketID: 1, coreID: 0, llcCPUs: "4-7"},
+		5: {socketID: 1, coreID: 1, llcCPUs: "4-7"},
+		6: {socketID: 1, coreID: 0, llcCPUs: "4-7"},
+		7: {socketID: 1, coreID: 1, llcCPUs: "4-7"},
+	}
+	writeFakeSysFS(t, devicesDir, cpus, "0-7", map[int]string{0: "0-3", 1: "4-7"})
+
+	machineInfo := &cadvisorapi.MachineInfo{NumCores: 8, NumSockets: 2}
+	topo, err := discoverFromSysFS(devicesDir, machineInfo)
+	if err != nil {
+		t.Fatalf("discoverFromSysFS() failed: %v", err)
+	}
+
+	want := &CPUTopology{
+		NumCPUs:      8,
+		NumCores:     4,
+		NumSockets:   2,
+		NumDies:      2,
+		NumNUMANodes: 2,
+		CPUDetails: map[int]CPUInfo{
+			0: {NUMANodeID: 0, SocketID: 0, DieID: 0, ClusterID: 0, CoreID: 0, UncoreCacheID: 0},
+			1: {NUMANodeID: 0, SocketID: 0, DieID: 0, ClusterID: 0, CoreID: 1, UncoreCacheID: 0},
+			2: {NUMANodeID: 0, SocketID: 0, DieID: 0, ClusterID: 0, CoreID: 0, UncoreCacheID: 0},
+			3: {NUMANodeID: 0, SocketID: 0, DieID: 0, ClusterID: 0, CoreID: 1, UncoreCacheID: 0},
+			4: {NUMANodeID: 1, SocketID: 1, DieID: 1, ClusterID: 4, CoreID: 4, UncoreCacheID: 4},
+			5: {NUMANodeID: 1, SocketID: 1, DieID: 1, ClusterID: 4, CoreID: 5, UncoreCacheID: 4},
+			6: {NUMANodeID: 1, SocketID: 1, DieID: 1, ClusterID: 4, CoreID: 4, UncoreCacheID: 4},
+			7: {NUMANodeID: 1, SocketID: 1, DieID: 1, ClusterID: 4, CoreID: 5, UncoreCacheID: 4},
+		},
+	}
+	if !reflect.DeepEqual(topo, want) {
+		t.Errorf("discoverFromSysFS() = %v, want %v", topo, want)
+	}
+}
+
+func Test_discoverFromSysFSNoCacheNoNUMA(t *testing.T) {
+	// A minimal tree without cache and NUMA enumeration: the uncore caches
+	// fall back to the sockets and every CPU lands on NUMA node 0.
+	devicesDir := t.TempDir()
+	cpus := map[int]sysFSCPU{
+		0: {socketID: 0, coreID: 0},
+		1: {socketID: 0, coreID: 1},
+		2: {socketID: 0, coreID: 0},
+		3: {socketID: 0, coreID: 1},
+	}
+	writeFakeSysFS(t, devicesDir, cpus, "0-3", nil)
+
+	topo, err := discoverFromSysFS(devicesDir, &cadvisorapi.MachineInfo{NumCores: 4, NumSockets: 1})
+	if err != nil {
+		t.Fatalf("discoverFromSysFS() failed: %v", err)
+	}
+	if topo.NumNUMANodes != 1 || topo.CPUDetails.NUMANodes().List()[0] != 0 {
+		t.Errorf("expected a single NUMA node 0, got %v", topo)
+	}
+	if topo.CPUDetails.UncoreCaches().Size() != 1 {
+		t.Errorf("expected a single uncore cache, got %v", topo)
+	}
+}
+
+func Test_discoverFromSysFSCrossValidation(t *testing.T) {
+	devicesDir := t.TempDir()
+	cpus := map[int]sysFSCPU{
+		0: {socketID: 0, coreID: 0},
+		1: {socketID: 0, coreID: 0},
+	}
+	writeFakeSysFS(t, devicesDir, cpus, "0-1", map[int]string{0: "0-1"})
+
+	tests := []struct {
+		name        string
+		machineInfo *cadvisorapi.MachineInfo
+		wantErr     string
+	}{
+		{
+			name:        "matching counts",
+			machineInfo: &cadvisorapi.MachineInfo{NumCores: 2, NumSockets: 1},
+		},
+		{
+			name:        "no cadvisor data",
+			machineInfo: nil,
+		},
+		{
+			name:        "CPU count mismatch",
+			machineInfo: &cadvisorapi.MachineInfo{NumCores: 4, NumSockets: 1},
+			wantErr:     "sysfs reports 2 CPUs but cadvisor reports 4",
+		},
+		{
+			name:        "socket count mismatch",
+			machineInfo: &cadvisorapi.MachineInfo{NumCores: 2, NumSockets: 2},
+			wantErr:     "sysfs reports 1 sockets but cadvisor reports 2",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := discoverFromSysFS(devicesDir, tc.machineInfo)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("discoverFromSysFS() failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package topology
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"strings"
 
 	cadvisorapi "github.com/google/cadvisor/info/v1"
 	"k8s.io/klog/v2"
@@ -35,17 +37,35 @@ type CPUDetails map[int]CPUInfo
 // CPU  - logical CPU, cadvisor - thread
 // Core - physical CPU, cadvisor - Core
 // Socket - socket, cadvisor - Socket
+// Die - CPU die, not reported by cadvisor, read from sysfs
 // NUMA Node - NUMA cell, cadvisor - Node
 type CPUTopology struct {
 	NumCPUs      int
 	NumCores     int
 	NumSockets   int
+	NumDies      int
 	NumNUMANodes int
 	CPUDetails   CPUDetails
 }
 
+// Fingerprint returns a stable hash of the topology. Two discoveries yield
+// the same fingerprint if and only if they expose the same logical CPUs with
+// the same NUMA node, socket, core and uncore cache placement.
+func (topo *CPUTopology) Fingerprint() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "cpus:%d cores:%d sockets:%d numanodes:%d", topo.NumCPUs, topo.NumCores, topo.NumSockets, topo.NumNUMANodes)
+	// Walk the CPUs in ID order so the rendering is deterministic.
+	for _, cpu := range topo.CPUDetails.CPUs().List() {
+		info := topo.CPUDetails[cpu]
+		fmt.Fprintf(&sb, " %d:%d/%d/%d/%d", cpu, info.NUMANodeID, info.SocketID, info.CoreID, info.UncoreCacheID)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(sb.String())))
+}
+
 // CPUsPerCore returns the number of logical CPUs are associated with
-// each core.
+// each core. On machines where SMT is disabled on a subset of cores the
+// cores differ in width and this is only an average; use ThreadsPerCore
+// for the exact count of a specific core.
 func (topo *CPUTopology) CPUsPerCore() int {
 	if topo.NumCores == 0 {
 		return 0
@@ -53,6 +73,79 @@ func (topo *CPUTopology) CPUsPerCore() int {
 	return topo.NumCPUs / topo.NumCores
 }
 
+// ThreadsPerCore returns the number of logical CPUs backed by the given
+// physical core, or 0 when the core is unknown.
+func (topo *CPUTopology) ThreadsPerCore(coreID int) int {
+	return topo.CPUDetails.CPUsInCores(coreID).Size()
+}
+
+// MaxThreadsPerCore returns the largest number of logical CPUs backed by any
+// single physical core. Equal to CPUsPerCore on machines with uniform SMT.
+func (topo *CPUTopology) MaxThreadsPerCore() int {
+	maxThreads := 0
+	for _, core := range topo.CPUDetails.Cores().List() {
+		if threads := topo.ThreadsPerCore(core); threads > maxThreads {
+			maxThreads = threads
+		}
+	}
+	return maxThreads
+}
+
+// HasHeterogeneousSMT returns whether the physical cores differ in the
+// number of threads they expose, as happens when SMT is disabled on only a
+// subset of cores.
+func (topo *CPUTopology) HasHeterogeneousSMT() bool {
+	threads := -1
+	for _, core := range topo.CPUDetails.Cores().List() {
+		if threads != -1 && threads != topo.ThreadsPerCore(core) {
+			return true
+		}
+		threads = topo.ThreadsPerCore(core)
+	}
+	return false
+}
+
+// SMTAlignableCPUCount returns whether a request for numCPUs logical CPUs can
+// be decomposed into whole physical cores on this machine. With uniform SMT
+// this is the usual multiple-of-threads-per-core check; when SMT is disabled
+// on a subset of cores, the narrower cores can absorb what would otherwise be
+// a misaligned remainder, so the count is checked against the actual core
+// inventory instead.
+func (topo *CPUTopology) SMTAlignableCPUCount(numCPUs int) bool {
+	return topo.CPUDetails.SMTAlignableCPUCount(numCPUs)
+}
+
+// SMTAlignableCPUCount returns whether some combination of the whole cores in
+// this CPUDetails adds up to exactly numCPUs logical CPUs. See the method of
+// the same name on CPUTopology; callers interested in what can be carved out
+// right now rather than in the machine inventory pass a CPUDetails restricted
+// to the free CPUs.
+func (d CPUDetails) SMTAlignableCPUCount(numCPUs int) bool {
+	coresByThreads := map[int]int{}
+	for _, core := range d.Cores().List() {
+		coresByThreads[d.CPUsInCores(core).Size()]++
+	}
+	if len(coresByThreads) == 1 {
+		for threads := range coresByThreads {
+			return numCPUs%threads == 0
+		}
+	}
+	// Bounded subset sum over the core inventory: reachable[n] is true when
+	// some combination of whole cores adds up to exactly n logical CPUs.
+	reachable := make([]bool, numCPUs+1)
+	reachable[0] = true
+	for threads, count := range coresByThreads {
+		for i := 0; i < count; i++ {
+			for want := numCPUs; want >= threads; want-- {
+				if reachable[want-threads] {
+					reachable[want] = true
+				}
+			}
+		}
+	}
+	return reachable[numCPUs]
+}
+
 // CPUsPerSocket returns the number of logical CPUs are associated with
 // each socket.
 func (topo *CPUTopology) CPUsPerSocket() int {
@@ -90,11 +183,17 @@ func (topo *CPUTopology) CPUNUMANodeID(cpu int) (int, error) {
 	return info.NUMANodeID, nil
 }
 
-// CPUInfo contains the NUMA, socket, and core IDs associated with a CPU.
+// CPUInfo contains the NUMA, socket, die, uncore cache and core IDs
+// associated with a CPU, together with the type of the backing core on
+// hybrid CPUs.
 type CPUInfo struct {
-	NUMANodeID int
-	SocketID   int
-	CoreID     int
+	NUMANodeID    int
+	SocketID      int
+	DieID         int
+	ClusterID     int
+	CoreID        int
+	UncoreCacheID int
+	CoreType      CoreType
 }
 
 // KeepOnly returns a new CPUDetails object with only the supplied cpus.
@@ -208,6 +307,104 @@ func (d CPUDetails) CoresInSockets(ids ...int) cpuset.CPUSet {
 	return cpuset.New(coreIDs...)
 }
 
+// UncoreCaches returns all of the uncore cache IDs associated with the CPUs
+// in this CPUDetails.
+func (d CPUDetails) UncoreCaches() cpuset.CPUSet {
+	var cacheIDs []int
+	for _, info := range d {
+		cacheIDs = append(cacheIDs, info.UncoreCacheID)
+	}
+	return cpuset.New(cacheIDs...)
+}
+
+// CPUsInUncoreCaches returns all of the logical CPU IDs associated with the
+// given uncore cache IDs in this CPUDetails.
+func (d CPUDetails) CPUsInUncoreCaches(ids ...int) cpuset.CPUSet {
+	var cpuIDs []int
+	for _, id := range ids {
+		for cpu, info := range d {
+			if info.UncoreCacheID == id {
+				cpuIDs = append(cpuIDs, cpu)
+			}
+		}
+	}
+	return cpuset.New(cpuIDs...)
+}
+
+// Clusters returns all of the cluster IDs associated with the CPUs in this
+// CPUDetails.
+func (d CPUDetails) Clusters() cpuset.CPUSet {
+	var clusterIDs []int
+	for _, info := range d {
+		clusterIDs = append(clusterIDs, info.ClusterID)
+	}
+	return cpuset.New(clusterIDs...)
+}
+
+// CPUsInClusters returns all of the logical CPU IDs associated with the
+// given cluster IDs in this CPUDetails.
+func (d CPUDetails) CPUsInClusters(ids ...int) cpuset.CPUSet {
+	var cpuIDs []int
+	for _, id := range ids {
+		for cpu, info := range d {
+			if info.ClusterID == id {
+				cpuIDs = append(cpuIDs, cpu)
+			}
+		}
+	}
+	return cpuset.New(cpuIDs...)
+}
+
+// Dies returns all of the die IDs associated with the CPUs in this
+// CPUDetails.
+func (d CPUDetails) Dies() cpuset.CPUSet {
+	var dieIDs []int
+	for _, info := range d {
+		dieIDs = append(dieIDs, info.DieID)
+	}
+	return cpuset.New(dieIDs...)
+}
+
+// CPUsInDies returns all of the logical CPU IDs associated with the given
+// die IDs in this CPUDetails.
+func (d CPUDetails) CPUsInDies(ids ...int) cpuset.CPUSet {
+	var cpuIDs []int
+	for _, id := range ids {
+		for cpu, info := range d {
+			if info.DieID == id {
+				cpuIDs = append(cpuIDs, cpu)
+			}
+		}
+	}
+	return cpuset.New(cpuIDs...)
+}
+
+// NUMANodesInDies returns all of the logical NUMANode IDs associated with
+// the given die IDs in this CPUDetails.
+func (d CPUDetails) NUMANodesInDies(ids ...int) cpuset.CPUSet {
+	var numaNodeIDs []int
+	for _, id := range ids {
+		for _, info := range d {
+			if info.DieID == id {
+				numaNodeIDs = append(numaNodeIDs, info.NUMANodeID)
+			}
+		}
+	}
+	return cpuset.New(numaNodeIDs...)
+}
+
+// CPUsWithCoreType returns all of the logical CPU IDs with the given core
+// type in this CPUDetails.
+func (d CPUDetails) CPUsWithCoreType(coreType CoreType) cpuset.CPUSet {
+	var cpuIDs []int
+	for cpu, info := range d {
+		if info.CoreType == coreType {
+			cpuIDs = append(cpuIDs, cpu)
+		}
+	}
+	return cpuset.New(cpuIDs...)
+}
+
 // CPUs returns all of the logical CPU IDs in this CPUDetails.
 func (d CPUDetails) CPUs() cpuset.CPUSet {
 	var cpuIDs []int
@@ -253,6 +450,9 @@ func Discover(machineInfo *cadvisorapi.MachineInfo) (*CPUTopology, error) {
 
 	CPUDetails := CPUDetails{}
 	numPhysicalCores := 0
+	coreTypes := readCoreTypes(sysDevicesDir)
+	dieIDs := dieIDsByCPU(sysDevicesDir, cpuSockets(machineInfo.Topology))
+	clusterIDs := clusterIDsByCPU(sysDevicesDir, cpuSockets(machineInfo.Topology))
 
 	for _, node := range machineInfo.Topology {
 		numPhysicalCores += len(node.Cores)
@@ -260,9 +460,13 @@ func Discover(machineInfo *cadvisorapi.MachineInfo) (*CPUTopology, error) {
 			if coreID, err := getUniqueCoreID(core.Threads); err == nil {
 				for _, cpu := range core.Threads {
 					CPUDetails[cpu] = CPUInfo{
-						CoreID:     coreID,
-						SocketID:   core.SocketID,
-						NUMANodeID: node.Id,
+						CoreID:        coreID,
+						SocketID:      core.SocketID,
+						DieID:         cpuDieID(dieIDs, cpu, core.SocketID),
+						ClusterID:     cpuClusterID(clusterIDs, cpu, getUncoreCacheID(core)),
+						NUMANodeID:    node.Id,
+						UncoreCacheID: getUncoreCacheID(core),
+						CoreType:      cpuCoreType(coreTypes, cpu),
 					}
 				}
 			} else {
@@ -275,6 +479,7 @@ func Discover(machineInfo *cadvisorapi.MachineInfo) (*CPUTopology, error) {
 	return &CPUTopology{
 		NumCPUs:      machineInfo.NumCores,
 		NumSockets:   machineInfo.NumSockets,
+		NumDies:      CPUDetails.Dies().Size(),
 		NumCores:     numPhysicalCores,
 		NumNUMANodes: CPUDetails.NUMANodes().Size(),
 		CPUDetails:   CPUDetails,
@@ -302,3 +507,27 @@ func getUniqueCoreID(threads []int) (coreID int, err error) {
 
 	return min, nil
 }
+
+// cpuCoreType returns the type of the core backing the given logical CPU, or
+// CoreTypeUnknown when the platform did not expose core types.
+func cpuCoreType(coreTypes map[CoreType]cpuset.CPUSet, cpu int) CoreType {
+	for coreType, cpus := range coreTypes {
+		if cpus.Contains(cpu) {
+			return coreType
+		}
+	}
+	return CoreTypeUnknown
+}
+
+// getUncoreCacheID returns the uncore cache (last-level cache shared by a
+// group of cores) ID the given core belongs to. Not every platform exposes
+// uncore cache information; when it is missing, fall back to the socket ID,
+// since the last-level cache is not shared beyond the socket boundary.
+func getUncoreCacheID(core cadvisorapi.Core) int {
+	if len(core.UncoreCaches) == 0 {
+		return core.SocketID
+	}
+	// Even though cadvisor returns a list, a core belongs to a single
+	// uncore cache, so any entry past the first one is ignored.
+	return core.UncoreCaches[0].Id
+}
@@ -26,6 +26,11 @@ import (
 )
 
 func Test_Discover(t *testing.T) {
+	// Point the sysfs reads at an empty directory, so the discovery only sees
+	// the cadvisor fixtures regardless of what the host exposes.
+	oldSysDevicesDir := sysDevicesDir
+	sysDevicesDir = t.TempDir()
+	defer func() { sysDevicesDir = oldSysDevicesDir }()
 
 	tests := []struct {
 		name        string
@@ -60,17 +65,18 @@ func Test_Discover(t *testing.T) {
 			want: &CPUTopology{
 				NumCPUs:      8,
 				NumSockets:   1,
+				NumDies:      1,
 				NumCores:     4,
 				NumNUMANodes: 1,
 				CPUDetails: map[int]CPUInfo{
-					0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					2: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					3: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
-					4: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					5: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					6: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					7: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+					0: {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					1: {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					2: {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					3: {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					4: {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					5: {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					6: {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					7: {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
 				},
 			},
 			wantErr: false,
@@ -143,89 +149,90 @@ func Test_Discover(t *testing.T) {
 			want: &CPUTopology{
 				NumCPUs:      80,
 				NumSockets:   2,
+				NumDies:      2,
 				NumCores:     40,
 				NumNUMANodes: 4,
 				CPUDetails: map[int]CPUInfo{
-					0:  {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					1:  {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					2:  {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					3:  {CoreID: 3, SocketID: 0, NUMANodeID: 0},
-					4:  {CoreID: 4, SocketID: 0, NUMANodeID: 0},
-					5:  {CoreID: 5, SocketID: 0, NUMANodeID: 0},
-					6:  {CoreID: 6, SocketID: 0, NUMANodeID: 0},
-					7:  {CoreID: 7, SocketID: 0, NUMANodeID: 0},
-					8:  {CoreID: 8, SocketID: 0, NUMANodeID: 0},
-					9:  {CoreID: 9, SocketID: 0, NUMANodeID: 0},
-					10: {CoreID: 10, SocketID: 0, NUMANodeID: 1},
-					11: {CoreID: 11, SocketID: 0, NUMANodeID: 1},
-					12: {CoreID: 12, SocketID: 0, NUMANodeID: 1},
-					13: {CoreID: 13, SocketID: 0, NUMANodeID: 1},
-					14: {CoreID: 14, SocketID: 0, NUMANodeID: 1},
-					15: {CoreID: 15, SocketID: 0, NUMANodeID: 1},
-					16: {CoreID: 16, SocketID: 0, NUMANodeID: 1},
-					17: {CoreID: 17, SocketID: 0, NUMANodeID: 1},
-					18: {CoreID: 18, SocketID: 0, NUMANodeID: 1},
-					19: {CoreID: 19, SocketID: 0, NUMANodeID: 1},
-					20: {CoreID: 20, SocketID: 1, NUMANodeID: 2},
-					21: {CoreID: 21, SocketID: 1, NUMANodeID: 2},
-					22: {CoreID: 22, SocketID: 1, NUMANodeID: 2},
-					23: {CoreID: 23, SocketID: 1, NUMANodeID: 2},
-					24: {CoreID: 24, SocketID: 1, NUMANodeID: 2},
-					25: {CoreID: 25, SocketID: 1, NUMANodeID: 2},
-					26: {CoreID: 26, SocketID: 1, NUMANodeID: 2},
-					27: {CoreID: 27, SocketID: 1, NUMANodeID: 2},
-					28: {CoreID: 28, SocketID: 1, NUMANodeID: 2},
-					29: {CoreID: 29, SocketID: 1, NUMANodeID: 2},
-					30: {CoreID: 30, SocketID: 1, NUMANodeID: 3},
-					31: {CoreID: 31, SocketID: 1, NUMANodeID: 3},
-					32: {CoreID: 32, SocketID: 1, NUMANodeID: 3},
-					33: {CoreID: 33, SocketID: 1, NUMANodeID: 3},
-					34: {CoreID: 34, SocketID: 1, NUMANodeID: 3},
-					35: {CoreID: 35, SocketID: 1, NUMANodeID: 3},
-					36: {CoreID: 36, SocketID: 1, NUMANodeID: 3},
-					37: {CoreID: 37, SocketID: 1, NUMANodeID: 3},
-					38: {CoreID: 38, SocketID: 1, NUMANodeID: 3},
-					39: {CoreID: 39, SocketID: 1, NUMANodeID: 3},
-					40: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					41: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					42: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					43: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
-					44: {CoreID: 4, SocketID: 0, NUMANodeID: 0},
-					45: {CoreID: 5, SocketID: 0, NUMANodeID: 0},
-					46: {CoreID: 6, SocketID: 0, NUMANodeID: 0},
-					47: {CoreID: 7, SocketID: 0, NUMANodeID: 0},
-					48: {CoreID: 8, SocketID: 0, NUMANodeID: 0},
-					49: {CoreID: 9, SocketID: 0, NUMANodeID: 0},
-					50: {CoreID: 10, SocketID: 0, NUMANodeID: 1},
-					51: {CoreID: 11, SocketID: 0, NUMANodeID: 1},
-					52: {CoreID: 12, SocketID: 0, NUMANodeID: 1},
-					53: {CoreID: 13, SocketID: 0, NUMANodeID: 1},
-					54: {CoreID: 14, SocketID: 0, NUMANodeID: 1},
-					55: {CoreID: 15, SocketID: 0, NUMANodeID: 1},
-					56: {CoreID: 16, SocketID: 0, NUMANodeID: 1},
-					57: {CoreID: 17, SocketID: 0, NUMANodeID: 1},
-					58: {CoreID: 18, SocketID: 0, NUMANodeID: 1},
-					59: {CoreID: 19, SocketID: 0, NUMANodeID: 1},
-					60: {CoreID: 20, SocketID: 1, NUMANodeID: 2},
-					61: {CoreID: 21, SocketID: 1, NUMANodeID: 2},
-					62: {CoreID: 22, SocketID: 1, NUMANodeID: 2},
-					63: {CoreID: 23, SocketID: 1, NUMANodeID: 2},
-					64: {CoreID: 24, SocketID: 1, NUMANodeID: 2},
-					65: {CoreID: 25, SocketID: 1, NUMANodeID: 2},
-					66: {CoreID: 26, SocketID: 1, NUMANodeID: 2},
-					67: {CoreID: 27, SocketID: 1, NUMANodeID: 2},
-					68: {CoreID: 28, SocketID: 1, NUMANodeID: 2},
-					69: {CoreID: 29, SocketID: 1, NUMANodeID: 2},
-					70: {CoreID: 30, SocketID: 1, NUMANodeID: 3},
-					71: {CoreID: 31, SocketID: 1, NUMANodeID: 3},
-					72: {CoreID: 32, SocketID: 1, NUMANodeID: 3},
-					73: {CoreID: 33, SocketID: 1, NUMANodeID: 3},
-					74: {CoreID: 34, SocketID: 1, NUMANodeID: 3},
-					75: {CoreID: 35, SocketID: 1, NUMANodeID: 3},
-					76: {CoreID: 36, SocketID: 1, NUMANodeID: 3},
-					77: {CoreID: 37, SocketID: 1, NUMANodeID: 3},
-					78: {CoreID: 38, SocketID: 1, NUMANodeID: 3},
-					79: {CoreID: 39, SocketID: 1, NUMANodeID: 3},
+					0:  {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					1:  {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					2:  {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					3:  {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					4:  {CoreID: 4, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					5:  {CoreID: 5, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					6:  {CoreID: 6, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					7:  {CoreID: 7, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					8:  {CoreID: 8, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					9:  {CoreID: 9, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					10: {CoreID: 10, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					11: {CoreID: 11, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					12: {CoreID: 12, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					13: {CoreID: 13, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					14: {CoreID: 14, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					15: {CoreID: 15, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					16: {CoreID: 16, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					17: {CoreID: 17, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					18: {CoreID: 18, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					19: {CoreID: 19, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					20: {CoreID: 20, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					21: {CoreID: 21, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					22: {CoreID: 22, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					23: {CoreID: 23, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					24: {CoreID: 24, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					25: {CoreID: 25, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					26: {CoreID: 26, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					27: {CoreID: 27, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					28: {CoreID: 28, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					29: {CoreID: 29, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					30: {CoreID: 30, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					31: {CoreID: 31, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					32: {CoreID: 32, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					33: {CoreID: 33, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					34: {CoreID: 34, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					35: {CoreID: 35, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					36: {CoreID: 36, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					37: {CoreID: 37, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					38: {CoreID: 38, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					39: {CoreID: 39, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					40: {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					41: {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					42: {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					43: {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					44: {CoreID: 4, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					45: {CoreID: 5, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					46: {CoreID: 6, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					47: {CoreID: 7, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					48: {CoreID: 8, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					49: {CoreID: 9, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					50: {CoreID: 10, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					51: {CoreID: 11, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					52: {CoreID: 12, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					53: {CoreID: 13, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					54: {CoreID: 14, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					55: {CoreID: 15, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					56: {CoreID: 16, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					57: {CoreID: 17, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					58: {CoreID: 18, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					59: {CoreID: 19, SocketID: 0, DieID: 0, NUMANodeID: 1, ClusterID: 0, UncoreCacheID: 0},
+					60: {CoreID: 20, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					61: {CoreID: 21, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					62: {CoreID: 22, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					63: {CoreID: 23, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					64: {CoreID: 24, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					65: {CoreID: 25, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					66: {CoreID: 26, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					67: {CoreID: 27, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					68: {CoreID: 28, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					69: {CoreID: 29, SocketID: 1, DieID: 1, NUMANodeID: 2, ClusterID: 1, UncoreCacheID: 1},
+					70: {CoreID: 30, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					71: {CoreID: 31, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					72: {CoreID: 32, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					73: {CoreID: 33, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					74: {CoreID: 34, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					75: {CoreID: 35, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					76: {CoreID: 36, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					77: {CoreID: 37, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					78: {CoreID: 38, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
+					79: {CoreID: 39, SocketID: 1, DieID: 1, NUMANodeID: 3, ClusterID: 1, UncoreCacheID: 1},
 				},
 			},
 			wantErr: false,
@@ -300,89 +307,90 @@ func Test_Discover(t *testing.T) {
 			want: &CPUTopology{
 				NumCPUs:      80,
 				NumSockets:   4,
+				NumDies:      4,
 				NumCores:     40,
 				NumNUMANodes: 2,
 				CPUDetails: map[int]CPUInfo{
-					0:  {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					1:  {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					2:  {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					3:  {CoreID: 3, SocketID: 0, NUMANodeID: 0},
-					4:  {CoreID: 4, SocketID: 0, NUMANodeID: 0},
-					5:  {CoreID: 5, SocketID: 0, NUMANodeID: 0},
-					6:  {CoreID: 6, SocketID: 0, NUMANodeID: 0},
-					7:  {CoreID: 7, SocketID: 0, NUMANodeID: 0},
-					8:  {CoreID: 8, SocketID: 0, NUMANodeID: 0},
-					9:  {CoreID: 9, SocketID: 0, NUMANodeID: 0},
-					10: {CoreID: 10, SocketID: 1, NUMANodeID: 0},
-					11: {CoreID: 11, SocketID: 1, NUMANodeID: 0},
-					12: {CoreID: 12, SocketID: 1, NUMANodeID: 0},
-					13: {CoreID: 13, SocketID: 1, NUMANodeID: 0},
-					14: {CoreID: 14, SocketID: 1, NUMANodeID: 0},
-					15: {CoreID: 15, SocketID: 1, NUMANodeID: 0},
-					16: {CoreID: 16, SocketID: 1, NUMANodeID: 0},
-					17: {CoreID: 17, SocketID: 1, NUMANodeID: 0},
-					18: {CoreID: 18, SocketID: 1, NUMANodeID: 0},
-					19: {CoreID: 19, SocketID: 1, NUMANodeID: 0},
-					20: {CoreID: 20, SocketID: 2, NUMANodeID: 1},
-					21: {CoreID: 21, SocketID: 2, NUMANodeID: 1},
-					22: {CoreID: 22, SocketID: 2, NUMANodeID: 1},
-					23: {CoreID: 23, SocketID: 2, NUMANodeID: 1},
-					24: {CoreID: 24, SocketID: 2, NUMANodeID: 1},
-					25: {CoreID: 25, SocketID: 2, NUMANodeID: 1},
-					26: {CoreID: 26, SocketID: 2, NUMANodeID: 1},
-					27: {CoreID: 27, SocketID: 2, NUMANodeID: 1},
-					28: {CoreID: 28, SocketID: 2, NUMANodeID: 1},
-					29: {CoreID: 29, SocketID: 2, NUMANodeID: 1},
-					30: {CoreID: 30, SocketID: 3, NUMANodeID: 1},
-					31: {CoreID: 31, SocketID: 3, NUMANodeID: 1},
-					32: {CoreID: 32, SocketID: 3, NUMANodeID: 1},
-					33: {CoreID: 33, SocketID: 3, NUMANodeID: 1},
-					34: {CoreID: 34, SocketID: 3, NUMANodeID: 1},
-					35: {CoreID: 35, SocketID: 3, NUMANodeID: 1},
-					36: {CoreID: 36, SocketID: 3, NUMANodeID: 1},
-					37: {CoreID: 37, SocketID: 3, NUMANodeID: 1},
-					38: {CoreID: 38, SocketID: 3, NUMANodeID: 1},
-					39: {CoreID: 39, SocketID: 3, NUMANodeID: 1},
-					40: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					41: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					42: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					43: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
-					44: {CoreID: 4, SocketID: 0, NUMANodeID: 0},
-					45: {CoreID: 5, SocketID: 0, NUMANodeID: 0},
-					46: {CoreID: 6, SocketID: 0, NUMANodeID: 0},
-					47: {CoreID: 7, SocketID: 0, NUMANodeID: 0},
-					48: {CoreID: 8, SocketID: 0, NUMANodeID: 0},
-					49: {CoreID: 9, SocketID: 0, NUMANodeID: 0},
-					50: {CoreID: 10, SocketID: 1, NUMANodeID: 0},
-					51: {CoreID: 11, SocketID: 1, NUMANodeID: 0},
-					52: {CoreID: 12, SocketID: 1, NUMANodeID: 0},
-					53: {CoreID: 13, SocketID: 1, NUMANodeID: 0},
-					54: {CoreID: 14, SocketID: 1, NUMANodeID: 0},
-					55: {CoreID: 15, SocketID: 1, NUMANodeID: 0},
-					56: {CoreID: 16, SocketID: 1, NUMANodeID: 0},
-					57: {CoreID: 17, SocketID: 1, NUMANodeID: 0},
-					58: {CoreID: 18, SocketID: 1, NUMANodeID: 0},
-					59: {CoreID: 19, SocketID: 1, NUMANodeID: 0},
-					60: {CoreID: 20, SocketID: 2, NUMANodeID: 1},
-					61: {CoreID: 21, SocketID: 2, NUMANodeID: 1},
-					62: {CoreID: 22, SocketID: 2, NUMANodeID: 1},
-					63: {CoreID: 23, SocketID: 2, NUMANodeID: 1},
-					64: {CoreID: 24, SocketID: 2, NUMANodeID: 1},
-					65: {CoreID: 25, SocketID: 2, NUMANodeID: 1},
-					66: {CoreID: 26, SocketID: 2, NUMANodeID: 1},
-					67: {CoreID: 27, SocketID: 2, NUMANodeID: 1},
-					68: {CoreID: 28, SocketID: 2, NUMANodeID: 1},
-					69: {CoreID: 29, SocketID: 2, NUMANodeID: 1},
-					70: {CoreID: 30, SocketID: 3, NUMANodeID: 1},
-					71: {CoreID: 31, SocketID: 3, NUMANodeID: 1},
-					72: {CoreID: 32, SocketID: 3, NUMANodeID: 1},
-					73: {CoreID: 33, SocketID: 3, NUMANodeID: 1},
-					74: {CoreID: 34, SocketID: 3, NUMANodeID: 1},
-					75: {CoreID: 35, SocketID: 3, NUMANodeID: 1},
-					76: {CoreID: 36, SocketID: 3, NUMANodeID: 1},
-					77: {CoreID: 37, SocketID: 3, NUMANodeID: 1},
-					78: {CoreID: 38, SocketID: 3, NUMANodeID: 1},
-					79: {CoreID: 39, SocketID: 3, NUMANodeID: 1},
+					0:  {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					1:  {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					2:  {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					3:  {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					4:  {CoreID: 4, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					5:  {CoreID: 5, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					6:  {CoreID: 6, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					7:  {CoreID: 7, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					8:  {CoreID: 8, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					9:  {CoreID: 9, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					10: {CoreID: 10, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					11: {CoreID: 11, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					12: {CoreID: 12, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					13: {CoreID: 13, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					14: {CoreID: 14, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					15: {CoreID: 15, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					16: {CoreID: 16, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					17: {CoreID: 17, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					18: {CoreID: 18, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					19: {CoreID: 19, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					20: {CoreID: 20, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					21: {CoreID: 21, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					22: {CoreID: 22, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					23: {CoreID: 23, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					24: {CoreID: 24, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					25: {CoreID: 25, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					26: {CoreID: 26, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					27: {CoreID: 27, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					28: {CoreID: 28, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					29: {CoreID: 29, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					30: {CoreID: 30, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					31: {CoreID: 31, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					32: {CoreID: 32, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					33: {CoreID: 33, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					34: {CoreID: 34, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					35: {CoreID: 35, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					36: {CoreID: 36, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					37: {CoreID: 37, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					38: {CoreID: 38, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					39: {CoreID: 39, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					40: {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					41: {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					42: {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					43: {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					44: {CoreID: 4, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					45: {CoreID: 5, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					46: {CoreID: 6, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					47: {CoreID: 7, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					48: {CoreID: 8, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					49: {CoreID: 9, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					50: {CoreID: 10, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					51: {CoreID: 11, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					52: {CoreID: 12, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					53: {CoreID: 13, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					54: {CoreID: 14, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					55: {CoreID: 15, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					56: {CoreID: 16, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					57: {CoreID: 17, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					58: {CoreID: 18, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					59: {CoreID: 19, SocketID: 1, DieID: 1, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					60: {CoreID: 20, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					61: {CoreID: 21, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					62: {CoreID: 22, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					63: {CoreID: 23, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					64: {CoreID: 24, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					65: {CoreID: 25, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					66: {CoreID: 26, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					67: {CoreID: 27, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					68: {CoreID: 28, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					69: {CoreID: 29, SocketID: 2, DieID: 2, NUMANodeID: 1, ClusterID: 2, UncoreCacheID: 2},
+					70: {CoreID: 30, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					71: {CoreID: 31, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					72: {CoreID: 32, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					73: {CoreID: 33, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					74: {CoreID: 34, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					75: {CoreID: 35, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					76: {CoreID: 36, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					77: {CoreID: 37, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					78: {CoreID: 38, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
+					79: {CoreID: 39, SocketID: 3, DieID: 3, NUMANodeID: 1, ClusterID: 3, UncoreCacheID: 3},
 				},
 			},
 			wantErr: false,
@@ -410,13 +418,14 @@ func Test_Discover(t *testing.T) {
 			want: &CPUTopology{
 				NumCPUs:      4,
 				NumSockets:   2,
+				NumDies:      2,
 				NumCores:     4,
 				NumNUMANodes: 2,
 				CPUDetails: map[int]CPUInfo{
-					0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					1: {CoreID: 1, SocketID: 1, NUMANodeID: 1},
-					2: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					3: {CoreID: 3, SocketID: 1, NUMANodeID: 1},
+					0: {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					1: {CoreID: 1, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
+					2: {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					3: {CoreID: 3, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
 				},
 			},
 			wantErr: false,
@@ -446,21 +455,59 @@ func Test_Discover(t *testing.T) {
 			want: &CPUTopology{
 				NumCPUs:      12,
 				NumSockets:   2,
+				NumDies:      2,
 				NumCores:     6,
 				NumNUMANodes: 2,
 				CPUDetails: map[int]CPUInfo{
-					0:  {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					1:  {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					2:  {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					3:  {CoreID: 3, SocketID: 1, NUMANodeID: 1},
-					4:  {CoreID: 4, SocketID: 1, NUMANodeID: 1},
-					5:  {CoreID: 5, SocketID: 1, NUMANodeID: 1},
-					6:  {CoreID: 0, SocketID: 0, NUMANodeID: 0},
-					7:  {CoreID: 1, SocketID: 0, NUMANodeID: 0},
-					8:  {CoreID: 2, SocketID: 0, NUMANodeID: 0},
-					9:  {CoreID: 3, SocketID: 1, NUMANodeID: 1},
-					10: {CoreID: 4, SocketID: 1, NUMANodeID: 1},
-					11: {CoreID: 5, SocketID: 1, NUMANodeID: 1},
+					0:  {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					1:  {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					2:  {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					3:  {CoreID: 3, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
+					4:  {CoreID: 4, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
+					5:  {CoreID: 5, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
+					6:  {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					7:  {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					8:  {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					9:  {CoreID: 3, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
+					10: {CoreID: 4, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
+					11: {CoreID: 5, SocketID: 1, DieID: 1, NUMANodeID: 1, ClusterID: 1, UncoreCacheID: 1},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			// a single socket with the cores split across two uncore (last
+			// level) caches, like on several AMD EPYC SKUs
+			name: "OneSocketTwoUncoreCachesHT",
+			machineInfo: cadvisorapi.MachineInfo{
+				NumCores:   8,
+				NumSockets: 1,
+				Topology: []cadvisorapi.Node{
+					{Id: 0,
+						Cores: []cadvisorapi.Core{
+							{SocketID: 0, Id: 0, Threads: []int{0, 4}, UncoreCaches: []cadvisorapi.Cache{{Id: 0}}},
+							{SocketID: 0, Id: 1, Threads: []int{1, 5}, UncoreCaches: []cadvisorapi.Cache{{Id: 0}}},
+							{SocketID: 0, Id: 2, Threads: []int{2, 6}, UncoreCaches: []cadvisorapi.Cache{{Id: 1}}},
+							{SocketID: 0, Id: 3, Threads: []int{3, 7}, UncoreCaches: []cadvisorapi.Cache{{Id: 1}}},
+						},
+					},
+				},
+			},
+			want: &CPUTopology{
+				NumCPUs:      8,
+				NumSockets:   1,
+				NumDies:      1,
+				NumCores:     4,
+				NumNUMANodes: 1,
+				CPUDetails: map[int]CPUInfo{
+					0: {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					1: {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					2: {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					3: {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					4: {CoreID: 0, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					5: {CoreID: 1, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 0, UncoreCacheID: 0},
+					6: {CoreID: 2, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
+					7: {CoreID: 3, SocketID: 0, DieID: 0, NUMANodeID: 0, ClusterID: 1, UncoreCacheID: 1},
 				},
 			},
 			wantErr: false,
@@ -1100,3 +1147,135 @@ func TestCPUNUMANodeID(t *testing.T) {
 		})
 	}
 }
+
+func TestFingerprint(t *testing.T) {
+
+	topo := &CPUTopology{
+		NumCPUs:      4,
+		NumCores:     2,
+		NumSockets:   1,
+		NumNUMANodes: 1,
+		CPUDetails: map[int]CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			2: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			3: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		},
+	}
+
+	same := &CPUTopology{
+		NumCPUs:      4,
+		NumCores:     2,
+		NumSockets:   1,
+		NumNUMANodes: 1,
+		CPUDetails: map[int]CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			2: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			3: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		},
+	}
+
+	if topo.Fingerprint() != same.Fingerprint() {
+		t.Errorf("identical topologies produced different fingerprints")
+	}
+
+	// Moving a CPU to another core must change the fingerprint even though
+	// the counts stay identical.
+	moved := &CPUTopology{
+		NumCPUs:      4,
+		NumCores:     2,
+		NumSockets:   1,
+		NumNUMANodes: 1,
+		CPUDetails: map[int]CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			2: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			3: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+		},
+	}
+
+	if topo.Fingerprint() == moved.Fingerprint() {
+		t.Errorf("different topologies produced the same fingerprint")
+	}
+}
+
+func TestHeterogeneousSMT(t *testing.T) {
+	// Cores 0 (CPUs 0,4) and 1 (CPUs 1,5) have SMT enabled, cores 2 and 3
+	// expose a single thread each.
+	partial := &CPUTopology{
+		NumCPUs:      6,
+		NumCores:     4,
+		NumSockets:   1,
+		NumNUMANodes: 1,
+		CPUDetails: map[int]CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			2: {CoreID: 2, SocketID: 0, NUMANodeID: 0},
+			3: {CoreID: 3, SocketID: 0, NUMANodeID: 0},
+			4: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			5: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		},
+	}
+	uniform := &CPUTopology{
+		NumCPUs:      4,
+		NumCores:     2,
+		NumSockets:   1,
+		NumNUMANodes: 1,
+		CPUDetails: map[int]CPUInfo{
+			0: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			1: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+			2: {CoreID: 0, SocketID: 0, NUMANodeID: 0},
+			3: {CoreID: 1, SocketID: 0, NUMANodeID: 0},
+		},
+	}
+
+	if !partial.HasHeterogeneousSMT() {
+		t.Errorf("expected the partial SMT topology to be heterogeneous")
+	}
+	if uniform.HasHeterogeneousSMT() {
+		t.Errorf("expected the uniform SMT topology to be homogeneous")
+	}
+
+	if threads := partial.ThreadsPerCore(0); threads != 2 {
+		t.Errorf("ThreadsPerCore(0) = %d, want 2", threads)
+	}
+	if threads := partial.ThreadsPerCore(3); threads != 1 {
+		t.Errorf("ThreadsPerCore(3) = %d, want 1", threads)
+	}
+	if threads := partial.MaxThreadsPerCore(); threads != 2 {
+		t.Errorf("MaxThreadsPerCore() = %d, want 2", threads)
+	}
+	// The average rounds down and no longer tells the whole story.
+	if threads := partial.CPUsPerCore(); threads != 1 {
+		t.Errorf("CPUsPerCore() = %d, want 1", threads)
+	}
+
+	// Any count up to the total decomposes into whole cores on the partial
+	// SMT topology: the single-thread cores absorb the odd remainders.
+	for numCPUs := 0; numCPUs <= 6; numCPUs++ {
+		if !partial.SMTAlignableCPUCount(numCPUs) {
+			t.Errorf("SMTAlignableCPUCount(%d) = false on the partial SMT topology, want true", numCPUs)
+		}
+	}
+	if partial.SMTAlignableCPUCount(7) {
+		t.Errorf("SMTAlignableCPUCount(7) = true, want false: the request exceeds the core inventory")
+	}
+	// With uniform SMT only multiples of the thread count are alignable.
+	for numCPUs, want := range map[int]bool{1: false, 2: true, 3: false, 4: true} {
+		if got := uniform.SMTAlignableCPUCount(numCPUs); got != want {
+			t.Errorf("SMTAlignableCPUCount(%d) = %v on the uniform topology, want %v", numCPUs, got, want)
+		}
+	}
+
+	// Restricting the details to the free CPUs turns the same check into a
+	// what-fits-right-now check: with the narrow cores busy, only even counts
+	// can be carved out of the remaining SMT cores.
+	free := partial.CPUDetails.KeepOnly(cpuset.New(0, 1, 4, 5))
+	if free.SMTAlignableCPUCount(3) {
+		t.Errorf("SMTAlignableCPUCount(3) = true on the free subset, want false")
+	}
+	if !free.SMTAlignableCPUCount(4) {
+		t.Errorf("SMTAlignableCPUCount(4) = false on the free subset, want true")
+	}
+}
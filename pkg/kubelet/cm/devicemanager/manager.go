@@ -23,6 +23,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,7 +35,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	errorsutil "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/record"
 	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+	"k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/errors"
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
@@ -41,6 +47,7 @@ import (
 	plugin "k8s.io/kubernetes/pkg/kubelet/cm/devicemanager/plugin/v1beta1"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
 	"k8s.io/kubernetes/pkg/kubelet/config"
+	"k8s.io/kubernetes/pkg/kubelet/events"
 	"k8s.io/kubernetes/pkg/kubelet/lifecycle"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/pluginmanager/cache"
@@ -50,6 +57,12 @@ import (
 
 const nodeWithoutTopology = -1
 
+// checkpointPruningPeriod is the interval between two garbage collections of
+// stale checkpoint entries. Long-lived nodes accumulate entries of pods long
+// gone and of plugins long unregistered; left alone they slow down the
+// checkpoint restore and pollute the podresources API output.
+const checkpointPruningPeriod = 5 * time.Minute
+
 // ActivePodsFunc is a function that returns a list of pods to reconcile.
 type ActivePodsFunc func() []*v1.Pod
 
@@ -97,6 +110,28 @@ type ManagerImpl struct {
 	// init containers.
 	devicesToReuse PodReusableDevices
 
+	// allocationStrategies maps resource names to the strategy ("pack" or
+	// "spread") used to pick among equivalent devices when a container
+	// requests more than one. Resources without an entry keep the
+	// historical, unspecified ordering.
+	allocationStrategies map[string]string
+
+	// sharingCounts maps resource names to the number of containers which may
+	// share a single device of that resource. The kubelet exposes each device
+	// as that many allocatable slots, all inheriting the health and topology
+	// of the physical device, while the plugin keeps seeing only the physical
+	// device IDs. Resources without an entry are not shared.
+	sharingCounts map[string]int
+
+	// recorder and nodeRef are used to surface node events when a device
+	// plugin misbehaves. Both may be nil, in which case no events are emitted.
+	recorder record.EventRecorder
+	nodeRef  *v1.ObjectReference
+
+	// slowAllocationThreshold is the Allocate RPC latency above which a node
+	// event is emitted, pointing at the offending plugin. Zero disables the alert.
+	slowAllocationThreshold time.Duration
+
 	// pendingAdmissionPod contain the pod during the admission phase
 	pendingAdmissionPod *v1.Pod
 
@@ -123,18 +158,52 @@ type PodReusableDevices map[string]map[string]sets.Set[string]
 func (s *sourcesReadyStub) AddSource(source string) {}
 func (s *sourcesReadyStub) AllReady() bool          { return true }
 
+// Allocation strategies to pick among equivalent devices of a resource when
+// a container requests more than one of them.
+const (
+	// allocationStrategyPack packs the allocated devices on as few NUMA
+	// nodes as possible, for locality.
+	allocationStrategyPack = "pack"
+	// allocationStrategySpread spreads the allocated devices across the
+	// NUMA nodes they are attached to, for bandwidth.
+	allocationStrategySpread = "spread"
+)
+
+// sharedDeviceIDSeparator splits the ID the kubelet tracks for a slot of a
+// shareable device into the physical device ID, known to the plugin, and the
+// slot ordinal, known only to the kubelet.
+const sharedDeviceIDSeparator = "#"
+
 // NewManagerImpl creates a new manager.
-func NewManagerImpl(topology []cadvisorapi.Node, topologyAffinityStore topologymanager.Store) (*ManagerImpl, error) {
+func NewManagerImpl(topology []cadvisorapi.Node, topologyAffinityStore topologymanager.Store, allocationStrategies map[string]string, sharingCounts map[string]string, recorder record.EventRecorder, nodeRef *v1.ObjectReference, slowAllocationThreshold time.Duration) (*ManagerImpl, error) {
 	socketPath := pluginapi.KubeletSocket
 	if runtime.GOOS == "windows" {
 		socketPath = os.Getenv("SYSTEMDRIVE") + pluginapi.KubeletSocketWindows
 	}
-	return newManagerImpl(socketPath, topology, topologyAffinityStore)
+	return newManagerImpl(socketPath, topology, topologyAffinityStore, allocationStrategies, sharingCounts, recorder, nodeRef, slowAllocationThreshold)
 }
 
-func newManagerImpl(socketPath string, topology []cadvisorapi.Node, topologyAffinityStore topologymanager.Store) (*ManagerImpl, error) {
+func newManagerImpl(socketPath string, topology []cadvisorapi.Node, topologyAffinityStore topologymanager.Store, allocationStrategies map[string]string, sharingCounts map[string]string, recorder record.EventRecorder, nodeRef *v1.ObjectReference, slowAllocationThreshold time.Duration) (*ManagerImpl, error) {
 	klog.V(2).InfoS("Creating Device Plugin manager", "path", socketPath)
 
+	for resource, strategy := range allocationStrategies {
+		if strategy != allocationStrategyPack && strategy != allocationStrategySpread {
+			return nil, fmt.Errorf("unsupported allocation strategy %q for resource %q, expected %q or %q", strategy, resource, allocationStrategyPack, allocationStrategySpread)
+		}
+	}
+
+	shares := make(map[string]int)
+	for resource, value := range sharingCounts {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("invalid sharing count %q for resource %q, expected a positive integer", value, resource)
+		}
+		// A count of 1 means no sharing, same as no entry at all.
+		if count > 1 {
+			shares[resource] = count
+		}
+	}
+
 	var numaNodes []int
 	for _, node := range topology {
 		numaNodes = append(numaNodes, node.Id)
@@ -151,6 +220,12 @@ func newManagerImpl(socketPath string, topology []cadvisorapi.Node, topologyAffi
 		numaNodes:             numaNodes,
 		topologyAffinityStore: topologyAffinityStore,
 		devicesToReuse:        make(PodReusableDevices),
+		allocationStrategies:  allocationStrategies,
+		sharingCounts:         shares,
+
+		recorder:                recorder,
+		nodeRef:                 nodeRef,
+		slowAllocationThreshold: slowAllocationThreshold,
 	}
 
 	server, err := plugin.NewServer(socketPath, manager, manager)
@@ -189,7 +264,7 @@ func (m *ManagerImpl) CleanupPluginDirectory(dir string) error {
 	var errs []error
 	for _, name := range names {
 		filePath := filepath.Join(dir, name)
-		if filePath == m.checkpointFile() {
+		if filePath == m.CheckpointFile() {
 			continue
 		}
 		// TODO: Until the bug - https://github.com/golang/go/issues/33357 is fixed, os.stat wouldn't return the
@@ -250,45 +325,133 @@ func (m *ManagerImpl) PluginDisconnected(resourceName string) {
 // is captured. Also, registered device and device to container allocation
 // information is checkpointed to the disk.
 func (m *ManagerImpl) PluginListAndWatchReceiver(resourceName string, resp *pluginapi.ListAndWatchResponse) {
+	startTime := time.Now()
 	var devices []pluginapi.Device
 	for _, d := range resp.Devices {
 		devices = append(devices, *d)
 	}
 	m.genericDeviceUpdateCallback(resourceName, devices)
+	metrics.DevicePluginListAndWatchUpdateProcessDuration.WithLabelValues(resourceName).Observe(metrics.SinceInSeconds(startTime))
 }
 
 func (m *ManagerImpl) genericDeviceUpdateCallback(resourceName string, devices []pluginapi.Device) {
 	healthyCount := 0
+	shares := m.sharesPerDevice(resourceName)
 	m.mutex.Lock()
+	// Remember the allocatable slot count before the update, so changes can be
+	// surfaced. A resource seen for the first time is plugin registration, not
+	// a capacity change.
+	oldHealthy, seen := 0, false
+	if devices, ok := m.healthyDevices[resourceName]; ok {
+		oldHealthy, seen = devices.Len(), true
+	}
 	m.healthyDevices[resourceName] = sets.New[string]()
 	m.unhealthyDevices[resourceName] = sets.New[string]()
 	m.allDevices[resourceName] = make(map[string]pluginapi.Device)
 	for _, dev := range devices {
-		m.allDevices[resourceName][dev.ID] = dev
 		if dev.Health == pluginapi.Healthy {
-			m.healthyDevices[resourceName].Insert(dev.ID)
 			healthyCount++
-		} else {
-			m.unhealthyDevices[resourceName].Insert(dev.ID)
+		}
+		// A shareable device is tracked as multiple allocatable slots, all
+		// inheriting the health and the topology of the physical device.
+		for slot := 0; slot < shares; slot++ {
+			slotDev := dev
+			if shares > 1 {
+				slotDev.ID = sharedDeviceID(dev.ID, slot)
+			}
+			m.allDevices[resourceName][slotDev.ID] = slotDev
+			if slotDev.Health == pluginapi.Healthy {
+				m.healthyDevices[resourceName].Insert(slotDev.ID)
+			} else {
+				m.unhealthyDevices[resourceName].Insert(slotDev.ID)
+			}
 		}
 	}
+	newHealthy := m.healthyDevices[resourceName].Len()
 	m.mutex.Unlock()
+
+	metrics.DevicePluginAllocatableDevices.WithLabelValues(resourceName).Set(float64(newHealthy))
+	if seen && newHealthy != oldHealthy {
+		// A shrinking healthy count is a capacity loss the cluster should hear
+		// about, not just a node status diff.
+		eventType := v1.EventTypeNormal
+		if newHealthy < oldHealthy {
+			eventType = v1.EventTypeWarning
+		}
+		if m.recorder != nil {
+			m.recorder.Eventf(m.nodeRef, eventType, events.DeviceAllocatableChanged,
+				"Allocatable devices of resource %s changed from %d to %d", resourceName, oldHealthy, newHealthy)
+		}
+	}
+
 	if err := m.writeCheckpoint(); err != nil {
 		klog.ErrorS(err, "Writing checkpoint encountered")
 	}
 	klog.V(2).InfoS("Processed device updates for resource", "resourceName", resourceName, "totalCount", len(devices), "healthyCount", healthyCount)
 }
 
+// sharesPerDevice returns how many containers may share a single device of
+// the given resource, 1 meaning no sharing.
+func (m *ManagerImpl) sharesPerDevice(resource string) int {
+	if count, ok := m.sharingCounts[resource]; ok {
+		return count
+	}
+	return 1
+}
+
+// sharedDeviceID returns the ID under which the kubelet tracks the given slot
+// of a shareable device.
+func sharedDeviceID(deviceID string, slot int) string {
+	return fmt.Sprintf("%s%s%d", deviceID, sharedDeviceIDSeparator, slot)
+}
+
+// physicalDeviceID returns the plugin-known device ID backing the given slot
+// ID. IDs of non-shared devices pass through unmodified.
+func physicalDeviceID(deviceID string) string {
+	if idx := strings.LastIndex(deviceID, sharedDeviceIDSeparator); idx != -1 {
+		return deviceID[:idx]
+	}
+	return deviceID
+}
+
+// physicalDeviceIDs returns the physical device IDs backing the given slot
+// IDs, deduplicating slots of the same device.
+func physicalDeviceIDs(deviceIDs []string) []string {
+	seen := sets.New[string]()
+	var physical []string
+	for _, id := range deviceIDs {
+		phys := physicalDeviceID(id)
+		if seen.Has(phys) {
+			continue
+		}
+		seen.Insert(phys)
+		physical = append(physical, phys)
+	}
+	return physical
+}
+
 // GetWatcherHandler returns the plugin handler
 func (m *ManagerImpl) GetWatcherHandler() cache.PluginHandler {
 	return m.server
 }
 
-// checkpointFile returns device plugin checkpoint file path.
-func (m *ManagerImpl) checkpointFile() string {
+// CheckpointFile returns device plugin checkpoint file path.
+func (m *ManagerImpl) CheckpointFile() string {
 	return filepath.Join(m.checkpointdir, kubeletDeviceManagerCheckpoint)
 }
 
+// StateSnapshot returns the current device assignments in the same shape the
+// checkpoint uses.
+func (m *ManagerImpl) StateSnapshot() interface{} {
+	return m.podDevices.toCheckpointData()
+}
+
+// RemoveContainer is a no-op: devices are bound to the owning pod and freed
+// by UpdateAllocatedDevices once the pod terminates.
+func (m *ManagerImpl) RemoveContainer(containerID string) error {
+	return nil
+}
+
 // Start starts the Device Plugin Manager and start initialization of
 // podDevices and allocatedDevices information from checkpointed state and
 // starts device plugin registration service.
@@ -306,9 +469,75 @@ func (m *ManagerImpl) Start(activePods ActivePodsFunc, sourcesReady config.Sourc
 		klog.InfoS("Continue after failing to read checkpoint file. Device allocation info may NOT be up-to-date", "err", err)
 	}
 
+	go wait.Until(m.pruneCheckpoint, checkpointPruningPeriod, wait.NeverStop)
+
 	return m.server.Start()
 }
 
+// pruneCheckpoint garbage collects the checkpoint entries which can no longer
+// influence any allocation: entries of pods which are gone and entries
+// referring to resources whose plugin went away past the grace period, so the
+// resource is not registered anymore. Runs periodically, because entries go
+// stale without any triggering kubelet event, e.g. when a plugin is
+// decommissioned while no pod churn happens.
+func (m *ManagerImpl) pruneCheckpoint() {
+	if !m.sourcesReady.AllReady() {
+		return
+	}
+
+	m.mutex.Lock()
+	activeAndAdmittedPods := m.activePods()
+	if m.pendingAdmissionPod != nil {
+		activeAndAdmittedPods = append(activeAndAdmittedPods, m.pendingAdmissionPod)
+	}
+
+	stalePods := m.podDevices.pods()
+	for _, pod := range activeAndAdmittedPods {
+		stalePods.Delete(string(pod.UID))
+	}
+
+	// A resource is stale when it is unknown to both device lists and has no
+	// endpoint, not even a stopped one: GetCapacity() keeps stopped endpoints
+	// around until the grace period expires, so a plugin restart does not
+	// make its resource stale.
+	staleResources := m.podDevices.resources()
+	for resourceName := range m.healthyDevices {
+		staleResources.Delete(resourceName)
+	}
+	for resourceName := range m.unhealthyDevices {
+		staleResources.Delete(resourceName)
+	}
+	for resourceName := range m.endpoints {
+		staleResources.Delete(resourceName)
+	}
+
+	if len(stalePods) > 0 {
+		klog.V(3).InfoS("Pruning checkpoint entries of stale pods", "podUIDs", sets.List(stalePods))
+		m.podDevices.delete(sets.List(stalePods))
+		metrics.DevicePluginCheckpointPrunedEntries.WithLabelValues("stale_pod").Add(float64(len(stalePods)))
+	}
+	removedEntries := 0
+	if len(staleResources) > 0 {
+		klog.V(3).InfoS("Pruning checkpoint entries of stale resources", "resources", sets.List(staleResources))
+		removedEntries = m.podDevices.deleteResources(staleResources)
+		metrics.DevicePluginCheckpointPrunedEntries.WithLabelValues("stale_resource").Add(float64(removedEntries))
+	}
+
+	pruned := len(stalePods) > 0 || removedEntries > 0
+	if pruned {
+		// Regenerated allocatedDevices after we update pod allocation information.
+		m.allocatedDevices = m.podDevices.devices()
+	}
+	m.mutex.Unlock()
+
+	if !pruned {
+		return
+	}
+	if err := m.writeCheckpoint(); err != nil {
+		klog.ErrorS(err, "Error on writing checkpoint after pruning")
+	}
+}
+
 // Stop is the function that can stop the plugin server.
 // Can be called concurrently, more than once, and is safe to call
 // without a prior Start.
@@ -396,11 +625,16 @@ func (m *ManagerImpl) markResourceUnhealthy(resourceName string) {
 // cm.UpdatePluginResource() run during predicate Admit guarantees we adjust nodeinfo
 // capacity for already allocated pods so that they can continue to run. However, new pods
 // requiring device plugin resources will not be scheduled till device plugin re-registers.
+// If the DeviceManagerSingleNUMAAllocatable feature gate is enabled and the topology
+// manager runs with the single-numa-node policy, the allocatable count of each resource
+// is capped to the largest number of devices attached to a single NUMA node, because
+// that is the largest request the kubelet can actually admit.
 func (m *ManagerImpl) GetCapacity() (v1.ResourceList, v1.ResourceList, []string) {
 	needsUpdateCheckpoint := false
 	var capacity = v1.ResourceList{}
 	var allocatable = v1.ResourceList{}
 	deletedResources := sets.New[string]()
+	capPerNUMA := m.capAllocatablePerNUMANode()
 	m.mutex.Lock()
 	for resourceName, devices := range m.healthyDevices {
 		eI, ok := m.endpoints[resourceName]
@@ -416,8 +650,14 @@ func (m *ManagerImpl) GetCapacity() (v1.ResourceList, v1.ResourceList, []string)
 			deletedResources.Insert(resourceName)
 			needsUpdateCheckpoint = true
 		} else {
+			allocatableCount := devices.Len()
+			if capPerNUMA {
+				if capped, ok := m.maxDevicesPerNUMANode(resourceName, devices); ok {
+					allocatableCount = capped
+				}
+			}
 			capacity[v1.ResourceName(resourceName)] = *resource.NewQuantity(int64(devices.Len()), resource.DecimalSI)
-			allocatable[v1.ResourceName(resourceName)] = *resource.NewQuantity(int64(devices.Len()), resource.DecimalSI)
+			allocatable[v1.ResourceName(resourceName)] = *resource.NewQuantity(int64(allocatableCount), resource.DecimalSI)
 		}
 	}
 	for resourceName, devices := range m.unhealthyDevices {
@@ -446,6 +686,41 @@ func (m *ManagerImpl) GetCapacity() (v1.ResourceList, v1.ResourceList, []string)
 	return capacity, allocatable, deletedResources.UnsortedList()
 }
 
+// capAllocatablePerNUMANode returns true if the reported allocatable count of
+// each resource should be capped to what fits on a single NUMA node.
+func (m *ManagerImpl) capAllocatablePerNUMANode() bool {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.DeviceManagerSingleNUMAAllocatable) {
+		return false
+	}
+	policy := m.topologyAffinityStore.GetPolicy()
+	return policy != nil && policy.Name() == topologymanager.PolicySingleNumaNode
+}
+
+// maxDevicesPerNUMANode returns the largest number of the given devices which
+// are attached to a single NUMA node. If any of the devices does not report
+// topology information the per-node counts cannot be trusted, so the second
+// return value is false and the caller should not cap the allocatable count.
+// Must be called with m.mutex held.
+func (m *ManagerImpl) maxDevicesPerNUMANode(resourceName string, devices sets.Set[string]) (int, bool) {
+	perNUMADevices := make(map[int64]int)
+	for devID := range devices {
+		device, ok := m.allDevices[resourceName][devID]
+		if !ok || device.Topology == nil || len(device.Topology.Nodes) == 0 {
+			return 0, false
+		}
+		for _, node := range device.Topology.Nodes {
+			perNUMADevices[node.ID]++
+		}
+	}
+	maxDevices := 0
+	for _, count := range perNUMADevices {
+		if count > maxDevices {
+			maxDevices = count
+		}
+	}
+	return maxDevices, true
+}
+
 // Checkpoints device to container allocation information to disk.
 func (m *ManagerImpl) writeCheckpoint() error {
 	m.mutex.Lock()
@@ -647,8 +922,9 @@ func (m *ManagerImpl) devicesToAllocate(podUID, contName, resource string, requi
 			return allocated, nil
 		}
 		// Then fallback to allocate from the aligned set if no preferred list
-		// is returned (or not enough devices are returned in that list).
-		if allocateRemainingFrom(aligned) {
+		// is returned (or not enough devices are returned in that list),
+		// honoring the per-resource allocation strategy, if any.
+		if allocateRemainingFrom(m.devicesByAllocationStrategy(resource, aligned.Difference(allocated), needed)) {
 			return allocated, nil
 		}
 
@@ -685,6 +961,132 @@ func (m *ManagerImpl) devicesToAllocate(podUID, contName, resource string, requi
 	return nil, fmt.Errorf("unexpectedly allocated less resources than required. Requested: %d, Got: %d", required, required-needed)
 }
 
+// devicesByAllocationStrategy picks needed devices out of the candidates
+// according to the allocation strategy configured for the resource: "pack"
+// fills the NUMA node with the most candidate devices first, "spread"
+// round-robins across the NUMA nodes the candidates are attached to. When all
+// candidates are needed anyway the full set is returned unmodified; with no
+// strategy configured the pick falls back to the finer grained,
+// device-reported locality, if any.
+func (m *ManagerImpl) devicesByAllocationStrategy(resource string, candidates sets.Set[string], needed int) sets.Set[string] {
+	strategy := m.allocationStrategies[resource]
+	if candidates.Len() <= needed {
+		return candidates
+	}
+	if strategy == "" {
+		return m.devicesByLocality(resource, candidates, needed)
+	}
+
+	// Bucket the candidates by the first NUMA node they are attached to.
+	// Iterate the candidates in sorted order so the picks are deterministic.
+	perNodeDevices := make(map[int][]string)
+	var nodes []int
+	for _, d := range sets.List(candidates) {
+		nodeID := nodeWithoutTopology
+		if topology := m.allDevices[resource][d].Topology; topology != nil && len(topology.Nodes) > 0 {
+			nodeID = int(topology.Nodes[0].ID)
+		}
+		if _, ok := perNodeDevices[nodeID]; !ok {
+			nodes = append(nodes, nodeID)
+		}
+		perNodeDevices[nodeID] = append(perNodeDevices[nodeID], d)
+	}
+
+	// Sort the nodes by descending number of candidate devices, breaking
+	// ties by node id. Devices without NUMA association sort last.
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i] == nodeWithoutTopology {
+			return false
+		}
+		if nodes[j] == nodeWithoutTopology {
+			return true
+		}
+		if len(perNodeDevices[nodes[i]]) != len(perNodeDevices[nodes[j]]) {
+			return len(perNodeDevices[nodes[i]]) > len(perNodeDevices[nodes[j]])
+		}
+		return nodes[i] < nodes[j]
+	})
+
+	chosen := sets.New[string]()
+	switch strategy {
+	case allocationStrategyPack:
+		for _, node := range nodes {
+			for _, d := range perNodeDevices[node] {
+				chosen.Insert(d)
+				if chosen.Len() == needed {
+					return chosen
+				}
+			}
+		}
+	case allocationStrategySpread:
+		for i := 0; chosen.Len() < needed; i++ {
+			progress := false
+			for _, node := range nodes {
+				if i >= len(perNodeDevices[node]) {
+					continue
+				}
+				progress = true
+				chosen.Insert(perNodeDevices[node][i])
+				if chosen.Len() == needed {
+					return chosen
+				}
+			}
+			if !progress {
+				break
+			}
+		}
+	}
+	return chosen
+}
+
+// devicesByLocality picks needed devices out of the candidates packed on as
+// few device-reported locality domains (uncore cache, PCIe root complex) as
+// possible, so the devices allocated together share the last-level cache and
+// the PCIe fabric whenever the plugins expose that information. Candidates
+// are iterated in sorted order so the picks are deterministic. When no
+// candidate reports locality the full candidate set is returned unmodified,
+// keeping the historical behavior.
+func (m *ManagerImpl) devicesByLocality(resource string, candidates sets.Set[string], needed int) sets.Set[string] {
+	perDomainDevices := make(map[string][]string)
+	var domains []string
+	for _, d := range sets.List(candidates) {
+		domain := deviceLocalityDomain(m.allDevices[resource][d].Topology)
+		if _, ok := perDomainDevices[domain]; !ok {
+			domains = append(domains, domain)
+		}
+		perDomainDevices[domain] = append(perDomainDevices[domain], d)
+	}
+	if len(domains) == 1 && domains[0] == "" {
+		return candidates
+	}
+
+	// Sort the domains by descending number of candidate devices, breaking
+	// ties by domain. Devices without locality sort last.
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i] == "" {
+			return false
+		}
+		if domains[j] == "" {
+			return true
+		}
+		if len(perDomainDevices[domains[i]]) != len(perDomainDevices[domains[j]]) {
+			return len(perDomainDevices[domains[i]]) > len(perDomainDevices[domains[j]])
+		}
+		return domains[i] < domains[j]
+	})
+
+	chosen := sets.New[string]()
+	for _, domain := range domains {
+		for _, d := range perDomainDevices[domain] {
+			chosen.Insert(d)
+			if chosen.Len() == needed {
+				return chosen
+			}
+		}
+	}
+	return chosen
+}
+
 func (m *ManagerImpl) filterByAffinity(podUID, contName, resource string, available sets.Set[string]) (sets.Set[string], sets.Set[string], sets.Set[string]) {
 	// If alignment information is not available, just pass the available list back.
 	hint := m.topologyAffinityStore.GetAffinity(podUID, contName)
@@ -842,11 +1244,22 @@ func (m *ManagerImpl) allocateContainerResources(pod *v1.Pod, container *v1.Cont
 		}
 
 		devs := allocDevices.UnsortedList()
+		if m.sharesPerDevice(resource) > 1 {
+			// The plugin knows nothing about slots: translate them back to
+			// the physical devices backing them before issuing the call.
+			devs = physicalDeviceIDs(devs)
+		}
 		// TODO: refactor this part of code to just append a ContainerAllocationRequest
 		// in a passed in AllocateRequest pointer, and issues a single Allocate call per pod.
 		klog.V(3).InfoS("Making allocation request for device plugin", "devices", devs, "resourceName", resource)
 		resp, err := eI.e.allocate(devs)
-		metrics.DevicePluginAllocationDuration.WithLabelValues(resource).Observe(metrics.SinceInSeconds(startRPCTime))
+		allocateLatency := time.Since(startRPCTime)
+		metrics.DevicePluginAllocationDuration.WithLabelValues(resource).Observe(allocateLatency.Seconds())
+		if m.slowAllocationThreshold > 0 && allocateLatency > m.slowAllocationThreshold && m.recorder != nil {
+			m.recorder.Eventf(m.nodeRef, v1.EventTypeWarning, events.SlowDevicePluginAllocation,
+				"Device plugin for resource %s took %v to serve an Allocate request, longer than the %v threshold; pod startup is delayed",
+				resource, allocateLatency.Round(time.Millisecond), m.slowAllocationThreshold)
+		}
 		if err != nil {
 			// In case of allocation failure, we want to restore m.allocatedDevices
 			// to the actual allocated state from m.podDevices.
@@ -958,6 +1371,9 @@ func (m *ManagerImpl) callPreStartContainerIfNeeded(podUID, contName, resource s
 
 	m.mutex.Unlock()
 	devs := devices.UnsortedList()
+	if m.sharesPerDevice(resource) > 1 {
+		devs = physicalDeviceIDs(devs)
+	}
 	klog.V(4).InfoS("Issuing a PreStartContainer call for container", "containerName", contName, "podUID", podUID)
 	_, err := eI.e.preStartContainer(devs)
 	if err != nil {
@@ -980,9 +1396,18 @@ func (m *ManagerImpl) callGetPreferredAllocationIfAvailable(podUID, contName, re
 		return nil, nil
 	}
 
+	if m.sharesPerDevice(resource) > 1 {
+		// The plugin only knows physical device IDs, not the slot IDs the
+		// kubelet allocates from, so its preference cannot be honored.
+		klog.V(4).InfoS("Skipping GetPreferredAllocation for shared resource", "resourceName", resource)
+		return nil, nil
+	}
+
 	m.mutex.Unlock()
 	klog.V(4).InfoS("Issuing a GetPreferredAllocation call for container", "containerName", contName, "podUID", podUID)
+	startRPCTime := time.Now()
 	resp, err := eI.e.getPreferredAllocation(available.UnsortedList(), mustInclude.UnsortedList(), size)
+	metrics.DevicePluginGetPreferredAllocationDuration.WithLabelValues(resource).Observe(metrics.SinceInSeconds(startRPCTime))
 	m.mutex.Lock()
 	if err != nil {
 		return nil, fmt.Errorf("device plugin GetPreferredAllocation rpc failed with err: %v", err)
@@ -1046,6 +1471,20 @@ func (m *ManagerImpl) GetAllocatableDevices() ResourceDeviceInstances {
 	return resp
 }
 
+// GetAvailableDevices returns information about the healthy devices not
+// currently allocated to any container.
+func (m *ManagerImpl) GetAvailableDevices() ResourceDeviceInstances {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	free := make(map[string]sets.Set[string], len(m.healthyDevices))
+	for resourceName, ids := range m.healthyDevices {
+		free[resourceName] = ids.Difference(m.allocatedDevices[resourceName])
+	}
+	resp := m.allDevices.Filter(free)
+	klog.V(4).InfoS("GetAvailableDevices", "known", len(m.allDevices), "available", len(resp))
+	return resp
+}
+
 // GetDevices returns the devices used by the specified container
 func (m *ManagerImpl) GetDevices(podUID, containerName string) ResourceDeviceInstances {
 	return m.podDevices.getContainerDevices(podUID, containerName)
@@ -37,9 +37,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/wait"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/record"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
 	watcherapi "k8s.io/kubelet/pkg/apis/pluginregistration/v1"
+	"k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager/checkpoint"
@@ -47,6 +50,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 	"k8s.io/kubernetes/pkg/kubelet/config"
+	"k8s.io/kubernetes/pkg/kubelet/events"
 	"k8s.io/kubernetes/pkg/kubelet/lifecycle"
 	"k8s.io/kubernetes/pkg/kubelet/pluginmanager"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
@@ -96,7 +100,7 @@ func TestNewManagerImpl(t *testing.T) {
 	topologyStore := topologymanager.NewFakeManager()
 	require.NoError(t, err)
 	defer os.RemoveAll(socketDir)
-	_, err = newManagerImpl(socketName, nil, topologyStore)
+	_, err = newManagerImpl(socketName, nil, topologyStore, nil, nil, nil, nil, 0)
 	require.NoError(t, err)
 	os.RemoveAll(socketDir)
 }
@@ -267,7 +271,7 @@ func TestDevicePluginReRegistrationProbeMode(t *testing.T) {
 func setupDeviceManager(t *testing.T, devs []*pluginapi.Device, callback monitorCallback, socketName string,
 	topology []cadvisorapi.Node) (Manager, <-chan interface{}) {
 	topologyStore := topologymanager.NewFakeManager()
-	m, err := newManagerImpl(socketName, topology, topologyStore)
+	m, err := newManagerImpl(socketName, topology, topologyStore, nil, nil, nil, nil, 0)
 	require.NoError(t, err)
 	updateChan := make(chan interface{})
 
@@ -340,7 +344,7 @@ func TestUpdateCapacityAllocatable(t *testing.T) {
 	topologyStore := topologymanager.NewFakeManager()
 	require.NoError(t, err)
 	defer os.RemoveAll(socketDir)
-	testManager, err := newManagerImpl(socketName, nil, topologyStore)
+	testManager, err := newManagerImpl(socketName, nil, topologyStore, nil, nil, nil, nil, 0)
 	as := assert.New(t)
 	as.NotNil(testManager)
 	as.Nil(err)
@@ -477,12 +481,118 @@ func TestUpdateCapacityAllocatable(t *testing.T) {
 	as.True(testManager.isDevicePluginResource(resourceName2))
 }
 
+func TestUpdateAllocatableSingleNUMACap(t *testing.T) {
+	numaDevice := func(id string, health string, numaNodes ...int64) pluginapi.Device {
+		dev := pluginapi.Device{ID: id, Health: health}
+		if len(numaNodes) > 0 {
+			dev.Topology = &pluginapi.TopologyInfo{}
+			for _, numaNode := range numaNodes {
+				dev.Topology.Nodes = append(dev.Topology.Nodes, &pluginapi.NUMANode{ID: numaNode})
+			}
+		}
+		return dev
+	}
+
+	resourceName := "domain1.com/resource1"
+	testCases := []struct {
+		name                string
+		featureGate         bool
+		policy              topologymanager.Policy
+		devs                []pluginapi.Device
+		expectedCapacity    int64
+		expectedAllocatable int64
+	}{
+		{
+			name:        "single-numa-node caps allocatable to the best node",
+			featureGate: true,
+			policy:      topologymanager.NewSingleNumaNodePolicy(&topologymanager.NUMAInfo{Nodes: []int{0, 1}}, topologymanager.PolicyOptions{}),
+			devs: []pluginapi.Device{
+				numaDevice("Device1", pluginapi.Healthy, 0),
+				numaDevice("Device2", pluginapi.Healthy, 0),
+				numaDevice("Device3", pluginapi.Healthy, 1),
+			},
+			expectedCapacity:    3,
+			expectedAllocatable: 2,
+		},
+		{
+			name:        "unhealthy devices count for capacity only",
+			featureGate: true,
+			policy:      topologymanager.NewSingleNumaNodePolicy(&topologymanager.NUMAInfo{Nodes: []int{0, 1}}, topologymanager.PolicyOptions{}),
+			devs: []pluginapi.Device{
+				numaDevice("Device1", pluginapi.Healthy, 0),
+				numaDevice("Device2", pluginapi.Unhealthy, 0),
+				numaDevice("Device3", pluginapi.Healthy, 1),
+			},
+			expectedCapacity:    3,
+			expectedAllocatable: 1,
+		},
+		{
+			name:        "devices without topology disable the cap",
+			featureGate: true,
+			policy:      topologymanager.NewSingleNumaNodePolicy(&topologymanager.NUMAInfo{Nodes: []int{0, 1}}, topologymanager.PolicyOptions{}),
+			devs: []pluginapi.Device{
+				numaDevice("Device1", pluginapi.Healthy, 0),
+				numaDevice("Device2", pluginapi.Healthy, 0),
+				numaDevice("Device3", pluginapi.Healthy),
+			},
+			expectedCapacity:    3,
+			expectedAllocatable: 3,
+		},
+		{
+			name:        "best-effort policy does not cap",
+			featureGate: true,
+			policy:      topologymanager.NewBestEffortPolicy(&topologymanager.NUMAInfo{Nodes: []int{0, 1}}, topologymanager.PolicyOptions{}),
+			devs: []pluginapi.Device{
+				numaDevice("Device1", pluginapi.Healthy, 0),
+				numaDevice("Device2", pluginapi.Healthy, 0),
+				numaDevice("Device3", pluginapi.Healthy, 1),
+			},
+			expectedCapacity:    3,
+			expectedAllocatable: 3,
+		},
+		{
+			name:        "feature gate disabled does not cap",
+			featureGate: false,
+			policy:      topologymanager.NewSingleNumaNodePolicy(&topologymanager.NUMAInfo{Nodes: []int{0, 1}}, topologymanager.PolicyOptions{}),
+			devs: []pluginapi.Device{
+				numaDevice("Device1", pluginapi.Healthy, 0),
+				numaDevice("Device2", pluginapi.Healthy, 0),
+				numaDevice("Device3", pluginapi.Healthy, 1),
+			},
+			expectedCapacity:    3,
+			expectedAllocatable: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.DeviceManagerSingleNUMAAllocatable, tc.featureGate)
+
+			socketDir, socketName, _, err := tmpSocketDir()
+			require.NoError(t, err)
+			defer os.RemoveAll(socketDir)
+			testManager, err := newManagerImpl(socketName, nil, topologymanager.NewFakeManagerWithPolicy(tc.policy), nil, nil, nil, nil, 0)
+			require.NoError(t, err)
+
+			testManager.endpoints[resourceName] = endpointInfo{e: &endpointImpl{}, opts: nil}
+			testManager.genericDeviceUpdateCallback(resourceName, tc.devs)
+
+			capacity, allocatable, removedResources := testManager.GetCapacity()
+			resourceCapacity := capacity[v1.ResourceName(resourceName)]
+			resourceAllocatable := allocatable[v1.ResourceName(resourceName)]
+			assert.Equal(t, tc.expectedCapacity, resourceCapacity.Value())
+			assert.Equal(t, tc.expectedAllocatable, resourceAllocatable.Value())
+			assert.Empty(t, removedResources)
+		})
+	}
+}
+
 func TestGetAllocatableDevicesMultipleResources(t *testing.T) {
 	socketDir, socketName, _, err := tmpSocketDir()
 	topologyStore := topologymanager.NewFakeManager()
 	require.NoError(t, err)
 	defer os.RemoveAll(socketDir)
-	testManager, err := newManagerImpl(socketName, nil, topologyStore)
+	testManager, err := newManagerImpl(socketName, nil, topologyStore, nil, nil, nil, nil, 0)
 	as := assert.New(t)
 	as.NotNil(testManager)
 	as.Nil(err)
@@ -523,7 +633,7 @@ func TestGetAllocatableDevicesHealthTransition(t *testing.T) {
 	topologyStore := topologymanager.NewFakeManager()
 	require.NoError(t, err)
 	defer os.RemoveAll(socketDir)
-	testManager, err := newManagerImpl(socketName, nil, topologyStore)
+	testManager, err := newManagerImpl(socketName, nil, topologyStore, nil, nil, nil, nil, 0)
 	as := assert.New(t)
 	as.NotNil(testManager)
 	as.Nil(err)
@@ -1077,6 +1187,142 @@ func TestPodContainerDeviceAllocation(t *testing.T) {
 
 }
 
+func TestSlowDevicePluginAllocationEvent(t *testing.T) {
+	res1 := TestResource{
+		resourceName:     "domain1.com/resource1",
+		resourceQuantity: *resource.NewQuantity(int64(2), resource.DecimalSI),
+		devs:             checkpoint.DevicesPerNUMA{0: []string{"dev1", "dev2"}},
+		topology:         true,
+	}
+	as := require.New(t)
+
+	testCases := []struct {
+		description string
+		threshold   time.Duration
+		expectEvent bool
+	}{
+		{
+			description: "allocation latency over the threshold emits an event",
+			threshold:   time.Millisecond,
+			expectEvent: true,
+		},
+		{
+			description: "allocation latency under the threshold stays quiet",
+			threshold:   time.Minute,
+			expectEvent: false,
+		},
+		{
+			description: "zero threshold disables the alert",
+			threshold:   0,
+			expectEvent: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			podsStub := activePodsStub{
+				activePods: []*v1.Pod{},
+			}
+			tmpDir, err := os.MkdirTemp("", "checkpoint")
+			as.Nil(err)
+			defer os.RemoveAll(tmpDir)
+			testManager, err := getTestManager(tmpDir, podsStub.getActivePods, []TestResource{res1})
+			as.Nil(err)
+
+			recorder := record.NewFakeRecorder(10)
+			testManager.recorder = recorder
+			testManager.nodeRef = &v1.ObjectReference{Kind: "Node", Name: "test-node"}
+			testManager.slowAllocationThreshold = testCase.threshold
+
+			stub := allocateStubFunc()
+			testManager.endpoints[res1.resourceName] = endpointInfo{
+				e: &MockEndpoint{
+					allocateFunc: func(devs []string) (*pluginapi.AllocateResponse, error) {
+						time.Sleep(10 * time.Millisecond)
+						return stub(devs)
+					},
+				},
+			}
+
+			pod := makePod(v1.ResourceList{
+				v1.ResourceName(res1.resourceName): res1.resourceQuantity,
+			})
+			podsStub.updateActivePods([]*v1.Pod{pod})
+			err = testManager.Allocate(pod, &pod.Spec.Containers[0])
+			as.Nil(err)
+
+			select {
+			case event := <-recorder.Events:
+				if !testCase.expectEvent {
+					t.Fatalf("unexpected event: %v", event)
+				}
+				as.Contains(event, events.SlowDevicePluginAllocation)
+				as.Contains(event, res1.resourceName)
+			default:
+				if testCase.expectEvent {
+					t.Fatalf("expected a %s event, got none", events.SlowDevicePluginAllocation)
+				}
+			}
+		})
+	}
+}
+
+func TestDeviceAllocatableChangedEvent(t *testing.T) {
+	socketDir, socketName, _, err := tmpSocketDir()
+	require.NoError(t, err)
+	defer os.RemoveAll(socketDir)
+	testManager, err := newManagerImpl(socketName, nil, topologymanager.NewFakeManager(), nil, nil, nil, nil, 0)
+	as := require.New(t)
+	as.NotNil(testManager)
+	as.Nil(err)
+
+	recorder := record.NewFakeRecorder(10)
+	testManager.recorder = recorder
+	testManager.nodeRef = &v1.ObjectReference{Kind: "Node", Name: "test-node"}
+
+	resourceName := "domain1.com/resource1"
+	devs := []pluginapi.Device{
+		{ID: "Device1", Health: pluginapi.Healthy},
+		{ID: "Device2", Health: pluginapi.Healthy},
+	}
+
+	drainEvents := func() []string {
+		var events []string
+		for {
+			select {
+			case event := <-recorder.Events:
+				events = append(events, event)
+			default:
+				return events
+			}
+		}
+	}
+
+	// The first update for a resource is plugin registration, not a change.
+	testManager.genericDeviceUpdateCallback(resourceName, devs)
+	as.Empty(drainEvents())
+
+	// A device going unhealthy is a capacity loss worth a warning.
+	devs[1].Health = pluginapi.Unhealthy
+	testManager.genericDeviceUpdateCallback(resourceName, devs)
+	emitted := drainEvents()
+	as.Len(emitted, 1)
+	as.Contains(emitted[0], v1.EventTypeWarning)
+	as.Contains(emitted[0], events.DeviceAllocatableChanged)
+	as.Contains(emitted[0], "changed from 2 to 1")
+
+	// An unchanged healthy count stays quiet.
+	testManager.genericDeviceUpdateCallback(resourceName, devs)
+	as.Empty(drainEvents())
+
+	// The device coming back is a change too, but not a warning.
+	devs[1].Health = pluginapi.Healthy
+	testManager.genericDeviceUpdateCallback(resourceName, devs)
+	emitted = drainEvents()
+	as.Len(emitted, 1)
+	as.Contains(emitted[0], v1.EventTypeNormal)
+	as.Contains(emitted[0], "changed from 1 to 2")
+}
+
 func TestPodContainerDeviceToAllocate(t *testing.T) {
 	resourceName1 := "domain1.com/resource1"
 	resourceName2 := "domain2.com/resource2"
@@ -1850,3 +2096,279 @@ func TestGetTopologyHintsWithUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestDevicesByAllocationStrategy(t *testing.T) {
+	resource := "domain1.com/resource1"
+	devices := []pluginapi.Device{
+		makeNUMADevice("dev0", 0),
+		makeNUMADevice("dev1", 0),
+		makeNUMADevice("dev2", 0),
+		makeNUMADevice("dev3", 1),
+		makeNUMADevice("dev4", 1),
+		{ID: "dev5"},
+	}
+	candidates := sets.New[string]("dev0", "dev1", "dev2", "dev3", "dev4", "dev5")
+
+	tcases := []struct {
+		description string
+		strategy    string
+		needed      int
+		expected    sets.Set[string]
+	}{
+		{
+			description: "no strategy keeps the candidates untouched",
+			strategy:    "",
+			needed:      3,
+			expected:    candidates,
+		},
+		{
+			description: "all candidates needed keeps the candidates untouched",
+			strategy:    allocationStrategySpread,
+			needed:      6,
+			expected:    candidates,
+		},
+		{
+			description: "pack fills the most populated NUMA node first",
+			strategy:    allocationStrategyPack,
+			needed:      3,
+			expected:    sets.New[string]("dev0", "dev1", "dev2"),
+		},
+		{
+			description: "pack overflows on the next NUMA node",
+			strategy:    allocationStrategyPack,
+			needed:      4,
+			expected:    sets.New[string]("dev0", "dev1", "dev2", "dev3"),
+		},
+		{
+			description: "spread round-robins across NUMA nodes",
+			strategy:    allocationStrategySpread,
+			needed:      3,
+			expected:    sets.New[string]("dev0", "dev3", "dev5"),
+		},
+		{
+			description: "spread wraps around once every node contributed",
+			strategy:    allocationStrategySpread,
+			needed:      5,
+			expected:    sets.New[string]("dev0", "dev1", "dev3", "dev4", "dev5"),
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.description, func(t *testing.T) {
+			m := ManagerImpl{
+				allDevices:           NewResourceDeviceInstances(),
+				allocationStrategies: map[string]string{resource: tc.strategy},
+			}
+			m.allDevices[resource] = make(DeviceInstances)
+			for _, d := range devices {
+				m.allDevices[resource][d.ID] = d
+			}
+
+			chosen := m.devicesByAllocationStrategy(resource, candidates, tc.needed)
+			if !chosen.Equal(tc.expected) {
+				t.Errorf("expected %v, got %v", sets.List(tc.expected), sets.List(chosen))
+			}
+		})
+	}
+}
+
+func TestDevicesByLocality(t *testing.T) {
+	resource := "domain1.com/resource1"
+	devices := []pluginapi.Device{
+		makeLocalityDevice("dev0", 0, 0, 0),
+		makeLocalityDevice("dev1", 0, 0, 0),
+		makeLocalityDevice("dev2", 0, 1, 0),
+		makeLocalityDevice("dev3", 1, 4, 1),
+		{ID: "dev4"},
+	}
+	candidates := sets.New[string]("dev0", "dev1", "dev2", "dev3", "dev4")
+
+	tcases := []struct {
+		description string
+		needed      int
+		expected    sets.Set[string]
+	}{
+		{
+			description: "picks from the most populated locality domain first",
+			needed:      2,
+			expected:    sets.New[string]("dev0", "dev1"),
+		},
+		{
+			description: "overflows on the next locality domain",
+			needed:      3,
+			expected:    sets.New[string]("dev0", "dev1", "dev2"),
+		},
+		{
+			description: "devices without locality are picked last",
+			needed:      4,
+			expected:    sets.New[string]("dev0", "dev1", "dev2", "dev3"),
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.description, func(t *testing.T) {
+			m := ManagerImpl{
+				allDevices:           NewResourceDeviceInstances(),
+				allocationStrategies: map[string]string{},
+			}
+			m.allDevices[resource] = make(DeviceInstances)
+			for _, d := range devices {
+				m.allDevices[resource][d.ID] = d
+			}
+
+			// with no strategy configured, devicesByAllocationStrategy
+			// delegates to the device-reported locality
+			chosen := m.devicesByAllocationStrategy(resource, candidates, tc.needed)
+			if !chosen.Equal(tc.expected) {
+				t.Errorf("expected %v, got %v", sets.List(tc.expected), sets.List(chosen))
+			}
+		})
+	}
+
+	t.Run("no locality reported keeps the candidates untouched", func(t *testing.T) {
+		m := ManagerImpl{
+			allDevices:           NewResourceDeviceInstances(),
+			allocationStrategies: map[string]string{},
+		}
+		m.allDevices[resource] = make(DeviceInstances)
+		for _, d := range []pluginapi.Device{makeNUMADevice("dev0", 0), makeNUMADevice("dev1", 1), {ID: "dev2"}} {
+			m.allDevices[resource][d.ID] = d
+		}
+
+		candidates := sets.New[string]("dev0", "dev1", "dev2")
+		chosen := m.devicesByAllocationStrategy(resource, candidates, 2)
+		if !chosen.Equal(candidates) {
+			t.Errorf("expected %v, got %v", sets.List(candidates), sets.List(chosen))
+		}
+	})
+}
+
+func TestNewManagerImplAllocationStrategies(t *testing.T) {
+	socketDir, socketName, _, err := tmpSocketDir()
+	require.NoError(t, err)
+	defer os.RemoveAll(socketDir)
+
+	topologyStore := topologymanager.NewFakeManager()
+	_, err = newManagerImpl(socketName, nil, topologyStore, map[string]string{"domain1.com/resource1": allocationStrategySpread}, nil, nil, nil, 0)
+	require.NoError(t, err)
+
+	_, err = newManagerImpl(socketName, nil, topologyStore, map[string]string{"domain1.com/resource1": "bogus"}, nil, nil, nil, 0)
+	require.Error(t, err)
+}
+
+func TestNewManagerImplSharingCounts(t *testing.T) {
+	socketDir, socketName, _, err := tmpSocketDir()
+	require.NoError(t, err)
+	defer os.RemoveAll(socketDir)
+
+	topologyStore := topologymanager.NewFakeManager()
+	testManager, err := newManagerImpl(socketName, nil, topologyStore, nil, map[string]string{"domain1.com/resource1": "2"}, nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, testManager.sharesPerDevice("domain1.com/resource1"))
+	require.Equal(t, 1, testManager.sharesPerDevice("domain1.com/resource2"))
+
+	_, err = newManagerImpl(socketName, nil, topologyStore, nil, map[string]string{"domain1.com/resource1": "0"}, nil, nil, 0)
+	require.Error(t, err)
+
+	_, err = newManagerImpl(socketName, nil, topologyStore, nil, map[string]string{"domain1.com/resource1": "bogus"}, nil, nil, 0)
+	require.Error(t, err)
+}
+
+func TestDeviceSharingCounts(t *testing.T) {
+	socketDir, socketName, _, err := tmpSocketDir()
+	require.NoError(t, err)
+	defer os.RemoveAll(socketDir)
+
+	topologyStore := topologymanager.NewFakeManager()
+	testManager, err := newManagerImpl(socketName, nil, topologyStore, nil, map[string]string{testResourceName: "2"}, nil, nil, 0)
+	require.NoError(t, err)
+
+	devs := []pluginapi.Device{
+		{ID: "Dev1", Health: pluginapi.Healthy, Topology: &pluginapi.TopologyInfo{Nodes: []*pluginapi.NUMANode{{ID: 0}}}},
+		{ID: "Dev2", Health: pluginapi.Unhealthy},
+	}
+	testManager.genericDeviceUpdateCallback(testResourceName, devs)
+
+	require.Equal(t, 2, testManager.healthyDevices[testResourceName].Len(), "expected one slot per share of the healthy device")
+	require.Equal(t, 2, testManager.unhealthyDevices[testResourceName].Len(), "expected one slot per share of the unhealthy device")
+	for slotID := range testManager.healthyDevices[testResourceName] {
+		require.Equal(t, "Dev1", physicalDeviceID(slotID))
+		topology := testManager.allDevices[testResourceName][slotID].Topology
+		require.NotNil(t, topology)
+		require.Equal(t, int64(0), topology.Nodes[0].ID, "slots must inherit the physical device NUMA node")
+	}
+
+	require.Equal(t, []string{"Dev1"}, physicalDeviceIDs(sets.List(testManager.healthyDevices[testResourceName])), "slots of the same device must collapse to one physical ID")
+}
+
+func TestPruneCheckpoint(t *testing.T) {
+	registeredResource := "domain1.com/resource1"
+	unregisteredResource := "domain1.com/resource2"
+	stoppedPluginResource := "domain1.com/resource3"
+	as := assert.New(t)
+	tmpDir, err := os.MkdirTemp("", "checkpoint")
+	as.Nil(err)
+	defer os.RemoveAll(tmpDir)
+	ckm, err := checkpointmanager.NewCheckpointManager(tmpDir)
+	as.Nil(err)
+	activePods := func() []*v1.Pod {
+		return []*v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{UID: "activePod"}},
+		}
+	}
+	testManager := &ManagerImpl{
+		endpoints:         make(map[string]endpointInfo),
+		healthyDevices:    make(map[string]sets.Set[string]),
+		unhealthyDevices:  make(map[string]sets.Set[string]),
+		allocatedDevices:  make(map[string]sets.Set[string]),
+		podDevices:        newPodDevices(),
+		checkpointManager: ckm,
+		activePods:        activePods,
+		sourcesReady:      &sourcesReadyStub{},
+	}
+
+	testManager.healthyDevices[registeredResource] = sets.New[string]("dev1")
+	// A stopped endpoint within the grace period must keep its resource alive.
+	testManager.endpoints[stoppedPluginResource] = endpointInfo{e: newStoppedEndpointImpl(stoppedPluginResource), opts: nil}
+
+	testManager.podDevices.insert("activePod", "con1", registeredResource,
+		constructDevices([]string{"dev1"}),
+		newContainerAllocateResponse(
+			withDevices(map[string]string{"/dev/r1dev1": "/dev/r1dev1"}),
+		),
+	)
+	testManager.podDevices.insert("activePod", "con1", unregisteredResource,
+		constructDevices([]string{"dev2"}),
+		newContainerAllocateResponse(
+			withDevices(map[string]string{"/dev/r2dev2": "/dev/r2dev2"}),
+		),
+	)
+	testManager.podDevices.insert("activePod", "con1", stoppedPluginResource,
+		constructDevices([]string{"dev3"}),
+		newContainerAllocateResponse(
+			withDevices(map[string]string{"/dev/r3dev3": "/dev/r3dev3"}),
+		),
+	)
+	testManager.podDevices.insert("stalePod", "con1", registeredResource,
+		constructDevices([]string{"dev4"}),
+		newContainerAllocateResponse(
+			withDevices(map[string]string{"/dev/r1dev4": "/dev/r1dev4"}),
+		),
+	)
+	testManager.allocatedDevices = testManager.podDevices.devices()
+
+	testManager.pruneCheckpoint()
+
+	as.True(testManager.podDevices.hasPod("activePod"))
+	as.False(testManager.podDevices.hasPod("stalePod"), "the entries of gone pods must be pruned")
+	as.Equal(sets.New[string]("dev1"), testManager.podDevices.containerDevices("activePod", "con1", registeredResource))
+	as.Empty(testManager.podDevices.containerDevices("activePod", "con1", unregisteredResource), "the entries of unregistered resources must be pruned")
+	as.Equal(sets.New[string]("dev3"), testManager.podDevices.containerDevices("activePod", "con1", stoppedPluginResource), "the entries of resources with a stopped endpoint must be kept")
+	as.Equal(testManager.podDevices.devices(), testManager.allocatedDevices, "allocatedDevices must be regenerated after pruning")
+
+	// The pruned state must be the one visible after a restart.
+	testManager.podDevices = newPodDevices()
+	as.Nil(testManager.readCheckpoint())
+	as.False(testManager.podDevices.hasPod("stalePod"))
+	as.Empty(testManager.podDevices.containerDevices("activePod", "con1", unregisteredResource))
+}
@@ -42,6 +42,10 @@ type Client interface {
 	Connect() error
 	Run()
 	Disconnect() error
+	// SocketPath returns the endpoint this client connects to, so duplicate
+	// registrations of one endpoint through both registration paths can be
+	// told apart from an endpoint change.
+	SocketPath() string
 }
 
 type client struct {
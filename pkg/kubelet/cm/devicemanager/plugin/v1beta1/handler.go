@@ -39,8 +39,12 @@ func (s *server) GetPluginHandler() cache.PluginHandler {
 }
 
 func (s *server) RegisterPlugin(pluginName string, endpoint string, versions []string, pluginClientTimeout *time.Duration) error {
-	klog.V(2).InfoS("Registering plugin at endpoint", "plugin", pluginName, "endpoint", endpoint)
-	return s.connectClient(pluginName, endpoint)
+	version, err := negotiateVersionWithPlugin(versions...)
+	if err != nil {
+		return err
+	}
+	klog.V(2).InfoS("Registering plugin at endpoint", "plugin", pluginName, "endpoint", endpoint, "version", version)
+	return s.connectClient(pluginName, endpoint, version)
 }
 
 func (s *server) DeRegisterPlugin(pluginName string) {
@@ -54,7 +58,7 @@ func (s *server) DeRegisterPlugin(pluginName string) {
 func (s *server) ValidatePlugin(pluginName string, endpoint string, versions []string) error {
 	klog.V(2).InfoS("Got plugin at endpoint with versions", "plugin", pluginName, "endpoint", endpoint, "versions", versions)
 
-	if !s.isVersionCompatibleWithPlugin(versions...) {
+	if _, err := negotiateVersionWithPlugin(versions...); err != nil {
 		return fmt.Errorf("manager version, %s, is not among plugin supported versions %v", api.Version, versions)
 	}
 
@@ -65,8 +69,28 @@ func (s *server) ValidatePlugin(pluginName string, endpoint string, versions []s
 	return nil
 }
 
-func (s *server) connectClient(name string, socketPath string) error {
-	c := NewPluginClient(name, socketPath, s.chandler)
+func (s *server) connectClient(name string, socketPath string, version string) error {
+	if old := s.getClient(name); old != nil {
+		if old.SocketPath() == socketPath {
+			// The plugin registered through both the plugin watcher and the
+			// direct Register RPC. The first registration already connected
+			// this endpoint, so the duplicate one is a no-op.
+			klog.V(2).InfoS("Client already connected to the endpoint, deduplicating registration", "resource", name, "endpoint", socketPath)
+			return nil
+		}
+		// Same resource over a different endpoint: the plugin restarted, or
+		// two plugins contend for one resource name. The newest registration
+		// wins, regardless of which path either registration came through.
+		klog.V(2).InfoS("Client already connected to the resource over a different endpoint, replacing it", "resource", name, "endpoint", old.SocketPath(), "newEndpoint", socketPath)
+		if err := s.disconnectClient(name, old); err != nil {
+			klog.V(2).InfoS("Unable to disconnect old client", "resource", name, "err", err)
+		}
+	}
+
+	c, err := newClientForVersion(name, socketPath, version, s.chandler)
+	if err != nil {
+		return err
+	}
 
 	s.registerClient(name, c)
 	if err := c.Connect(); err != nil {
@@ -82,6 +106,19 @@ func (s *server) connectClient(name string, socketPath string) error {
 	return nil
 }
 
+// newClientForVersion constructs the endpoint adapter matching the negotiated
+// API version. Today v1beta1 is the only supported version, but selecting the
+// adapter here keeps per-version behavior expressible once a newer version of
+// the plugin API is introduced.
+func newClientForVersion(name string, socketPath string, version string, h ClientHandler) (Client, error) {
+	switch version {
+	case api.Version:
+		return NewPluginClient(name, socketPath, h), nil
+	default:
+		return nil, fmt.Errorf(errUnsupportedVersion, version, api.SupportedVersions)
+	}
+}
+
 func (s *server) disconnectClient(name string, c Client) error {
 	s.deregisterClient(name)
 	return c.Disconnect()
@@ -106,8 +143,9 @@ func (s *server) deregisterClient(name string) {
 func (s *server) runClient(name string, c Client) {
 	c.Run()
 
-	c = s.getClient(name)
-	if c == nil {
+	// The plugin may have re-registered over a different endpoint while this
+	// client was running; the newer client must not be torn down with it.
+	if s.getClient(name) != c {
 		return
 	}
 
@@ -144,8 +144,8 @@ func (s *server) Register(ctx context.Context, r *api.RegisterRequest) (*api.Emp
 	klog.InfoS("Got registration request from device plugin with resource", "resourceName", r.ResourceName)
 	metrics.DevicePluginRegistrationCount.WithLabelValues(r.ResourceName).Inc()
 
-	if !s.isVersionCompatibleWithPlugin(r.Version) {
-		err := fmt.Errorf(errUnsupportedVersion, r.Version, api.SupportedVersions)
+	version, err := negotiateVersionWithPlugin(r.Version)
+	if err != nil {
 		klog.InfoS("Bad registration request from device plugin with resource", "resourceName", r.ResourceName, "err", err)
 		return &api.Empty{}, err
 	}
@@ -156,7 +156,7 @@ func (s *server) Register(ctx context.Context, r *api.RegisterRequest) (*api.Emp
 		return &api.Empty{}, err
 	}
 
-	if err := s.connectClient(r.ResourceName, filepath.Join(s.socketDir, r.Endpoint)); err != nil {
+	if err := s.connectClient(r.ResourceName, filepath.Join(s.socketDir, r.Endpoint), version); err != nil {
 		klog.InfoS("Error connecting to device plugin client", "err", err)
 		return &api.Empty{}, err
 	}
@@ -164,19 +164,19 @@ func (s *server) Register(ctx context.Context, r *api.RegisterRequest) (*api.Emp
 	return &api.Empty{}, nil
 }
 
-func (s *server) isVersionCompatibleWithPlugin(versions ...string) bool {
-	// TODO(vikasc): Currently this is fine as we only have a single supported version. When we do need to support
-	// multiple versions in the future, we may need to extend this function to return a supported version.
-	// E.g., say kubelet supports v1beta1 and v1beta2, and we get v1alpha1 and v1beta1 from a device plugin,
-	// this function should return v1beta1
-	for _, version := range versions {
-		for _, supportedVersion := range api.SupportedVersions {
-			if version == supportedVersion {
-				return true
+// negotiateVersionWithPlugin returns the highest API version supported both by
+// the kubelet and by a plugin which advertised the given versions, or an error
+// if there is no mutually supported version. api.SupportedVersions is ordered
+// from oldest to newest, so the last mutually supported entry wins.
+func negotiateVersionWithPlugin(versions ...string) (string, error) {
+	for i := len(api.SupportedVersions) - 1; i >= 0; i-- {
+		for _, version := range versions {
+			if version == api.SupportedVersions[i] {
+				return version, nil
 			}
 		}
 	}
-	return false
+	return "", fmt.Errorf(errUnsupportedVersion, versions, api.SupportedVersions)
 }
 
 func (s *server) visitClients(visit func(r string, c Client)) {
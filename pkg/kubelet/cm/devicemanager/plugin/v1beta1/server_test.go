@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+)
+
+const testResourceName = "example.com/resource"
+
+// fakeClientHandler records the plugin connection lifecycle calls, standing in
+// for the device manager.
+type fakeClientHandler struct {
+	mutex        sync.Mutex
+	connected    []string
+	disconnected []string
+}
+
+func (h *fakeClientHandler) PluginConnected(resource string, p DevicePlugin) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.connected = append(h.connected, p.SocketPath())
+	return nil
+}
+
+func (h *fakeClientHandler) PluginDisconnected(resource string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.disconnected = append(h.disconnected, resource)
+}
+
+func (h *fakeClientHandler) PluginListAndWatchReceiver(resource string, resp *api.ListAndWatchResponse) {
+}
+
+func (h *fakeClientHandler) connectedEndpoints() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]string{}, h.connected...)
+}
+
+func (h *fakeClientHandler) disconnectedResources() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]string{}, h.disconnected...)
+}
+
+func startTestStub(t *testing.T, socketPath string) *Stub {
+	t.Helper()
+	devs := []*api.Device{
+		{ID: "dev-1", Health: api.Healthy},
+	}
+	stub := NewDevicePluginStub(devs, socketPath, testResourceName, false, false)
+	require.NoError(t, stub.Start())
+	t.Cleanup(func() {
+		stub.Stop()
+	})
+	return stub
+}
+
+// TestRegisterDualPathDeduplication covers a plugin registering through both
+// the plugin watcher path (RegisterPlugin) and the direct Register RPC: one
+// endpoint must end up with exactly one connected client, and a registration
+// over a new endpoint must deterministically replace the old client no matter
+// which path it came through.
+func TestRegisterDualPathDeduplication(t *testing.T) {
+	socketDir := t.TempDir()
+	pluginSocket := filepath.Join(socketDir, "plugin.sock")
+	startTestStub(t, pluginSocket)
+
+	handler := &fakeClientHandler{}
+	srv, err := NewServer(filepath.Join(socketDir, "kubelet.sock"), nil, handler)
+	require.NoError(t, err)
+	s := srv.(*server)
+	defer s.Stop()
+
+	// Register through the plugin watcher path first.
+	require.NoError(t, s.RegisterPlugin(testResourceName, pluginSocket, []string{api.Version}, nil))
+	require.Equal(t, []string{pluginSocket}, handler.connectedEndpoints())
+	first := s.getClient(testResourceName)
+	require.NotNil(t, first)
+
+	// The duplicate registration through the direct RPC path for the same
+	// endpoint is a no-op: still one client, no reconnection.
+	_, err = s.Register(context.Background(), &api.RegisterRequest{
+		Version:      api.Version,
+		Endpoint:     "plugin.sock",
+		ResourceName: testResourceName,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{pluginSocket}, handler.connectedEndpoints())
+	require.Empty(t, handler.disconnectedResources())
+	require.Equal(t, first, s.getClient(testResourceName))
+
+	// A registration for the same resource over a different endpoint wins
+	// over the existing client, which gets disconnected.
+	otherSocket := filepath.Join(socketDir, "plugin-restarted.sock")
+	startTestStub(t, otherSocket)
+
+	_, err = s.Register(context.Background(), &api.RegisterRequest{
+		Version:      api.Version,
+		Endpoint:     "plugin-restarted.sock",
+		ResourceName: testResourceName,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{pluginSocket, otherSocket}, handler.connectedEndpoints())
+	require.Equal(t, []string{testResourceName}, handler.disconnectedResources())
+
+	// The old client's run loop winds down asynchronously and must not tear
+	// down the replacement client along the way.
+	time.Sleep(500 * time.Millisecond)
+	replacement := s.getClient(testResourceName)
+	require.NotNil(t, replacement)
+	require.Equal(t, otherSocket, replacement.SocketPath())
+	require.Equal(t, []string{testResourceName}, handler.disconnectedResources())
+}
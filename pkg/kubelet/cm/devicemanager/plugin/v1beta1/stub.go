@@ -54,12 +54,19 @@ type Stub struct {
 	// getPreferredAllocFunc is used for handling getPreferredAllocation request
 	getPreferredAllocFunc stubGetPreferredAllocFunc
 
+	// preStartFunc is used for handling preStartContainer request
+	preStartFunc stubPreStartFunc
+
 	// registerControlFunc is used for controlling auto-registration of requests
 	registerControlFunc stubRegisterControlFunc
 
 	registrationStatus chan watcherapi.RegistrationStatus // for testing
 	endpoint           string                             // for testing
 
+	// configPath is the DeviceConfig file the device list was loaded from,
+	// empty when the stub was created with an explicit device list.
+	configPath string
+
 	kubeletRestartWatcher *fsnotify.Watcher
 }
 
@@ -81,6 +88,15 @@ func defaultAllocFunc(r *pluginapi.AllocateRequest, devs map[string]pluginapi.De
 	return &response, nil
 }
 
+// stubPreStartFunc is the function called when a preStartContainer request is received from Kubelet
+type stubPreStartFunc func(r *pluginapi.PreStartContainerRequest, devs map[string]pluginapi.Device) (*pluginapi.PreStartContainerResponse, error)
+
+func defaultPreStartFunc(r *pluginapi.PreStartContainerRequest, devs map[string]pluginapi.Device) (*pluginapi.PreStartContainerResponse, error) {
+	var response pluginapi.PreStartContainerResponse
+
+	return &response, nil
+}
+
 // stubRegisterControlFunc is the function called when a registration request is received from Kubelet
 type stubRegisterControlFunc func() bool
 
@@ -110,6 +126,7 @@ func NewDevicePluginStub(devs []*pluginapi.Device, socket string, name string, p
 
 		allocFunc:             defaultAllocFunc,
 		getPreferredAllocFunc: defaultGetPreferredAllocFunc,
+		preStartFunc:          defaultPreStartFunc,
 		kubeletRestartWatcher: watcher,
 	}
 }
@@ -124,6 +141,11 @@ func (m *Stub) SetAllocFunc(f stubAllocFunc) {
 	m.allocFunc = f
 }
 
+// SetPreStartFunc sets preStartFunc of the device plugin
+func (m *Stub) SetPreStartFunc(f stubPreStartFunc) {
+	m.preStartFunc = f
+}
+
 // SetRegisterControlFunc sets RegisterControlFunc of the device plugin
 func (m *Stub) SetRegisterControlFunc(f stubRegisterControlFunc) {
 	m.registerControlFunc = f
@@ -327,7 +349,14 @@ func (m *Stub) GetDevicePluginOptions(ctx context.Context, e *pluginapi.Empty) (
 // PreStartContainer resets the devices received
 func (m *Stub) PreStartContainer(ctx context.Context, r *pluginapi.PreStartContainerRequest) (*pluginapi.PreStartContainerResponse, error) {
 	klog.InfoS("PreStartContainer", "request", r)
-	return &pluginapi.PreStartContainerResponse{}, nil
+
+	devs := make(map[string]pluginapi.Device)
+
+	for _, dev := range m.devs {
+		devs[dev.ID] = *dev
+	}
+
+	return m.preStartFunc(r, devs)
 }
 
 // ListAndWatch lists devices and update that list according to the Update call
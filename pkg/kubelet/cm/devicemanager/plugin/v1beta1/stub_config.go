@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"os"
+
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+// DeviceConfig is the YAML schema of the stub device inventory file. It lets
+// tests describe a multi-NUMA device spread declaratively, for example:
+//
+//	devices:
+//	- id: dev-0
+//	  numaNodes: [0]
+//	- id: dev-1
+//	  health: Unhealthy
+//	  numaNodes: [1]
+type DeviceConfig struct {
+	Devices []DeviceSpec `json:"devices"`
+}
+
+// DeviceSpec describes a single device of the stub inventory.
+type DeviceSpec struct {
+	// ID is the unique device ID.
+	ID string `json:"id"`
+	// Health is the device health, either "Healthy" or "Unhealthy".
+	// Defaults to "Healthy".
+	Health string `json:"health,omitempty"`
+	// NUMANodes are the NUMA node IDs the device is affine to. Devices
+	// without NUMA nodes report no topology information.
+	NUMANodes []int64 `json:"numaNodes,omitempty"`
+}
+
+// LoadDeviceConfig reads a DeviceConfig YAML file and returns the device list
+// it describes.
+func LoadDeviceConfig(configPath string) ([]*pluginapi.Device, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config DeviceConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the device config %q: %w", configPath, err)
+	}
+
+	var devs []*pluginapi.Device
+	seen := map[string]bool{}
+	for _, spec := range config.Devices {
+		if spec.ID == "" {
+			return nil, fmt.Errorf("device without ID in the device config %q", configPath)
+		}
+		if seen[spec.ID] {
+			return nil, fmt.Errorf("duplicate device ID %q in the device config %q", spec.ID, configPath)
+		}
+		seen[spec.ID] = true
+
+		health := spec.Health
+		if health == "" {
+			health = pluginapi.Healthy
+		}
+		if health != pluginapi.Healthy && health != pluginapi.Unhealthy {
+			return nil, fmt.Errorf("unsupported health %q of device %q in the device config %q", spec.Health, spec.ID, configPath)
+		}
+
+		dev := &pluginapi.Device{
+			ID:     spec.ID,
+			Health: health,
+		}
+		if len(spec.NUMANodes) > 0 {
+			topology := &pluginapi.TopologyInfo{}
+			for _, node := range spec.NUMANodes {
+				topology.Nodes = append(topology.Nodes, &pluginapi.NUMANode{ID: node})
+			}
+			dev.Topology = topology
+		}
+		devs = append(devs, dev)
+	}
+
+	return devs, nil
+}
+
+// NewDevicePluginStubFromConfig returns an initialized DevicePlugin Stub
+// whose device list comes from the DeviceConfig file at configPath.
+// The stub remembers the path, so the inventory can be reloaded later with
+// ReloadConfig.
+func NewDevicePluginStubFromConfig(configPath string, socket string, name string, preStartContainerFlag bool, getPreferredAllocationFlag bool) (*Stub, error) {
+	devs, err := LoadDeviceConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stub := NewDevicePluginStub(devs, socket, name, preStartContainerFlag, getPreferredAllocationFlag)
+	stub.configPath = configPath
+	return stub, nil
+}
+
+// ReloadConfig re-reads the DeviceConfig file the stub was created from and
+// announces the new device list through ListAndWatch. It blocks until the
+// kubelet consumes the update, so it must only be called while the plugin is
+// registered. On error the previous device list stays in effect.
+func (m *Stub) ReloadConfig() error {
+	if m.configPath == "" {
+		return fmt.Errorf("the device plugin stub was not created from a config file")
+	}
+
+	devs, err := LoadDeviceConfig(m.configPath)
+	if err != nil {
+		return err
+	}
+
+	m.devs = devs
+	m.Update(devs)
+	return nil
+}
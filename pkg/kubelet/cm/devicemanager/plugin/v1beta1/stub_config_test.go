@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+)
+
+func TestLoadDeviceConfig(t *testing.T) {
+	testCases := []struct {
+		description   string
+		config        string
+		expectedDevs  []*pluginapi.Device
+		expectedError bool
+	}{
+		{
+			description: "topology-spread inventory",
+			config: `devices:
+- id: dev-0
+  numaNodes: [0]
+- id: dev-1
+  health: Unhealthy
+  numaNodes: [0, 1]
+- id: dev-2
+`,
+			expectedDevs: []*pluginapi.Device{
+				{
+					ID:     "dev-0",
+					Health: pluginapi.Healthy,
+					Topology: &pluginapi.TopologyInfo{
+						Nodes: []*pluginapi.NUMANode{{ID: 0}},
+					},
+				},
+				{
+					ID:     "dev-1",
+					Health: pluginapi.Unhealthy,
+					Topology: &pluginapi.TopologyInfo{
+						Nodes: []*pluginapi.NUMANode{{ID: 0}, {ID: 1}},
+					},
+				},
+				{
+					ID:     "dev-2",
+					Health: pluginapi.Healthy,
+				},
+			},
+		},
+		{
+			description: "empty inventory",
+			config:      `devices: []`,
+		},
+		{
+			description:   "device without ID",
+			config:        "devices:\n- health: Healthy\n",
+			expectedError: true,
+		},
+		{
+			description:   "duplicate device ID",
+			config:        "devices:\n- id: dev-0\n- id: dev-0\n",
+			expectedError: true,
+		},
+		{
+			description:   "unsupported health value",
+			config:        "devices:\n- id: dev-0\n  health: Sick\n",
+			expectedError: true,
+		},
+		{
+			description:   "unknown field",
+			config:        "devices:\n- id: dev-0\n  vendor: example.com\n",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(testCase.config), 0644); err != nil {
+				t.Fatalf("failed to write the device config: %v", err)
+			}
+
+			devs, err := LoadDeviceConfig(configPath)
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error, got the devices %v", devs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(devs, testCase.expectedDevs) {
+				t.Fatalf("expected the devices %v, got %v", testCase.expectedDevs, devs)
+			}
+		})
+	}
+
+	t.Run("the config file does not exist", func(t *testing.T) {
+		if _, err := LoadDeviceConfig(filepath.Join(t.TempDir(), "does-not-exist.yaml")); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestReloadConfigWithoutConfigFile(t *testing.T) {
+	stub := NewDevicePluginStub(nil, filepath.Join(t.TempDir(), "plugin.sock"), testResourceName, false, false)
+	if err := stub.ReloadConfig(); err == nil {
+		t.Fatalf("expected an error reloading a stub created with an explicit device list")
+	}
+}
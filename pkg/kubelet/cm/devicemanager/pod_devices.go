@@ -98,6 +98,48 @@ func (pdev *podDevices) delete(pods []string) {
 	}
 }
 
+// resources returns the names of all the resources referenced by any container entry.
+func (pdev *podDevices) resources() sets.Set[string] {
+	pdev.RLock()
+	defer pdev.RUnlock()
+	ret := sets.New[string]()
+	for _, containers := range pdev.devs {
+		for _, resources := range containers {
+			for resource := range resources {
+				ret.Insert(resource)
+			}
+		}
+	}
+	return ret
+}
+
+// deleteResources removes every container entry referring to any of the given
+// resources, together with the containers and pods left empty by the removal.
+// Returns the number of container entries removed.
+func (pdev *podDevices) deleteResources(resources sets.Set[string]) int {
+	pdev.Lock()
+	defer pdev.Unlock()
+	removed := 0
+	for podUID, containers := range pdev.devs {
+		for contName, contResources := range containers {
+			for resource := range contResources {
+				if !resources.Has(resource) {
+					continue
+				}
+				delete(contResources, resource)
+				removed++
+			}
+			if len(contResources) == 0 {
+				delete(containers, contName)
+			}
+		}
+		if len(containers) == 0 {
+			delete(pdev.devs, podUID)
+		}
+	}
+	return removed
+}
+
 // Returns list of device Ids allocated to the given pod for the given resource.
 // Returns nil if we don't have cached state for the given <podUID, resource>.
 func (pdev *podDevices) podDevices(podUID, resource string) sets.Set[string] {
@@ -17,6 +17,9 @@ limitations under the License.
 package devicemanager
 
 import (
+	"fmt"
+	"sort"
+
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
@@ -209,17 +212,82 @@ func (m *ManagerImpl) generateDeviceTopologyHints(resource string, available set
 
 	// Loop back through all hints and update the 'Preferred' field based on
 	// counting the number of bits sets in the affinity mask and comparing it
-	// to the minAffinity. Only those with an equal number of bits set will be
-	// considered preferred.
+	// to the preferred affinity size. Only those with an equal number of bits
+	// set will be considered preferred. The preferred size is usually the
+	// minimal one; resources configured to spread their devices across NUMA
+	// nodes instead prefer masks wide enough to place each device on a
+	// different node, when the machine allows it.
+	preferredAffinitySize := minAffinitySize
+	if m.allocationStrategies[resource] == allocationStrategySpread {
+		if spreadSize := min(request, m.numaNodesWithDevices(resource)); spreadSize > preferredAffinitySize {
+			preferredAffinitySize = spreadSize
+		}
+	}
+
+	// Among the masks of the preferred size, the device-reported locality,
+	// when available, narrows the preference further: masks under which the
+	// whole request fits in a single uncore cache and PCIe root complex
+	// domain win over the ones where it does not. When no mask fits, or the
+	// devices report no locality, every preferred-size mask stays preferred
+	// as before. Resources spread across NUMA nodes want the devices apart,
+	// so the narrowing does not apply to them.
+	localityPreferred := sets.New[int]()
+	if m.allocationStrategies[resource] != allocationStrategySpread {
+		for i := range hints {
+			if hints[i].NUMANodeAffinity.Count() != preferredAffinitySize {
+				continue
+			}
+			if m.maskHasDeviceLocality(resource, hints[i].NUMANodeAffinity, request) {
+				localityPreferred.Insert(i)
+			}
+		}
+	}
 	for i := range hints {
-		if hints[i].NUMANodeAffinity.Count() == minAffinitySize {
-			hints[i].Preferred = true
+		if hints[i].NUMANodeAffinity.Count() != preferredAffinitySize {
+			continue
+		}
+		if localityPreferred.Len() > 0 && !localityPreferred.Has(i) {
+			continue
 		}
+		hints[i].Preferred = true
 	}
 
 	return hints
 }
 
+// maskHasDeviceLocality reports whether the request can be satisfied with
+// devices which are attached to the NUMA nodes of the given mask and all
+// share one locality domain.
+func (m *ManagerImpl) maskHasDeviceLocality(resource string, mask bitmask.BitMask, request int) bool {
+	perDomainDevices := make(map[string]int)
+	for _, device := range m.allDevices[resource] {
+		if !mask.AnySet(m.getNUMANodeIds(device.Topology)) {
+			continue
+		}
+		domain := deviceLocalityDomain(device.Topology)
+		if domain == "" {
+			continue
+		}
+		perDomainDevices[domain]++
+		if perDomainDevices[domain] >= request {
+			return true
+		}
+	}
+	return false
+}
+
+// numaNodesWithDevices returns how many distinct NUMA nodes have at least one
+// device of the given resource attached to them.
+func (m *ManagerImpl) numaNodesWithDevices(resource string) int {
+	nodes := sets.New[int]()
+	for _, device := range m.allDevices[resource] {
+		for _, id := range m.getNUMANodeIds(device.Topology) {
+			nodes.Insert(id)
+		}
+	}
+	return nodes.Len()
+}
+
 func (m *ManagerImpl) getNUMANodeIds(topology *pluginapi.TopologyInfo) []int {
 	if topology == nil {
 		return nil
@@ -231,6 +299,27 @@ func (m *ManagerImpl) getNUMANodeIds(topology *pluginapi.TopologyInfo) []int {
 	return ids
 }
 
+// deviceLocalityDomain renders the optional device-reported locality, the
+// uncore caches and PCIe root complexes the device is attached to, as a
+// comparable string. Devices mapping to the same non-empty domain share the
+// last-level cache and the PCIe fabric, so allocating them together keeps
+// their traffic local. The empty string means the device reports no locality.
+func deviceLocalityDomain(topology *pluginapi.TopologyInfo) string {
+	if topology == nil || (len(topology.UncoreCaches) == 0 && len(topology.PcieRootComplexes) == 0) {
+		return ""
+	}
+	var caches, complexes []int
+	for _, c := range topology.UncoreCaches {
+		caches = append(caches, int(c.ID))
+	}
+	for _, p := range topology.PcieRootComplexes {
+		complexes = append(complexes, int(p.ID))
+	}
+	sort.Ints(caches)
+	sort.Ints(complexes)
+	return fmt.Sprintf("llc:%v,pcie:%v", caches, complexes)
+}
+
 func (m *ManagerImpl) getPodDeviceRequest(pod *v1.Pod) map[string]int {
 	// for these device plugin resources, requests == limits
 	limits := resource.PodLimits(pod, resource.PodResourcesOptions{
@@ -50,6 +50,17 @@ func makeNUMADevice(id string, numa int) pluginapi.Device {
 	}
 }
 
+func makeLocalityDevice(id string, numa, uncoreCache, pcieRootComplex int) pluginapi.Device {
+	return pluginapi.Device{
+		ID: id,
+		Topology: &pluginapi.TopologyInfo{
+			Nodes:             []*pluginapi.NUMANode{{ID: int64(numa)}},
+			UncoreCaches:      []*pluginapi.UncoreCache{{ID: int64(uncoreCache)}},
+			PcieRootComplexes: []*pluginapi.PCIeRootComplex{{ID: int64(pcieRootComplex)}},
+		},
+	}
+}
+
 func makeSocketMask(sockets ...int) bitmask.BitMask {
 	mask, _ := bitmask.NewBitMask(sockets...)
 	return mask
@@ -1686,3 +1697,161 @@ func getPodScopeTestCases() []topologyHintTestCase {
 		},
 	}
 }
+
+func TestGetTopologyHintsWithAllocationStrategySpread(t *testing.T) {
+	testResource := "testdevice"
+	pod := makePod(v1.ResourceList{
+		v1.ResourceName(testResource): resource.MustParse("2"),
+	})
+	devices := []pluginapi.Device{
+		makeNUMADevice("Dev1", 0),
+		makeNUMADevice("Dev2", 0),
+		makeNUMADevice("Dev3", 1),
+		makeNUMADevice("Dev4", 1),
+	}
+
+	tcases := []struct {
+		description   string
+		strategy      string
+		expectedHints []topologymanager.TopologyHint
+	}{
+		{
+			description: "without a strategy single-node masks stay preferred",
+			strategy:    "",
+			expectedHints: []topologymanager.TopologyHint{
+				{NUMANodeAffinity: makeSocketMask(0), Preferred: true},
+				{NUMANodeAffinity: makeSocketMask(1), Preferred: true},
+				{NUMANodeAffinity: makeSocketMask(0, 1), Preferred: false},
+			},
+		},
+		{
+			description: "spread prefers masks wide enough to use one device per node",
+			strategy:    allocationStrategySpread,
+			expectedHints: []topologymanager.TopologyHint{
+				{NUMANodeAffinity: makeSocketMask(0), Preferred: false},
+				{NUMANodeAffinity: makeSocketMask(1), Preferred: false},
+				{NUMANodeAffinity: makeSocketMask(0, 1), Preferred: true},
+			},
+		},
+	}
+
+	for _, tc := range tcases {
+		m := ManagerImpl{
+			allDevices:           NewResourceDeviceInstances(),
+			healthyDevices:       make(map[string]sets.Set[string]),
+			allocatedDevices:     make(map[string]sets.Set[string]),
+			podDevices:           newPodDevices(),
+			sourcesReady:         &sourcesReadyStub{},
+			activePods:           func() []*v1.Pod { return []*v1.Pod{pod} },
+			numaNodes:            []int{0, 1},
+			allocationStrategies: map[string]string{testResource: tc.strategy},
+		}
+
+		m.allDevices[testResource] = make(DeviceInstances)
+		m.healthyDevices[testResource] = sets.New[string]()
+		for _, d := range devices {
+			m.allDevices[testResource][d.ID] = d
+			m.healthyDevices[testResource].Insert(d.ID)
+		}
+
+		hints := m.GetTopologyHints(pod, &pod.Spec.Containers[0])
+
+		sort.SliceStable(hints[testResource], func(i, j int) bool {
+			return hints[testResource][i].LessThan(hints[testResource][j])
+		})
+		sort.SliceStable(tc.expectedHints, func(i, j int) bool {
+			return tc.expectedHints[i].LessThan(tc.expectedHints[j])
+		})
+		if !reflect.DeepEqual(hints[testResource], tc.expectedHints) {
+			t.Errorf("%v: Expected result to be %#v, got %#v", tc.description, tc.expectedHints, hints[testResource])
+		}
+	}
+}
+
+func TestGetTopologyHintsWithDeviceLocality(t *testing.T) {
+	testResource := "testdevice"
+	pod := makePod(v1.ResourceList{
+		v1.ResourceName(testResource): resource.MustParse("2"),
+	})
+
+	tcases := []struct {
+		description   string
+		devices       []pluginapi.Device
+		expectedHints []topologymanager.TopologyHint
+	}{
+		{
+			description: "only the node where the request fits in one uncore cache stays preferred",
+			devices: []pluginapi.Device{
+				// node 0 has enough devices, but split across uncore caches
+				makeLocalityDevice("Dev1", 0, 0, 0),
+				makeLocalityDevice("Dev2", 0, 1, 0),
+				makeLocalityDevice("Dev3", 1, 4, 1),
+				makeLocalityDevice("Dev4", 1, 4, 1),
+			},
+			expectedHints: []topologymanager.TopologyHint{
+				{NUMANodeAffinity: makeSocketMask(0), Preferred: false},
+				{NUMANodeAffinity: makeSocketMask(1), Preferred: true},
+				{NUMANodeAffinity: makeSocketMask(0, 1), Preferred: false},
+			},
+		},
+		{
+			description: "no mask fits in one locality domain, all minimal masks stay preferred",
+			devices: []pluginapi.Device{
+				makeLocalityDevice("Dev1", 0, 0, 0),
+				makeLocalityDevice("Dev2", 0, 1, 0),
+				makeLocalityDevice("Dev3", 1, 4, 1),
+				makeLocalityDevice("Dev4", 1, 5, 1),
+			},
+			expectedHints: []topologymanager.TopologyHint{
+				{NUMANodeAffinity: makeSocketMask(0), Preferred: true},
+				{NUMANodeAffinity: makeSocketMask(1), Preferred: true},
+				{NUMANodeAffinity: makeSocketMask(0, 1), Preferred: false},
+			},
+		},
+		{
+			description: "devices without locality keep the historical preference",
+			devices: []pluginapi.Device{
+				makeNUMADevice("Dev1", 0),
+				makeNUMADevice("Dev2", 0),
+				makeNUMADevice("Dev3", 1),
+				makeNUMADevice("Dev4", 1),
+			},
+			expectedHints: []topologymanager.TopologyHint{
+				{NUMANodeAffinity: makeSocketMask(0), Preferred: true},
+				{NUMANodeAffinity: makeSocketMask(1), Preferred: true},
+				{NUMANodeAffinity: makeSocketMask(0, 1), Preferred: false},
+			},
+		},
+	}
+
+	for _, tc := range tcases {
+		m := ManagerImpl{
+			allDevices:       NewResourceDeviceInstances(),
+			healthyDevices:   make(map[string]sets.Set[string]),
+			allocatedDevices: make(map[string]sets.Set[string]),
+			podDevices:       newPodDevices(),
+			sourcesReady:     &sourcesReadyStub{},
+			activePods:       func() []*v1.Pod { return []*v1.Pod{pod} },
+			numaNodes:        []int{0, 1},
+		}
+
+		m.allDevices[testResource] = make(DeviceInstances)
+		m.healthyDevices[testResource] = sets.New[string]()
+		for _, d := range tc.devices {
+			m.allDevices[testResource][d.ID] = d
+			m.healthyDevices[testResource].Insert(d.ID)
+		}
+
+		hints := m.GetTopologyHints(pod, &pod.Spec.Containers[0])
+
+		sort.SliceStable(hints[testResource], func(i, j int) bool {
+			return hints[testResource][i].LessThan(hints[testResource][j])
+		})
+		sort.SliceStable(tc.expectedHints, func(i, j int) bool {
+			return tc.expectedHints[i].LessThan(tc.expectedHints[j])
+		})
+		if !reflect.DeepEqual(hints[testResource], tc.expectedHints) {
+			t.Errorf("%v: Expected result to be %#v, got %#v", tc.description, tc.expectedHints, hints[testResource])
+		}
+	}
+}
@@ -66,6 +66,10 @@ type Manager interface {
 	// GetAllocatableDevices returns information about all the devices known to the manager
 	GetAllocatableDevices() ResourceDeviceInstances
 
+	// GetAvailableDevices returns information about the healthy devices not
+	// currently allocated to any container
+	GetAvailableDevices() ResourceDeviceInstances
+
 	// ShouldResetExtendedResourceCapacity returns whether the extended resources should be reset or not,
 	// depending on the checkpoint file availability. Absence of the checkpoint file strongly indicates
 	// the node has been recreated.
@@ -81,6 +85,21 @@ type Manager interface {
 
 	// UpdateAllocatedDevices frees any Devices that are bound to terminated pods.
 	UpdateAllocatedDevices()
+
+	// RemoveContainer is called after Kubelet decides to kill or delete a
+	// container. Devices are bound to the owning pod rather than to the
+	// individual containers and are freed by UpdateAllocatedDevices once the
+	// pod terminates, so this is a no-op kept for uniformity with the other
+	// resource managers.
+	RemoveContainer(containerID string) error
+
+	// CheckpointFile returns the path of the checkpoint file the manager
+	// uses to persist the device assignments across kubelet restarts.
+	CheckpointFile() string
+
+	// StateSnapshot returns a point-in-time, JSON-serializable copy of the
+	// device assignments of the manager, for instrumentation and debugging.
+	StateSnapshot() interface{}
 }
 
 // DeviceRunContainerOptions contains the combined container runtime settings to consume its allocated devices.
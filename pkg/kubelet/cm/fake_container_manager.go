@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	internalapi "k8s.io/cri-api/pkg/apis"
 	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
@@ -72,6 +73,13 @@ func (cm *FakeContainerManager) GetNodeConfig() NodeConfig {
 	return NodeConfig{}
 }
 
+func (cm *FakeContainerManager) GetResourceManagersConfigz() ResourceManagersConfigz {
+	cm.Lock()
+	defer cm.Unlock()
+	cm.CalledFunctions = append(cm.CalledFunctions, "GetResourceManagersConfigz")
+	return ResourceManagersConfigz{}
+}
+
 func (cm *FakeContainerManager) GetMountedSubsystems() *CgroupSubsystems {
 	cm.Lock()
 	defer cm.Unlock()
@@ -136,6 +144,20 @@ func (cm *FakeContainerManager) GetDevicePluginResourceCapacity() (v1.ResourceLi
 	return nil, nil, []string{}
 }
 
+func (cm *FakeContainerManager) GetResourceManagersCapacity() v1.ResourceList {
+	cm.Lock()
+	defer cm.Unlock()
+	cm.CalledFunctions = append(cm.CalledFunctions, "GetResourceManagersCapacity")
+	return nil
+}
+
+func (cm *FakeContainerManager) GetNodeResourceTopology() *noderesourcetopology.NodeResourceTopology {
+	cm.Lock()
+	defer cm.Unlock()
+	cm.CalledFunctions = append(cm.CalledFunctions, "GetNodeResourceTopology")
+	return nil
+}
+
 func (cm *FakeContainerManager) NewPodContainerManager() PodContainerManager {
 	cm.Lock()
 	defer cm.Unlock()
@@ -199,6 +221,13 @@ func (cm *FakeContainerManager) GetAllocateResourcesPodAdmitHandler() lifecycle.
 	return topologymanager.NewFakeManager()
 }
 
+func (cm *FakeContainerManager) PodTopologyAligned(podUID string) (bool, bool) {
+	cm.Lock()
+	defer cm.Unlock()
+	cm.CalledFunctions = append(cm.CalledFunctions, "PodTopologyAligned")
+	return false, false
+}
+
 func (cm *FakeContainerManager) UpdateAllocatedDevices() {
 	cm.Lock()
 	defer cm.Unlock()
@@ -219,6 +248,19 @@ func (cm *FakeContainerManager) GetAllocatableCPUs() []int64 {
 	return nil
 }
 
+func (cm *FakeContainerManager) GetCPUTopology(_, _ string) []*podresourcesapi.CPUTopologyInfo {
+	cm.Lock()
+	defer cm.Unlock()
+	cm.CalledFunctions = append(cm.CalledFunctions, "GetCPUTopology")
+	return nil
+}
+
+func (cm *FakeContainerManager) GetAllocatableCPUTopology() []*podresourcesapi.CPUTopologyInfo {
+	cm.Lock()
+	defer cm.Unlock()
+	return nil
+}
+
 func (cm *FakeContainerManager) GetMemory(_, _ string) []*podresourcesapi.ContainerMemory {
 	cm.Lock()
 	defer cm.Unlock()
@@ -236,6 +278,13 @@ func (cm *FakeContainerManager) GetDynamicResources(pod *v1.Pod, container *v1.C
 	return nil
 }
 
+func (cm *FakeContainerManager) DryRunTopologyAdmission(pod *v1.Pod) *podresourcesapi.DryRunAdmissionResponse {
+	cm.Lock()
+	defer cm.Unlock()
+	cm.CalledFunctions = append(cm.CalledFunctions, "DryRunTopologyAdmission")
+	return &podresourcesapi.DryRunAdmissionResponse{Admit: true}
+}
+
 func (cm *FakeContainerManager) GetNodeAllocatableAbsolute() v1.ResourceList {
 	cm.Lock()
 	defer cm.Unlock()
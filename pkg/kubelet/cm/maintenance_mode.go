@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"os"
+	"path/filepath"
+
+	v1 "k8s.io/api/core/v1"
+
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
+	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
+	"k8s.io/kubernetes/pkg/kubelet/cm/admission"
+)
+
+const (
+	// maintenanceModeFileName is the name of the flag file under the kubelet
+	// root directory which puts the resource managers in maintenance mode.
+	// The administrator creates it (e.g. with touch) to stop new exclusive
+	// allocations before starting node maintenance, and removes it to resume
+	// normal operation. The file is checked on every admission, so the
+	// toggle takes effect without a kubelet restart.
+	maintenanceModeFileName = "resource_managers_maintenance"
+)
+
+// maintenanceModeEnabled reports whether the resource managers maintenance
+// mode flag file is present under the kubelet root directory.
+func maintenanceModeEnabled(kubeletRootDir string) bool {
+	_, err := os.Stat(filepath.Join(kubeletRootDir, maintenanceModeFileName))
+	return err == nil
+}
+
+// podRequestsExclusiveAllocations reports whether admitting the pod would
+// carve out new exclusive resources from the managers: exclusive CPUs (and,
+// with them, pinned memory) for a Guaranteed pod with integer CPU requests,
+// or devices for any container requesting an extended resource. Pods sharing
+// the common pools are unaffected by maintenance mode.
+func podRequestsExclusiveAllocations(pod *v1.Pod) bool {
+	guaranteed := v1qos.GetPodQOS(pod) == v1.PodQOSGuaranteed
+	for _, container := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		for resourceName, quantity := range container.Resources.Requests {
+			if v1helper.IsExtendedResourceName(resourceName) && !quantity.IsZero() {
+				return true
+			}
+		}
+		if guaranteed {
+			cpuQuantity := container.Resources.Requests[v1.ResourceCPU]
+			if cpuQuantity.Value()*1000 == cpuQuantity.MilliValue() && !cpuQuantity.IsZero() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maintenanceModeError is the admission error returned for pods requesting
+// exclusive allocations while the resource managers are in maintenance mode.
+type maintenanceModeError struct{}
+
+var _ admission.Error = (*maintenanceModeError)(nil)
+var _ admission.RemediableError = (*maintenanceModeError)(nil)
+
+func (e *maintenanceModeError) Error() string {
+	return "resource managers are in maintenance mode, new exclusive allocations are refused"
+}
+
+func (e *maintenanceModeError) Type() string {
+	return admission.ErrorReasonMaintenance
+}
+
+func (e *maintenanceModeError) RemediationHint() string {
+	return "retry once the node maintenance completed, or schedule the pod on a different node"
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestMaintenanceModeEnabled(t *testing.T) {
+	kubeletRootDir := t.TempDir()
+
+	if maintenanceModeEnabled(kubeletRootDir) {
+		t.Errorf("Expected maintenance mode to be disabled without the flag file")
+	}
+
+	flagFile := filepath.Join(kubeletRootDir, maintenanceModeFileName)
+	if err := os.WriteFile(flagFile, nil, 0644); err != nil {
+		t.Fatalf("Unexpected error creating the flag file: %v", err)
+	}
+	if !maintenanceModeEnabled(kubeletRootDir) {
+		t.Errorf("Expected maintenance mode to be enabled with the flag file present")
+	}
+
+	if err := os.Remove(flagFile); err != nil {
+		t.Fatalf("Unexpected error removing the flag file: %v", err)
+	}
+	if maintenanceModeEnabled(kubeletRootDir) {
+		t.Errorf("Expected maintenance mode to be disabled again after removing the flag file")
+	}
+}
+
+func TestPodRequestsExclusiveAllocations(t *testing.T) {
+	makePod := func(requests, limits v1.ResourceList) *v1.Pod {
+		return &v1.Pod{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: "cnt-1",
+						Resources: v1.ResourceRequirements{
+							Requests: requests,
+							Limits:   limits,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		pod      *v1.Pod
+		expected bool
+	}{
+		{
+			name: "burstable pod sharing the pool",
+			pod: makePod(
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+				nil,
+			),
+			expected: false,
+		},
+		{
+			name: "guaranteed pod with fractional CPUs",
+			pod: makePod(
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("128Mi")},
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("128Mi")},
+			),
+			expected: false,
+		},
+		{
+			name: "guaranteed pod with integer CPUs",
+			pod: makePod(
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("128Mi")},
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("128Mi")},
+			),
+			expected: true,
+		},
+		{
+			name: "burstable pod requesting devices",
+			pod: makePod(
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), "vendor.example.com/gpu": resource.MustParse("1")},
+				v1.ResourceList{"vendor.example.com/gpu": resource.MustParse("1")},
+			),
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := podRequestsExclusiveAllocations(tc.pod); got != tc.expected {
+				t.Errorf("Expected podRequestsExclusiveAllocations to return %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 	"k8s.io/kubernetes/pkg/kubelet/config"
 	"k8s.io/kubernetes/pkg/kubelet/status"
 )
@@ -86,6 +87,31 @@ func (m *fakeManager) GetMemory(podUID, containerName string) []state.Block {
 	return []state.Block{}
 }
 
+// GetAdmissibleAffinities computes the NUMA node masks on which a hypothetical
+// memory request could currently be satisfied
+func (m *fakeManager) GetAdmissibleAffinities(requestedResources map[v1.ResourceName]uint64) []bitmask.BitMask {
+	klog.InfoS("Get Admissible Affinities")
+	return nil
+}
+
+// CheckpointFile returns the path of the checkpoint file
+func (m *fakeManager) CheckpointFile() string {
+	klog.InfoS("CheckpointFile")
+	return ""
+}
+
+// StateSnapshot returns a copy of the memory assignments
+func (m *fakeManager) StateSnapshot() interface{} {
+	klog.InfoS("StateSnapshot")
+	return nil
+}
+
+// ConfigSnapshot returns a copy of the effective manager configuration
+func (m *fakeManager) ConfigSnapshot() interface{} {
+	klog.InfoS("ConfigSnapshot")
+	return nil
+}
+
 // NewFakeManager creates empty/fake memory manager
 func NewFakeManager() Manager {
 	return &fakeManager{
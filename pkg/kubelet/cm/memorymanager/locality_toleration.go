@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LocalityTolerationAnnotationPrefix is the prefix of the per-container
+	// annotation a guaranteed pod can use to relax the memory placement of a
+	// single container. The container name follows the prefix, like
+	// `locality-toleration.memory-manager.kubernetes.io/<container-name>`.
+	// Only the "anywhere" value is supported: the memory of that container
+	// may come from any NUMA node, so the static policy neither pins it nor
+	// accounts its request against a specific set of nodes. The other
+	// containers of the pod keep the usual strict guarantees.
+	LocalityTolerationAnnotationPrefix = "locality-toleration.memory-manager.kubernetes.io/"
+
+	// localityTolerationAnywhere is the only supported value of the
+	// LocalityTolerationAnnotationPrefix annotations.
+	localityTolerationAnywhere = "anywhere"
+)
+
+// containerToleratesAnyLocality returns true if the pod declared, through the
+// LocalityTolerationAnnotationPrefix annotation, that the memory of the named
+// container may be placed on any NUMA node.
+func containerToleratesAnyLocality(pod *v1.Pod, containerName string) bool {
+	return pod.Annotations[LocalityTolerationAnnotationPrefix+containerName] == localityTolerationAnywhere
+}
+
+// podToleratesAnyLocality returns true if every container of the pod,
+// including the init containers, tolerates any memory locality.
+func podToleratesAnyLocality(pod *v1.Pod) bool {
+	for _, ctn := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if !containerToleratesAnyLocality(pod, ctn.Name) {
+			return false
+		}
+	}
+	return true
+}
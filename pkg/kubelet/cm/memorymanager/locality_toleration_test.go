@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestContainerToleratesAnyLocality(t *testing.T) {
+	testCases := []struct {
+		description string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			description: "pod without annotations",
+		},
+		{
+			description: "container tolerating any locality",
+			annotations: map[string]string{LocalityTolerationAnnotationPrefix + "container1": localityTolerationAnywhere},
+			expected:    true,
+		},
+		{
+			description: "toleration set for a different container",
+			annotations: map[string]string{LocalityTolerationAnnotationPrefix + "container2": localityTolerationAnywhere},
+		},
+		{
+			description: "container with an unsupported toleration value",
+			annotations: map[string]string{LocalityTolerationAnnotationPrefix + "container1": "same-socket"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: testCase.annotations,
+				},
+			}
+			if got := containerToleratesAnyLocality(pod, "container1"); got != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestPodToleratesAnyLocality(t *testing.T) {
+	testCases := []struct {
+		description string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			description: "pod without annotations",
+		},
+		{
+			description: "only one of the containers tolerates any locality",
+			annotations: map[string]string{LocalityTolerationAnnotationPrefix + "container1": localityTolerationAnywhere},
+		},
+		{
+			description: "all the containers tolerate any locality",
+			annotations: map[string]string{
+				LocalityTolerationAnnotationPrefix + "init-container1": localityTolerationAnywhere,
+				LocalityTolerationAnnotationPrefix + "container1":      localityTolerationAnywhere,
+				LocalityTolerationAnnotationPrefix + "container2":      localityTolerationAnywhere,
+			},
+			expected: true,
+		},
+		{
+			description: "the init container does not tolerate any locality",
+			annotations: map[string]string{
+				LocalityTolerationAnnotationPrefix + "container1": localityTolerationAnywhere,
+				LocalityTolerationAnnotationPrefix + "container2": localityTolerationAnywhere,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: testCase.annotations,
+				},
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{
+						{Name: "init-container1"},
+					},
+					Containers: []v1.Container{
+						{Name: "container1"},
+						{Name: "container2"},
+					},
+				},
+			}
+			if got := podToleratesAnyLocality(pod); got != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -19,13 +19,18 @@ package memorymanager
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	cadvisorapi "github.com/google/cadvisor/info/v1"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 	"k8s.io/klog/v2"
 	corev1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
@@ -33,7 +38,10 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 	"k8s.io/kubernetes/pkg/kubelet/config"
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	"k8s.io/kubernetes/pkg/kubelet/status"
 	"k8s.io/kubernetes/pkg/kubelet/types"
 )
@@ -41,6 +49,11 @@ import (
 // memoryManagerStateFileName is the file name where memory manager stores its state
 const memoryManagerStateFileName = "memory_manager_state"
 
+// defaultReconcilePeriod is the duration between calls to reconcileState when
+// no period is configured. The value mirrors the default reconcile period of
+// the CPU manager.
+const defaultReconcilePeriod = 10 * time.Second
+
 // ActivePodsFunc is a function that returns a list of active pods
 type ActivePodsFunc func() []*v1.Pod
 
@@ -91,6 +104,24 @@ type Manager interface {
 
 	// GetMemory returns the memory allocated by a container from NUMA nodes
 	GetMemory(podUID, containerName string) []state.Block
+
+	// GetAdmissibleAffinities computes, without mutating any state, the NUMA
+	// node masks on which a hypothetical memory request (one size per
+	// resource name) could currently be satisfied, so external consumers can
+	// estimate pod feasibility without going through admission.
+	GetAdmissibleAffinities(requestedResources map[v1.ResourceName]uint64) []bitmask.BitMask
+
+	// CheckpointFile returns the path of the checkpoint file the manager
+	// uses to persist the memory assignments across kubelet restarts.
+	CheckpointFile() string
+
+	// StateSnapshot returns a point-in-time, JSON-serializable copy of the
+	// memory assignments of the manager, for instrumentation and debugging.
+	StateSnapshot() interface{}
+
+	// ConfigSnapshot returns a JSON-serializable copy of the effective,
+	// post-defaulting configuration the manager is running with.
+	ConfigSnapshot() interface{}
 }
 
 type manager struct {
@@ -121,12 +152,20 @@ type manager struct {
 	// We use it to determine when we can purge inactive pods from checkpointed state.
 	sourcesReady config.SourcesReady
 
+	// reconcilePeriod is the duration between calls to reconcileState.
+	reconcilePeriod time.Duration
+
 	// stateFileDirectory holds the directory where the state file for checkpoints is held.
 	stateFileDirectory string
 
 	// allocatableMemory holds the allocatable memory for each NUMA node
 	allocatableMemory []state.Block
 
+	// lastUpdatedMems holds the cpuset.mems value last applied to each
+	// running container by the reconcile loop, indexed by pod UID and
+	// container name.
+	lastUpdatedMems map[string]map[string]string
+
 	// pendingAdmissionPod contain the pod during the admission phase
 	pendingAdmissionPod *v1.Pod
 }
@@ -134,9 +173,13 @@ type manager struct {
 var _ Manager = &manager{}
 
 // NewManager returns new instance of the memory manager
-func NewManager(policyName string, machineInfo *cadvisorapi.MachineInfo, nodeAllocatableReservation v1.ResourceList, reservedMemory []kubeletconfig.MemoryReservation, stateFileDirectory string, affinity topologymanager.Store) (Manager, error) {
+func NewManager(policyName string, reconcilePeriod time.Duration, machineInfo *cadvisorapi.MachineInfo, nodeAllocatableReservation v1.ResourceList, reservedMemory []kubeletconfig.MemoryReservation, stateFileDirectory string, affinity topologymanager.Store) (Manager, error) {
 	var policy Policy
 
+	if reconcilePeriod <= 0 {
+		reconcilePeriod = defaultReconcilePeriod
+	}
+
 	switch policyType(policyName) {
 
 	case policyTypeNone:
@@ -153,13 +196,26 @@ func NewManager(policyName string, machineInfo *cadvisorapi.MachineInfo, nodeAll
 			return nil, err
 		}
 
+	case policyTypeBestEffort:
+		systemReserved, err := getSystemReservedMemory(machineInfo, nodeAllocatableReservation, reservedMemory)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err = NewPolicyBestEffort(machineInfo, systemReserved, affinity)
+		if err != nil {
+			return nil, err
+		}
+
 	default:
 		return nil, fmt.Errorf("unknown policy: \"%s\"", policyName)
 	}
 
 	manager := &manager{
 		policy:             policy,
+		reconcilePeriod:    reconcilePeriod,
 		stateFileDirectory: stateFileDirectory,
+		lastUpdatedMems:    map[string]map[string]string{},
 	}
 	manager.sourcesReady = &sourcesReadyStub{}
 	return manager, nil
@@ -189,6 +245,14 @@ func (m *manager) Start(activePods ActivePodsFunc, sourcesReady config.SourcesRe
 
 	m.allocatableMemory = m.policy.GetAllocatableMemory(m.state)
 
+	if m.policy.Name() != string(policyTypeNone) {
+		// Periodically call m.reconcileState() to re-apply cpuset.mems to the
+		// running containers whose memory affinity diverged from the state,
+		// in the same vein as the CPU manager reconcile loop. cpuset.mems is
+		// otherwise only set on container creation.
+		go wait.Until(func() { m.reconcileState() }, m.reconcilePeriod, wait.NeverStop)
+	}
+
 	return nil
 }
 
@@ -282,6 +346,32 @@ func (m *manager) State() state.Reader {
 	return m.state
 }
 
+func (m *manager) CheckpointFile() string {
+	return filepath.Join(m.stateFileDirectory, memoryManagerStateFileName)
+}
+
+// StateSnapshot returns the current memory assignments in the same shape the
+// state checkpoint uses.
+func (m *manager) StateSnapshot() interface{} {
+	return m.state.GetMemoryAssignments()
+}
+
+// ConfigSnapshot returns the effective configuration the manager is running
+// with: the policy picked at startup and, for the static policy, the reserved
+// memory computed per NUMA node.
+func (m *manager) ConfigSnapshot() interface{} {
+	snapshot := struct {
+		PolicyName     string               `json:"policyName"`
+		ReservedMemory systemReservedMemory `json:"reservedMemory,omitempty"`
+	}{
+		PolicyName: m.policy.Name(),
+	}
+	if staticPolicy, ok := m.policy.(*staticPolicy); ok {
+		snapshot.ReservedMemory = staticPolicy.systemReserved
+	}
+	return snapshot
+}
+
 // GetPodTopologyHints returns the topology hints for the topology manager
 func (m *manager) GetPodTopologyHints(pod *v1.Pod) map[string][]topologymanager.TopologyHint {
 	// The pod is during the admission phase. We need to save the pod to avoid it
@@ -360,6 +450,152 @@ func (m *manager) removeStaleState() {
 func (m *manager) policyRemoveContainerByRef(podUID string, containerName string) {
 	m.policy.RemoveContainer(m.state, podUID, containerName)
 	m.containerMap.RemoveByContainerRef(podUID, containerName)
+
+	if _, ok := m.lastUpdatedMems[podUID]; ok {
+		delete(m.lastUpdatedMems[podUID], containerName)
+		if len(m.lastUpdatedMems[podUID]) == 0 {
+			delete(m.lastUpdatedMems, podUID)
+		}
+	}
+}
+
+type reconciledContainer struct {
+	podName       string
+	containerName string
+	containerID   string
+}
+
+// reconcileState re-applies cpuset.mems to the running containers whose
+// memory affinity diverged from the memory manager assignments. cpuset.mems
+// is otherwise only set on container creation, so any state change happening
+// afterwards (e.g. after a kubelet restart with a different configuration)
+// would never reach the running containers.
+func (m *manager) reconcileState() (success []reconciledContainer, failure []reconciledContainer) {
+	ctx := context.Background()
+	success = []reconciledContainer{}
+	failure = []reconciledContainer{}
+
+	m.removeStaleState()
+	for _, pod := range m.activePods() {
+		pstatus, ok := m.podStatusProvider.GetPodStatus(pod.UID)
+		if !ok {
+			klog.V(4).InfoS("ReconcileState: skipping pod; status not found", "pod", klog.KObj(pod))
+			failure = append(failure, reconciledContainer{pod.Name, "", ""})
+			continue
+		}
+
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			containerID, err := findContainerIDByName(&pstatus, container.Name)
+			if err != nil {
+				klog.V(4).InfoS("ReconcileState: skipping container; ID not found in pod status", "pod", klog.KObj(pod), "containerName", container.Name, "err", err)
+				failure = append(failure, reconciledContainer{pod.Name, container.Name, ""})
+				continue
+			}
+
+			cstatus, err := findContainerStatusByName(&pstatus, container.Name)
+			if err != nil {
+				klog.V(4).InfoS("ReconcileState: skipping container; container status not found in pod status", "pod", klog.KObj(pod), "containerName", container.Name, "err", err)
+				failure = append(failure, reconciledContainer{pod.Name, container.Name, ""})
+				continue
+			}
+
+			if cstatus.State.Running == nil {
+				// only running containers can be updated.
+				continue
+			}
+
+			mems := m.containerMemoryAffinity(string(pod.UID), container.Name)
+			if mems == "" {
+				// the container memory is not pinned, nothing to reconcile.
+				continue
+			}
+
+			m.Lock()
+			lmems, seen := m.lastUpdatedMems[string(pod.UID)][container.Name]
+			m.Unlock()
+			if mems == lmems {
+				success = append(success, reconciledContainer{pod.Name, container.Name, containerID})
+				continue
+			}
+			if seen {
+				// the affinity previously applied to the container diverged
+				// from the current assignment.
+				metrics.MemoryManagerCpusetMemsDriftTotal.Inc()
+			}
+
+			klog.V(4).InfoS("ReconcileState: updating container", "pod", klog.KObj(pod), "containerName", container.Name, "containerID", containerID, "mems", mems)
+			if err := m.updateContainerMemoryAffinity(ctx, containerID, mems); err != nil {
+				klog.ErrorS(err, "ReconcileState: failed to update container", "pod", klog.KObj(pod), "containerName", container.Name, "containerID", containerID, "mems", mems)
+				failure = append(failure, reconciledContainer{pod.Name, container.Name, containerID})
+				continue
+			}
+
+			m.Lock()
+			if m.lastUpdatedMems[string(pod.UID)] == nil {
+				m.lastUpdatedMems[string(pod.UID)] = make(map[string]string)
+			}
+			m.lastUpdatedMems[string(pod.UID)][container.Name] = mems
+			m.Unlock()
+			success = append(success, reconciledContainer{pod.Name, container.Name, containerID})
+		}
+	}
+	return success, failure
+}
+
+// containerMemoryAffinity renders the NUMA affinity of the container memory
+// blocks the same way the affinity is rendered on container creation (see
+// InternalContainerLifecycle.PreCreateContainer), or returns an empty string
+// if the container memory is not pinned.
+func (m *manager) containerMemoryAffinity(podUID, containerName string) string {
+	numaNodes := sets.New[int]()
+	for _, block := range m.state.GetMemoryBlocks(podUID, containerName) {
+		numaNodes.Insert(block.NUMAAffinity...)
+	}
+	if numaNodes.Len() == 0 {
+		return ""
+	}
+
+	affinity := make([]string, 0, numaNodes.Len())
+	for _, numaNode := range sets.List(numaNodes) {
+		affinity = append(affinity, strconv.Itoa(numaNode))
+	}
+	return strings.Join(affinity, ",")
+}
+
+func (m *manager) updateContainerMemoryAffinity(ctx context.Context, containerID string, mems string) error {
+	return m.containerRuntime.UpdateContainerResources(
+		ctx,
+		containerID,
+		&runtimeapi.ContainerResources{
+			Linux: &runtimeapi.LinuxContainerResources{
+				CpusetMems: mems,
+			},
+		})
+}
+
+func findContainerIDByName(status *v1.PodStatus, name string) (string, error) {
+	allStatuses := status.InitContainerStatuses
+	allStatuses = append(allStatuses, status.ContainerStatuses...)
+	for _, container := range allStatuses {
+		if container.Name == name && container.ContainerID != "" {
+			cid := &kubecontainer.ContainerID{}
+			err := cid.ParseString(container.ContainerID)
+			if err != nil {
+				return "", err
+			}
+			return cid.ID, nil
+		}
+	}
+	return "", fmt.Errorf("unable to find ID for container with name %v in pod status (it may not be running)", name)
+}
+
+func findContainerStatusByName(status *v1.PodStatus, name string) (*v1.ContainerStatus, error) {
+	for _, containerStatus := range append(status.InitContainerStatuses, status.ContainerStatuses...) {
+		if containerStatus.Name == name {
+			return &containerStatus, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find status for container with name %v in pod status (it may not be running)", name)
 }
 
 func getTotalMemoryTypeReserved(machineInfo *cadvisorapi.MachineInfo, reservedMemory []kubeletconfig.MemoryReservation) (map[v1.ResourceName]resource.Quantity, error) {
@@ -465,6 +701,15 @@ func (m *manager) GetMemory(podUID, containerName string) []state.Block {
 	return m.state.GetMemoryBlocks(podUID, containerName)
 }
 
+// GetAdmissibleAffinities computes the NUMA node masks on which a hypothetical
+// memory request could currently be satisfied, it is a dry run
+func (m *manager) GetAdmissibleAffinities(requestedResources map[v1.ResourceName]uint64) []bitmask.BitMask {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.policy.GetAdmissibleAffinities(m.state, requestedResources)
+}
+
 func (m *manager) setPodPendingAdmission(pod *v1.Pod) {
 	m.Lock()
 	defer m.Unlock()
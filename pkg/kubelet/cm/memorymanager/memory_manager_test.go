@@ -38,6 +38,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 )
 
 const (
@@ -118,6 +119,10 @@ func (p *mockPolicy) GetAllocatableMemory(s state.State) []state.Block {
 	return []state.Block{}
 }
 
+func (p *mockPolicy) GetAdmissibleAffinities(s state.State, requestedResources map[v1.ResourceName]uint64) []bitmask.BitMask {
+	return nil
+}
+
 type mockRuntimeService struct {
 	err error
 }
@@ -1352,7 +1357,10 @@ func TestAddContainer(t *testing.T) {
 					},
 				},
 			},
-			expectedAllocateError:     fmt.Errorf("[memorymanager] failed to get the default NUMA affinity, no NUMA nodes with enough memory is available"),
+			expectedAllocateError: MemoryAffinityError{RequestedResources: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: 12 * gb,
+				hugepages1Gi:      4 * gb,
+			}},
 			expectedAddContainerError: nil,
 			podAllocate: getPod("fakePod2", "fakeContainer2", &v1.ResourceRequirements{
 				Limits: v1.ResourceList{
@@ -1988,7 +1996,7 @@ func TestNewManager(t *testing.T) {
 			}
 			defer os.RemoveAll(stateFileDirectory)
 
-			mgr, err := NewManager(string(testCase.policyName), &testCase.machineInfo, testCase.nodeAllocatableReservation, testCase.systemReservedMemory, stateFileDirectory, testCase.affinity)
+			mgr, err := NewManager(string(testCase.policyName), defaultReconcilePeriod, &testCase.machineInfo, testCase.nodeAllocatableReservation, testCase.systemReservedMemory, stateFileDirectory, testCase.affinity)
 
 			if !reflect.DeepEqual(err, testCase.expectedError) {
 				t.Errorf("Could not create the Memory Manager. Expected error: '%v', but got: '%v'",
@@ -2517,3 +2525,89 @@ func returnMachineInfo() cadvisorapi.MachineInfo {
 		},
 	}
 }
+
+func TestReconcileState(t *testing.T) {
+	testCases := []struct {
+		description     string
+		assignments     state.ContainerMemoryAssignments
+		lastUpdatedMems map[string]map[string]string
+		expectedMems    string
+	}{
+		{
+			description:  "container without memory pinning is skipped",
+			assignments:  state.ContainerMemoryAssignments{},
+			expectedMems: "",
+		},
+		{
+			description: "pinned container is updated",
+			assignments: state.ContainerMemoryAssignments{
+				"fakePod1": map[string][]state.Block{
+					"fakeContainer1": {
+						{NUMAAffinity: []int{0}, Type: v1.ResourceMemory, Size: 1 * gb},
+					},
+				},
+			},
+			expectedMems: "0",
+		},
+		{
+			description: "drifted container is updated with the current affinity",
+			assignments: state.ContainerMemoryAssignments{
+				"fakePod1": map[string][]state.Block{
+					"fakeContainer1": {
+						{NUMAAffinity: []int{0, 1}, Type: v1.ResourceMemory, Size: 1 * gb},
+					},
+				},
+			},
+			lastUpdatedMems: map[string]map[string]string{
+				"fakePod1": {"fakeContainer1": "0"},
+			},
+			expectedMems: "0,1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			pod := getPod("fakePod1", "fakeContainer1", requirementsGuaranteed)
+			pod.Name = "fakePod1"
+			mgr := &manager{
+				policy:       &mockPolicy{},
+				state:        state.NewMemoryState(),
+				containerMap: containermap.NewContainerMap(),
+				containerRuntime: mockRuntimeService{
+					err: nil,
+				},
+				activePods: func() []*v1.Pod { return []*v1.Pod{pod} },
+				podStatusProvider: mockPodStatusProvider{
+					podStatus: v1.PodStatus{
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name:        "fakeContainer1",
+								ContainerID: "docker://fakeContainerID",
+								State: v1.ContainerState{
+									Running: &v1.ContainerStateRunning{},
+								},
+							},
+						},
+					},
+					found: true,
+				},
+				lastUpdatedMems: testCase.lastUpdatedMems,
+			}
+			if mgr.lastUpdatedMems == nil {
+				mgr.lastUpdatedMems = map[string]map[string]string{}
+			}
+			mgr.sourcesReady = &sourcesReadyStub{}
+			mgr.state.SetMemoryAssignments(testCase.assignments)
+
+			_, failure := mgr.reconcileState()
+			if len(failure) != 0 {
+				t.Errorf("expected no failures, but got: %v", failure)
+			}
+
+			mems := mgr.lastUpdatedMems["fakePod1"]["fakeContainer1"]
+			if mems != testCase.expectedMems {
+				t.Errorf("expected applied cpuset.mems %q, but got: %q", testCase.expectedMems, mems)
+			}
+		})
+	}
+}
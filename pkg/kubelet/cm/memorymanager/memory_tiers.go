@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/cpuset"
+)
+
+const (
+	// memoryTieringDir is where kernels with memory tiering support (6.1+)
+	// expose the tier composition of the machine. Each memory_tier%d
+	// directory groups the NUMA nodes whose memory falls in the same
+	// abstract distance band; lower numbers mean faster memory, so DRAM
+	// ends up in the lowest-numbered tier and slower memory, like
+	// CXL-attached expanders, in higher-numbered ones.
+	memoryTieringDir = "/sys/devices/virtual/memory_tiering"
+
+	// PreferredMemoryTierAnnotation is the annotation a guaranteed pod can
+	// use to demand placement on a specific memory tier. Only the "dram"
+	// value is supported: the pod memory must come from NUMA nodes in the
+	// top (fastest) memory tier. Pods without the annotation can be placed
+	// on, or spill over to, any tier.
+	PreferredMemoryTierAnnotation = "memory-manager.kubernetes.io/preferred-memory-tier"
+
+	// memoryTierDRAM is the only supported value of PreferredMemoryTierAnnotation.
+	memoryTierDRAM = "dram"
+)
+
+// readTopTierNUMANodes returns the NUMA node IDs whose memory belongs to the
+// top (fastest) memory tier, as reported by the kernel under `tieringDir`.
+// Machines without memory tiering support have no such directory; the caller
+// is expected to treat the resulting error as "all the nodes are DRAM".
+func readTopTierNUMANodes(tieringDir string) ([]int, error) {
+	entries, err := os.ReadDir(tieringDir)
+	if err != nil {
+		return nil, err
+	}
+
+	topTier := -1
+	var topTierNodes []int
+	for _, entry := range entries {
+		var tier int
+		if _, err := fmt.Sscanf(entry.Name(), "memory_tier%d", &tier); err != nil {
+			continue
+		}
+		if topTier != -1 && tier >= topTier {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(tieringDir, entry.Name(), "nodelist"))
+		if err != nil {
+			return nil, err
+		}
+		// The nodelist file uses the same list format as cpu lists, e.g. "0-1,4".
+		nodes, err := cpuset.Parse(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the node list of %s: %w", entry.Name(), err)
+		}
+
+		topTier = tier
+		topTierNodes = nodes.List()
+	}
+
+	if topTier == -1 {
+		return nil, fmt.Errorf("no memory tiers found in %s", tieringDir)
+	}
+	return topTierNodes, nil
+}
+
+// podRequestsTopTierMemory returns true if the pod demanded DRAM-only
+// placement through the PreferredMemoryTierAnnotation annotation.
+func podRequestsTopTierMemory(pod *v1.Pod) bool {
+	return pod.Annotations[PreferredMemoryTierAnnotation] == memoryTierDRAM
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeMemoryTier(t *testing.T, tieringDir string, tier int, nodelist string) {
+	t.Helper()
+
+	tierDir := filepath.Join(tieringDir, fmt.Sprintf("memory_tier%d", tier))
+	if err := os.MkdirAll(tierDir, 0755); err != nil {
+		t.Fatalf("failed to create the tier directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tierDir, "nodelist"), []byte(nodelist+"\n"), 0644); err != nil {
+		t.Fatalf("failed to write the tier nodelist: %v", err)
+	}
+}
+
+func TestReadTopTierNUMANodes(t *testing.T) {
+	testCases := []struct {
+		description   string
+		tiers         map[int]string
+		expectedNodes []int
+		expectedError bool
+	}{
+		{
+			description:   "single tier machine, all the nodes are top tier",
+			tiers:         map[int]string{4: "0-1"},
+			expectedNodes: []int{0, 1},
+		},
+		{
+			description:   "multiple tiers, only the lowest numbered one is top tier",
+			tiers:         map[int]string{4: "0,2", 5: "1,3"},
+			expectedNodes: []int{0, 2},
+		},
+		{
+			description:   "no memory tier entries under the tiering directory",
+			tiers:         map[int]string{},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			tieringDir := t.TempDir()
+			for tier, nodelist := range testCase.tiers {
+				writeMemoryTier(t, tieringDir, tier, nodelist)
+			}
+
+			nodes, err := readTopTierNUMANodes(tieringDir)
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error, got the nodes %v", nodes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(nodes, testCase.expectedNodes) {
+				t.Fatalf("expected the top tier nodes %v, got %v", testCase.expectedNodes, nodes)
+			}
+		})
+	}
+
+	t.Run("the tiering directory does not exist", func(t *testing.T) {
+		if _, err := readTopTierNUMANodes(filepath.Join(t.TempDir(), "does-not-exist")); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestPodRequestsTopTierMemory(t *testing.T) {
+	testCases := []struct {
+		description string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			description: "pod without annotations",
+		},
+		{
+			description: "pod demanding DRAM-only placement",
+			annotations: map[string]string{PreferredMemoryTierAnnotation: memoryTierDRAM},
+			expected:    true,
+		},
+		{
+			description: "pod with an unsupported memory tier value",
+			annotations: map[string]string{PreferredMemoryTierAnnotation: "cxl"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: testCase.annotations,
+				},
+			}
+			if got := podRequestsTopTierMemory(pod); got != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 )
 
 // Type defines the policy type
@@ -43,4 +44,8 @@ type Policy interface {
 	GetPodTopologyHints(s state.State, pod *v1.Pod) map[string][]topologymanager.TopologyHint
 	// GetAllocatableMemory returns the amount of allocatable memory for each NUMA node
 	GetAllocatableMemory(s state.State) []state.Block
+	// GetAdmissibleAffinities computes, without mutating any state, the NUMA
+	// node masks on which a hypothetical memory request could currently be
+	// satisfied.
+	GetAdmissibleAffinities(s state.State, requestedResources map[v1.ResourceName]uint64) []bitmask.BitMask
 }
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+)
+
+const policyTypeBestEffort policyType = "BestEffort"
+
+// bestEffortPolicy computes NUMA affinity and pins memory exactly like the
+// static policy, but never fails the pod admission because of it: when the
+// memory of a container cannot be placed with the requested locality, the
+// container runs with unrestricted memory affinity instead of being rejected.
+type bestEffortPolicy struct {
+	*staticPolicy
+}
+
+var _ Policy = &bestEffortPolicy{}
+
+// NewPolicyBestEffort returns new best-effort policy instance
+func NewPolicyBestEffort(machineInfo *cadvisorapi.MachineInfo, reserved systemReservedMemory, affinity topologymanager.Store) (Policy, error) {
+	static, err := NewPolicyStatic(machineInfo, reserved, affinity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bestEffortPolicy{staticPolicy: static.(*staticPolicy)}, nil
+}
+
+func (p *bestEffortPolicy) Name() string {
+	return string(policyTypeBestEffort)
+}
+
+// Allocate call is idempotent. Unlike the static policy, a placement failure
+// admits the container without memory pinning instead of propagating the
+// error: no memory blocks are recorded, so the container keeps unrestricted
+// cpuset.mems.
+func (p *bestEffortPolicy) Allocate(s state.State, pod *v1.Pod, container *v1.Container) error {
+	if err := p.staticPolicy.Allocate(s, pod, container); err != nil {
+		klog.InfoS("Memory locality cannot be satisfied, admitting the container with unrestricted memory affinity", "pod", klog.KObj(pod), "containerName", container.Name, "err", err)
+	}
+	return nil
+}
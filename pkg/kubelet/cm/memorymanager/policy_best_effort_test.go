@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+)
+
+func initBestEffortTest(t *testing.T, machineState state.NUMANodeMap, systemReserved systemReservedMemory) (Policy, state.State) {
+	t.Helper()
+
+	p, err := NewPolicyBestEffort(nil, systemReserved, topologymanager.NewFakeManager())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// override whatever the host sysfs reported to keep the tests deterministic
+	p.(*bestEffortPolicy).topTierNUMANodes = nil
+	p.(*bestEffortPolicy).localityDomains = nil
+
+	s := state.NewMemoryState()
+	s.SetMachineState(machineState)
+	return p, s
+}
+
+func TestBestEffortPolicyAllocate(t *testing.T) {
+	newMachineState := func() state.NUMANodeMap {
+		return state.NUMANodeMap{
+			0: &state.NUMANodeState{
+				MemoryMap: map[v1.ResourceName]*state.MemoryTable{
+					v1.ResourceMemory: {
+						Allocatable:    1536 * mb,
+						Free:           1536 * mb,
+						Reserved:       0,
+						SystemReserved: 512 * mb,
+						TotalMemSize:   2 * gb,
+					},
+				},
+				Cells:               []int{0},
+				NumberOfAssignments: 0,
+			},
+		}
+	}
+
+	systemReserved := systemReservedMemory{
+		0: map[v1.ResourceName]uint64{
+			v1.ResourceMemory: 512 * mb,
+		},
+	}
+
+	newRequirements := func(memory string) *v1.ResourceRequirements {
+		return &v1.ResourceRequirements{
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("1000m"),
+				v1.ResourceMemory: resource.MustParse(memory),
+			},
+			Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("1000m"),
+				v1.ResourceMemory: resource.MustParse(memory),
+			},
+		}
+	}
+
+	t.Run("should pin the container, when the locality can be satisfied", func(t *testing.T) {
+		p, s := initBestEffortTest(t, newMachineState(), systemReserved)
+		pod := getPod("pod1", "container1", newRequirements("1Gi"))
+
+		if err := p.Allocate(s, pod, &pod.Spec.Containers[0]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		blocks := s.GetMemoryBlocks("pod1", "container1")
+		if len(blocks) != 1 {
+			t.Fatalf("expected one memory block, got: '%+v'", blocks)
+		}
+		if len(blocks[0].NUMAAffinity) != 1 || blocks[0].NUMAAffinity[0] != 0 {
+			t.Fatalf("expected the memory block pinned to the NUMA node 0, got: '%+v'", blocks[0])
+		}
+	})
+
+	t.Run("should admit the container without pinning, when the locality cannot be satisfied", func(t *testing.T) {
+		p, s := initBestEffortTest(t, newMachineState(), systemReserved)
+		// the request exceeds the allocatable memory of the machine, the
+		// static policy would fail the admission here
+		pod := getPod("pod1", "container1", newRequirements("2Gi"))
+
+		if err := p.Allocate(s, pod, &pod.Spec.Containers[0]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if blocks := s.GetMemoryBlocks("pod1", "container1"); blocks != nil {
+			t.Fatalf("expected no memory blocks, got: '%+v'", blocks)
+		}
+		if !areMachineStatesEqual(s.GetMachineState(), newMachineState()) {
+			t.Fatalf("expected the machine state to be untouched, got: '%+v'", s.GetMachineState())
+		}
+	})
+}
+
+func TestBestEffortPolicyName(t *testing.T) {
+	p, _ := initBestEffortTest(t, state.NUMANodeMap{}, systemReservedMemory{
+		0: map[v1.ResourceName]uint64{
+			v1.ResourceMemory: 512 * mb,
+		},
+	})
+	if p.Name() != string(policyTypeBestEffort) {
+		t.Fatalf("expected the policy name %q, got %q", policyTypeBestEffort, p.Name())
+	}
+}
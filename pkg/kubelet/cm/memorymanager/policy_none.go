@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 )
 
 const policyTypeNone policyType = "None"
@@ -70,3 +71,8 @@ func (p *none) GetPodTopologyHints(s state.State, pod *v1.Pod) map[string][]topo
 func (p *none) GetAllocatableMemory(s state.State) []state.Block {
 	return []state.Block{}
 }
+
+// GetAdmissibleAffinities returns nil, the policy does not track memory
+func (p *none) GetAdmissibleAffinities(s state.State, requestedResources map[v1.ResourceName]uint64) []bitmask.BitMask {
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	cadvisorapi "github.com/google/cadvisor/info/v1"
 
@@ -40,6 +41,41 @@ import (
 
 const policyTypeStatic policyType = "Static"
 
+// ErrorMemoryAffinity represents the type of a MemoryAffinityError
+const ErrorMemoryAffinity = "MemoryAffinityError"
+
+// MemoryAffinityError represents a failure to find any set of NUMA nodes
+// with enough free memory of every requested type to satisfy a container.
+type MemoryAffinityError struct {
+	// RequestedResources holds the request sizes, in bytes, per memory type.
+	RequestedResources map[v1.ResourceName]uint64
+}
+
+func (e MemoryAffinityError) Error() string {
+	return "[memorymanager] failed to get the default NUMA affinity, no NUMA nodes with enough memory is available"
+}
+
+// Type returns human-readable type of this error. Used in the admission control to populate Admission Failure reason.
+func (e MemoryAffinityError) Type() string {
+	return ErrorMemoryAffinity
+}
+
+// RemediationHint assembles an actionable suggestion from the request sizes
+// the error carries.
+func (e MemoryAffinityError) RemediationHint() string {
+	resourceNames := make([]string, 0, len(e.RequestedResources))
+	for resourceName := range e.RequestedResources {
+		resourceNames = append(resourceNames, string(resourceName))
+	}
+	sort.Strings(resourceNames)
+	requests := make([]string, 0, len(resourceNames))
+	for _, resourceName := range resourceNames {
+		size := resource.NewQuantity(int64(e.RequestedResources[v1.ResourceName(resourceName)]), resource.BinarySI)
+		requests = append(requests, fmt.Sprintf("%s of %s", size, resourceName))
+	}
+	return fmt.Sprintf("the node has no free NUMA node combination fitting %s; reduce the requests or wait for pinned pods to terminate", strings.Join(requests, " and "))
+}
+
 type systemReservedMemory map[int]map[v1.ResourceName]uint64
 type reusableMemory map[string]map[string]map[v1.ResourceName]uint64
 
@@ -56,6 +92,16 @@ type staticPolicy struct {
 	// Note that the restartable init container memory is not included here,
 	// because it is not reusable.
 	initContainersReusableMemory reusableMemory
+	// topTierNUMANodes contains the NUMA nodes whose memory belongs to the
+	// top (fastest) memory tier, typically DRAM as opposed to CXL-attached
+	// expanders. Nil when the machine exposes no tiering information, in
+	// which case all the nodes are treated as top tier.
+	topTierNUMANodes []int
+	// localityDomains maps every NUMA node to the die and uncore cache
+	// domains its CPUs belong to. Nil when the CPU topology of the machine
+	// could not be discovered, in which case memory locality is tracked at
+	// NUMA node granularity only.
+	localityDomains localityDomains
 }
 
 var _ Policy = &staticPolicy{}
@@ -75,11 +121,29 @@ func NewPolicyStatic(machineInfo *cadvisorapi.MachineInfo, reserved systemReserv
 		return nil, fmt.Errorf("[memorymanager] you should specify the system reserved memory")
 	}
 
+	topTierNUMANodes, err := readTopTierNUMANodes(memoryTieringDir)
+	if err != nil {
+		// Expected on machines without memory tiering support: all the
+		// memory is DRAM and there is no tier to steer away from.
+		klog.V(4).InfoS("Memory tiering information unavailable, treating all NUMA nodes as top tier", "err", err)
+		topTierNUMANodes = nil
+	} else {
+		klog.InfoS("Detected top tier memory NUMA nodes", "topTierNUMANodes", topTierNUMANodes)
+	}
+
+	domains, err := discoverLocalityDomains(machineInfo)
+	if err != nil {
+		klog.V(4).InfoS("CPU topology unavailable, tracking memory locality at NUMA node granularity only", "err", err)
+		domains = nil
+	}
+
 	return &staticPolicy{
 		machineInfo:                  machineInfo,
 		systemReserved:               reserved,
 		affinity:                     affinity,
 		initContainersReusableMemory: reusableMemory{},
+		topTierNUMANodes:             topTierNUMANodes,
+		localityDomains:              domains,
 	}, nil
 }
 
@@ -102,6 +166,13 @@ func (p *staticPolicy) Allocate(s state.State, pod *v1.Pod, container *v1.Contai
 		return nil
 	}
 
+	// the container tolerates any memory locality, do not pin it and do not
+	// account its request against specific NUMA nodes
+	if containerToleratesAnyLocality(pod, container.Name) {
+		klog.InfoS("Container tolerates any memory locality, skipping the pinning", "pod", klog.KObj(pod), "containerName", container.Name)
+		return nil
+	}
+
 	podUID := string(pod.UID)
 	klog.InfoS("Allocate", "pod", klog.KObj(pod), "containerName", container.Name)
 	// container belongs in an exclusively allocated pool
@@ -389,6 +460,13 @@ func (p *staticPolicy) GetPodTopologyHints(s state.State, pod *v1.Pod) map[strin
 		return nil
 	}
 
+	// only when every container of the pod tolerates any memory locality the
+	// pod as a whole has no placement preference; a single strict container
+	// is enough to keep the usual hints
+	if podToleratesAnyLocality(pod) {
+		return nil
+	}
+
 	reqRsrcs, err := getPodRequestedResources(pod)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get pod requested resources", "pod", klog.KObj(pod), "podUID", pod.UID)
@@ -417,6 +495,12 @@ func (p *staticPolicy) GetTopologyHints(s state.State, pod *v1.Pod, container *v
 		return nil
 	}
 
+	// the container tolerates any memory locality, so it expresses no
+	// placement preference to the topology manager
+	if containerToleratesAnyLocality(pod, container.Name) {
+		return nil
+	}
+
 	requestedResources, err := getRequestedResources(pod, container)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get container requested resources", "pod", klog.KObj(pod), "podUID", pod.UID, "containerName", container.Name)
@@ -456,9 +540,100 @@ func getRequestedResources(pod *v1.Pod, container *v1.Container) (map[v1.Resourc
 		}
 		requestedResources[resourceName] = uint64(requestedSize)
 	}
+
+	// Hugepages backing emptyDir volumes are consumed against the container
+	// hugepage limits, so container-level requests normally cover them. A pod
+	// can still mount such a volume larger than what its containers request,
+	// and that slack would bypass hinting and pinning entirely. To keep
+	// volume-backed hugepages aligned like container-level ones, the slack is
+	// accounted to the first app container, which carries it on behalf of the
+	// whole pod.
+	if len(pod.Spec.Containers) > 0 && container.Name == pod.Spec.Containers[0].Name {
+		volumeResources, err := getVolumeRequestedResources(pod)
+		if err != nil {
+			return nil, err
+		}
+		for resourceName, volumeSize := range volumeResources {
+			var covered uint64
+			for _, ctr := range pod.Spec.Containers {
+				if quantity, ok := ctr.Resources.Requests[resourceName]; ok {
+					size, _ := quantity.AsInt64()
+					covered += uint64(size)
+				}
+			}
+			if volumeSize > covered {
+				requestedResources[resourceName] += volumeSize - covered
+			}
+		}
+	}
+
 	return requestedResources, nil
 }
 
+// getVolumeRequestedResources returns the hugepage demand the pod carries in
+// hugetlb-backed emptyDir volumes, keyed by hugepage resource name. A volume
+// using the sized medium notation (HugePages-<size>) declares its page size
+// explicitly; with the bare HugePages medium the page size is the single one
+// the pod containers request.
+func getVolumeRequestedResources(pod *v1.Pod) (map[v1.ResourceName]uint64, error) {
+	requestedResources := map[v1.ResourceName]uint64{}
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		emptyDir := volume.EmptyDir
+		if emptyDir == nil || !corehelper.IsHugePageMedium(emptyDir.Medium) {
+			continue
+		}
+		if emptyDir.SizeLimit == nil || emptyDir.SizeLimit.IsZero() {
+			// Without a size limit there is no demand to pin anywhere.
+			klog.InfoS("Hugepages emptyDir volume has no size limit, cannot account it for NUMA alignment", "pod", klog.KObj(pod), "volumeName", volume.Name)
+			continue
+		}
+
+		var resourceName v1.ResourceName
+		if emptyDir.Medium == v1.StorageMediumHugePages {
+			name, ok := podSingleHugePageResource(pod)
+			if !ok {
+				klog.InfoS("Could not resolve the page size of the hugepages emptyDir volume, cannot account it for NUMA alignment", "pod", klog.KObj(pod), "volumeName", volume.Name)
+				continue
+			}
+			resourceName = name
+		} else {
+			pageSize, err := corehelper.HugePageSizeFromMedium(emptyDir.Medium)
+			if err != nil {
+				return nil, err
+			}
+			resourceName = corehelper.HugePageResourceName(pageSize)
+		}
+
+		size, succeed := emptyDir.SizeLimit.AsInt64()
+		if !succeed {
+			return nil, fmt.Errorf("[memorymanager] failed to represent quantity as int64")
+		}
+		requestedResources[resourceName] += uint64(size)
+	}
+	return requestedResources, nil
+}
+
+// podSingleHugePageResource returns the hugepage resource name the pod
+// containers request, when exactly one page size is in use. The bare
+// HugePages emptyDir medium is only valid under that condition.
+func podSingleHugePageResource(pod *v1.Pod) (v1.ResourceName, bool) {
+	var found v1.ResourceName
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, ctr := range containers {
+		for resourceName := range ctr.Resources.Requests {
+			if !corehelper.IsHugePageResourceName(resourceName) {
+				continue
+			}
+			if found != "" && found != resourceName {
+				return "", false
+			}
+			found = resourceName
+		}
+	}
+	return found, found != ""
+}
+
 func (p *staticPolicy) calculateHints(machineState state.NUMANodeMap, pod *v1.Pod, requestedResources map[v1.ResourceName]uint64) map[string][]topologymanager.TopologyHint {
 	var numaNodes []int
 	for n := range machineState {
@@ -474,6 +649,12 @@ func (p *staticPolicy) calculateHints(machineState state.NUMANodeMap, pod *v1.Po
 		maskBits := mask.GetBits()
 		singleNUMAHint := len(maskBits) == 1
 
+		// guaranteed pods can demand DRAM-only placement; skip the masks which
+		// include NUMA nodes backed by a slower memory tier
+		if podRequestsTopTierMemory(pod) && !p.isMaskTopTier(maskBits) {
+			return
+		}
+
 		totalFreeSize := map[v1.ResourceName]uint64{}
 		totalAllocatableSize := map[v1.ResourceName]uint64{}
 		// calculate total free and allocatable memory for the node mask
@@ -554,8 +735,84 @@ func (p *staticPolicy) calculateHints(machineState state.NUMANodeMap, pod *v1.Po
 	return hints
 }
 
+// GetAdmissibleAffinities computes, without mutating any state, the NUMA node
+// masks on which a hypothetical memory request (one size per resource name)
+// could currently be satisfied. The masks obey the same rules calculateHints
+// enforces during a real admission: enough free memory of every requested
+// type, and compatibility with the NUMA node groups pinned down by the
+// existing allocations. Schedulers and autoscalers can use the result to
+// estimate pod feasibility without going through admission.
+func (p *staticPolicy) GetAdmissibleAffinities(s state.State, requestedResources map[v1.ResourceName]uint64) []bitmask.BitMask {
+	machineState := s.GetMachineState()
+	var numaNodes []int
+	for n := range machineState {
+		numaNodes = append(numaNodes, n)
+	}
+	sort.Ints(numaNodes)
+
+	var admissible []bitmask.BitMask
+	bitmask.IterateBitMasks(numaNodes, func(mask bitmask.BitMask) {
+		maskBits := mask.GetBits()
+		singleNUMAHint := len(maskBits) == 1
+
+		// the node already in group with another node, it can not be used for the single NUMA node allocation
+		if singleNUMAHint && len(machineState[maskBits[0]].Cells) > 1 {
+			return
+		}
+
+		for _, nodeID := range maskBits {
+			// the node already used for the memory allocation
+			if !singleNUMAHint && machineState[nodeID].NumberOfAssignments > 0 {
+				// the node used for the single NUMA memory allocation, it can not be used for the multi NUMA node allocation
+				if len(machineState[nodeID].Cells) == 1 {
+					return
+				}
+
+				// the node already used with different group of nodes, it can not be use with in the current hint
+				if !areGroupsEqual(machineState[nodeID].Cells, maskBits) {
+					return
+				}
+			}
+		}
+
+		if !isAffinitySatisfyRequest(machineState, mask, requestedResources) {
+			return
+		}
+		admissible = append(admissible, mask)
+	})
+	return admissible
+}
+
 func (p *staticPolicy) isHintPreferred(maskBits []int, minAffinitySize int) bool {
-	return len(maskBits) == minAffinitySize
+	if len(maskBits) != minAffinitySize {
+		return false
+	}
+	// a reservation crossing NUMA nodes stays aligned with the CPUs only when
+	// the nodes share a die; without locality information any minimal mask is
+	// preferred, as before
+	return len(maskBits) == 1 || p.maskSharesDie(maskBits)
+}
+
+// isMaskTopTier returns true if all the NUMA nodes in maskBits belong to the
+// top (fastest) memory tier. Without tiering information all the nodes are
+// treated as top tier.
+func (p *staticPolicy) isMaskTopTier(maskBits []int) bool {
+	if p.topTierNUMANodes == nil {
+		return true
+	}
+	for _, nodeID := range maskBits {
+		isTopTier := false
+		for _, topTierNodeID := range p.topTierNUMANodes {
+			if nodeID == topTierNodeID {
+				isTopTier = true
+				break
+			}
+		}
+		if !isTopTier {
+			return false
+		}
+	}
+	return true
 }
 
 func areGroupsEqual(group1, group2 []int) bool {
@@ -701,6 +958,12 @@ func (p *staticPolicy) getDefaultMachineState() state.NUMANodeMap {
 			Cells:               []int{node.Id},
 		}
 
+		// record the sub-NUMA locality of the node, when known
+		if domain, ok := p.localityDomains[node.Id]; ok {
+			defaultMachineState[node.Id].Dies = append([]int{}, domain.dies...)
+			defaultMachineState[node.Id].UncoreCaches = append([]int{}, domain.uncoreCaches...)
+		}
+
 		// fill memory table with huge pages values
 		for _, hugepage := range node.HugePages {
 			hugepageQuantity := resource.NewQuantity(int64(hugepage.PageSize)*1024, resource.BinarySI)
@@ -753,7 +1016,7 @@ func (p *staticPolicy) getResourceSystemReserved(nodeID int, resourceName v1.Res
 func (p *staticPolicy) getDefaultHint(machineState state.NUMANodeMap, pod *v1.Pod, requestedResources map[v1.ResourceName]uint64) (*topologymanager.TopologyHint, error) {
 	hints := p.calculateHints(machineState, pod, requestedResources)
 	if len(hints) < 1 {
-		return nil, fmt.Errorf("[memorymanager] failed to get the default NUMA affinity, no NUMA nodes with enough memory is available")
+		return nil, MemoryAffinityError{RequestedResources: requestedResources}
 	}
 
 	// hints for all memory types should be the same, so we will check hints only for regular memory type
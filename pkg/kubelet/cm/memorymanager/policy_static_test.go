@@ -132,6 +132,8 @@ type testStaticPolicy struct {
 	topologyHint                 *topologymanager.TopologyHint
 	expectedTopologyHints        map[string][]topologymanager.TopologyHint
 	initContainersReusableMemory reusableMemory
+	topTierNUMANodes             []int
+	localityDomains              localityDomains
 }
 
 func initTests(t *testing.T, testCase *testStaticPolicy, hint *topologymanager.TopologyHint, initContainersReusableMemory reusableMemory) (Policy, state.State, error) {
@@ -147,6 +149,9 @@ func initTests(t *testing.T, testCase *testStaticPolicy, hint *topologymanager.T
 	if initContainersReusableMemory != nil {
 		p.(*staticPolicy).initContainersReusableMemory = initContainersReusableMemory
 	}
+	// override whatever the host sysfs reported to keep the tests deterministic
+	p.(*staticPolicy).topTierNUMANodes = testCase.topTierNUMANodes
+	p.(*staticPolicy).localityDomains = testCase.localityDomains
 	s := state.NewMemoryState()
 	s.SetMachineState(testCase.machineState)
 	s.SetMemoryAssignments(testCase.assignments)
@@ -1302,9 +1307,12 @@ func TestStaticPolicyAllocate(t *testing.T) {
 					v1.ResourceMemory: 512 * mb,
 				},
 			},
-			pod:           getPod("pod2", "container2", requirementsGuaranteed),
-			expectedError: fmt.Errorf("[memorymanager] failed to get the default NUMA affinity, no NUMA nodes with enough memory is available"),
-			topologyHint:  &topologymanager.TopologyHint{},
+			pod: getPod("pod2", "container2", requirementsGuaranteed),
+			expectedError: MemoryAffinityError{RequestedResources: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: gb,
+				hugepages1Gi:      gb,
+			}},
+			topologyHint: &topologymanager.TopologyHint{},
 		},
 		{
 			description: "should fail when no NUMA affinity was provided under the topology manager preferred hint and default hint has preferred false",
@@ -3504,6 +3512,271 @@ func TestStaticPolicyGetTopologyHints(t *testing.T) {
 	}
 }
 
+func TestStaticPolicyGetTopologyHintsMemoryTiers(t *testing.T) {
+	getPodWithMemoryTier := func(podUID string, containerName string, requirements *v1.ResourceRequirements) *v1.Pod {
+		pod := getPod(podUID, containerName, requirements)
+		pod.Annotations = map[string]string{
+			PreferredMemoryTierAnnotation: memoryTierDRAM,
+		}
+		return pod
+	}
+
+	newMachineState := func() state.NUMANodeMap {
+		return state.NUMANodeMap{
+			0: &state.NUMANodeState{
+				MemoryMap: map[v1.ResourceName]*state.MemoryTable{
+					v1.ResourceMemory: {
+						Allocatable:    1536 * mb,
+						Free:           1536 * mb,
+						Reserved:       0,
+						SystemReserved: 512 * mb,
+						TotalMemSize:   2 * gb,
+					},
+					hugepages1Gi: {
+						Allocatable:    gb,
+						Free:           gb,
+						Reserved:       0,
+						SystemReserved: 0,
+						TotalMemSize:   gb,
+					},
+				},
+				Cells:               []int{0},
+				NumberOfAssignments: 0,
+			},
+			1: &state.NUMANodeState{
+				MemoryMap: map[v1.ResourceName]*state.MemoryTable{
+					v1.ResourceMemory: {
+						Allocatable:    1536 * mb,
+						Free:           1536 * mb,
+						Reserved:       0,
+						SystemReserved: 512 * mb,
+						TotalMemSize:   2 * gb,
+					},
+					hugepages1Gi: {
+						Allocatable:    gb,
+						Free:           gb,
+						Reserved:       0,
+						SystemReserved: 0,
+						TotalMemSize:   gb,
+					},
+				},
+				Cells:               []int{1},
+				NumberOfAssignments: 0,
+			},
+		}
+	}
+
+	systemReserved := systemReservedMemory{
+		0: map[v1.ResourceName]uint64{
+			v1.ResourceMemory: 512 * mb,
+		},
+		1: map[v1.ResourceName]uint64{
+			v1.ResourceMemory: 512 * mb,
+		},
+	}
+
+	testCases := []testStaticPolicy{
+		{
+			description:      "should provide only top tier hints, when the pod demands DRAM-only placement",
+			machineState:     newMachineState(),
+			pod:              getPodWithMemoryTier("pod1", "container1", requirementsGuaranteed),
+			systemReserved:   systemReserved,
+			topTierNUMANodes: []int{0},
+			expectedTopologyHints: map[string][]topologymanager.TopologyHint{
+				string(v1.ResourceMemory): {
+					{
+						NUMANodeAffinity: newNUMAAffinity(0),
+						Preferred:        true,
+					},
+				},
+				string(hugepages1Gi): {
+					{
+						NUMANodeAffinity: newNUMAAffinity(0),
+						Preferred:        true,
+					},
+				},
+			},
+		},
+		{
+			description:      "should provide hints for all the tiers, when the pod does not demand DRAM-only placement",
+			machineState:     newMachineState(),
+			pod:              getPod("pod1", "container1", requirementsGuaranteed),
+			systemReserved:   systemReserved,
+			topTierNUMANodes: []int{0},
+			expectedTopologyHints: map[string][]topologymanager.TopologyHint{
+				string(v1.ResourceMemory): {
+					{
+						NUMANodeAffinity: newNUMAAffinity(0),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(1),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(0, 1),
+						Preferred:        false,
+					},
+				},
+				string(hugepages1Gi): {
+					{
+						NUMANodeAffinity: newNUMAAffinity(0),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(1),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(0, 1),
+						Preferred:        false,
+					},
+				},
+			},
+		},
+		{
+			description:      "should provide hints for all the NUMA nodes, when all of them belong to the top tier",
+			machineState:     newMachineState(),
+			pod:              getPodWithMemoryTier("pod1", "container1", requirementsGuaranteed),
+			systemReserved:   systemReserved,
+			topTierNUMANodes: []int{0, 1},
+			expectedTopologyHints: map[string][]topologymanager.TopologyHint{
+				string(v1.ResourceMemory): {
+					{
+						NUMANodeAffinity: newNUMAAffinity(0),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(1),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(0, 1),
+						Preferred:        false,
+					},
+				},
+				string(hugepages1Gi): {
+					{
+						NUMANodeAffinity: newNUMAAffinity(0),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(1),
+						Preferred:        true,
+					},
+					{
+						NUMANodeAffinity: newNUMAAffinity(0, 1),
+						Preferred:        false,
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			p, s, err := initTests(t, &testCase, nil, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			topologyHints := p.GetTopologyHints(s, testCase.pod, &testCase.pod.Spec.Containers[0])
+			if !reflect.DeepEqual(topologyHints, testCase.expectedTopologyHints) {
+				t.Fatalf("The actual topology hints: '%+v' are different from the expected one: '%+v'", topologyHints, testCase.expectedTopologyHints)
+			}
+		})
+	}
+}
+
+func TestStaticPolicyLocalityToleration(t *testing.T) {
+	getPodWithLocalityToleration := func(podUID string, containerName string, requirements *v1.ResourceRequirements) *v1.Pod {
+		pod := getPod(podUID, containerName, requirements)
+		pod.Annotations = map[string]string{
+			LocalityTolerationAnnotationPrefix + containerName: localityTolerationAnywhere,
+		}
+		return pod
+	}
+
+	newMachineState := func() state.NUMANodeMap {
+		return state.NUMANodeMap{
+			0: &state.NUMANodeState{
+				MemoryMap: map[v1.ResourceName]*state.MemoryTable{
+					v1.ResourceMemory: {
+						Allocatable:    1536 * mb,
+						Free:           1536 * mb,
+						Reserved:       0,
+						SystemReserved: 512 * mb,
+						TotalMemSize:   2 * gb,
+					},
+					hugepages1Gi: {
+						Allocatable:    gb,
+						Free:           gb,
+						Reserved:       0,
+						SystemReserved: 0,
+						TotalMemSize:   gb,
+					},
+				},
+				Cells:               []int{0},
+				NumberOfAssignments: 0,
+			},
+		}
+	}
+
+	systemReserved := systemReservedMemory{
+		0: map[v1.ResourceName]uint64{
+			v1.ResourceMemory: 512 * mb,
+		},
+	}
+
+	t.Run("should not pin a container tolerating any locality", func(t *testing.T) {
+		testCase := testStaticPolicy{
+			machineState:   newMachineState(),
+			pod:            getPodWithLocalityToleration("pod1", "container1", requirementsGuaranteed),
+			systemReserved: systemReserved,
+		}
+		p, s, err := initTests(t, &testCase, nil, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if topologyHints := p.GetTopologyHints(s, testCase.pod, &testCase.pod.Spec.Containers[0]); topologyHints != nil {
+			t.Fatalf("expected no topology hints, got: '%+v'", topologyHints)
+		}
+		if podTopologyHints := p.GetPodTopologyHints(s, testCase.pod); podTopologyHints != nil {
+			t.Fatalf("expected no pod topology hints, got: '%+v'", podTopologyHints)
+		}
+
+		if err := p.Allocate(s, testCase.pod, &testCase.pod.Spec.Containers[0]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if blocks := s.GetMemoryBlocks("pod1", "container1"); blocks != nil {
+			t.Fatalf("expected no memory blocks, got: '%+v'", blocks)
+		}
+		if !areMachineStatesEqual(s.GetMachineState(), newMachineState()) {
+			t.Fatalf("expected the machine state to be untouched, got: '%+v'", s.GetMachineState())
+		}
+	})
+
+	t.Run("should keep pinning a container without the toleration", func(t *testing.T) {
+		testCase := testStaticPolicy{
+			machineState:   newMachineState(),
+			pod:            getPod("pod1", "container1", requirementsGuaranteed),
+			systemReserved: systemReserved,
+		}
+		p, s, err := initTests(t, &testCase, nil, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := p.Allocate(s, testCase.pod, &testCase.pod.Spec.Containers[0]); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if blocks := s.GetMemoryBlocks("pod1", "container1"); blocks == nil {
+			t.Fatalf("expected memory blocks for the container")
+		}
+	})
+}
+
 func Test_getPodRequestedResources(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -3776,3 +4049,226 @@ func Test_getPodRequestedResources(t *testing.T) {
 		})
 	}
 }
+
+func TestStaticPolicyGetAdmissibleAffinities(t *testing.T) {
+	newMachineState := func(cells []int, numberOfAssignments int) state.NUMANodeMap {
+		machineState := state.NUMANodeMap{}
+		for _, node := range []int{0, 1} {
+			nodeCells := cells
+			if nodeCells == nil {
+				nodeCells = []int{node}
+			}
+			machineState[node] = &state.NUMANodeState{
+				MemoryMap: map[v1.ResourceName]*state.MemoryTable{
+					v1.ResourceMemory: {
+						Allocatable:    1536 * mb,
+						Free:           1536 * mb,
+						Reserved:       0,
+						SystemReserved: 512 * mb,
+						TotalMemSize:   2 * gb,
+					},
+				},
+				Cells:               nodeCells,
+				NumberOfAssignments: numberOfAssignments,
+			}
+		}
+		return machineState
+	}
+
+	testCases := []struct {
+		description        string
+		machineState       state.NUMANodeMap
+		requestedResources map[v1.ResourceName]uint64
+		expectedAffinities []bitmask.BitMask
+	}{
+		{
+			description:  "should return all masks with enough free memory, when the request fits on a single NUMA node",
+			machineState: newMachineState(nil, 0),
+			requestedResources: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: gb,
+			},
+			expectedAffinities: []bitmask.BitMask{
+				newNUMAAffinity(0),
+				newNUMAAffinity(1),
+				newNUMAAffinity(0, 1),
+			},
+		},
+		{
+			description:  "should return only the multi NUMA node mask, when the request does not fit on a single NUMA node",
+			machineState: newMachineState(nil, 0),
+			requestedResources: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: 2 * gb,
+			},
+			expectedAffinities: []bitmask.BitMask{
+				newNUMAAffinity(0, 1),
+			},
+		},
+		{
+			description:  "should skip single NUMA node masks for nodes that belong to a multi NUMA node group",
+			machineState: newMachineState([]int{0, 1}, 2),
+			requestedResources: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: gb,
+			},
+			expectedAffinities: []bitmask.BitMask{
+				newNUMAAffinity(0, 1),
+			},
+		},
+		{
+			description:  "should not return any mask, when the request does not fit on the machine",
+			machineState: newMachineState(nil, 0),
+			requestedResources: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: 4 * gb,
+			},
+			expectedAffinities: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			p, s, err := initTests(t, &testStaticPolicy{
+				machineState: testCase.machineState,
+				systemReserved: systemReservedMemory{
+					0: map[v1.ResourceName]uint64{
+						v1.ResourceMemory: 512 * mb,
+					},
+					1: map[v1.ResourceName]uint64{
+						v1.ResourceMemory: 512 * mb,
+					},
+				},
+			}, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			affinities := p.GetAdmissibleAffinities(s, testCase.requestedResources)
+			if !reflect.DeepEqual(affinities, testCase.expectedAffinities) {
+				t.Errorf("expected admissible affinities %v, but got %v", testCase.expectedAffinities, affinities)
+			}
+		})
+	}
+}
+
+func Test_getRequestedResourcesHugePageVolumes(t *testing.T) {
+	hugePageVolume := func(name string, medium v1.StorageMedium, sizeLimit string) v1.Volume {
+		limit := resource.MustParse(sizeLimit)
+		return v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{
+					Medium:    medium,
+					SizeLimit: &limit,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		pod         *v1.Pod
+		expected    map[v1.ResourceName]uint64
+	}{
+		{
+			description: "sized medium volume with no container hugepage requests",
+			pod: func() *v1.Pod {
+				pod := getPod("pod1", "container1", &v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				})
+				pod.Spec.Volumes = []v1.Volume{hugePageVolume("hugetlb", v1.StorageMedium("HugePages-1Gi"), "2Gi")}
+				return pod
+			}(),
+			expected: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: gb,
+				hugepages1Gi:      2 * gb,
+			},
+		},
+		{
+			description: "container requests cover part of the volume, only the slack is added",
+			pod: func() *v1.Pod {
+				pod := getPod("pod1", "container1", requirementsGuaranteed)
+				pod.Spec.Volumes = []v1.Volume{hugePageVolume("hugetlb", v1.StorageMedium("HugePages-1Gi"), "2Gi")}
+				return pod
+			}(),
+			expected: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: gb,
+				hugepages1Gi:      2 * gb,
+			},
+		},
+		{
+			description: "bare medium resolves the page size from the container requests",
+			pod: func() *v1.Pod {
+				pod := getPod("pod1", "container1", requirementsGuaranteed)
+				pod.Spec.Volumes = []v1.Volume{hugePageVolume("hugetlb", v1.StorageMediumHugePages, "3Gi")}
+				return pod
+			}(),
+			expected: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: gb,
+				hugepages1Gi:      3 * gb,
+			},
+		},
+		{
+			description: "bare medium with no container hugepage requests cannot be accounted",
+			pod: func() *v1.Pod {
+				pod := getPod("pod1", "container1", &v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				})
+				pod.Spec.Volumes = []v1.Volume{hugePageVolume("hugetlb", v1.StorageMediumHugePages, "2Gi")}
+				return pod
+			}(),
+			expected: map[v1.ResourceName]uint64{
+				v1.ResourceMemory: gb,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			actual, err := getRequestedResources(testCase.pod, &testCase.pod.Spec.Containers[0])
+			if err != nil {
+				t.Fatalf("getRequestedResources() failed: %v", err)
+			}
+			if diff := cmp.Diff(testCase.expected, actual); diff != "" {
+				t.Errorf("getRequestedResources() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// The slack is carried by the first app container only: the second
+	// container of the same pod reports just its own requests.
+	pod := getPodWithInitContainers(
+		"pod1",
+		[]v1.Container{
+			{
+				Name: "container1",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+			{
+				Name: "container2",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+		nil,
+	)
+	pod.Spec.Volumes = []v1.Volume{hugePageVolume("hugetlb", v1.StorageMedium("HugePages-1Gi"), "1Gi")}
+	actual, err := getRequestedResources(pod, &pod.Spec.Containers[1])
+	if err != nil {
+		t.Fatalf("getRequestedResources() failed: %v", err)
+	}
+	expected := map[v1.ResourceName]uint64{
+		v1.ResourceMemory: gb,
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("getRequestedResources() mismatch (-want +got):\n%s", diff)
+	}
+}
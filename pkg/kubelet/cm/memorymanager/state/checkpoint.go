@@ -18,14 +18,21 @@ package state
 
 import (
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/dump"
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
 	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/checksum"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/errors"
 )
 
+var _ checkpointmanager.Checkpoint = &MemoryManagerCheckpointV1{}
 var _ checkpointmanager.Checkpoint = &MemoryManagerCheckpoint{}
 
-// MemoryManagerCheckpoint struct is used to store memory/pod assignments in a checkpoint
+// MemoryManagerCheckpoint struct is used to store memory/pod assignments in a checkpoint in v2 format
 type MemoryManagerCheckpoint struct {
 	PolicyName   string                     `json:"policyName"`
 	MachineState NUMANodeMap                `json:"machineState"`
@@ -33,30 +40,101 @@ type MemoryManagerCheckpoint struct {
 	Checksum     checksum.Checksum          `json:"checksum"`
 }
 
+// NUMANodeStateV1 mirrors NUMANodeState as it was before the sub-NUMA
+// locality fields were added, to keep validating the checksums of checkpoints
+// written in the v1 format.
+type NUMANodeStateV1 struct {
+	NumberOfAssignments int                              `json:"numberOfAssignments"`
+	MemoryMap           map[v1.ResourceName]*MemoryTable `json:"memoryMap"`
+	Cells               []int                            `json:"cells"`
+}
+
+// NUMANodeMapV1 contains the v1 format memory information for each NUMA node
+type NUMANodeMapV1 map[int]*NUMANodeStateV1
+
+// MemoryManagerCheckpointV1 struct is used to store memory/pod assignments in a checkpoint in v1 format
+type MemoryManagerCheckpointV1 struct {
+	PolicyName   string                     `json:"policyName"`
+	MachineState NUMANodeMapV1              `json:"machineState"`
+	Entries      ContainerMemoryAssignments `json:"entries,omitempty"`
+	Checksum     checksum.Checksum          `json:"checksum"`
+}
+
+// MemoryManagerCheckpointV2 struct is used to store memory/pod assignments in a checkpoint in v2 format
+type MemoryManagerCheckpointV2 = MemoryManagerCheckpoint
+
 // NewMemoryManagerCheckpoint returns an instance of Checkpoint
 func NewMemoryManagerCheckpoint() *MemoryManagerCheckpoint {
 	//nolint:staticcheck // unexported-type-in-api user-facing error message
-	return &MemoryManagerCheckpoint{
+	return newMemoryManagerCheckpointV2()
+}
+
+func newMemoryManagerCheckpointV1() *MemoryManagerCheckpointV1 {
+	return &MemoryManagerCheckpointV1{
+		Entries:      ContainerMemoryAssignments{},
+		MachineState: NUMANodeMapV1{},
+	}
+}
+
+func newMemoryManagerCheckpointV2() *MemoryManagerCheckpointV2 {
+	return &MemoryManagerCheckpointV2{
 		Entries:      ContainerMemoryAssignments{},
 		MachineState: NUMANodeMap{},
 	}
 }
 
-// MarshalCheckpoint returns marshalled checkpoint
-func (mp *MemoryManagerCheckpoint) MarshalCheckpoint() ([]byte, error) {
+// MarshalCheckpoint returns marshalled checkpoint in v1 format
+func (mp *MemoryManagerCheckpointV1) MarshalCheckpoint() ([]byte, error) {
+	// make sure checksum wasn't set before so it doesn't affect output checksum
+	mp.Checksum = 0
+	mp.Checksum = checksum.New(mp)
+	return json.Marshal(*mp)
+}
+
+// MarshalCheckpoint returns marshalled checkpoint in v2 format
+func (mp *MemoryManagerCheckpointV2) MarshalCheckpoint() ([]byte, error) {
 	// make sure checksum wasn't set before so it doesn't affect output checksum
 	mp.Checksum = 0
 	mp.Checksum = checksum.New(mp)
 	return json.Marshal(*mp)
 }
 
-// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint
-func (mp *MemoryManagerCheckpoint) UnmarshalCheckpoint(blob []byte) error {
+// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint in v1 format
+func (mp *MemoryManagerCheckpointV1) UnmarshalCheckpoint(blob []byte) error {
+	return json.Unmarshal(blob, mp)
+}
+
+// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint in v2 format
+func (mp *MemoryManagerCheckpointV2) UnmarshalCheckpoint(blob []byte) error {
 	return json.Unmarshal(blob, mp)
 }
 
-// VerifyChecksum verifies that current checksum of checkpoint is valid
-func (mp *MemoryManagerCheckpoint) VerifyChecksum() error {
+// VerifyChecksum verifies that current checksum of checkpoint is valid in v1 format
+func (mp *MemoryManagerCheckpointV1) VerifyChecksum() error {
+	// v1 checksums were computed when the checkpoint and machine state types
+	// were the ones without the V1 suffix, before the v2 format was
+	// introduced
+	ck := mp.Checksum
+	mp.Checksum = 0
+	object := dump.ForHash(mp)
+	object = strings.NewReplacer(
+		"MemoryManagerCheckpointV1", "MemoryManagerCheckpoint",
+		"NUMANodeMapV1", "NUMANodeMap",
+		"NUMANodeStateV1", "NUMANodeState",
+	).Replace(object)
+	mp.Checksum = ck
+
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%v", object)
+	if mp.Checksum != checksum.Checksum(hash.Sum32()) {
+		return errors.ErrCorruptCheckpoint
+	}
+
+	return nil
+}
+
+// VerifyChecksum verifies that current checksum of checkpoint is valid in v2 format
+func (mp *MemoryManagerCheckpointV2) VerifyChecksum() error {
 	ck := mp.Checksum
 	mp.Checksum = 0
 	err := ck.Verify(mp)
@@ -41,6 +41,12 @@ type NUMANodeState struct {
 	// For example if some container has pinning 0,1,2, NUMA nodes 0,1,2 under the state will have
 	// this parameter equals to [0, 1, 2]
 	Cells []int `json:"cells"`
+	// Dies contains the platform-unique die IDs the CPUs of this NUMA node
+	// belong to. Empty when the sub-NUMA locality of the machine is unknown.
+	Dies []int `json:"dies,omitempty"`
+	// UncoreCaches contains the uncore cache IDs the CPUs of this NUMA node
+	// belong to. Empty when the sub-NUMA locality of the machine is unknown.
+	UncoreCaches []int `json:"uncoreCaches,omitempty"`
 }
 
 // NUMANodeMap contains memory information for each NUMA node.
@@ -58,6 +64,12 @@ func (nm NUMANodeMap) Clone() NUMANodeMap {
 		clone[node] = &NUMANodeState{}
 		clone[node].NumberOfAssignments = s.NumberOfAssignments
 		clone[node].Cells = append([]int{}, s.Cells...)
+		if s.Dies != nil {
+			clone[node].Dies = append([]int{}, s.Dies...)
+		}
+		if s.UncoreCaches != nil {
+			clone[node].UncoreCaches = append([]int{}, s.UncoreCaches...)
+		}
 
 		if s.MemoryMap == nil {
 			continue
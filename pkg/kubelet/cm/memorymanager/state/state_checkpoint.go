@@ -58,26 +58,52 @@ func NewCheckpointState(stateDir, checkpointName, policyName string) (State, err
 	return stateCheckpoint, nil
 }
 
+// migrateV1CheckpointToV2Checkpoint() converts checkpoints from the v1 format to the v2 format
+func (sc *stateCheckpoint) migrateV1CheckpointToV2Checkpoint(src *MemoryManagerCheckpointV1, dst *MemoryManagerCheckpointV2) {
+	// v2 only adds the sub-NUMA locality of the nodes, which v1 checkpoints
+	// do not carry
+	if src.PolicyName != "" {
+		dst.PolicyName = src.PolicyName
+	}
+	for nodeID, nodeState := range src.MachineState {
+		dst.MachineState[nodeID] = &NUMANodeState{
+			NumberOfAssignments: nodeState.NumberOfAssignments,
+			MemoryMap:           nodeState.MemoryMap,
+			Cells:               nodeState.Cells,
+		}
+	}
+	if len(src.Entries) > 0 {
+		dst.Entries = src.Entries
+	}
+}
+
 // restores state from a checkpoint and creates it if it doesn't exist
 func (sc *stateCheckpoint) restoreState() error {
 	sc.Lock()
 	defer sc.Unlock()
 	var err error
 
-	checkpoint := NewMemoryManagerCheckpoint()
-	if err = sc.checkpointManager.GetCheckpoint(sc.checkpointName, checkpoint); err != nil {
-		if err == errors.ErrCheckpointNotFound {
-			return sc.storeState()
+	checkpointV1 := newMemoryManagerCheckpointV1()
+	checkpointV2 := newMemoryManagerCheckpointV2()
+
+	if err = sc.checkpointManager.GetCheckpoint(sc.checkpointName, checkpointV1); err != nil {
+		checkpointV1 = newMemoryManagerCheckpointV1() // reset it back to 0
+		if err = sc.checkpointManager.GetCheckpoint(sc.checkpointName, checkpointV2); err != nil {
+			if err == errors.ErrCheckpointNotFound {
+				return sc.storeState()
+			}
+			return err
 		}
-		return err
 	}
 
-	if sc.policyName != checkpoint.PolicyName {
-		return fmt.Errorf("[memorymanager] configured policy %q differs from state checkpoint policy %q", sc.policyName, checkpoint.PolicyName)
+	sc.migrateV1CheckpointToV2Checkpoint(checkpointV1, checkpointV2)
+
+	if sc.policyName != checkpointV2.PolicyName {
+		return fmt.Errorf("[memorymanager] configured policy %q differs from state checkpoint policy %q", sc.policyName, checkpointV2.PolicyName)
 	}
 
-	sc.cache.SetMachineState(checkpoint.MachineState)
-	sc.cache.SetMemoryAssignments(checkpoint.Entries)
+	sc.cache.SetMachineState(checkpointV2.MachineState)
+	sc.cache.SetMemoryAssignments(checkpointV2.Entries)
 
 	klog.V(2).InfoS("State checkpoint: restored state from checkpoint")
 
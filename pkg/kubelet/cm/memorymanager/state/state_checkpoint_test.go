@@ -18,6 +18,7 @@ package state
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -144,6 +145,84 @@ func TestCheckpointStateRestore(t *testing.T) {
 	}
 }
 
+func TestCheckpointStateGoldenFiles(t *testing.T) {
+	// The testdata directory holds verbatim checkpoints written by past
+	// kubelet versions, checksums included. Every file must load through
+	// NewCheckpointState, keeping the upgrade path working for every format
+	// the kubelet ever wrote.
+	testCases := []struct {
+		goldenFile    string
+		policyName    string
+		expectedState *stateMemory
+	}{
+		{
+			"memory_manager_state-static-kubelet-1.22",
+			"static",
+			&stateMemory{
+				assignments: ContainerMemoryAssignments{
+					"pod-a": map[string][]Block{
+						"container1": {
+							{
+								NUMAAffinity: []int{0},
+								Type:         v1.ResourceMemory,
+								Size:         512,
+							},
+						},
+					},
+				},
+				machineState: NUMANodeMap{
+					0: &NUMANodeState{
+						MemoryMap: map[v1.ResourceName]*MemoryTable{
+							v1.ResourceMemory: {
+								Allocatable:    1536,
+								Free:           1024,
+								Reserved:       512,
+								SystemReserved: 512,
+								TotalMemSize:   2048,
+							},
+						},
+						Cells:               []int{0},
+						NumberOfAssignments: 1,
+					},
+				},
+			},
+		},
+	}
+
+	// every file in the corpus must be exercised by a test case
+	covered := make(map[string]bool)
+	for _, tc := range testCases {
+		covered[tc.goldenFile] = true
+	}
+	goldenFiles, err := os.ReadDir("testdata")
+	assert.NoError(t, err, "could not read the golden checkpoint corpus")
+	for _, goldenFile := range goldenFiles {
+		if !covered[goldenFile.Name()] {
+			t.Errorf("golden checkpoint %q has no test case", goldenFile.Name())
+		}
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.goldenFile, func(t *testing.T) {
+			blob, err := os.ReadFile(filepath.Join("testdata", tc.goldenFile))
+			assert.NoError(t, err, "could not read golden checkpoint")
+
+			testingDir, err := os.MkdirTemp("", "memorymanager_state_test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(testingDir)
+
+			assert.NoError(t, os.WriteFile(filepath.Join(testingDir, testingCheckpoint), blob, 0644), "could not create testing checkpoint")
+
+			restoredState, err := NewCheckpointState(testingDir, testingCheckpoint, tc.policyName)
+			assert.NoError(t, err, "unexpected error while creating checkpointState")
+
+			assertStateEqual(t, restoredState, tc.expectedState)
+		})
+	}
+}
+
 func TestCheckpointStateStore(t *testing.T) {
 	expectedState := &stateMemory{
 		assignments: ContainerMemoryAssignments{
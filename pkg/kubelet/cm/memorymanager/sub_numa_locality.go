@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"fmt"
+	"sort"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+)
+
+// localityDomain holds the sub-NUMA locality of a single NUMA node: the
+// platform-unique dies and uncore caches its CPUs belong to.
+type localityDomain struct {
+	dies         []int
+	uncoreCaches []int
+}
+
+// localityDomains maps every NUMA node of the machine to its sub-NUMA
+// locality. A NUMA node without CPUs, like a CXL-attached memory expander,
+// has no entry.
+type localityDomains map[int]localityDomain
+
+// localityDomainsFromCPUTopology extracts the per NUMA node die and uncore
+// cache domains from a discovered CPU topology.
+func localityDomainsFromCPUTopology(topo *topology.CPUTopology) localityDomains {
+	dies := map[int]map[int]bool{}
+	uncoreCaches := map[int]map[int]bool{}
+	for _, info := range topo.CPUDetails {
+		if dies[info.NUMANodeID] == nil {
+			dies[info.NUMANodeID] = map[int]bool{}
+			uncoreCaches[info.NUMANodeID] = map[int]bool{}
+		}
+		dies[info.NUMANodeID][info.DieID] = true
+		uncoreCaches[info.NUMANodeID][info.UncoreCacheID] = true
+	}
+
+	domains := localityDomains{}
+	for node := range dies {
+		domains[node] = localityDomain{
+			dies:         sortedDomainIDs(dies[node]),
+			uncoreCaches: sortedDomainIDs(uncoreCaches[node]),
+		}
+	}
+	return domains
+}
+
+func sortedDomainIDs(set map[int]bool) []int {
+	ids := make([]int, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// discoverLocalityDomains discovers the CPU topology of the machine and
+// returns the sub-NUMA locality domains it implies. The caller is expected to
+// treat an error as "track memory locality at NUMA node granularity only".
+func discoverLocalityDomains(machineInfo *cadvisorapi.MachineInfo) (localityDomains, error) {
+	if machineInfo == nil {
+		return nil, fmt.Errorf("no machine info available")
+	}
+
+	topo, err := topology.Discover(machineInfo)
+	if err != nil {
+		return nil, err
+	}
+	return localityDomainsFromCPUTopology(topo), nil
+}
+
+// maskSharesDie returns true if all the NUMA nodes in maskBits have CPUs on
+// a common die, so memory reserved across them stays local to the CPUs of
+// that die. Without locality information every mask is treated as sharing a
+// die, which preserves the NUMA-only behavior.
+func (p *staticPolicy) maskSharesDie(maskBits []int) bool {
+	if p.localityDomains == nil {
+		return true
+	}
+
+	var sharedDies map[int]bool
+	for _, nodeID := range maskBits {
+		domain, ok := p.localityDomains[nodeID]
+		if !ok {
+			// a NUMA node without CPUs belongs to no die
+			return false
+		}
+
+		if sharedDies == nil {
+			sharedDies = map[int]bool{}
+			for _, die := range domain.dies {
+				sharedDies[die] = true
+			}
+			continue
+		}
+
+		remaining := map[int]bool{}
+		for _, die := range domain.dies {
+			if sharedDies[die] {
+				remaining[die] = true
+			}
+		}
+		sharedDies = remaining
+	}
+	return len(sharedDies) > 0
+}
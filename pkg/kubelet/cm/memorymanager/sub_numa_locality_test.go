@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology/fixtures"
+	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+)
+
+func TestLocalityDomainsFromCPUTopology(t *testing.T) {
+	t.Run("simple machine, one die and one uncore cache per NUMA node", func(t *testing.T) {
+		domains := localityDomainsFromCPUTopology(fixtures.New(fixtures.Config{
+			Sockets:          2,
+			CoresPerNUMANode: 4,
+		}))
+
+		expected := localityDomains{
+			0: {dies: []int{0}, uncoreCaches: []int{0}},
+			1: {dies: []int{1}, uncoreCaches: []int{1}},
+		}
+		if !reflect.DeepEqual(domains, expected) {
+			t.Fatalf("expected the locality domains %+v, got %+v", expected, domains)
+		}
+	})
+
+	t.Run("EPYC Genoa, multiple NUMA nodes per die and uncore caches per NUMA node", func(t *testing.T) {
+		domains := localityDomainsFromCPUTopology(fixtures.EPYCGenoaDualSocket())
+
+		if len(domains) != 8 {
+			t.Fatalf("expected locality domains for 8 NUMA nodes, got %+v", domains)
+		}
+		expectedNode0 := localityDomain{dies: []int{0}, uncoreCaches: []int{0, 1, 2}}
+		if !reflect.DeepEqual(domains[0], expectedNode0) {
+			t.Fatalf("expected the NUMA node 0 locality domain %+v, got %+v", expectedNode0, domains[0])
+		}
+		expectedNode5 := localityDomain{dies: []int{1}, uncoreCaches: []int{15, 16, 17}}
+		if !reflect.DeepEqual(domains[5], expectedNode5) {
+			t.Fatalf("expected the NUMA node 5 locality domain %+v, got %+v", expectedNode5, domains[5])
+		}
+	})
+}
+
+func TestMaskSharesDie(t *testing.T) {
+	testCases := []struct {
+		description     string
+		localityDomains localityDomains
+		maskBits        []int
+		expected        bool
+	}{
+		{
+			description: "no locality information, every mask shares a die",
+			maskBits:    []int{0, 1},
+			expected:    true,
+		},
+		{
+			description: "single NUMA node",
+			localityDomains: localityDomains{
+				0: {dies: []int{0}},
+			},
+			maskBits: []int{0},
+			expected: true,
+		},
+		{
+			description: "NUMA nodes on the same die",
+			localityDomains: localityDomains{
+				0: {dies: []int{0}},
+				1: {dies: []int{0}},
+			},
+			maskBits: []int{0, 1},
+			expected: true,
+		},
+		{
+			description: "NUMA nodes on different dies",
+			localityDomains: localityDomains{
+				0: {dies: []int{0}},
+				1: {dies: []int{1}},
+			},
+			maskBits: []int{0, 1},
+			expected: false,
+		},
+		{
+			description: "NUMA node without CPUs shares no die",
+			localityDomains: localityDomains{
+				0: {dies: []int{0}},
+			},
+			maskBits: []int{0, 1},
+			expected: false,
+		},
+		{
+			description: "NUMA nodes sharing one die out of several",
+			localityDomains: localityDomains{
+				0: {dies: []int{0, 1}},
+				1: {dies: []int{1}},
+			},
+			maskBits: []int{0, 1},
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := &staticPolicy{localityDomains: testCase.localityDomains}
+			if got := p.maskSharesDie(testCase.maskBits); got != testCase.expected {
+				t.Fatalf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestStaticPolicyGetTopologyHintsSubNUMALocality(t *testing.T) {
+	// the request fits in no single NUMA node, so the only possible hint
+	// spans both of them
+	requirements := &v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("1000m"),
+			v1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("1000m"),
+			v1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+
+	newMachineState := func() state.NUMANodeMap {
+		machineState := state.NUMANodeMap{}
+		for _, nodeID := range []int{0, 1} {
+			machineState[nodeID] = &state.NUMANodeState{
+				MemoryMap: map[v1.ResourceName]*state.MemoryTable{
+					v1.ResourceMemory: {
+						Allocatable:    1536 * mb,
+						Free:           1536 * mb,
+						Reserved:       0,
+						SystemReserved: 512 * mb,
+						TotalMemSize:   2 * gb,
+					},
+				},
+				Cells:               []int{nodeID},
+				NumberOfAssignments: 0,
+			}
+		}
+		return machineState
+	}
+
+	systemReserved := systemReservedMemory{
+		0: map[v1.ResourceName]uint64{
+			v1.ResourceMemory: 512 * mb,
+		},
+		1: map[v1.ResourceName]uint64{
+			v1.ResourceMemory: 512 * mb,
+		},
+	}
+
+	newExpectedHints := func(preferred bool) map[string][]topologymanager.TopologyHint {
+		return map[string][]topologymanager.TopologyHint{
+			string(v1.ResourceMemory): {
+				{
+					NUMANodeAffinity: newNUMAAffinity(0, 1),
+					Preferred:        preferred,
+				},
+			},
+		}
+	}
+
+	testCases := []testStaticPolicy{
+		{
+			description:           "should prefer the cross-NUMA hint, when the nodes share a die",
+			machineState:          newMachineState(),
+			pod:                   getPod("pod1", "container1", requirements),
+			systemReserved:        systemReserved,
+			localityDomains:       localityDomains{0: {dies: []int{0}}, 1: {dies: []int{0}}},
+			expectedTopologyHints: newExpectedHints(true),
+		},
+		{
+			description:           "should not prefer the cross-NUMA hint, when the nodes are on different dies",
+			machineState:          newMachineState(),
+			pod:                   getPod("pod1", "container1", requirements),
+			systemReserved:        systemReserved,
+			localityDomains:       localityDomains{0: {dies: []int{0}}, 1: {dies: []int{1}}},
+			expectedTopologyHints: newExpectedHints(false),
+		},
+		{
+			description:           "should prefer the cross-NUMA hint, when the sub-NUMA locality is unknown",
+			machineState:          newMachineState(),
+			pod:                   getPod("pod1", "container1", requirements),
+			systemReserved:        systemReserved,
+			expectedTopologyHints: newExpectedHints(true),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			p, s, err := initTests(t, &testCase, nil, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			topologyHints := p.GetTopologyHints(s, testCase.pod, &testCase.pod.Spec.Containers[0])
+			if !reflect.DeepEqual(topologyHints, testCase.expectedTopologyHints) {
+				t.Fatalf("The actual topology hints: '%+v' are different from the expected one: '%+v'", topologyHints, testCase.expectedTopologyHints)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"sort"
+
+	nrt "k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager"
+	memorymanagerstate "k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
+	"k8s.io/utils/cpuset"
+)
+
+// GetNodeResourceTopology assembles the per-zone accounting of the resource
+// managers, to be published as a node annotation. Returns nil when the
+// topology manager policy is none, since without alignment there is nothing
+// for a topology-aware consumer to predict.
+func (cm *containerManagerImpl) GetNodeResourceTopology() *nrt.NodeResourceTopology {
+	if cm.NodeConfig.TopologyManagerPolicy == "" || cm.NodeConfig.TopologyManagerPolicy == "none" {
+		return nil
+	}
+
+	var allocatableCPUs topology.CPUDetails
+	freeCPUs := cpuset.New()
+	if cm.cpuManager != nil {
+		allocatableCPUs = cm.cpuManager.GetAllocatableCPUDetails()
+		if cpuState := cm.cpuManager.State(); cpuState != nil {
+			freeCPUs = cpuState.GetDefaultCPUSet()
+		}
+	}
+	var machineMemory memorymanagerstate.NUMANodeMap
+	if cm.memoryManager != nil && cm.memoryManager.State() != nil {
+		machineMemory = cm.memoryManager.State().GetMachineState()
+	}
+	var allocatableDevices, availableDevices devicemanager.ResourceDeviceInstances
+	if cm.deviceManager != nil {
+		allocatableDevices = cm.deviceManager.GetAllocatableDevices()
+		availableDevices = cm.deviceManager.GetAvailableDevices()
+	}
+
+	return &nrt.NodeResourceTopology{
+		TopologyPolicy: cm.NodeConfig.TopologyManagerPolicy,
+		TopologyScope:  cm.NodeConfig.TopologyManagerScope,
+		Zones:          nodeResourceTopologyZones(allocatableCPUs, freeCPUs, machineMemory, allocatableDevices, availableDevices),
+	}
+}
+
+// nodeResourceTopologyZones builds the zone list from the raw accounting of
+// the resource managers: the CPUs usable for exclusive allocation and the
+// ones still unallocated, the per-NUMA memory state, and the devices known
+// and still unallocated. NUMA zones aggregate all three; uncore cache zones
+// carry only CPUs and are nested inside their NUMA zone.
+func nodeResourceTopologyZones(allocatableCPUs topology.CPUDetails, freeCPUs cpuset.CPUSet, machineMemory memorymanagerstate.NUMANodeMap, allocatableDevices, availableDevices devicemanager.ResourceDeviceInstances) []nrt.Zone {
+	numaResources := make(map[int]map[string]nrt.ResourceInfo)
+	resourcesOf := func(numaNode int) map[string]nrt.ResourceInfo {
+		if numaResources[numaNode] == nil {
+			numaResources[numaNode] = make(map[string]nrt.ResourceInfo)
+		}
+		return numaResources[numaNode]
+	}
+
+	for _, numaNode := range allocatableCPUs.NUMANodes().List() {
+		cpus := allocatableCPUs.CPUsInNUMANodes(numaNode)
+		resourcesOf(numaNode)["cpu"] = nrt.ResourceInfo{
+			Allocatable: int64(cpus.Size()),
+			Available:   int64(cpus.Intersection(freeCPUs).Size()),
+		}
+	}
+
+	for numaNode, nodeState := range machineMemory {
+		for resourceName, table := range nodeState.MemoryMap {
+			resourcesOf(numaNode)[string(resourceName)] = nrt.ResourceInfo{
+				Allocatable: int64(table.Allocatable),
+				Available:   int64(table.Free),
+			}
+		}
+	}
+
+	countDevicesByNUMA := func(devices devicemanager.DeviceInstances) map[int]int64 {
+		counts := make(map[int]int64)
+		for _, device := range devices {
+			if device.Topology == nil {
+				continue
+			}
+			for _, node := range device.Topology.Nodes {
+				counts[int(node.ID)]++
+			}
+		}
+		return counts
+	}
+	for resourceName, devices := range allocatableDevices {
+		available := countDevicesByNUMA(availableDevices[resourceName])
+		for numaNode, count := range countDevicesByNUMA(devices) {
+			resourcesOf(numaNode)[resourceName] = nrt.ResourceInfo{
+				Allocatable: count,
+				Available:   available[numaNode],
+			}
+		}
+	}
+
+	numaNodes := make([]int, 0, len(numaResources))
+	for numaNode := range numaResources {
+		numaNodes = append(numaNodes, numaNode)
+	}
+	sort.Ints(numaNodes)
+
+	var zones []nrt.Zone
+	for _, numaNode := range numaNodes {
+		zones = append(zones, nrt.Zone{
+			Name:      nrt.ZoneName(nrt.ZoneTypeNUMA, numaNode),
+			Type:      nrt.ZoneTypeNUMA,
+			Resources: numaResources[numaNode],
+		})
+	}
+
+	for _, uncoreCache := range allocatableCPUs.UncoreCaches().List() {
+		cpus := allocatableCPUs.CPUsInUncoreCaches(uncoreCache)
+		parent := ""
+		if numaNodes := allocatableCPUs.KeepOnly(cpus).NUMANodes().List(); len(numaNodes) > 0 {
+			parent = nrt.ZoneName(nrt.ZoneTypeNUMA, numaNodes[0])
+		}
+		zones = append(zones, nrt.Zone{
+			Name:   nrt.ZoneName(nrt.ZoneTypeUncoreCache, uncoreCache),
+			Type:   nrt.ZoneTypeUncoreCache,
+			Parent: parent,
+			Resources: map[string]nrt.ResourceInfo{
+				"cpu": {
+					Allocatable: int64(cpus.Size()),
+					Available:   int64(cpus.Intersection(freeCPUs).Size()),
+				},
+			},
+		})
+	}
+
+	return zones
+}
@@ -0,0 +1,122 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+	nrt "k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager"
+	memorymanagerstate "k8s.io/kubernetes/pkg/kubelet/cm/memorymanager/state"
+	"k8s.io/utils/cpuset"
+)
+
+func TestNodeResourceTopologyZones(t *testing.T) {
+	// Two NUMA nodes with four CPUs each, one uncore cache per node.
+	allocatableCPUs := topology.CPUDetails{}
+	for cpu := 0; cpu < 8; cpu++ {
+		numaNode := cpu / 4
+		allocatableCPUs[cpu] = topology.CPUInfo{
+			NUMANodeID:    numaNode,
+			SocketID:      numaNode,
+			CoreID:        cpu,
+			UncoreCacheID: numaNode,
+		}
+	}
+	// Two CPUs of NUMA node 0 are exclusively allocated.
+	freeCPUs := cpuset.New(0, 1, 4, 5, 6, 7)
+
+	machineMemory := memorymanagerstate.NUMANodeMap{
+		0: &memorymanagerstate.NUMANodeState{
+			MemoryMap: map[v1.ResourceName]*memorymanagerstate.MemoryTable{
+				v1.ResourceMemory:                  {Allocatable: 1024, Free: 512},
+				v1.ResourceHugePagesPrefix + "2Mi": {Allocatable: 128, Free: 128},
+			},
+		},
+		1: &memorymanagerstate.NUMANodeState{
+			MemoryMap: map[v1.ResourceName]*memorymanagerstate.MemoryTable{
+				v1.ResourceMemory: {Allocatable: 1024, Free: 1024},
+			},
+		},
+	}
+
+	gpuResource := "vendor.example.com/gpu"
+	numaTopology := func(numaNode int64) *pluginapi.TopologyInfo {
+		return &pluginapi.TopologyInfo{Nodes: []*pluginapi.NUMANode{{ID: numaNode}}}
+	}
+	allocatableDevices := devicemanager.ResourceDeviceInstances{
+		gpuResource: devicemanager.DeviceInstances{
+			"gpu-0": pluginapi.Device{ID: "gpu-0", Topology: numaTopology(0)},
+			"gpu-1": pluginapi.Device{ID: "gpu-1", Topology: numaTopology(1)},
+		},
+	}
+	availableDevices := devicemanager.ResourceDeviceInstances{
+		gpuResource: devicemanager.DeviceInstances{
+			"gpu-1": pluginapi.Device{ID: "gpu-1", Topology: numaTopology(1)},
+		},
+	}
+
+	expected := []nrt.Zone{
+		{
+			Name: "numa-0",
+			Type: nrt.ZoneTypeNUMA,
+			Resources: map[string]nrt.ResourceInfo{
+				"cpu":           {Allocatable: 4, Available: 2},
+				"memory":        {Allocatable: 1024, Available: 512},
+				"hugepages-2Mi": {Allocatable: 128, Available: 128},
+				gpuResource:     {Allocatable: 1, Available: 0},
+			},
+		},
+		{
+			Name: "numa-1",
+			Type: nrt.ZoneTypeNUMA,
+			Resources: map[string]nrt.ResourceInfo{
+				"cpu":       {Allocatable: 4, Available: 4},
+				"memory":    {Allocatable: 1024, Available: 1024},
+				gpuResource: {Allocatable: 1, Available: 1},
+			},
+		},
+		{
+			Name:   "uncorecache-0",
+			Type:   nrt.ZoneTypeUncoreCache,
+			Parent: "numa-0",
+			Resources: map[string]nrt.ResourceInfo{
+				"cpu": {Allocatable: 4, Available: 2},
+			},
+		},
+		{
+			Name:   "uncorecache-1",
+			Type:   nrt.ZoneTypeUncoreCache,
+			Parent: "numa-1",
+			Resources: map[string]nrt.ResourceInfo{
+				"cpu": {Allocatable: 4, Available: 4},
+			},
+		},
+	}
+
+	zones := nodeResourceTopologyZones(allocatableCPUs, freeCPUs, machineMemory, allocatableDevices, availableDevices)
+	if !reflect.DeepEqual(zones, expected) {
+		t.Errorf("unexpected zones: expected %+v got %+v", expected, zones)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	libcontainercgroups "github.com/opencontainers/runc/libcontainer/cgroups"
 	v1 "k8s.io/api/core/v1"
@@ -31,6 +32,7 @@ import (
 	"k8s.io/klog/v2"
 	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
 	kubefeatures "k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
 )
 
 const (
@@ -90,9 +92,15 @@ func (m *podContainerManagerImpl) EnsureExists(pod *v1.Pod) error {
 		if enforceMemoryQoS {
 			klog.V(4).InfoS("MemoryQoS config for pod", "pod", klog.KObj(pod), "unified", containerConfig.ResourceParameters.Unified)
 		}
+		startTime := time.Now()
 		if err := m.cgroupManager.Create(containerConfig); err != nil {
 			return fmt.Errorf("failed to create container for %v : %v", podContainerName, err)
 		}
+		// The cgroup write concludes the resource managers' admission work for
+		// a Guaranteed pod, so account it as the last phase of the breakdown.
+		if v1qos.GetPodQOS(pod) == v1.PodQOSGuaranteed {
+			metrics.TopologyManagerAdmissionPhaseDuration.WithLabelValues(metrics.AdmissionPhaseCgroupWrite).Observe(metrics.SinceInSeconds(startTime))
+		}
 	}
 	return nil
 }
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policyoptions holds the option-parsing machinery shared by the
+// resource manager policies. Each manager takes a free-form map of option
+// names to values from the kubelet configuration, and each needs the same
+// scaffolding around it: maturity tiers bound to feature gates, typed value
+// parsing, deprecation warnings. Keeping the scaffolding in one place spares
+// every manager its own copy and keeps the error messages consistent across
+// them.
+package policyoptions
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+)
+
+// Registry describes the policy options one resource manager accepts: which
+// maturity tier each option belongs to, the feature gates guarding the alpha
+// and beta tiers, and the options kept only for compatibility. The sets are
+// held by reference, so a registry built from package-level sets observes
+// later insertions, which the tests rely on.
+type Registry struct {
+	// Component names the owning manager in error messages, e.g. "CPU Manager".
+	Component string
+	// AlphaGate and BetaGate guard the options of the matching tier.
+	AlphaGate featuregate.Feature
+	BetaGate  featuregate.Feature
+	// Alpha, Beta and Stable hold the option names of each maturity tier.
+	Alpha  sets.Set[string]
+	Beta   sets.Set[string]
+	Stable sets.Set[string]
+	// Deprecated maps an option name to the message logged when the option is
+	// used. Deprecated options are still accepted and must also appear in
+	// their maturity tier.
+	Deprecated map[string]string
+}
+
+// CheckAvailable verifies that the given option exists and that the feature
+// gate of its maturity tier is enabled, logging a warning when the option is
+// deprecated. It returns nil on success, or an error describing the failure.
+func (r Registry) CheckAvailable(option string) error {
+	if !r.Alpha.Has(option) && !r.Beta.Has(option) && !r.Stable.Has(option) {
+		return fmt.Errorf("unknown %s Policy option: %q", r.Component, option)
+	}
+
+	if r.Alpha.Has(option) && !utilfeature.DefaultFeatureGate.Enabled(r.AlphaGate) {
+		return fmt.Errorf("%s Policy Alpha-level Options not enabled, but option %q provided", r.Component, option)
+	}
+
+	if r.Beta.Has(option) && !utilfeature.DefaultFeatureGate.Enabled(r.BetaGate) {
+		return fmt.Errorf("%s Policy Beta-level Options not enabled, but option %q provided", r.Component, option)
+	}
+
+	if message, ok := r.Deprecated[option]; ok {
+		klog.InfoS("Deprecated policy option used", "component", r.Component, "option", option, "message", message)
+	}
+
+	return nil
+}
+
+// Bool parses the value of a boolean option.
+func Bool(option, value string) (bool, error) {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, BadValue(option, err)
+	}
+	return parsed, nil
+}
+
+// Int parses the value of an integer option.
+func Int(option, value string) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, BadValue(option, err)
+	}
+	return parsed, nil
+}
+
+// BadValue wraps an option-specific validation error in the same form the
+// typed parsers use, so every malformed value reads the same no matter which
+// manager rejected it.
+func BadValue(option string, err error) error {
+	return fmt.Errorf("bad value for option %q: %w", option, err)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyoptions
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	pkgfeatures "k8s.io/kubernetes/pkg/features"
+)
+
+func testRegistry() Registry {
+	return Registry{
+		Component: "Fake Manager",
+		AlphaGate: pkgfeatures.TopologyManagerPolicyAlphaOptions,
+		BetaGate:  pkgfeatures.TopologyManagerPolicyBetaOptions,
+		Alpha:     sets.New[string]("alpha-option"),
+		Beta:      sets.New[string]("beta-option"),
+		Stable:    sets.New[string]("stable-option"),
+		Deprecated: map[string]string{
+			"stable-option": "use something else",
+		},
+	}
+}
+
+func TestRegistryCheckAvailable(t *testing.T) {
+	testCases := []struct {
+		option           string
+		alphaGateEnabled bool
+		betaGateEnabled  bool
+		expectedErr      string
+	}{
+		{
+			option:      "no-such-option",
+			expectedErr: `unknown Fake Manager Policy option: "no-such-option"`,
+		},
+		{
+			option:      "alpha-option",
+			expectedErr: "Fake Manager Policy Alpha-level Options not enabled,",
+		},
+		{
+			option:           "alpha-option",
+			alphaGateEnabled: true,
+		},
+		{
+			option:      "beta-option",
+			expectedErr: "Fake Manager Policy Beta-level Options not enabled,",
+		},
+		{
+			option:          "beta-option",
+			betaGateEnabled: true,
+		},
+		{
+			// Deprecated options only warn, they are still accepted.
+			option: "stable-option",
+		},
+	}
+	for _, tcase := range testCases {
+		t.Run(tcase.option, func(t *testing.T) {
+			featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.TopologyManagerPolicyAlphaOptions, tcase.alphaGateEnabled)
+			featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, pkgfeatures.TopologyManagerPolicyBetaOptions, tcase.betaGateEnabled)
+
+			err := testRegistry().CheckAvailable(tcase.option)
+			if tcase.expectedErr == "" {
+				if err != nil {
+					t.Errorf("expected option %q to be available, got %v", tcase.option, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tcase.expectedErr) {
+				t.Errorf("expected error containing %q, got %v", tcase.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestTypedValues(t *testing.T) {
+	if value, err := Bool("some-option", "true"); err != nil || !value {
+		t.Errorf("expected true, got %v %v", value, err)
+	}
+	if _, err := Bool("some-option", "not a boolean"); err == nil || !strings.Contains(err.Error(), `bad value for option "some-option"`) {
+		t.Errorf("expected a bad value error, got %v", err)
+	}
+	if value, err := Int("some-option", "42"); err != nil || value != 42 {
+		t.Errorf("expected 42, got %v %v", value, err)
+	}
+	if _, err := Int("some-option", "not a number"); err == nil || !strings.Contains(err.Error(), `bad value for option "some-option"`) {
+		t.Errorf("expected a bad value error, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/devicemanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/memorymanager"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager"
+)
+
+// ResourceManager is the interface shared by the resource-specific managers
+// (CPU, memory and device manager) which take part in pod admission. On top
+// of the topology manager hint provider methods, used to bid for and commit
+// NUMA-aligned allocations, it exposes the container cleanup hook and the
+// persisted allocation state of each manager in a uniform shape, so callers
+// can treat the managers generically - e.g. for instrumentation - instead of
+// enumerating them case by case.
+type ResourceManager interface {
+	// The resource managers participate in pod admission through the
+	// topology manager, which merges their hints and triggers the actual
+	// allocation on each of them.
+	topologymanager.HintProvider
+
+	// RemoveContainer is called after Kubelet decides to kill or delete a
+	// container. After this call, any resources the manager exclusively
+	// assigned to the container are freed.
+	RemoveContainer(containerID string) error
+
+	// CheckpointFile returns the path of the checkpoint file the manager
+	// uses to persist its assignments across kubelet restarts.
+	CheckpointFile() string
+
+	// StateSnapshot returns a point-in-time, JSON-serializable copy of the
+	// allocation state of the manager, in a manager-specific shape meant
+	// for instrumentation and debugging rather than programmatic access.
+	StateSnapshot() interface{}
+}
+
+// All the resource managers must expose the common interface on top of their
+// resource-specific one.
+var (
+	_ ResourceManager = (cpumanager.Manager)(nil)
+	_ ResourceManager = (memorymanager.Manager)(nil)
+	_ ResourceManager = (devicemanager.Manager)(nil)
+)
+
+// ResourceManagersConfigz collects the effective, post-defaulting
+// configuration of the resource managers, in the manager-specific shapes
+// their ConfigSnapshot methods return. Unlike the kubeletconfig section of
+// /configz, which reports the configuration as it was given, this reflects
+// what the managers computed from it at startup: defaults applied, reserved
+// sets resolved and feature-gated options pruned.
+type ResourceManagersConfigz struct {
+	CPUManager      interface{} `json:"cpuManager,omitempty"`
+	MemoryManager   interface{} `json:"memoryManager,omitempty"`
+	TopologyManager interface{} `json:"topologyManager,omitempty"`
+}
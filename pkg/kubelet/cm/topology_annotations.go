@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"k8s.io/utils/cpuset"
+
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+const (
+	// uncoreCacheIDsAnnotationKey is the OCI annotation through which the
+	// kubelet exposes to the runtime the uncore cache IDs of the cpus
+	// exclusively assigned to a container.
+	uncoreCacheIDsAnnotationKey = "alignment.kubelet.kubernetes.io/uncore-cache-ids"
+	// numaNodeIDsAnnotationKey is the OCI annotation through which the
+	// kubelet exposes to the runtime the NUMA nodes the container resources
+	// are aligned to.
+	numaNodeIDsAnnotationKey = "alignment.kubelet.kubernetes.io/numa-node-ids"
+)
+
+// topologyAnnotations renders the given uncore cache and NUMA node IDs as
+// annotations to be passed to the runtime on container creation, so runtimes
+// and NRI plugins can apply additional tuning (e.g. vCPU pinning) consistent
+// with the kubelet allocation decisions. IDs are rendered in the canonical
+// cpuset list format (e.g. "0-1,4").
+func topologyAnnotations(uncoreCacheIDs cpuset.CPUSet, numaNodeIDs []int) []kubecontainer.Annotation {
+	var annotations []kubecontainer.Annotation
+	if uncoreCacheIDs.Size() > 0 {
+		annotations = append(annotations, kubecontainer.Annotation{
+			Name:  uncoreCacheIDsAnnotationKey,
+			Value: uncoreCacheIDs.String(),
+		})
+	}
+	if len(numaNodeIDs) > 0 {
+		annotations = append(annotations, kubecontainer.Annotation{
+			Name:  numaNodeIDsAnnotationKey,
+			Value: cpuset.New(numaNodeIDs...).String(),
+		})
+	}
+	return annotations
+}
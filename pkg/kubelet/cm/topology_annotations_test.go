@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cm
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/cpuset"
+
+	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+)
+
+func TestTopologyAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		uncoreCacheIDs cpuset.CPUSet
+		numaNodeIDs    []int
+		expected       []kubecontainer.Annotation
+	}{
+		{
+			name:           "no alignment data",
+			uncoreCacheIDs: cpuset.New(),
+		},
+		{
+			name:           "uncore caches only",
+			uncoreCacheIDs: cpuset.New(0, 1),
+			expected: []kubecontainer.Annotation{
+				{Name: uncoreCacheIDsAnnotationKey, Value: "0-1"},
+			},
+		},
+		{
+			name:           "numa nodes only",
+			uncoreCacheIDs: cpuset.New(),
+			numaNodeIDs:    []int{0},
+			expected: []kubecontainer.Annotation{
+				{Name: numaNodeIDsAnnotationKey, Value: "0"},
+			},
+		},
+		{
+			name:           "uncore caches and numa nodes",
+			uncoreCacheIDs: cpuset.New(2),
+			numaNodeIDs:    []int{0, 1},
+			expected: []kubecontainer.Annotation{
+				{Name: uncoreCacheIDsAnnotationKey, Value: "2"},
+				{Name: numaNodeIDsAnnotationKey, Value: "0-1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := topologyAnnotations(tt.uncoreCacheIDs, tt.numaNodeIDs)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("topologyAnnotations() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
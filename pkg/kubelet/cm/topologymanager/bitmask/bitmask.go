@@ -19,7 +19,7 @@ package bitmask
 import (
 	"fmt"
 	"math/bits"
-	"strconv"
+	"strings"
 )
 
 // BitMask interface allows hint providers to create BitMasks for TopologyHints
@@ -42,17 +42,25 @@ type BitMask interface {
 	GetBits() []int
 }
 
-type bitMask uint64
+// bitsPerWord is the width of a single mask word.
+const bitsPerWord = 64
+
+// bitMask is a little-endian slice of words which grows on demand, so masks
+// are not capped at 64 bits and machines with many NUMA domains (e.g. SNC
+// enabled multi-socket servers) can be represented. Trailing zero words are
+// allowed and never affect comparisons: masks of different widths holding the
+// same bits are equal.
+type bitMask []uint64
 
 // NewEmptyBitMask creates a new, empty BitMask
 func NewEmptyBitMask() BitMask {
-	s := bitMask(0)
+	s := bitMask{}
 	return &s
 }
 
 // NewBitMask creates a new BitMask
 func NewBitMask(bits ...int) (BitMask, error) {
-	s := bitMask(0)
+	s := bitMask{}
 	err := (&s).Add(bits...)
 	if err != nil {
 		return nil, err
@@ -60,67 +68,104 @@ func NewBitMask(bits ...int) (BitMask, error) {
 	return &s, nil
 }
 
+// grow extends the mask with zero words until it holds at least `words` words.
+func (s *bitMask) grow(words int) {
+	for len(*s) < words {
+		*s = append(*s, 0)
+	}
+}
+
+// word returns the word holding `bit`, or zero if the mask is too short.
+func (s *bitMask) word(bit int) uint64 {
+	if index := bit / bitsPerWord; index < len(*s) {
+		return (*s)[index]
+	}
+	return 0
+}
+
 // Add adds the bits with topology affinity to the BitMask
 func (s *bitMask) Add(bits ...int) error {
-	mask := *s
 	for _, i := range bits {
-		if i < 0 || i >= 64 {
-			return fmt.Errorf("bit number must be in range 0-63")
+		if i < 0 {
+			return fmt.Errorf("bit number must not be negative")
 		}
-		mask |= 1 << uint64(i)
 	}
-	*s = mask
+	for _, i := range bits {
+		s.grow(i/bitsPerWord + 1)
+		(*s)[i/bitsPerWord] |= 1 << uint(i%bitsPerWord)
+	}
 	return nil
 }
 
 // Remove removes specified bits from BitMask
 func (s *bitMask) Remove(bits ...int) error {
-	mask := *s
 	for _, i := range bits {
-		if i < 0 || i >= 64 {
-			return fmt.Errorf("bit number must be in range 0-63")
+		if i < 0 {
+			return fmt.Errorf("bit number must not be negative")
+		}
+	}
+	for _, i := range bits {
+		if index := i / bitsPerWord; index < len(*s) {
+			(*s)[index] &^= 1 << uint(i%bitsPerWord)
 		}
-		mask &^= 1 << uint64(i)
 	}
-	*s = mask
 	return nil
 }
 
 // And performs and operation on all bits in masks
 func (s *bitMask) And(masks ...BitMask) {
 	for _, m := range masks {
-		*s &= *m.(*bitMask)
+		other := *m.(*bitMask)
+		for i := range *s {
+			if i < len(other) {
+				(*s)[i] &= other[i]
+			} else {
+				(*s)[i] = 0
+			}
+		}
 	}
 }
 
 // Or performs or operation on all bits in masks
 func (s *bitMask) Or(masks ...BitMask) {
 	for _, m := range masks {
-		*s |= *m.(*bitMask)
+		other := *m.(*bitMask)
+		s.grow(len(other))
+		for i := range other {
+			(*s)[i] |= other[i]
+		}
 	}
 }
 
 // Clear resets all bits in mask to zero
 func (s *bitMask) Clear() {
-	*s = 0
+	*s = bitMask{}
 }
 
-// Fill sets all bits in mask to one
+// Fill sets all bits in the current width of the mask (at least one word) to one
 func (s *bitMask) Fill() {
-	*s = bitMask(^uint64(0))
+	s.grow(1)
+	for i := range *s {
+		(*s)[i] = ^uint64(0)
+	}
 }
 
 // IsEmpty checks mask to see if all bits are zero
 func (s *bitMask) IsEmpty() bool {
-	return *s == 0
+	for _, word := range *s {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
 }
 
 // IsSet checks bit in mask to see if bit is set to one
 func (s *bitMask) IsSet(bit int) bool {
-	if bit < 0 || bit >= 64 {
+	if bit < 0 {
 		return false
 	}
-	return (*s & (1 << uint64(bit))) > 0
+	return (s.word(bit) & (1 << uint(bit%bitsPerWord))) > 0
 }
 
 // AnySet checks bit in mask to see if any provided bit is set to one
@@ -133,9 +178,37 @@ func (s *bitMask) AnySet(bits []int) bool {
 	return false
 }
 
+// compare returns a negative number, zero or a positive number if the numeric
+// value of `s` is respectively less than, equal to or greater than the one of
+// `mask`. Masks of different widths are compared as if the shorter one was
+// zero-extended.
+func (s *bitMask) compare(mask BitMask) int {
+	other := *mask.(*bitMask)
+	length := len(*s)
+	if len(other) > length {
+		length = len(other)
+	}
+	for i := length - 1; i >= 0; i-- {
+		var word, otherWord uint64
+		if i < len(*s) {
+			word = (*s)[i]
+		}
+		if i < len(other) {
+			otherWord = other[i]
+		}
+		if word < otherWord {
+			return -1
+		}
+		if word > otherWord {
+			return 1
+		}
+	}
+	return 0
+}
+
 // IsEqual checks if masks are equal
 func (s *bitMask) IsEqual(mask BitMask) bool {
-	return *s == *mask.(*bitMask)
+	return s.compare(mask) == 0
 }
 
 // IsNarrowerThan checks if one mask is narrower than another.
@@ -152,36 +225,58 @@ func (s *bitMask) IsNarrowerThan(mask BitMask) bool {
 
 // IsLessThan checks which bitmask has more lower-numbered bits set.
 func (s *bitMask) IsLessThan(mask BitMask) bool {
-	return *s < *mask.(*bitMask)
+	return s.compare(mask) < 0
 }
 
 // IsGreaterThan checks which bitmask has more higher-numbered bits set.
 func (s *bitMask) IsGreaterThan(mask BitMask) bool {
-	return *s > *mask.(*bitMask)
+	return s.compare(mask) > 0
 }
 
 // String converts mask to string
 func (s *bitMask) String() string {
 	grouping := 2
-	for shift := 64 - grouping; shift > 0; shift -= grouping {
-		if *s > (1 << uint(shift)) {
-			return fmt.Sprintf("%0"+strconv.Itoa(shift+grouping)+"b", *s)
+	width := grouping
+	for i := len(*s) - 1; i >= 0 && width == grouping; i-- {
+		for bit := bitsPerWord - 1; bit >= 0; bit-- {
+			if (*s)[i]&(1<<uint(bit)) != 0 {
+				digits := i*bitsPerWord + bit + 1
+				digits = ((digits + grouping - 1) / grouping) * grouping
+				if digits > width {
+					width = digits
+				}
+				break
+			}
 		}
 	}
-	return fmt.Sprintf("%0"+strconv.Itoa(grouping)+"b", *s)
+	var b strings.Builder
+	for bit := width - 1; bit >= 0; bit-- {
+		if s.IsSet(bit) {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
 }
 
 // Count counts number of bits in mask set to one
 func (s *bitMask) Count() int {
-	return bits.OnesCount64(uint64(*s))
+	count := 0
+	for _, word := range *s {
+		count += bits.OnesCount64(word)
+	}
+	return count
 }
 
 // Getbits returns each bit number with bits set to one
 func (s *bitMask) GetBits() []int {
 	var bits []int
-	for i := uint64(0); i < 64; i++ {
-		if (*s & (1 << i)) > 0 {
-			bits = append(bits, int(i))
+	for i, word := range *s {
+		for bit := 0; bit < bitsPerWord; bit++ {
+			if (word & (1 << uint(bit))) > 0 {
+				bits = append(bits, i*bitsPerWord+bit)
+			}
 		}
 	}
 	return bits
@@ -189,14 +284,14 @@ func (s *bitMask) GetBits() []int {
 
 // And is a package level implementation of 'and' between first and masks
 func And(first BitMask, masks ...BitMask) BitMask {
-	s := *first.(*bitMask)
+	s := append(bitMask{}, *first.(*bitMask)...)
 	s.And(masks...)
 	return &s
 }
 
 // Or is a package level implementation of 'or' between first and masks
 func Or(first BitMask, masks ...BitMask) BitMask {
-	s := *first.(*bitMask)
+	s := append(bitMask{}, *first.(*bitMask)...)
 	s.Or(masks...)
 	return &s
 }
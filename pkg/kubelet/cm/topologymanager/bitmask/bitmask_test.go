@@ -702,3 +702,44 @@ func TestIsGreaterThan(t *testing.T) {
 		}
 	}
 }
+
+func TestBitsBeyondSingleWord(t *testing.T) {
+	mask, err := NewBitMask(2, 67, 130)
+	if err != nil {
+		t.Fatalf("Unexpected error creating a mask with high bits: %v", err)
+	}
+	if mask.Count() != 3 {
+		t.Errorf("Expected 3 bits set, got %v", mask.Count())
+	}
+	if !reflect.DeepEqual(mask.GetBits(), []int{2, 67, 130}) {
+		t.Errorf("Expected bits [2 67 130], got %v", mask.GetBits())
+	}
+	for _, bit := range []int{2, 67, 130} {
+		if !mask.IsSet(bit) {
+			t.Errorf("Expected bit %v to be set", bit)
+		}
+	}
+	if mask.IsSet(66) || mask.IsSet(131) {
+		t.Errorf("Unexpected bit set in mask %v", mask)
+	}
+
+	// Operations between masks of different widths treat the narrower mask
+	// as zero-extended.
+	narrow, _ := NewBitMask(2)
+	if And(mask, narrow).Count() != 1 || !And(mask, narrow).IsSet(2) {
+		t.Errorf("Expected and mask to hold bit 2 only, got %v", And(mask, narrow))
+	}
+	or := Or(narrow, mask)
+	if !or.IsEqual(mask) {
+		t.Errorf("Expected or mask to equal %v, got %v", mask, or)
+	}
+	if !narrow.IsNarrowerThan(mask) {
+		t.Errorf("Expected mask %v to be narrower than %v", narrow, mask)
+	}
+	if err := mask.Remove(67, 130); err != nil {
+		t.Fatalf("Unexpected error removing high bits: %v", err)
+	}
+	if !mask.IsEqual(narrow) {
+		t.Errorf("Expected masks to be equal after removal, have %v and %v", mask, narrow)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/dump"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/checksum"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/errors"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+)
+
+// checkpointName is the name of the file the hint assignments are
+// checkpointed under, inside the kubelet root directory.
+const checkpointName = "topology_manager_state"
+
+var _ checkpointmanager.Checkpoint = &topologyManagerCheckpointV1{}
+var _ checkpointmanager.Checkpoint = &topologyManagerCheckpoint{}
+
+// hintCheckpointEntry is the serialized form of a TopologyHint. The affinity
+// is stored as the list of NUMA node ids the bitmask has set, which
+// round-trips through JSON unlike the bitmask itself.
+type hintCheckpointEntry struct {
+	NUMANodeAffinity []int             `json:"numaNodeAffinity,omitempty"`
+	Preferred        bool              `json:"preferred"`
+	Attributes       map[string]string `json:"attributes,omitempty"`
+}
+
+// topologyManagerCheckpoint stores the per-pod, per-container hint
+// assignments, so the affinity decisions for still-running containers survive
+// a kubelet restart. The policy and scope names pin the configuration the
+// hints were computed under: hints computed under a different configuration
+// are not comparable and are discarded on restore.
+type topologyManagerCheckpoint struct {
+	PolicyName string                                    `json:"policyName"`
+	Scope      string                                    `json:"scope"`
+	Entries    map[string]map[string]hintCheckpointEntry `json:"entries,omitempty"`
+	Checksum   checksum.Checksum                         `json:"checksum"`
+}
+
+// hintCheckpointEntryV1 mirrors hintCheckpointEntry as it was before the
+// hints carried attributes, to keep validating the checksums of checkpoints
+// written in the v1 format.
+type hintCheckpointEntryV1 struct {
+	NUMANodeAffinity []int `json:"numaNodeAffinity,omitempty"`
+	Preferred        bool  `json:"preferred"`
+}
+
+// topologyManagerCheckpointV1 stores the hint assignments in the v1 format.
+type topologyManagerCheckpointV1 struct {
+	PolicyName string                                      `json:"policyName"`
+	Scope      string                                      `json:"scope"`
+	Entries    map[string]map[string]hintCheckpointEntryV1 `json:"entries,omitempty"`
+	Checksum   checksum.Checksum                           `json:"checksum"`
+}
+
+func newTopologyManagerCheckpoint() *topologyManagerCheckpoint {
+	return &topologyManagerCheckpoint{
+		Entries: make(map[string]map[string]hintCheckpointEntry),
+	}
+}
+
+// MarshalCheckpoint returns marshalled checkpoint
+func (cp *topologyManagerCheckpoint) MarshalCheckpoint() ([]byte, error) {
+	// make sure checksum wasn't set before so it doesn't affect output checksum
+	cp.Checksum = 0
+	cp.Checksum = checksum.New(cp)
+	return json.Marshal(*cp)
+}
+
+// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint
+func (cp *topologyManagerCheckpoint) UnmarshalCheckpoint(blob []byte) error {
+	return json.Unmarshal(blob, cp)
+}
+
+// VerifyChecksum verifies that current checksum of checkpoint is valid
+func (cp *topologyManagerCheckpoint) VerifyChecksum() error {
+	ck := cp.Checksum
+	cp.Checksum = 0
+	err := ck.Verify(cp)
+	cp.Checksum = ck
+	return err
+}
+
+// MarshalCheckpoint returns marshalled checkpoint in v1 format
+func (cp *topologyManagerCheckpointV1) MarshalCheckpoint() ([]byte, error) {
+	// make sure checksum wasn't set before so it doesn't affect output checksum
+	cp.Checksum = 0
+	cp.Checksum = checksum.New(cp)
+	return json.Marshal(*cp)
+}
+
+// UnmarshalCheckpoint tries to unmarshal passed bytes to checkpoint in v1 format
+func (cp *topologyManagerCheckpointV1) UnmarshalCheckpoint(blob []byte) error {
+	return json.Unmarshal(blob, cp)
+}
+
+// VerifyChecksum verifies that current checksum of checkpoint is valid in v1 format
+func (cp *topologyManagerCheckpointV1) VerifyChecksum() error {
+	// v1 checksums were computed when the checkpoint and entry types were the
+	// ones without the V1 suffix, before the attributes were added
+	ck := cp.Checksum
+	cp.Checksum = 0
+	object := dump.ForHash(cp)
+	object = strings.NewReplacer(
+		"topologyManagerCheckpointV1", "topologyManagerCheckpoint",
+		"hintCheckpointEntryV1", "hintCheckpointEntry",
+	).Replace(object)
+	cp.Checksum = ck
+
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%v", object)
+	if cp.Checksum != checksum.Checksum(hash.Sum32()) {
+		return errors.ErrCorruptCheckpoint
+	}
+
+	return nil
+}
+
+// migrateV1Checkpoint fills a checkpoint in the current format from one in
+// the v1 format. The v1 entries carried no attributes, so there is nothing to
+// translate beyond the layout.
+func migrateV1Checkpoint(src *topologyManagerCheckpointV1, dst *topologyManagerCheckpoint) {
+	dst.PolicyName = src.PolicyName
+	dst.Scope = src.Scope
+	for podUID, containers := range src.Entries {
+		dst.Entries[podUID] = make(map[string]hintCheckpointEntry)
+		for containerName, entry := range containers {
+			dst.Entries[podUID][containerName] = hintCheckpointEntry{
+				NUMANodeAffinity: entry.NUMANodeAffinity,
+				Preferred:        entry.Preferred,
+			}
+		}
+	}
+}
+
+// checkpointFromTopologyHints converts the hints a scope tracks into their
+// checkpoint representation.
+func checkpointFromTopologyHints(policyName string, scopeName string, hints podTopologyHints) *topologyManagerCheckpoint {
+	cp := newTopologyManagerCheckpoint()
+	cp.PolicyName = policyName
+	cp.Scope = scopeName
+	for podUID, containers := range hints {
+		cp.Entries[podUID] = make(map[string]hintCheckpointEntry)
+		for containerName, hint := range containers {
+			entry := hintCheckpointEntry{Preferred: hint.Preferred, Attributes: hint.Attributes}
+			if hint.NUMANodeAffinity != nil {
+				entry.NUMANodeAffinity = hint.NUMANodeAffinity.GetBits()
+			}
+			cp.Entries[podUID][containerName] = entry
+		}
+	}
+	return cp
+}
+
+// topologyHintsFromCheckpoint is the inverse of checkpointFromTopologyHints.
+func topologyHintsFromCheckpoint(cp *topologyManagerCheckpoint) (podTopologyHints, error) {
+	hints := podTopologyHints{}
+	for podUID, containers := range cp.Entries {
+		hints[podUID] = make(map[string]TopologyHint)
+		for containerName, entry := range containers {
+			hint := TopologyHint{Preferred: entry.Preferred, Attributes: entry.Attributes}
+			if len(entry.NUMANodeAffinity) > 0 {
+				affinity, err := bitmask.NewBitMask(entry.NUMANodeAffinity...)
+				if err != nil {
+					return nil, fmt.Errorf("invalid NUMA affinity checkpointed for pod %q container %q: %w", podUID, containerName, err)
+				}
+				hint.NUMANodeAffinity = affinity
+			}
+			hints[podUID][containerName] = hint
+		}
+	}
+	return hints, nil
+}
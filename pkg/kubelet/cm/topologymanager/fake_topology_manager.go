@@ -64,10 +64,19 @@ func (m *fakeManager) GetPolicy() Policy {
 	return m.policy
 }
 
+func (m *fakeManager) ConfigSnapshot() interface{} {
+	klog.InfoS("ConfigSnapshot")
+	return nil
+}
+
 func (m *fakeManager) AddHintProvider(h HintProvider) {
 	klog.InfoS("AddHintProvider", "hintProvider", h)
 }
 
+func (m *fakeManager) RemoveHintProvider(h HintProvider) {
+	klog.InfoS("RemoveHintProvider", "hintProvider", h)
+}
+
 func (m *fakeManager) AddContainer(pod *v1.Pod, container *v1.Container, containerID string) {
 	klog.InfoS("AddContainer", "pod", klog.KObj(pod), "containerName", container.Name, "containerID", containerID)
 }
@@ -77,6 +86,27 @@ func (m *fakeManager) RemoveContainer(containerID string) error {
 	return nil
 }
 
+func (m *fakeManager) IsPodAligned(podUID string) (bool, bool) {
+	klog.InfoS("IsPodAligned", "podUID", podUID)
+	if m.hint == nil {
+		return false, false
+	}
+	return m.hint.Preferred, true
+}
+
+func (m *fakeManager) DryRunAdmit(pod *v1.Pod) []ContainerAdmissionPrediction {
+	klog.InfoS("DryRunAdmit", "pod", klog.KObj(pod))
+	var predictions []ContainerAdmissionPrediction
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		predictions = append(predictions, ContainerAdmissionPrediction{
+			ContainerName: container.Name,
+			Affinity:      m.GetAffinity(string(pod.UID), container.Name),
+			Admit:         true,
+		})
+	}
+	return predictions
+}
+
 func (m *fakeManager) Admit(attrs *lifecycle.PodAdmitAttributes) lifecycle.PodAdmitResult {
 	klog.InfoS("Topology Admit Handler")
 	return admission.GetPodAdmitResult(nil)
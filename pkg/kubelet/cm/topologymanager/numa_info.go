@@ -18,11 +18,19 @@ package topologymanager
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	cadvisorapi "github.com/google/cadvisor/info/v1"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 )
 
+// sysNodeDir is where the kernel exposes the NUMA distance matrix. It is a
+// variable only so the tests can point it at a fixture directory.
+var sysNodeDir = "/sys/devices/system/node"
+
 type NUMADistances map[int][]uint64
 
 type NUMAInfo struct {
@@ -40,7 +48,13 @@ func NewNUMAInfo(topology []cadvisorapi.Node, opts PolicyOptions) (*NUMAInfo, er
 		if opts.PreferClosestNUMA {
 			nodeDistance = node.Distances
 			if nodeDistance == nil {
-				return nil, fmt.Errorf("error getting NUMA distances from cadvisor")
+				// cadvisor builds without NUMA distance support leave the
+				// field empty; fall back to what the kernel exposes.
+				var err error
+				nodeDistance, err = readSysFSNUMADistances(sysNodeDir, node.Id)
+				if err != nil {
+					return nil, fmt.Errorf("error getting NUMA distances for node %d: %w", node.Id, err)
+				}
 			}
 		}
 		distances[node.Id] = nodeDistance
@@ -54,6 +68,29 @@ func NewNUMAInfo(topology []cadvisorapi.Node, opts PolicyOptions) (*NUMAInfo, er
 	return numaInfo, nil
 }
 
+// readSysFSNUMADistances parses the distance matrix row the kernel exposes
+// for the given NUMA node: a single line of space-separated integers, one per
+// node, with the distance to the node itself by convention being 10.
+func readSysFSNUMADistances(nodeDir string, nodeID int) ([]uint64, error) {
+	path := filepath.Join(nodeDir, fmt.Sprintf("node%d", nodeID), "distance")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var distances []uint64
+	for _, field := range strings.Fields(strings.TrimSpace(string(data))) {
+		distance, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse distance %q in %q: %w", field, path, err)
+		}
+		distances = append(distances, distance)
+	}
+	if len(distances) == 0 {
+		return nil, fmt.Errorf("empty distance matrix row in %q", path)
+	}
+	return distances, nil
+}
+
 func (n *NUMAInfo) Narrowest(m1 bitmask.BitMask, m2 bitmask.BitMask) bitmask.BitMask {
 	if m1.IsNarrowerThan(m2) {
 		return m1
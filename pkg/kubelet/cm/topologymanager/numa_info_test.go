@@ -18,6 +18,8 @@ package topologymanager
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -27,6 +29,12 @@ import (
 )
 
 func TestNUMAInfo(t *testing.T) {
+	// Keep the sysfs fallback away from the distance matrix of the machine
+	// running the tests.
+	oldSysNodeDir := sysNodeDir
+	sysNodeDir = t.TempDir()
+	defer func() { sysNodeDir = oldSysNodeDir }()
+
 	tcases := []struct {
 		name             string
 		topology         []cadvisorapi.Node
@@ -325,7 +333,7 @@ func TestNUMAInfo(t *testing.T) {
 				},
 			},
 			expectedNUMAInfo: nil,
-			expectedErr:      fmt.Errorf("error getting NUMA distances from cadvisor"),
+			expectedErr:      fmt.Errorf("error getting NUMA distances for node 9"),
 			opts: PolicyOptions{
 				PreferClosestNUMA: true,
 			},
@@ -704,3 +712,42 @@ func TestClosest(t *testing.T) {
 		})
 	}
 }
+
+func TestNUMAInfoSysFSFallback(t *testing.T) {
+	oldSysNodeDir := sysNodeDir
+	sysNodeDir = t.TempDir()
+	defer func() { sysNodeDir = oldSysNodeDir }()
+
+	for nodeID, row := range map[int]string{0: "10 21\n", 1: "21 10\n"} {
+		nodeDir := filepath.Join(sysNodeDir, fmt.Sprintf("node%d", nodeID))
+		if err := os.MkdirAll(nodeDir, 0755); err != nil {
+			t.Fatalf("could not create %q: %v", nodeDir, err)
+		}
+		if err := os.WriteFile(filepath.Join(nodeDir, "distance"), []byte(row), 0644); err != nil {
+			t.Fatalf("could not write the distance file: %v", err)
+		}
+	}
+
+	// cadvisor reports no distances: they must come from sysfs.
+	topology := []cadvisorapi.Node{{Id: 0}, {Id: 1}}
+	numaInfo, err := NewNUMAInfo(topology, PolicyOptions{PreferClosestNUMA: true})
+	if err != nil {
+		t.Fatalf("NewNUMAInfo() failed: %v", err)
+	}
+	expected := NUMADistances{
+		0: {10, 21},
+		1: {21, 10},
+	}
+	if !reflect.DeepEqual(numaInfo.NUMADistances, expected) {
+		t.Errorf("expected distances %v but got %v", expected, numaInfo.NUMADistances)
+	}
+
+	// A malformed row must fail loudly rather than feed garbage distances
+	// into the hint comparison.
+	if err := os.WriteFile(filepath.Join(sysNodeDir, "node1", "distance"), []byte("10 bogus\n"), 0644); err != nil {
+		t.Fatalf("could not write the distance file: %v", err)
+	}
+	if _, err := NewNUMAInfo(topology, PolicyOptions{PreferClosestNUMA: true}); err == nil {
+		t.Errorf("expected an error for the malformed distance matrix row")
+	}
+}
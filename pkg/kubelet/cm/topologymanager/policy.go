@@ -17,6 +17,9 @@ limitations under the License.
 package topologymanager
 
 import (
+	"sort"
+	"time"
+
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 )
@@ -27,7 +30,23 @@ type Policy interface {
 	Name() string
 	// Returns a merged TopologyHint based on input from hint providers
 	// and a Pod Admit Handler Response based on hints and policy type
-	Merge(providersHints []map[string][]TopologyHint) (TopologyHint, bool)
+	Merge(providersHints []ProviderHints) (TopologyHint, bool)
+}
+
+// ProviderHints associates the hints returned by a single hint provider with
+// the identity of that provider. Carrying the provider name alongside its
+// hints lets the merge code and the debug output attribute each hint to its
+// source (e.g. devicemanager, cpumanager, memorymanager), instead of relying
+// on the position in an anonymous slice of maps.
+type ProviderHints struct {
+	// Provider identifies the hint provider which produced Hints.
+	Provider string
+	// Hints maps each resource name to the hints the provider returned for it.
+	Hints map[string][]TopologyHint
+	// CollectedAt records when Hints were gathered from the provider. The
+	// scopes use it to detect hints which went stale while the rest of the
+	// provider chain was being queried, and refresh them before the merge.
+	CollectedAt time.Time
 }
 
 // Merge a TopologyHints permutation to a single hint by performing a bitwise-AND
@@ -38,6 +57,7 @@ func mergePermutation(defaultAffinity bitmask.BitMask, permutation []TopologyHin
 	// of them encode unpreferred allocations.
 	preferred := true
 	var numaAffinities []bitmask.BitMask
+	var attributes map[string]string
 	for _, hint := range permutation {
 		// Only consider hints that have an actual NUMANodeAffinity set.
 		if hint.NUMANodeAffinity != nil {
@@ -51,39 +71,51 @@ func mergePermutation(defaultAffinity bitmask.BitMask, permutation []TopologyHin
 		if !hint.Preferred {
 			preferred = false
 		}
+		// Carry over the attributes the providers attached, without
+		// interpreting them. On a key clash the first hint in the
+		// permutation wins; providers are expected to prefix their keys.
+		for key, value := range hint.Attributes {
+			if attributes == nil {
+				attributes = make(map[string]string)
+			}
+			if _, ok := attributes[key]; !ok {
+				attributes[key] = value
+			}
+		}
 	}
 
 	// Merge the affinities using a bitwise-and operation.
 	mergedAffinity := bitmask.And(defaultAffinity, numaAffinities...)
 	// Build a mergedHint from the merged affinity mask, setting preferred as
 	// appropriate based on the logic above.
-	return TopologyHint{mergedAffinity, preferred}
+	return TopologyHint{NUMANodeAffinity: mergedAffinity, Preferred: preferred, Attributes: attributes}
 }
 
-func filterProvidersHints(providersHints []map[string][]TopologyHint) [][]TopologyHint {
+func filterProvidersHints(providersHints []ProviderHints) [][]TopologyHint {
 	// Loop through all hint providers and save an accumulated list of the
 	// hints returned by each hint provider. If no hints are provided, assume
 	// that provider has no preference for topology-aware allocation.
 	var allProviderHints [][]TopologyHint
-	for _, hints := range providersHints {
+	for _, providerHints := range providersHints {
+		hints := providerHints.Hints
 		// If hints is nil, insert a single, preferred any-numa hint into allProviderHints.
 		if len(hints) == 0 {
-			klog.InfoS("Hint Provider has no preference for NUMA affinity with any resource")
-			allProviderHints = append(allProviderHints, []TopologyHint{{nil, true}})
+			klog.InfoS("Hint Provider has no preference for NUMA affinity with any resource", "provider", providerHints.Provider)
+			allProviderHints = append(allProviderHints, []TopologyHint{{Preferred: true}})
 			continue
 		}
 
 		// Otherwise, accumulate the hints for each resource type into allProviderHints.
 		for resource := range hints {
 			if hints[resource] == nil {
-				klog.InfoS("Hint Provider has no preference for NUMA affinity with resource", "resource", resource)
-				allProviderHints = append(allProviderHints, []TopologyHint{{nil, true}})
+				klog.InfoS("Hint Provider has no preference for NUMA affinity with resource", "provider", providerHints.Provider, "resource", resource)
+				allProviderHints = append(allProviderHints, []TopologyHint{{Preferred: true}})
 				continue
 			}
 
 			if len(hints[resource]) == 0 {
-				klog.InfoS("Hint Provider has no possible NUMA affinities for resource", "resource", resource)
-				allProviderHints = append(allProviderHints, []TopologyHint{{nil, false}})
+				klog.InfoS("Hint Provider has no possible NUMA affinities for resource", "provider", providerHints.Provider, "resource", resource)
+				allProviderHints = append(allProviderHints, []TopologyHint{{Preferred: false}})
 				continue
 			}
 
@@ -93,6 +125,23 @@ func filterProvidersHints(providersHints []map[string][]TopologyHint) [][]Topolo
 	return allProviderHints
 }
 
+// zeroHintResources returns the names of the resources for which a provider
+// returned an empty, non-nil hint slice, meaning the request cannot be
+// satisfied on any NUMA node. This is distinct from a nil slice, which means
+// the provider has no preference for that resource.
+func zeroHintResources(providersHints []ProviderHints) []string {
+	var resources []string
+	for _, providerHints := range providersHints {
+		for resource, hints := range providerHints.Hints {
+			if hints != nil && len(hints) == 0 {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	sort.Strings(resources)
+	return resources
+}
+
 func narrowestHint(hints []TopologyHint) *TopologyHint {
 	if len(hints) == 0 {
 		return nil
@@ -137,6 +186,10 @@ type HintMerger struct {
 	// NUMA nodes to satisfy its allocation.
 	BestNonPreferredAffinityCount int
 	CompareNUMAAffinityMasks      func(candidate *TopologyHint, current *TopologyHint) (best *TopologyHint)
+	// BalanceNUMADistribution, when set, ranks permutations of equally
+	// preferred hints by how the resources they represent are distributed
+	// across NUMA nodes, instead of only by the merged affinity mask.
+	BalanceNUMADistribution bool
 }
 
 func NewHintMerger(numaInfo *NUMAInfo, hints [][]TopologyHint, policyName string, opts PolicyOptions) HintMerger {
@@ -164,6 +217,7 @@ func NewHintMerger(numaInfo *NUMAInfo, hints [][]TopologyHint, policyName string
 		Hints:                         hints,
 		BestNonPreferredAffinityCount: maxOfMinAffinityCounts(hints),
 		CompareNUMAAffinityMasks:      compareNumaAffinityMasks,
+		BalanceNUMADistribution:       opts.BalanceNUMADistribution,
 	}
 
 	return merger
@@ -292,22 +346,101 @@ func (m HintMerger) compare(current *TopologyHint, candidate *TopologyHint) *Top
 
 }
 
+// distributionScore summarizes how the resources behind a permutation of
+// hints are laid out across NUMA nodes. A permutation is considered better
+// than another if it touches fewer distinct NUMA nodes, or, the number of
+// nodes being equal, if the resources are spread more evenly among them.
+type distributionScore struct {
+	// distinctNodes is the number of distinct NUMA nodes set in any of the
+	// affinity masks of the permutation.
+	distinctNodes int
+	// imbalance is the difference between the number of hints requesting
+	// the most loaded NUMA node and the number requesting the least loaded
+	// one. A permutation where every resource lands on the same set of
+	// nodes has imbalance 0.
+	imbalance int
+}
+
+func (s distributionScore) less(o distributionScore) bool {
+	if s.distinctNodes != o.distinctNodes {
+		return s.distinctNodes < o.distinctNodes
+	}
+	return s.imbalance < o.imbalance
+}
+
+func permutationDistributionScore(permutation []TopologyHint) distributionScore {
+	counts := map[int]int{}
+	for _, hint := range permutation {
+		if hint.NUMANodeAffinity == nil {
+			continue
+		}
+		for _, node := range hint.NUMANodeAffinity.GetBits() {
+			counts[node]++
+		}
+	}
+
+	score := distributionScore{distinctNodes: len(counts)}
+	minCount, maxCount := 0, 0
+	for _, count := range counts {
+		if minCount == 0 || count < minCount {
+			minCount = count
+		}
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	score.imbalance = maxCount - minCount
+	return score
+}
+
+// compareWithDistribution works like compare, but candidates of equal
+// preference are first ranked by their distribution score; the regular
+// comparison only acts as a tie-breaker. Preferred hints still always win
+// over non-preferred ones, whatever their distribution looks like.
+func (m HintMerger) compareWithDistribution(current *TopologyHint, currentScore distributionScore, candidate *TopologyHint, candidateScore distributionScore) *TopologyHint {
+	if candidate.NUMANodeAffinity.Count() == 0 {
+		return current
+	}
+	if current == nil {
+		return candidate
+	}
+	if current.Preferred != candidate.Preferred {
+		return m.compare(current, candidate)
+	}
+	if candidateScore.less(currentScore) {
+		return candidate
+	}
+	if currentScore.less(candidateScore) {
+		return current
+	}
+	return m.compare(current, candidate)
+}
+
 func (m HintMerger) Merge() TopologyHint {
 	defaultAffinity := m.NUMAInfo.DefaultAffinityMask()
 
 	var bestHint *TopologyHint
-	iterateAllProviderTopologyHints(m.Hints, func(permutation []TopologyHint) {
+	var bestScore distributionScore
+	iterateAllProviderTopologyHints(defaultAffinity, m.Hints, func(permutation []TopologyHint) {
 		// Get the NUMANodeAffinity from each hint in the permutation and see if any
 		// of them encode unpreferred allocations.
 		mergedHint := mergePermutation(defaultAffinity, permutation)
 
 		// Compare the current bestHint with the candidate mergedHint and
 		// update bestHint if appropriate.
-		bestHint = m.compare(bestHint, &mergedHint)
+		if !m.BalanceNUMADistribution {
+			bestHint = m.compare(bestHint, &mergedHint)
+			return
+		}
+		score := permutationDistributionScore(permutation)
+		if m.compareWithDistribution(bestHint, bestScore, &mergedHint, score) == &mergedHint {
+			bestHint = &mergedHint
+			bestScore = score
+		}
 	})
 
 	if bestHint == nil {
-		bestHint = &TopologyHint{defaultAffinity, false}
+		bestHint = &TopologyHint{NUMANodeAffinity: defaultAffinity, Preferred: false}
 	}
 
 	return *bestHint
@@ -333,10 +466,17 @@ func (m HintMerger) Merge() TopologyHint {
 //	                providerHints[-1][z]
 //	            }
 //	            callback(permutation)
-func iterateAllProviderTopologyHints(allProviderHints [][]TopologyHint, callback func([]TopologyHint)) {
+//
+// Branches of the permutation tree whose running affinity intersection is
+// already empty are pruned: the bitwise-AND can only shrink further down the
+// tree, and the merge code never picks a hint with an empty affinity, so no
+// permutation below such a branch can contribute a candidate. This keeps the
+// enumeration tractable on machines with many NUMA nodes, where the full
+// cross-product of hints would explode combinatorially.
+func iterateAllProviderTopologyHints(defaultAffinity bitmask.BitMask, allProviderHints [][]TopologyHint, callback func([]TopologyHint)) {
 	// Internal helper function to accumulate the permutation before calling the callback.
-	var iterate func(i int, accum []TopologyHint)
-	iterate = func(i int, accum []TopologyHint) {
+	var iterate func(i int, accum []TopologyHint, mergedAffinity bitmask.BitMask)
+	iterate = func(i int, accum []TopologyHint, mergedAffinity bitmask.BitMask) {
 		// Base case: we have looped through all providers and have a full permutation.
 		if i == len(allProviderHints) {
 			callback(accum)
@@ -346,8 +486,16 @@ func iterateAllProviderTopologyHints(allProviderHints [][]TopologyHint, callback
 		// Loop through all hints for provider 'i', and recurse to build the
 		// permutation of this hint with all hints from providers 'i++'.
 		for j := range allProviderHints[i] {
-			iterate(i+1, append(accum, allProviderHints[i][j]))
+			hint := allProviderHints[i][j]
+			affinity := mergedAffinity
+			if hint.NUMANodeAffinity != nil {
+				affinity = bitmask.And(mergedAffinity, hint.NUMANodeAffinity)
+				if affinity.IsEmpty() {
+					continue
+				}
+			}
+			iterate(i+1, append(accum, hint), affinity)
 		}
 	}
-	iterate(0, []TopologyHint{})
+	iterate(0, []TopologyHint{}, defaultAffinity)
 }
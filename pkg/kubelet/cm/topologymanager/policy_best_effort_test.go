@@ -28,12 +28,12 @@ func TestPolicyBestEffortCanAdmitPodResult(t *testing.T) {
 	}{
 		{
 			name:     "Preferred is set to false in topology hints",
-			hint:     TopologyHint{nil, false},
+			hint:     TopologyHint{Preferred: false},
 			expected: true,
 		},
 		{
 			name:     "Preferred is set to true in topology hints",
-			hint:     TopologyHint{nil, true},
+			hint:     TopologyHint{Preferred: true},
 			expected: true,
 		},
 	}
@@ -73,3 +73,16 @@ func TestPolicyBestEffortMergeClosestNUMA(t *testing.T) {
 
 	testPolicyMerge(policy, tcases, t)
 }
+
+func TestPolicyBestEffortMergeBalanceNUMADistribution(t *testing.T) {
+	numaInfo := commonNUMAInfoFourNodes()
+	opts := PolicyOptions{
+		BalanceNUMADistribution: true,
+	}
+	policy := &bestEffortPolicy{numaInfo: numaInfo, opts: opts}
+
+	tcases := commonPolicyMergeTestCases(numaInfo.Nodes)
+	tcases = append(tcases, policy.mergeTestCasesBalanceNUMADistribution(numaInfo.Nodes)...)
+
+	testPolicyMerge(policy, tcases, t)
+}
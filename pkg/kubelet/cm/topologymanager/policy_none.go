@@ -36,6 +36,6 @@ func (p *nonePolicy) canAdmitPodResult(hint *TopologyHint) bool {
 	return true
 }
 
-func (p *nonePolicy) Merge(providersHints []map[string][]TopologyHint) (TopologyHint, bool) {
+func (p *nonePolicy) Merge(providersHints []ProviderHints) (TopologyHint, bool) {
 	return TopologyHint{}, p.canAdmitPodResult(nil)
 }
@@ -46,12 +46,12 @@ func TestPolicyNoneCanAdmitPodResult(t *testing.T) {
 	}{
 		{
 			name:     "Preferred is set to false in topology hints",
-			hint:     TopologyHint{nil, false},
+			hint:     TopologyHint{Preferred: false},
 			expected: true,
 		},
 		{
 			name:     "Preferred is set to true in topology hints",
-			hint:     TopologyHint{nil, true},
+			hint:     TopologyHint{Preferred: true},
 			expected: true,
 		},
 	}
@@ -69,21 +69,24 @@ func TestPolicyNoneCanAdmitPodResult(t *testing.T) {
 func TestPolicyNoneMerge(t *testing.T) {
 	tcases := []struct {
 		name           string
-		providersHints []map[string][]TopologyHint
+		providersHints []ProviderHints
 		expectedHint   TopologyHint
 		expectedAdmit  bool
 	}{
 		{
 			name:           "merged empty providers hints",
-			providersHints: []map[string][]TopologyHint{},
+			providersHints: []ProviderHints{},
 			expectedHint:   TopologyHint{},
 			expectedAdmit:  true,
 		},
 		{
 			name: "merge with a single provider with a single preferred resource",
-			providersHints: []map[string][]TopologyHint{
+			providersHints: []ProviderHints{
 				{
-					"resource": {{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true}},
+					Provider: "provider",
+					Hints: map[string][]TopologyHint{
+						"resource": {{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true}},
+					},
 				},
 			},
 			expectedHint:  TopologyHint{},
@@ -91,9 +94,12 @@ func TestPolicyNoneMerge(t *testing.T) {
 		},
 		{
 			name: "merge with a single provider with a single non-preferred resource",
-			providersHints: []map[string][]TopologyHint{
+			providersHints: []ProviderHints{
 				{
-					"resource": {{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false}},
+					Provider: "provider",
+					Hints: map[string][]TopologyHint{
+						"resource": {{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false}},
+					},
 				},
 			},
 			expectedHint:  TopologyHint{},
@@ -18,43 +18,55 @@ package topologymanager
 
 import (
 	"fmt"
-	"strconv"
 
 	"k8s.io/apimachinery/pkg/util/sets"
-	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	kubefeatures "k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/cm/policyoptions"
 )
 
 const (
-	PreferClosestNUMANodes string = "prefer-closest-numa-nodes"
+	PreferClosestNUMANodes  string = "prefer-closest-numa-nodes"
+	BalanceNUMADistribution string = "balance-numa-distribution"
+	ShadowPolicy            string = "shadow-policy"
 )
 
 var (
-	alphaOptions = sets.New[string]()
-	betaOptions  = sets.New[string](
+	alphaOptions = sets.New[string](
+		BalanceNUMADistribution,
+		ShadowPolicy,
+	)
+	betaOptions = sets.New[string](
 		PreferClosestNUMANodes,
 	)
 	stableOptions = sets.New[string]()
-)
-
-func CheckPolicyOptionAvailable(option string) error {
-	if !alphaOptions.Has(option) && !betaOptions.Has(option) && !stableOptions.Has(option) {
-		return fmt.Errorf("unknown Topology Manager Policy option: %q", option)
-	}
 
-	if alphaOptions.Has(option) && !utilfeature.DefaultFeatureGate.Enabled(kubefeatures.TopologyManagerPolicyAlphaOptions) {
-		return fmt.Errorf("Topology Manager Policy Alpha-level Options not enabled, but option %q provided", option)
-	}
-
-	if betaOptions.Has(option) && !utilfeature.DefaultFeatureGate.Enabled(kubefeatures.TopologyManagerPolicyBetaOptions) {
-		return fmt.Errorf("Topology Manager Policy Beta-level Options not enabled, but option %q provided", option)
+	// optionsRegistry binds the option tiers to the topology manager feature gates.
+	optionsRegistry = policyoptions.Registry{
+		Component: "Topology Manager",
+		AlphaGate: kubefeatures.TopologyManagerPolicyAlphaOptions,
+		BetaGate:  kubefeatures.TopologyManagerPolicyBetaOptions,
+		Alpha:     alphaOptions,
+		Beta:      betaOptions,
+		Stable:    stableOptions,
 	}
+)
 
-	return nil
+func CheckPolicyOptionAvailable(option string) error {
+	return optionsRegistry.CheckAvailable(option)
 }
 
 type PolicyOptions struct {
 	PreferClosestNUMA bool
+	// BalanceNUMADistribution makes the hint merge pick, among equally
+	// preferred combinations of provider hints, the one which spans the
+	// fewest distinct NUMA nodes and spreads the resources most evenly
+	// across them, instead of just the narrowest merged bitmask.
+	BalanceNUMADistribution bool
+	// ShadowPolicyName names a policy to be evaluated in shadow mode: it
+	// receives the same provider hints as the active policy on every merge,
+	// and its decisions are logged and compared against the active ones
+	// without affecting admission.
+	ShadowPolicyName string
 }
 
 func NewPolicyOptions(policyOptions map[string]string) (PolicyOptions, error) {
@@ -66,11 +78,19 @@ func NewPolicyOptions(policyOptions map[string]string) (PolicyOptions, error) {
 
 		switch name {
 		case PreferClosestNUMANodes:
-			optValue, err := strconv.ParseBool(value)
+			optValue, err := policyoptions.Bool(name, value)
 			if err != nil {
-				return opts, fmt.Errorf("bad value for option %q: %w", name, err)
+				return opts, err
 			}
 			opts.PreferClosestNUMA = optValue
+		case BalanceNUMADistribution:
+			optValue, err := policyoptions.Bool(name, value)
+			if err != nil {
+				return opts, err
+			}
+			opts.BalanceNUMADistribution = optValue
+		case ShadowPolicy:
+			opts.ShadowPolicyName = value
 		default:
 			// this should never be reached, we already detect unknown options,
 			// but we keep it as further safety.
@@ -21,7 +21,6 @@ import (
 	"strings"
 	"testing"
 
-	"k8s.io/apimachinery/pkg/util/sets"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/component-base/featuregate"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
@@ -69,6 +68,44 @@ func TestNewTopologyManagerOptions(t *testing.T) {
 		{
 			description: "return empty TopologyManagerOptions",
 		},
+		{
+			description:       "return TopologyManagerOptions with BalanceNUMADistribution set to true",
+			featureGate:       pkgfeatures.TopologyManagerPolicyAlphaOptions,
+			featureGateEnable: true,
+			expectedOptions: PolicyOptions{
+				BalanceNUMADistribution: true,
+			},
+			policyOptions: map[string]string{
+				BalanceNUMADistribution: "true",
+			},
+		},
+		{
+			description: "fail to set BalanceNUMADistribution when TopologyManagerPolicyAlphaOptions feature gate is not set",
+			featureGate: pkgfeatures.TopologyManagerPolicyAlphaOptions,
+			policyOptions: map[string]string{
+				BalanceNUMADistribution: "true",
+			},
+			expectedErr: fmt.Errorf("Topology Manager Policy Alpha-level Options not enabled,"),
+		},
+		{
+			description:       "return TopologyManagerOptions with ShadowPolicyName set",
+			featureGate:       pkgfeatures.TopologyManagerPolicyAlphaOptions,
+			featureGateEnable: true,
+			expectedOptions: PolicyOptions{
+				ShadowPolicyName: PolicySingleNumaNode,
+			},
+			policyOptions: map[string]string{
+				ShadowPolicy: PolicySingleNumaNode,
+			},
+		},
+		{
+			description: "fail to set ShadowPolicy when TopologyManagerPolicyAlphaOptions feature gate is not set",
+			featureGate: pkgfeatures.TopologyManagerPolicyAlphaOptions,
+			policyOptions: map[string]string{
+				ShadowPolicy: PolicySingleNumaNode,
+			},
+			expectedErr: fmt.Errorf("Topology Manager Policy Alpha-level Options not enabled,"),
+		},
 		{
 			description:       "fail to parse options",
 			featureGate:       pkgfeatures.TopologyManagerPolicyAlphaOptions,
@@ -112,7 +149,7 @@ func TestNewTopologyManagerOptions(t *testing.T) {
 	}
 
 	betaOptions.Insert(fancyBetaOption)
-	alphaOptions = sets.New[string](fancyAlphaOption)
+	alphaOptions.Insert(fancyAlphaOption)
 
 	for _, tcase := range testCases {
 		t.Run(tcase.description, func(t *testing.T) {
@@ -144,6 +181,10 @@ func TestPolicyDefaultsAvailable(t *testing.T) {
 			option:            PreferClosestNUMANodes,
 			expectedAvailable: true,
 		},
+		{
+			option:            BalanceNUMADistribution,
+			expectedAvailable: false,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.option, func(t *testing.T) {
@@ -182,6 +223,18 @@ func TestPolicyOptionsAvailable(t *testing.T) {
 			featureGateEnable: false,
 			expectedAvailable: true,
 		},
+		{
+			option:            BalanceNUMADistribution,
+			featureGate:       pkgfeatures.TopologyManagerPolicyAlphaOptions,
+			featureGateEnable: true,
+			expectedAvailable: true,
+		},
+		{
+			option:            BalanceNUMADistribution,
+			featureGate:       pkgfeatures.TopologyManagerPolicyAlphaOptions,
+			featureGateEnable: false,
+			expectedAvailable: false,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.option, func(t *testing.T) {
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
+)
+
+type shadowPolicy struct {
+	// active is the policy whose decisions drive admission.
+	active Policy
+	// shadow is the policy evaluated alongside the active one. Its decisions
+	// are logged and compared with the active ones, but never influence
+	// admission.
+	shadow Policy
+}
+
+var _ Policy = &shadowPolicy{}
+
+// NewShadowPolicy returns a policy which merges hints with the given active
+// policy, and additionally evaluates the given shadow policy against the same
+// provider hints. Divergences between the two decisions are logged and counted
+// in a metric, so a candidate policy can be evaluated safely on production
+// workloads before it is made active.
+func NewShadowPolicy(active Policy, shadow Policy) Policy {
+	return &shadowPolicy{
+		active: active,
+		shadow: shadow,
+	}
+}
+
+func (p *shadowPolicy) Name() string {
+	return p.active.Name()
+}
+
+func (p *shadowPolicy) Merge(providersHints []ProviderHints) (TopologyHint, bool) {
+	bestHint, admit := p.active.Merge(providersHints)
+
+	shadowHint, shadowAdmit := p.shadow.Merge(providersHints)
+	klog.V(4).InfoS("Shadow policy merge", "shadowPolicy", p.shadow.Name(), "shadowBestHint", shadowHint, "shadowAdmit", shadowAdmit)
+	if shadowAdmit != admit || !shadowHint.IsEqual(bestHint) {
+		metrics.TopologyManagerShadowPolicyDivergencesTotal.Inc()
+		klog.InfoS("Shadow policy diverged from the active policy", "policy", p.active.Name(), "bestHint", bestHint, "admit", admit, "shadowPolicy", p.shadow.Name(), "shadowBestHint", shadowHint, "shadowAdmit", shadowAdmit)
+	}
+
+	return bestHint, admit
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"testing"
+)
+
+func TestShadowPolicyName(t *testing.T) {
+	numaInfo := commonNUMAInfoTwoNodes()
+	policy := NewShadowPolicy(&bestEffortPolicy{numaInfo: numaInfo}, &singleNumaNodePolicy{numaInfo: numaInfo})
+	if policy.Name() != PolicyBestEffort {
+		t.Errorf("Expected Policy Name to be %q, got %q", PolicyBestEffort, policy.Name())
+	}
+}
+
+func TestShadowPolicyMerge(t *testing.T) {
+	tcases := []struct {
+		name           string
+		providersHints []ProviderHints
+		expected       TopologyHint
+		expectedAdmit  bool
+	}{
+		{
+			name: "single NUMA node hint, active and shadow policies agree",
+			providersHints: []ProviderHints{
+				{
+					Provider: "resource",
+					Hints: map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+						},
+					},
+				},
+			},
+			expected:      TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			expectedAdmit: true,
+		},
+		{
+			name: "cross NUMA node hint, the shadow policy divergence must not affect admission",
+			providersHints: []ProviderHints{
+				{
+					Provider: "resource",
+					Hints: map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+						},
+					},
+				},
+			},
+			expected:      TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+			expectedAdmit: true,
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			numaInfo := commonNUMAInfoTwoNodes()
+			policy := NewShadowPolicy(&bestEffortPolicy{numaInfo: numaInfo}, &singleNumaNodePolicy{numaInfo: numaInfo})
+
+			actual, admit := policy.Merge(tc.providersHints)
+			if !actual.IsEqual(tc.expected) {
+				t.Errorf("Expected result to be %v, got %v", tc.expected, actual)
+			}
+			if admit != tc.expectedAdmit {
+				t.Errorf("Expected admit to be %t, got %t", tc.expectedAdmit, admit)
+			}
+		})
+	}
+}
@@ -58,7 +58,7 @@ func filterSingleNumaHints(allResourcesHints [][]TopologyHint) [][]TopologyHint
 	return filteredResourcesHints
 }
 
-func (p *singleNumaNodePolicy) Merge(providersHints []map[string][]TopologyHint) (TopologyHint, bool) {
+func (p *singleNumaNodePolicy) Merge(providersHints []ProviderHints) (TopologyHint, bool) {
 	filteredHints := filterProvidersHints(providersHints)
 	// Filter to only include don't cares and hints with a single NUMA node.
 	singleNumaHints := filterSingleNumaHints(filteredHints)
@@ -67,7 +67,7 @@ func (p *singleNumaNodePolicy) Merge(providersHints []map[string][]TopologyHint)
 	bestHint := merger.Merge()
 
 	if bestHint.NUMANodeAffinity.IsEqual(p.numaInfo.DefaultAffinityMask()) {
-		bestHint = TopologyHint{nil, bestHint.Preferred}
+		bestHint = TopologyHint{Preferred: bestHint.Preferred, Attributes: bestHint.Attributes}
 	}
 
 	admit := p.canAdmitPodResult(&bestHint)
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
+)
+
+type singleNumaPreferredPolicy struct {
+	// numaInfo represents list of NUMA Nodes available on the underlying machine and distances between them
+	numaInfo *NUMAInfo
+	opts     PolicyOptions
+}
+
+var _ Policy = &singleNumaPreferredPolicy{}
+
+// PolicySingleNumaPreferred policy name.
+const PolicySingleNumaPreferred string = "single-numa-preferred"
+
+// maxSpillNUMANodes is the largest number of NUMA nodes an allocation is
+// allowed to spill over under the single-numa-preferred policy when no single
+// NUMA node can satisfy it.
+const maxSpillNUMANodes = 2
+
+// NewSingleNumaPreferredPolicy returns single-numa-preferred policy, which
+// behaves like single-numa-node as long as the requested resources fit on a
+// single NUMA node but, when they cannot, admits the pod with the smallest
+// possible spill - at most maxSpillNUMANodes nodes, the closest ones when the
+// prefer-closest-numa policy option is enabled - instead of rejecting it.
+func NewSingleNumaPreferredPolicy(numaInfo *NUMAInfo, opts PolicyOptions) Policy {
+	return &singleNumaPreferredPolicy{numaInfo: numaInfo, opts: opts}
+}
+
+func (p *singleNumaPreferredPolicy) Name() string {
+	return PolicySingleNumaPreferred
+}
+
+func (p *singleNumaPreferredPolicy) canAdmitPodResult(hint *TopologyHint) bool {
+	// Preferred hints carry the strict single NUMA node alignments and the
+	// don't care results; the non-preferred hints which survived the spill
+	// filter are admitted too, that being the whole point of this policy.
+	return hint.Preferred || hint.NUMANodeAffinity != nil
+}
+
+// Return hints that have valid bitmasks with at most maxNUMANodes bits set,
+// plus the don't care hints.
+func filterSpillableHints(allResourcesHints [][]TopologyHint, maxNUMANodes int) [][]TopologyHint {
+	var filteredResourcesHints [][]TopologyHint
+	for _, oneResourceHints := range allResourcesHints {
+		var filtered []TopologyHint
+		for _, hint := range oneResourceHints {
+			if hint.NUMANodeAffinity == nil && hint.Preferred {
+				filtered = append(filtered, hint)
+			}
+			if hint.NUMANodeAffinity != nil && hint.NUMANodeAffinity.Count() <= maxNUMANodes {
+				filtered = append(filtered, hint)
+			}
+		}
+		filteredResourcesHints = append(filteredResourcesHints, filtered)
+	}
+	return filteredResourcesHints
+}
+
+func (p *singleNumaPreferredPolicy) Merge(providersHints []ProviderHints) (TopologyHint, bool) {
+	filteredHints := filterProvidersHints(providersHints)
+	// Keep the don't cares and the hints spilling over no more NUMA nodes
+	// than the policy tolerates. Resources whose narrowest hint is wider
+	// than that end up with no candidate hints and fail the merge, exactly
+	// like unalignable resources do under single-numa-node.
+	spillableHints := filterSpillableHints(filteredHints, maxSpillNUMANodes)
+
+	merger := NewHintMerger(p.numaInfo, spillableHints, p.Name(), p.opts)
+	bestHint := merger.Merge()
+
+	// Only normalize the preferred full-machine result, which means all the
+	// providers returned don't care hints. A non-preferred full-machine mask
+	// is either a spill over the whole machine (on machines with up to
+	// maxSpillNUMANodes NUMA nodes) or a failed merge, and must keep its
+	// affinity so canAdmitPodResult can tell the two apart by width.
+	if bestHint.Preferred && bestHint.NUMANodeAffinity.IsEqual(p.numaInfo.DefaultAffinityMask()) {
+		bestHint = TopologyHint{Preferred: bestHint.Preferred, Attributes: bestHint.Attributes}
+	}
+	if !bestHint.Preferred && bestHint.NUMANodeAffinity != nil && bestHint.NUMANodeAffinity.Count() > maxSpillNUMANodes {
+		bestHint = TopologyHint{Preferred: false, Attributes: bestHint.Attributes}
+	}
+
+	admit := p.canAdmitPodResult(&bestHint)
+	if admit {
+		if bestHint.NUMANodeAffinity == nil || bestHint.NUMANodeAffinity.Count() == 1 {
+			metrics.TopologyManagerSingleNUMAPreferredStrictTotal.Inc()
+		} else {
+			metrics.TopologyManagerSingleNUMAPreferredSpillsTotal.Inc()
+		}
+	}
+	return bestHint, admit
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPolicySingleNumaPreferredName(t *testing.T) {
+	numaInfo := commonNUMAInfoTwoNodes()
+	policy := &singleNumaPreferredPolicy{numaInfo: numaInfo, opts: PolicyOptions{}}
+	if policy.Name() != PolicySingleNumaPreferred {
+		t.Errorf("Expected Policy Name to be %s, got %s", PolicySingleNumaPreferred, policy.Name())
+	}
+}
+
+func TestPolicySingleNumaPreferredCanAdmitPodResult(t *testing.T) {
+	tcases := []struct {
+		name     string
+		hint     TopologyHint
+		expected bool
+	}{
+		{
+			name:     "Preferred is set to false, no affinity",
+			hint:     TopologyHint{Preferred: false},
+			expected: false,
+		},
+		{
+			name:     "Preferred is set to true, no affinity",
+			hint:     TopologyHint{Preferred: true},
+			expected: true,
+		},
+		{
+			name:     "Preferred is set to false, spill affinity",
+			hint:     TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			expected: true,
+		},
+	}
+	numaInfo := commonNUMAInfoTwoNodes()
+
+	for _, tc := range tcases {
+		policy := singleNumaPreferredPolicy{numaInfo: numaInfo, opts: PolicyOptions{}}
+		result := policy.canAdmitPodResult(&tc.hint)
+
+		if result != tc.expected {
+			t.Errorf("%v: Expected result to be %t, got %t", tc.name, tc.expected, result)
+		}
+	}
+}
+
+func TestPolicySingleNumaPreferredFilterSpillableHints(t *testing.T) {
+	tcases := []struct {
+		name              string
+		allResources      [][]TopologyHint
+		expectedResources [][]TopologyHint
+	}{
+		{
+			name:              "filter empty resources",
+			allResources:      [][]TopologyHint{},
+			expectedResources: [][]TopologyHint(nil),
+		},
+		{
+			name: "keep don't care hints, drop non-preferred nil hints",
+			allResources: [][]TopologyHint{
+				{
+					{NUMANodeAffinity: nil, Preferred: false},
+				},
+				{
+					{NUMANodeAffinity: nil, Preferred: true},
+				},
+			},
+			expectedResources: [][]TopologyHint{
+				[]TopologyHint(nil),
+				{
+					{NUMANodeAffinity: nil, Preferred: true},
+				},
+			},
+		},
+		{
+			name: "keep hints up to the spill bound, preferred or not",
+			allResources: [][]TopologyHint{
+				{
+					{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+					{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
+				},
+			},
+			expectedResources: [][]TopologyHint{
+				{
+					{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+				},
+			},
+		},
+		{
+			name: "drop all hints wider than the spill bound",
+			allResources: [][]TopologyHint{
+				{
+					{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
+					{NUMANodeAffinity: NewTestBitMask(0, 1, 2, 3), Preferred: false},
+				},
+			},
+			expectedResources: [][]TopologyHint{
+				[]TopologyHint(nil),
+			},
+		},
+	}
+
+	for _, tc := range tcases {
+		actual := filterSpillableHints(tc.allResources, maxSpillNUMANodes)
+		if !reflect.DeepEqual(tc.expectedResources, actual) {
+			t.Errorf("%v: Test Case: %v", tc.name, tc)
+			t.Errorf("%v: Expected result: %v, got %v", tc.name, tc.expectedResources, actual)
+		}
+	}
+}
+
+func TestPolicySingleNumaPreferredMerge(t *testing.T) {
+	numaInfo := commonNUMAInfoFourNodes()
+	policy := &singleNumaPreferredPolicy{numaInfo: numaInfo, opts: PolicyOptions{}}
+
+	tcases := []struct {
+		name          string
+		hp            []HintProvider
+		expectedHint  TopologyHint
+		expectedAdmit bool
+	}{
+		{
+			name:          "No providers",
+			hp:            []HintProvider{},
+			expectedHint:  TopologyHint{Preferred: true},
+			expectedAdmit: true,
+		},
+		{
+			name: "Single provider, preferred single NUMA node hint",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+						},
+					},
+				},
+			},
+			expectedHint:  TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			expectedAdmit: true,
+		},
+		{
+			name: "Single provider, only a two node spill available",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+						},
+					},
+				},
+			},
+			expectedHint:  TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			expectedAdmit: true,
+		},
+		{
+			name: "Single provider, preferred hint beats the spill",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+							{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+						},
+					},
+				},
+			},
+			expectedHint:  TopologyHint{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+			expectedAdmit: true,
+		},
+		{
+			name: "Two providers, single NUMA node impossible, spill on the common pair",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource1": {
+							{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+							{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+						},
+					},
+				},
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource2": {
+							{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+						},
+					},
+				},
+			},
+			expectedHint:  TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			expectedAdmit: true,
+		},
+		{
+			name: "Single provider, narrowest hint wider than the spill bound",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
+						},
+					},
+				},
+			},
+			expectedHint:  TopologyHint{Preferred: false},
+			expectedAdmit: false,
+		},
+		{
+			name: "Two providers, conflicting single NUMA node hints",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource1": {
+							{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+						},
+					},
+				},
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource2": {
+							{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+						},
+					},
+				},
+			},
+			expectedHint:  TopologyHint{Preferred: false},
+			expectedAdmit: false,
+		},
+	}
+
+	for _, tc := range tcases {
+		var providersHints []ProviderHints
+		for _, provider := range tc.hp {
+			hints := provider.GetTopologyHints(&v1.Pod{}, &v1.Container{})
+			providersHints = append(providersHints, ProviderHints{Provider: hintProviderName(provider), Hints: hints})
+		}
+
+		actual, admit := policy.Merge(providersHints)
+		if !reflect.DeepEqual(actual, tc.expectedHint) {
+			t.Errorf("%v: Expected Topology Hint to be %v, got %v", tc.name, tc.expectedHint, actual)
+		}
+		if admit != tc.expectedAdmit {
+			t.Errorf("%v: Expected admit to be %t, got %t", tc.name, tc.expectedAdmit, admit)
+		}
+	}
+}
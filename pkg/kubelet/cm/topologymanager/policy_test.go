@@ -1274,10 +1274,10 @@ func (p *singleNumaNodePolicy) mergeTestCases(numaNodes []int) []policyMergeTest
 
 func testPolicyMerge(policy Policy, tcases []policyMergeTestCase, t *testing.T) {
 	for _, tc := range tcases {
-		var providersHints []map[string][]TopologyHint
+		var providersHints []ProviderHints
 		for _, provider := range tc.hp {
 			hints := provider.GetTopologyHints(&v1.Pod{}, &v1.Container{})
-			providersHints = append(providersHints, hints)
+			providersHints = append(providersHints, ProviderHints{Provider: hintProviderName(provider), Hints: hints})
 		}
 
 		actual, _ := policy.Merge(providersHints)
@@ -1287,6 +1287,54 @@ func testPolicyMerge(policy Policy, tcases []policyMergeTestCase, t *testing.T)
 	}
 }
 
+func TestMergePermutationAttributes(t *testing.T) {
+	tcases := []struct {
+		name        string
+		permutation []TopologyHint
+		expected    map[string]string
+	}{
+		{
+			name: "no attributes merge to no attributes",
+			permutation: []TopologyHint{
+				{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+				{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			},
+			expected: nil,
+		},
+		{
+			name: "attributes from different providers are unioned",
+			permutation: []TopologyHint{
+				{NUMANodeAffinity: NewTestBitMask(0), Preferred: true, Attributes: map[string]string{"vendor.example.com/model": "fancy"}},
+				{NUMANodeAffinity: NewTestBitMask(0), Preferred: true, Attributes: map[string]string{"vendor.example.com/link": "xlink"}},
+			},
+			expected: map[string]string{
+				"vendor.example.com/model": "fancy",
+				"vendor.example.com/link":  "xlink",
+			},
+		},
+		{
+			name: "on a key clash the first hint wins",
+			permutation: []TopologyHint{
+				{NUMANodeAffinity: NewTestBitMask(0), Preferred: true, Attributes: map[string]string{"vendor.example.com/model": "fancy"}},
+				{NUMANodeAffinity: NewTestBitMask(0), Preferred: true, Attributes: map[string]string{"vendor.example.com/model": "plain"}},
+			},
+			expected: map[string]string{
+				"vendor.example.com/model": "fancy",
+			},
+		},
+	}
+
+	defaultAffinity := NewTestBitMask(0, 1)
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged := mergePermutation(defaultAffinity, tc.permutation)
+			if !reflect.DeepEqual(merged.Attributes, tc.expected) {
+				t.Errorf("Expected merged attributes to be %v, got %v", tc.expected, merged.Attributes)
+			}
+		})
+	}
+}
+
 func TestMaxOfMinAffinityCounts(t *testing.T) {
 	tcases := []struct {
 		hints    [][]TopologyHint
@@ -1299,7 +1347,7 @@ func TestMaxOfMinAffinityCounts(t *testing.T) {
 		{
 			[][]TopologyHint{
 				{
-					TopologyHint{NewTestBitMask(), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(), Preferred: true},
 				},
 			},
 			0,
@@ -1307,7 +1355,7 @@ func TestMaxOfMinAffinityCounts(t *testing.T) {
 		{
 			[][]TopologyHint{
 				{
-					TopologyHint{NewTestBitMask(0), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
 				},
 			},
 			1,
@@ -1315,7 +1363,7 @@ func TestMaxOfMinAffinityCounts(t *testing.T) {
 		{
 			[][]TopologyHint{
 				{
-					TopologyHint{NewTestBitMask(0, 1), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
 				},
 			},
 			2,
@@ -1323,8 +1371,8 @@ func TestMaxOfMinAffinityCounts(t *testing.T) {
 		{
 			[][]TopologyHint{
 				{
-					TopologyHint{NewTestBitMask(0, 1), true},
-					TopologyHint{NewTestBitMask(0, 1, 2), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: true},
 				},
 			},
 			2,
@@ -1332,11 +1380,11 @@ func TestMaxOfMinAffinityCounts(t *testing.T) {
 		{
 			[][]TopologyHint{
 				{
-					TopologyHint{NewTestBitMask(0, 1), true},
-					TopologyHint{NewTestBitMask(0, 1, 2), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: true},
 				},
 				{
-					TopologyHint{NewTestBitMask(0, 1, 2), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: true},
 				},
 			},
 			3,
@@ -1344,12 +1392,12 @@ func TestMaxOfMinAffinityCounts(t *testing.T) {
 		{
 			[][]TopologyHint{
 				{
-					TopologyHint{NewTestBitMask(0, 1), true},
-					TopologyHint{NewTestBitMask(0, 1, 2), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: true},
 				},
 				{
-					TopologyHint{NewTestBitMask(0, 1, 2), true},
-					TopologyHint{NewTestBitMask(0, 1, 2, 3), true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: true},
+					TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2, 3), Preferred: true},
 				},
 			},
 			3,
@@ -1378,175 +1426,175 @@ func TestCompareHintsNarrowest(t *testing.T) {
 			"candidate.NUMANodeAffinity.Count() == 0 (1)",
 			-1,
 			nil,
-			&TopologyHint{bitmask.NewEmptyBitMask(), false},
+			&TopologyHint{NUMANodeAffinity: bitmask.NewEmptyBitMask(), Preferred: false},
 			"current",
 		},
 		{
 			"candidate.NUMANodeAffinity.Count() == 0 (2)",
 			-1,
-			&TopologyHint{NewTestBitMask(), true},
-			&TopologyHint{NewTestBitMask(), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(), Preferred: true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(), Preferred: false},
 			"current",
 		},
 		{
 			"current == nil (1)",
 			-1,
 			nil,
-			&TopologyHint{NewTestBitMask(0), true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
 			"candidate",
 		},
 		{
 			"current == nil (2)",
 			-1,
 			nil,
-			&TopologyHint{NewTestBitMask(0), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
 			"candidate",
 		},
 		{
 			"!current.Preferred && candidate.Preferred",
 			-1,
-			&TopologyHint{NewTestBitMask(0), false},
-			&TopologyHint{NewTestBitMask(0), true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
 			"candidate",
 		},
 		{
 			"current.Preferred && !candidate.Preferred",
 			-1,
-			&TopologyHint{NewTestBitMask(0), true},
-			&TopologyHint{NewTestBitMask(0), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
 			"current",
 		},
 		{
 			"current.Preferred && candidate.Preferred (1)",
 			-1,
-			&TopologyHint{NewTestBitMask(0), true},
-			&TopologyHint{NewTestBitMask(0), true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
 			"current",
 		},
 		{
 			"current.Preferred && candidate.Preferred (2)",
 			-1,
-			&TopologyHint{NewTestBitMask(0, 1), true},
-			&TopologyHint{NewTestBitMask(0), true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
 			"candidate",
 		},
 		{
 			"current.Preferred && candidate.Preferred (3)",
 			-1,
-			&TopologyHint{NewTestBitMask(0), true},
-			&TopologyHint{NewTestBitMask(0, 1), true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (1.1)",
 			1,
-			&TopologyHint{NewTestBitMask(0, 1), false},
-			&TopologyHint{NewTestBitMask(0, 1), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (1.2)",
 			1,
-			&TopologyHint{NewTestBitMask(1, 2), false},
-			&TopologyHint{NewTestBitMask(0, 1), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
 			"candidate",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (1.3)",
 			1,
-			&TopologyHint{NewTestBitMask(0, 1), false},
-			&TopologyHint{NewTestBitMask(1, 2), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (2.1)",
 			2,
-			&TopologyHint{NewTestBitMask(0, 1), false},
-			&TopologyHint{NewTestBitMask(0), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (2.2)",
 			2,
-			&TopologyHint{NewTestBitMask(0, 1), false},
-			&TopologyHint{NewTestBitMask(0, 1), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (2.3)",
 			2,
-			&TopologyHint{NewTestBitMask(1, 2), false},
-			&TopologyHint{NewTestBitMask(0, 1), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
 			"candidate",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (2.4)",
 			2,
-			&TopologyHint{NewTestBitMask(0, 1), false},
-			&TopologyHint{NewTestBitMask(1, 2), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3a)",
 			2,
-			&TopologyHint{NewTestBitMask(0), false},
-			&TopologyHint{NewTestBitMask(0, 1, 2), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3b)",
 			2,
-			&TopologyHint{NewTestBitMask(0), false},
-			&TopologyHint{NewTestBitMask(0, 1), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
 			"candidate",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3ca.1)",
 			3,
-			&TopologyHint{NewTestBitMask(0), false},
-			&TopologyHint{NewTestBitMask(0, 1), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
 			"candidate",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3ca.2)",
 			3,
-			&TopologyHint{NewTestBitMask(0), false},
-			&TopologyHint{NewTestBitMask(1, 2), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2), Preferred: false},
 			"candidate",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3ca.3)",
 			4,
-			&TopologyHint{NewTestBitMask(0, 1), false},
-			&TopologyHint{NewTestBitMask(1, 2, 3), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2, 3), Preferred: false},
 			"candidate",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3cb)",
 			4,
-			&TopologyHint{NewTestBitMask(1, 2, 3), false},
-			&TopologyHint{NewTestBitMask(0, 1), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2, 3), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3cc.1)",
 			4,
-			&TopologyHint{NewTestBitMask(0, 1, 2), false},
-			&TopologyHint{NewTestBitMask(0, 1, 2), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3cc.2)",
 			4,
-			&TopologyHint{NewTestBitMask(0, 1, 2), false},
-			&TopologyHint{NewTestBitMask(1, 2, 3), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2, 3), Preferred: false},
 			"current",
 		},
 		{
 			"!current.Preferred && !candidate.Preferred (3cc.3)",
 			4,
-			&TopologyHint{NewTestBitMask(1, 2, 3), false},
-			&TopologyHint{NewTestBitMask(0, 1, 2), false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(1, 2, 3), Preferred: false},
+			&TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1, 2), Preferred: false},
 			"candidate",
 		},
 	}
@@ -1608,3 +1656,287 @@ func commonNUMAInfoEightNodes() *NUMAInfo {
 		},
 	}
 }
+
+func (p *bestEffortPolicy) mergeTestCasesBalanceNUMADistribution(numaNodes []int) []policyMergeTestCase {
+	return []policyMergeTestCase{
+		{
+			name: "Two providers, second with multiple hints, cluster on fewest distinct NUMA nodes",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource1": {
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 1),
+								Preferred:        true,
+							},
+						},
+					},
+				},
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource2": {
+							{
+								NUMANodeAffinity: NewTestBitMask(0),
+								Preferred:        true,
+							},
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 1, 2),
+								Preferred:        true,
+							},
+						},
+					},
+				},
+			},
+			expected: TopologyHint{
+				NUMANodeAffinity: NewTestBitMask(0),
+				Preferred:        false,
+			},
+		},
+		{
+			name: "Two providers, preferred combination wins over better distributed non-preferred one",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource1": {
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 1),
+								Preferred:        true,
+							},
+						},
+					},
+				},
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource2": {
+							{
+								NUMANodeAffinity: NewTestBitMask(1, 2),
+								Preferred:        true,
+							},
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 1),
+								Preferred:        true,
+							},
+						},
+					},
+				},
+			},
+			expected: TopologyHint{
+				NUMANodeAffinity: NewTestBitMask(0, 1),
+				Preferred:        true,
+			},
+		},
+		{
+			name: "Two providers, equally distributed combinations fall back to the narrowest mask",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource1": {
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 1),
+								Preferred:        true,
+							},
+						},
+					},
+				},
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource2": {
+							{
+								NUMANodeAffinity: NewTestBitMask(0),
+								Preferred:        true,
+							},
+							{
+								NUMANodeAffinity: NewTestBitMask(1),
+								Preferred:        true,
+							},
+						},
+					},
+				},
+			},
+			expected: TopologyHint{
+				NUMANodeAffinity: NewTestBitMask(0),
+				Preferred:        false,
+			},
+		},
+		{
+			name: "Two providers, fewer distinct NUMA nodes wins over narrower merged mask",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource1": {
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 1),
+								Preferred:        false,
+							},
+						},
+					},
+				},
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource2": {
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 2),
+								Preferred:        true,
+							},
+							{
+								NUMANodeAffinity: NewTestBitMask(0, 1),
+								Preferred:        true,
+							},
+						},
+					},
+				},
+			},
+			expected: TopologyHint{
+				NUMANodeAffinity: NewTestBitMask(0, 1),
+				Preferred:        false,
+			},
+		},
+	}
+}
+
+func TestPermutationDistributionScore(t *testing.T) {
+	tcases := []struct {
+		name        string
+		permutation []TopologyHint
+		expected    distributionScore
+	}{
+		{
+			name:        "empty permutation",
+			permutation: []TopologyHint{},
+			expected:    distributionScore{},
+		},
+		{
+			name: "nil affinities carry no NUMA placement",
+			permutation: []TopologyHint{
+				{Preferred: true},
+				{Preferred: true},
+			},
+			expected: distributionScore{},
+		},
+		{
+			name: "single hint, balanced by construction",
+			permutation: []TopologyHint{
+				{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+			},
+			expected: distributionScore{distinctNodes: 2, imbalance: 0},
+		},
+		{
+			name: "overlapping hints on the same nodes",
+			permutation: []TopologyHint{
+				{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+				{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+			},
+			expected: distributionScore{distinctNodes: 2, imbalance: 0},
+		},
+		{
+			name: "one node more loaded than the others",
+			permutation: []TopologyHint{
+				{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+				{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			},
+			expected: distributionScore{distinctNodes: 2, imbalance: 1},
+		},
+		{
+			name: "hints spread across disjoint nodes",
+			permutation: []TopologyHint{
+				{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+				{NUMANodeAffinity: NewTestBitMask(2), Preferred: true},
+				{Preferred: true},
+			},
+			expected: distributionScore{distinctNodes: 3, imbalance: 0},
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := permutationDistributionScore(tc.permutation)
+			if actual != tc.expected {
+				t.Errorf("Expected distribution score to be %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func BenchmarkHintMergerMerge(b *testing.B) {
+	numaInfo := commonNUMAInfoEightNodes()
+
+	// Build a deliberately dense hint set: each provider offers every single
+	// node plus every pair of adjacent nodes, so the cross-product merge has
+	// to walk a large number of permutations.
+	var resourceHints []TopologyHint
+	for _, node := range numaInfo.Nodes {
+		resourceHints = append(resourceHints, TopologyHint{NUMANodeAffinity: NewTestBitMask(node), Preferred: true})
+	}
+	for i := 0; i < len(numaInfo.Nodes)-1; i++ {
+		resourceHints = append(resourceHints, TopologyHint{NUMANodeAffinity: NewTestBitMask(numaInfo.Nodes[i], numaInfo.Nodes[i+1]), Preferred: false})
+	}
+	hints := [][]TopologyHint{resourceHints, resourceHints, resourceHints}
+
+	for _, balance := range []bool{false, true} {
+		name := "narrowest"
+		if balance {
+			name = BalanceNUMADistribution
+		}
+		b.Run(name, func(b *testing.B) {
+			merger := NewHintMerger(numaInfo, hints, PolicyBestEffort, PolicyOptions{BalanceNUMADistribution: balance})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				merger.Merge()
+			}
+		})
+	}
+}
+
+func TestZeroHintResources(t *testing.T) {
+	tcases := []struct {
+		name           string
+		providersHints []ProviderHints
+		expected       []string
+	}{
+		{
+			name:           "no providers",
+			providersHints: nil,
+			expected:       nil,
+		},
+		{
+			name: "nil hints mean no preference, not unsatisfiable",
+			providersHints: []ProviderHints{
+				{
+					Provider: "provider-1",
+					Hints: map[string][]TopologyHint{
+						"resource-1": nil,
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "empty non-nil hints mean unsatisfiable",
+			providersHints: []ProviderHints{
+				{
+					Provider: "provider-1",
+					Hints: map[string][]TopologyHint{
+						"resource-1": {},
+						"resource-2": {
+							{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+						},
+					},
+				},
+				{
+					Provider: "provider-2",
+					Hints: map[string][]TopologyHint{
+						"resource-3": {},
+					},
+				},
+			},
+			expected: []string{"resource-1", "resource-3"},
+		},
+	}
+
+	for _, tc := range tcases {
+		actual := zeroHintResources(tc.providersHints)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("Test Case: %s: Expected zero hint resources to be %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
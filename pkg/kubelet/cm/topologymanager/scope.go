@@ -17,13 +17,18 @@ limitations under the License.
 package topologymanager
 
 import (
+	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
+	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
 	"k8s.io/kubernetes/pkg/kubelet/cm/admission"
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/lifecycle"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
 )
 
 const (
@@ -31,10 +36,21 @@ const (
 	containerTopologyScope = "container"
 	// podTopologyScope specifies the TopologyManagerScope per pod.
 	podTopologyScope = "pod"
+	// dieTopologyScope specifies the TopologyManagerScope per container,
+	// additionally keeping the alignment within a single CPU die.
+	dieTopologyScope = "die"
 	// noneTopologyScope specifies the TopologyManagerScope when topologyPolicyName is none.
 	noneTopologyScope = "none"
 )
 
+// providersHintsTTL is how long the hints collected from a provider stay
+// fresh. The providers are queried one at a time, so with a long admission
+// queue or a slow provider down the chain, the availability snapshot the
+// early providers reported may no longer match reality by the time the hints
+// are merged. Hints older than this are recomputed right before the merge.
+// It is a variable only to be tunable from the tests.
+var providersHintsTTL = 1 * time.Second
+
 type podTopologyHints map[string]map[string]TopologyHint
 
 // Scope interface for Topology Manager
@@ -42,13 +58,29 @@ type Scope interface {
 	Name() string
 	GetPolicy() Policy
 	Admit(pod *v1.Pod) lifecycle.PodAdmitResult
+	// DryRunAdmit computes the hint merge outcome an admission of the pod
+	// would produce, without recording hints or allocating resources
+	DryRunAdmit(pod *v1.Pod) []ContainerAdmissionPrediction
 	// AddHintProvider adds a hint provider to manager to indicate the hint provider
 	// wants to be consoluted with when making topology hints
 	AddHintProvider(h HintProvider)
+	// RemoveHintProvider deregisters a previously added hint provider, so it
+	// is no longer consulted during admission
+	RemoveHintProvider(h HintProvider)
 	// AddContainer adds pod to Manager for tracking
 	AddContainer(pod *v1.Pod, container *v1.Container, containerID string)
 	// RemoveContainer removes pod from Manager tracking
 	RemoveContainer(containerID string) error
+	// IsPodAligned returns whether all the containers of the given pod were
+	// admitted with a preferred hint, and whether the scope tracks the pod
+	// at all
+	IsPodAligned(podUID string) (aligned bool, tracked bool)
+	// topologyHintsSnapshot returns a copy of the tracked hints, to be
+	// checkpointed
+	topologyHintsSnapshot() podTopologyHints
+	// restoreTopologyHints replaces the tracked hints with a set read back
+	// from a checkpoint
+	restoreTopologyHints(hints podTopologyHints)
 	// Store is the interface for storing pod topology hints
 	Store
 }
@@ -65,6 +97,18 @@ type scope struct {
 	policy Policy
 	// Mapping of (PodUid, ContainerName) to ContainerID for Adding/Removing Pods from PodTopologyHints mapping
 	podMap containermap.ContainerMap
+	// nonPreferredPods tracks the pods which were admitted with a
+	// non-preferred hint. When capacity frees up, a better alignment may
+	// become available for these pods; the kubelet cannot re-pin running
+	// containers, but it can surface the missed opportunity to operators.
+	nonPreferredPods map[string]struct{}
+	// restartableInitContainers tracks, per pod, the names of the
+	// restartable init containers (sidecars). These restart while the pod
+	// keeps running, so their hints must survive the container removal for
+	// the whole pod lifetime: the restarted instance has to find the
+	// affinity the pod was admitted with. Only the pod scope populates the
+	// map; the other scopes leave it nil.
+	restartableInitContainers map[string]map[string]struct{}
 }
 
 func (s *scope) Name() string {
@@ -87,16 +131,146 @@ func (s *scope) setTopologyHints(podUID string, containerName string, th Topolog
 	s.podTopologyHints[podUID][containerName] = th
 }
 
+// topologyHintsSnapshot returns a deep copy of the tracked hints, so they can
+// be checkpointed without holding the lock over the disk write.
+func (s *scope) topologyHintsSnapshot() podTopologyHints {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshot := make(podTopologyHints, len(s.podTopologyHints))
+	for podUID, containers := range s.podTopologyHints {
+		snapshot[podUID] = make(map[string]TopologyHint, len(containers))
+		for containerName, hint := range containers {
+			snapshot[podUID][containerName] = hint
+		}
+	}
+	return snapshot
+}
+
+// restoreTopologyHints replaces the tracked hints with the given set, read
+// back from a checkpoint on kubelet startup.
+func (s *scope) restoreTopologyHints(hints podTopologyHints) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.podTopologyHints = hints
+}
+
+// setRestartableInitContainer flags the given container as a restartable init
+// container, pinning its hints for the whole pod lifetime.
+func (s *scope) setRestartableInitContainer(podUID string, containerName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.restartableInitContainers[podUID] == nil {
+		s.restartableInitContainers[podUID] = make(map[string]struct{})
+	}
+	s.restartableInitContainers[podUID][containerName] = struct{}{}
+}
+
+// isRestartableInitContainer tells whether the given container was flagged as
+// a restartable init container at admission. Must be called with s.mutex held.
+func (s *scope) isRestartableInitContainer(podUID string, containerName string) bool {
+	_, ok := s.restartableInitContainers[podUID][containerName]
+	return ok
+}
+
+// remainingHintsReleasable tells whether the hints still tracked for the
+// given pod can be dropped altogether: either none is left, or the only ones
+// left belong to restartable init containers none of which is running
+// anymore, meaning the pod itself is going away rather than a sidecar
+// restarting mid-run. Must be called with s.mutex held.
+func (s *scope) remainingHintsReleasable(podUID string) bool {
+	for containerName := range s.podTopologyHints[podUID] {
+		if !s.isRestartableInitContainer(podUID, containerName) {
+			return false
+		}
+		if _, err := s.podMap.GetContainerID(podUID, containerName); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *scope) GetAffinity(podUID string, containerName string) TopologyHint {
 	return s.getTopologyHints(podUID, containerName)
 }
 
+// IsPodAligned returns whether all the containers of the given pod were
+// admitted with a preferred hint. The second return value is false if the
+// scope does not track the pod, either because it was never admitted or
+// because the policy records no hints (like the none policy does).
+func (s *scope) IsPodAligned(podUID string) (bool, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	hints, ok := s.podTopologyHints[podUID]
+	if !ok {
+		return false, false
+	}
+	for _, hint := range hints {
+		if !hint.Preferred {
+			return false, true
+		}
+	}
+	return true, true
+}
+
 func (s *scope) GetPolicy() Policy {
 	return s.policy
 }
 
 func (s *scope) AddHintProvider(h HintProvider) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	s.hintProviders = append(s.hintProviders, h)
+	metrics.TopologyManagerHintProviders.Set(float64(len(s.hintProviders)))
+}
+
+func (s *scope) RemoveHintProvider(h HintProvider) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, provider := range s.hintProviders {
+		if provider == h {
+			s.hintProviders = append(s.hintProviders[:i], s.hintProviders[i+1:]...)
+			metrics.TopologyManagerHintProviders.Set(float64(len(s.hintProviders)))
+			return
+		}
+	}
+	klog.InfoS("RemoveHintProvider: provider not registered", "hintProvider", hintProviderName(h))
+}
+
+// providers returns a snapshot of the registered hint providers. Providers can
+// register and deregister at any time, and their GetTopologyHints/Allocate
+// calls are too slow to run under the lock, so all the iteration over the
+// providers happens on a snapshot.
+func (s *scope) providers() []HintProvider {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	providers := make([]HintProvider, len(s.hintProviders))
+	copy(providers, s.hintProviders)
+	return providers
+}
+
+// refreshStaleProvidersHints walks the accumulated hints and recomputes, via
+// the collect callback, the ones which went past their TTL while the rest of
+// the provider chain was being queried. `providers` must be the same snapshot,
+// in the same order, the hints were accumulated from. Only the stale entries
+// are paid for again; fresh ones are merged as they are.
+func refreshStaleProvidersHints(providersHints []ProviderHints, providers []HintProvider, collect func(HintProvider) map[string][]TopologyHint) []ProviderHints {
+	for i, provider := range providers {
+		age := time.Since(providersHints[i].CollectedAt)
+		if age <= providersHintsTTL {
+			continue
+		}
+		klog.InfoS("TopologyHints went stale before merging, recomputing", "provider", providersHints[i].Provider, "age", age)
+		providersHints[i].Hints = collect(provider)
+		providersHints[i].CollectedAt = time.Now()
+	}
+	return providersHints
 }
 
 // It would be better to implement this function in topologymanager instead of scope
@@ -126,15 +300,92 @@ func (s *scope) RemoveContainer(containerID string) error {
 	// containerName are already associated with a *different* containerID now. Only remove
 	// the TopologyHints associated with that podUID and containerName if this is not true
 	if _, err := s.podMap.GetContainerID(podUIDString, containerName); err != nil {
-		delete(s.podTopologyHints[podUIDString], containerName)
-		if len(s.podTopologyHints[podUIDString]) == 0 {
+		// Restartable init containers restart while the pod keeps running,
+		// and the restarted instance must keep the affinity the pod was
+		// admitted with: their hints are only released with the whole pod.
+		if !s.isRestartableInitContainer(podUIDString, containerName) {
+			delete(s.podTopologyHints[podUIDString], containerName)
+		}
+		if s.remainingHintsReleasable(podUIDString) {
 			delete(s.podTopologyHints, podUIDString)
+			delete(s.restartableInitContainers, podUIDString)
+			delete(s.nonPreferredPods, podUIDString)
+			// The pod released its resources, so capacity freed up: a
+			// better alignment may now be available for the pods which
+			// were admitted with a non-preferred hint.
+			s.notifyRealignmentPossible()
 		}
 	}
 
 	return nil
 }
 
+// trackNonPreferredAdmission records that the given pod was admitted with a
+// non-preferred hint, so later events which free up capacity can surface
+// that a realignment became possible for it. Preferred admissions are ignored.
+func (s *scope) trackNonPreferredAdmission(podUID string, hint TopologyHint) {
+	if hint.Preferred {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.nonPreferredPods == nil {
+		s.nonPreferredPods = make(map[string]struct{})
+	}
+	if _, ok := s.nonPreferredPods[podUID]; ok {
+		return
+	}
+	s.nonPreferredPods[podUID] = struct{}{}
+	metrics.TopologyManagerNonPreferredAdmissionsTotal.Inc()
+}
+
+// notifyRealignmentPossible emits, for each pod still admitted with a
+// non-preferred hint, a metric and a log entry noting that a better alignment
+// possibly became available. Must be called with s.mutex held.
+func (s *scope) notifyRealignmentPossible() {
+	for podUID := range s.nonPreferredPods {
+		metrics.TopologyManagerRealignmentPossibleTotal.Inc()
+		klog.V(2).InfoS("Realignment possible for pod admitted with a non-preferred alignment", "podUID", podUID)
+	}
+}
+
+// hintProviderName returns a human friendly identifier for the given hint
+// provider, used to attribute hints in logs and in the data passed to the
+// policy Merge. The concrete type name is stable and unambiguous among the
+// in-tree providers (devicemanager, cpumanager, memorymanager), so there is
+// no need for the providers to identify themselves explicitly.
+func hintProviderName(provider HintProvider) string {
+	return fmt.Sprintf("%T", provider)
+}
+
+// observeAdmissionPhase records the duration of one phase of a Guaranteed pod
+// admission. Pods of the other QoS classes take a fast path through the hint
+// providers, so breaking their admission down would only add noise.
+func observeAdmissionPhase(pod *v1.Pod, phase string, startTime time.Time) {
+	if v1qos.GetPodQOS(pod) != v1.PodQOSGuaranteed {
+		return
+	}
+	metrics.TopologyManagerAdmissionPhaseDuration.WithLabelValues(phase).Observe(metrics.SinceInSeconds(startTime))
+}
+
+// allocationPhaseForProvider maps a hint provider to the admission phase its
+// Allocate call is accounted under. Providers without a dedicated phase get
+// an empty string and are not observed.
+func allocationPhaseForProvider(provider HintProvider) string {
+	name := hintProviderName(provider)
+	switch {
+	case strings.Contains(name, "cpumanager"):
+		return metrics.AdmissionPhaseCPUAlloc
+	case strings.Contains(name, "memorymanager"):
+		return metrics.AdmissionPhaseMemoryAlloc
+	case strings.Contains(name, "devicemanager"):
+		return metrics.AdmissionPhaseDeviceAlloc
+	}
+	return ""
+}
+
 func (s *scope) admitPolicyNone(pod *v1.Pod) lifecycle.PodAdmitResult {
 	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
 		err := s.allocateAlignedResources(pod, &container)
@@ -148,8 +399,12 @@ func (s *scope) admitPolicyNone(pod *v1.Pod) lifecycle.PodAdmitResult {
 // It would be better to implement this function in topologymanager instead of scope
 // but topologymanager do not track providers anymore
 func (s *scope) allocateAlignedResources(pod *v1.Pod, container *v1.Container) error {
-	for _, provider := range s.hintProviders {
+	for _, provider := range s.providers() {
+		startTime := time.Now()
 		err := provider.Allocate(pod, container)
+		if phase := allocationPhaseForProvider(provider); phase != "" {
+			observeAdmissionPhase(pod, phase, startTime)
+		}
 		if err != nil {
 			return err
 		}
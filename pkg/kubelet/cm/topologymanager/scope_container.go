@@ -17,6 +17,8 @@ limitations under the License.
 package topologymanager
 
 import (
+	"time"
+
 	"k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/kubelet/cm/admission"
@@ -40,21 +42,29 @@ func NewContainerScope(policy Policy) Scope {
 			podTopologyHints: podTopologyHints{},
 			policy:           policy,
 			podMap:           containermap.NewContainerMap(),
+			nonPreferredPods: make(map[string]struct{}),
 		},
 	}
 }
 
 func (s *containerScope) Admit(pod *v1.Pod) lifecycle.PodAdmitResult {
 	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
-		bestHint, admit := s.calculateAffinity(pod, &container)
+		bestHint, admit, zeroHinted := s.calculateAffinity(pod, &container)
 		klog.InfoS("Best TopologyHint", "bestHint", bestHint, "pod", klog.KObj(pod), "containerName", container.Name)
 
+		if len(zeroHinted) > 0 {
+			klog.InfoS("Resources with no possible NUMA affinity", "resources", zeroHinted, "pod", klog.KObj(pod), "containerName", container.Name)
+			for _, resource := range zeroHinted {
+				metrics.TopologyManagerZeroHintResourcesTotal.WithLabelValues(resource).Inc()
+			}
+		}
 		if !admit {
 			metrics.TopologyManagerAdmissionErrorsTotal.Inc()
-			return admission.GetPodAdmitResult(&TopologyAffinityError{})
+			return admission.GetPodAdmitResult(&TopologyAffinityError{Resources: zeroHinted})
 		}
 		klog.InfoS("Topology Affinity", "bestHint", bestHint, "pod", klog.KObj(pod), "containerName", container.Name)
 		s.setTopologyHints(string(pod.UID), container.Name, bestHint)
+		s.trackNonPreferredAdmission(string(pod.UID), bestHint)
 
 		err := s.allocateAlignedResources(pod, &container)
 		if err != nil {
@@ -65,21 +75,49 @@ func (s *containerScope) Admit(pod *v1.Pod) lifecycle.PodAdmitResult {
 	return admission.GetPodAdmitResult(nil)
 }
 
-func (s *containerScope) accumulateProvidersHints(pod *v1.Pod, container *v1.Container) []map[string][]TopologyHint {
-	var providersHints []map[string][]TopologyHint
+// DryRunAdmit evaluates each container against the current availability, the
+// way Admit would, but without recording hints or allocating resources. The
+// outcome of every container is reported even when an earlier one would be
+// refused. Since nothing is allocated, the later containers do not see the
+// resources the earlier ones would have consumed: the prediction is exact for
+// the first refused container and optimistic past it.
+func (s *containerScope) DryRunAdmit(pod *v1.Pod) []ContainerAdmissionPrediction {
+	var predictions []ContainerAdmissionPrediction
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		bestHint, admit, _ := s.calculateAffinity(pod, &container)
+		klog.InfoS("DryRunAdmit container affinity", "bestHint", bestHint, "admit", admit, "pod", klog.KObj(pod), "containerName", container.Name)
+		predictions = append(predictions, ContainerAdmissionPrediction{
+			ContainerName: container.Name,
+			Affinity:      bestHint,
+			Admit:         admit,
+		})
+	}
+	return predictions
+}
+
+func (s *containerScope) accumulateProvidersHints(pod *v1.Pod, container *v1.Container) []ProviderHints {
+	var providersHints []ProviderHints
 
-	for _, provider := range s.hintProviders {
+	providers := s.providers()
+	for _, provider := range providers {
 		// Get the TopologyHints for a Container from a provider.
 		hints := provider.GetTopologyHints(pod, container)
-		providersHints = append(providersHints, hints)
-		klog.InfoS("TopologyHints", "hints", hints, "pod", klog.KObj(pod), "containerName", container.Name)
+		providersHints = append(providersHints, ProviderHints{Provider: hintProviderName(provider), Hints: hints, CollectedAt: time.Now()})
+		klog.InfoS("TopologyHints", "provider", hintProviderName(provider), "hints", hints, "pod", klog.KObj(pod), "containerName", container.Name)
 	}
-	return providersHints
+	return refreshStaleProvidersHints(providersHints, providers, func(provider HintProvider) map[string][]TopologyHint {
+		return provider.GetTopologyHints(pod, container)
+	})
 }
 
-func (s *containerScope) calculateAffinity(pod *v1.Pod, container *v1.Container) (TopologyHint, bool) {
+func (s *containerScope) calculateAffinity(pod *v1.Pod, container *v1.Container) (TopologyHint, bool, []string) {
+	startTime := time.Now()
 	providersHints := s.accumulateProvidersHints(pod, container)
+	observeAdmissionPhase(pod, metrics.AdmissionPhaseHintGeneration, startTime)
+
+	startTime = time.Now()
 	bestHint, admit := s.policy.Merge(providersHints)
+	observeAdmissionPhase(pod, metrics.AdmissionPhaseMerge, startTime)
 	klog.InfoS("ContainerTopologyHint", "bestHint", bestHint)
-	return bestHint, admit
+	return bestHint, admit, zeroHintResources(providersHints)
 }
@@ -132,9 +132,20 @@ func TestContainerCalculateAffinity(t *testing.T) {
 
 		ctnScope.calculateAffinity(&v1.Pod{}, &v1.Container{})
 		actual := ctnScope.policy.(*mockPolicy).ph
-		if !reflect.DeepEqual(tc.expected, actual) {
+		if len(actual) != len(tc.expected) {
 			t.Errorf("Test Case: %s", tc.name)
 			t.Errorf("Expected result to be %v, got %v", tc.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i].Provider == "" {
+				t.Errorf("Test Case: %s", tc.name)
+				t.Errorf("Expected hints to be attributed to a provider, got %v", actual[i])
+			}
+			if !reflect.DeepEqual(tc.expected[i], actual[i].Hints) {
+				t.Errorf("Test Case: %s", tc.name)
+				t.Errorf("Expected result to be %v, got %v", tc.expected[i], actual[i].Hints)
+			}
 		}
 	}
 }
@@ -261,8 +272,91 @@ func TestContainerAccumulateProvidersHints(t *testing.T) {
 			},
 		}
 		actual := ctnScope.accumulateProvidersHints(&v1.Pod{}, &v1.Container{})
-		if !reflect.DeepEqual(actual, tc.expected) {
+		if len(actual) != len(tc.expected) {
 			t.Errorf("Test Case %s: Expected NUMANodeAffinity in result to be %v, got %v", tc.name, tc.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i].Provider == "" {
+				t.Errorf("Test Case %s: Expected hints to be attributed to a provider, got %v", tc.name, actual[i])
+			}
+			if !reflect.DeepEqual(actual[i].Hints, tc.expected[i]) {
+				t.Errorf("Test Case %s: Expected NUMANodeAffinity in result to be %v, got %v", tc.name, tc.expected[i], actual[i].Hints)
+			}
+		}
+	}
+}
+
+func TestContainerScopeDryRunAdmit(t *testing.T) {
+	numaInfo := &NUMAInfo{
+		Nodes: []int{0, 1},
+		NUMADistances: NUMADistances{
+			0: {10, 11},
+			1: {11, 10},
+		},
+	}
+	singleNumaPolicy := NewSingleNumaNodePolicy(numaInfo, PolicyOptions{})
+
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "container-1"},
+				{Name: "container-2"},
+			},
+		},
+	}
+
+	tcases := []struct {
+		name     string
+		hp       []HintProvider
+		expected []ContainerAdmissionPrediction
+	}{
+		{
+			name: "aligned hints admit every container",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+						},
+					},
+				},
+			},
+			expected: []ContainerAdmissionPrediction{
+				{ContainerName: "container-1", Affinity: TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true}, Admit: true},
+				{ContainerName: "container-2", Affinity: TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true}, Admit: true},
+			},
+		},
+		{
+			name: "cross-numa hints refuse every container",
+			hp: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+						},
+					},
+				},
+			},
+			expected: []ContainerAdmissionPrediction{
+				{ContainerName: "container-1", Affinity: TopologyHint{}, Admit: false},
+				{ContainerName: "container-2", Affinity: TopologyHint{}, Admit: false},
+			},
+		},
+	}
+
+	for _, tc := range tcases {
+		ctnScope := &containerScope{
+			scope{
+				hintProviders: tc.hp,
+				policy:        singleNumaPolicy,
+				name:          containerTopologyScope,
+			},
+		}
+
+		actual := ctnScope.DryRunAdmit(pod)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("Test Case: %s: Expected predictions to be %v, got %v", tc.name, tc.expected, actual)
 		}
 	}
 }
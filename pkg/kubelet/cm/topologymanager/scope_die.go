@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"sort"
+
+	cadvisorapi "github.com/google/cadvisor/info/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+)
+
+// dieResourceName is the pseudo resource under which the die-level hints are
+// fed into the policy merge, alongside the hints of the real resources.
+const dieResourceName = "die"
+
+type dieScope struct {
+	containerScope
+}
+
+// Ensure dieScope implements Scope interface
+var _ Scope = &dieScope{}
+
+// NewDieScope returns a die scope: hints are generated and merged per
+// container like in the container scope, but the merge additionally receives
+// one die-level hint per die, each covering the NUMA nodes the die backs, so
+// the resulting affinity lands within a single die whenever the hints of the
+// real resources allow it.
+func NewDieScope(policy Policy, dieAffinities []bitmask.BitMask) Scope {
+	s := &dieScope{
+		containerScope{
+			scope{
+				name:             dieTopologyScope,
+				podTopologyHints: podTopologyHints{},
+				policy:           policy,
+				podMap:           containermap.NewContainerMap(),
+				nonPreferredPods: make(map[string]struct{}),
+			},
+		},
+	}
+	s.AddHintProvider(newDieHintProvider(dieAffinities))
+	return s
+}
+
+// dieNUMAAffinities returns one affinity mask per die discovered on the
+// machine, each covering the NUMA nodes backed by that die.
+func dieNUMAAffinities(nodes []cadvisorapi.Node) []bitmask.BitMask {
+	numaNodesPerDie := topology.NUMANodesPerDie(nodes)
+
+	dies := make([]int, 0, len(numaNodesPerDie))
+	for die := range numaNodesPerDie {
+		dies = append(dies, die)
+	}
+	sort.Ints(dies)
+
+	var affinities []bitmask.BitMask
+	for _, die := range dies {
+		affinity, _ := bitmask.NewBitMask(numaNodesPerDie[die].List()...)
+		affinities = append(affinities, affinity)
+	}
+	return affinities
+}
+
+// maxNUMANodesPerDieHints bounds the per-die hint expansion. Dies back a
+// handful of NUMA nodes (one, or a few with sub-NUMA clustering), so the
+// bound is never hit on real hardware; should a die report more nodes, only
+// the whole-die hint is generated for it instead of the full subset list.
+const maxNUMANodesPerDieHints = 8
+
+// dieHintProvider feeds the die-level hints into the merge. The die scope
+// registers it next to the real hint providers: any allocation whose affinity
+// is fully contained in one die is acceptable at die granularity, so each die
+// yields one preferred hint per non-empty subset of the NUMA nodes it backs.
+// Listing the subsets explicitly lets the merge keep the preferred flag when
+// the real resources align to a part of a die.
+type dieHintProvider struct {
+	hintsByResource map[string][]TopologyHint
+}
+
+var _ HintProvider = &dieHintProvider{}
+
+func newDieHintProvider(dieAffinities []bitmask.BitMask) *dieHintProvider {
+	var hints []TopologyHint
+	for _, affinity := range dieAffinities {
+		hints = append(hints, dieSubsetHints(affinity)...)
+	}
+	if len(hints) == 0 {
+		// No dies discovered: do not constrain the merge.
+		return &dieHintProvider{}
+	}
+	return &dieHintProvider{
+		hintsByResource: map[string][]TopologyHint{dieResourceName: hints},
+	}
+}
+
+// dieSubsetHints returns one preferred hint per non-empty subset of the NUMA
+// nodes in the given die affinity.
+func dieSubsetHints(affinity bitmask.BitMask) []TopologyHint {
+	nodes := affinity.GetBits()
+	if len(nodes) > maxNUMANodesPerDieHints {
+		return []TopologyHint{{NUMANodeAffinity: affinity, Preferred: true}}
+	}
+
+	var hints []TopologyHint
+	for subset := 1; subset < (1 << len(nodes)); subset++ {
+		var subsetNodes []int
+		for i, node := range nodes {
+			if subset&(1<<i) != 0 {
+				subsetNodes = append(subsetNodes, node)
+			}
+		}
+		subsetAffinity, _ := bitmask.NewBitMask(subsetNodes...)
+		hints = append(hints, TopologyHint{NUMANodeAffinity: subsetAffinity, Preferred: true})
+	}
+	return hints
+}
+
+func (p *dieHintProvider) hints() map[string][]TopologyHint {
+	return p.hintsByResource
+}
+
+func (p *dieHintProvider) GetTopologyHints(pod *v1.Pod, container *v1.Container) map[string][]TopologyHint {
+	return p.hints()
+}
+
+func (p *dieHintProvider) GetPodTopologyHints(pod *v1.Pod) map[string][]TopologyHint {
+	return p.hints()
+}
+
+func (p *dieHintProvider) Allocate(pod *v1.Pod, container *v1.Container) error {
+	// The dies are not a resource to hand out, there is nothing to allocate.
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologymanager
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+)
+
+func TestDieHintProviderHints(t *testing.T) {
+	tcases := []struct {
+		name          string
+		dieAffinities []bitmask.BitMask
+		expectedHints map[string][]TopologyHint
+	}{
+		{
+			name:          "no dies discovered",
+			dieAffinities: nil,
+			expectedHints: nil,
+		},
+		{
+			name: "one NUMA node per die",
+			dieAffinities: []bitmask.BitMask{
+				NewTestBitMask(0),
+				NewTestBitMask(1),
+			},
+			expectedHints: map[string][]TopologyHint{
+				dieResourceName: {
+					{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+				},
+			},
+		},
+		{
+			name: "multi NUMA node dies expand to the subsets",
+			dieAffinities: []bitmask.BitMask{
+				NewTestBitMask(0, 1),
+				NewTestBitMask(2, 3),
+			},
+			expectedHints: map[string][]TopologyHint{
+				dieResourceName: {
+					{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(2), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(3), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(2, 3), Preferred: true},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := newDieHintProvider(tc.dieAffinities)
+			hints := provider.GetTopologyHints(&v1.Pod{}, &v1.Container{})
+			if !reflect.DeepEqual(hints, tc.expectedHints) {
+				t.Errorf("expected hints %v, but got %v", tc.expectedHints, hints)
+			}
+			podHints := provider.GetPodTopologyHints(&v1.Pod{})
+			if !reflect.DeepEqual(podHints, tc.expectedHints) {
+				t.Errorf("expected pod hints %v, but got %v", tc.expectedHints, podHints)
+			}
+		})
+	}
+}
+
+func TestDieScopeCalculateAffinity(t *testing.T) {
+	numaInfo := &NUMAInfo{Nodes: []int{0, 1, 2, 3}}
+	dieAffinities := []bitmask.BitMask{
+		NewTestBitMask(0, 1),
+		NewTestBitMask(2, 3),
+	}
+
+	tcases := []struct {
+		name     string
+		hints    map[string][]TopologyHint
+		expected TopologyHint
+	}{
+		{
+			name: "allocation fitting a single die keeps the die alignment",
+			hints: map[string][]TopologyHint{
+				"cpu": {
+					{NUMANodeAffinity: NewTestBitMask(2), Preferred: true},
+					{NUMANodeAffinity: NewTestBitMask(0, 1, 2, 3), Preferred: false},
+				},
+			},
+			expected: TopologyHint{NUMANodeAffinity: NewTestBitMask(2), Preferred: true},
+		},
+		{
+			name: "allocation spanning a whole die stays preferred",
+			hints: map[string][]TopologyHint{
+				"cpu": {
+					{NUMANodeAffinity: NewTestBitMask(2, 3), Preferred: true},
+				},
+			},
+			expected: TopologyHint{NUMANodeAffinity: NewTestBitMask(2, 3), Preferred: true},
+		},
+		{
+			name: "allocation crossing dies is admitted without a preferred alignment",
+			hints: map[string][]TopologyHint{
+				"cpu": {
+					{NUMANodeAffinity: NewTestBitMask(1, 2), Preferred: true},
+				},
+			},
+			expected: TopologyHint{NUMANodeAffinity: NewTestBitMask(1), Preferred: false},
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := NewDieScope(NewBestEffortPolicy(numaInfo, PolicyOptions{}), dieAffinities).(*dieScope)
+			scope.AddHintProvider(&mockHintProvider{th: tc.hints})
+
+			bestHint, admit, _ := scope.calculateAffinity(&v1.Pod{}, &v1.Container{})
+			if !admit {
+				t.Errorf("expected the container to be admitted")
+			}
+			if !bestHint.IsEqual(tc.expected) {
+				t.Errorf("expected best hint %v, but got %v", tc.expected, bestHint)
+			}
+		})
+	}
+}
@@ -44,3 +44,16 @@ func NewNoneScope() Scope {
 func (s *noneScope) Admit(pod *v1.Pod) lifecycle.PodAdmitResult {
 	return s.admitPolicyNone(pod)
 }
+
+// DryRunAdmit always predicts admission: the none policy performs no
+// alignment, so no affinity is computed and nothing can be refused.
+func (s *noneScope) DryRunAdmit(pod *v1.Pod) []ContainerAdmissionPrediction {
+	var predictions []ContainerAdmissionPrediction
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		predictions = append(predictions, ContainerAdmissionPrediction{
+			ContainerName: container.Name,
+			Admit:         true,
+		})
+	}
+	return predictions
+}
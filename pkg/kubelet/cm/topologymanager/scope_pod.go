@@ -17,12 +17,15 @@ limitations under the License.
 package topologymanager
 
 import (
+	"time"
+
 	"k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/kubelet/cm/admission"
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"k8s.io/kubernetes/pkg/kubelet/lifecycle"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
+	"k8s.io/kubernetes/pkg/kubelet/types"
 )
 
 type podScope struct {
@@ -36,20 +39,39 @@ var _ Scope = &podScope{}
 func NewPodScope(policy Policy) Scope {
 	return &podScope{
 		scope{
-			name:             podTopologyScope,
-			podTopologyHints: podTopologyHints{},
-			policy:           policy,
-			podMap:           containermap.NewContainerMap(),
+			name:                      podTopologyScope,
+			podTopologyHints:          podTopologyHints{},
+			policy:                    policy,
+			podMap:                    containermap.NewContainerMap(),
+			nonPreferredPods:          make(map[string]struct{}),
+			restartableInitContainers: make(map[string]map[string]struct{}),
 		},
 	}
 }
 
 func (s *podScope) Admit(pod *v1.Pod) lifecycle.PodAdmitResult {
-	bestHint, admit := s.calculateAffinity(pod)
+	bestHint, admit, zeroHinted := s.calculateAffinity(pod)
 	klog.InfoS("Best TopologyHint", "bestHint", bestHint, "pod", klog.KObj(pod))
+	if len(zeroHinted) > 0 {
+		klog.InfoS("Resources with no possible NUMA affinity", "resources", zeroHinted, "pod", klog.KObj(pod))
+		for _, resource := range zeroHinted {
+			metrics.TopologyManagerZeroHintResourcesTotal.WithLabelValues(resource).Inc()
+		}
+	}
 	if !admit {
 		metrics.TopologyManagerAdmissionErrorsTotal.Inc()
-		return admission.GetPodAdmitResult(&TopologyAffinityError{})
+		return admission.GetPodAdmitResult(&TopologyAffinityError{Resources: zeroHinted})
+	}
+	s.trackNonPreferredAdmission(string(pod.UID), bestHint)
+
+	// Flag the restartable init containers (sidecars) up front, so their
+	// hints stay pinned for the whole pod lifetime: a sidecar restarting
+	// mid-run must find the affinity the pod was admitted with.
+	for i := range pod.Spec.InitContainers {
+		container := &pod.Spec.InitContainers[i]
+		if types.IsRestartableInitContainer(container) {
+			s.setRestartableInitContainer(string(pod.UID), container.Name)
+		}
 	}
 
 	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
@@ -65,21 +87,47 @@ func (s *podScope) Admit(pod *v1.Pod) lifecycle.PodAdmitResult {
 	return admission.GetPodAdmitResult(nil)
 }
 
-func (s *podScope) accumulateProvidersHints(pod *v1.Pod) []map[string][]TopologyHint {
-	var providersHints []map[string][]TopologyHint
+// DryRunAdmit evaluates the pod against the current availability, the way
+// Admit would, but without recording hints or allocating resources. The pod
+// scope merges a single affinity for the whole pod, so every container
+// reports the same hint and verdict.
+func (s *podScope) DryRunAdmit(pod *v1.Pod) []ContainerAdmissionPrediction {
+	bestHint, admit, _ := s.calculateAffinity(pod)
+	klog.InfoS("DryRunAdmit pod affinity", "bestHint", bestHint, "admit", admit, "pod", klog.KObj(pod))
+	var predictions []ContainerAdmissionPrediction
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		predictions = append(predictions, ContainerAdmissionPrediction{
+			ContainerName: container.Name,
+			Affinity:      bestHint,
+			Admit:         admit,
+		})
+	}
+	return predictions
+}
 
-	for _, provider := range s.hintProviders {
+func (s *podScope) accumulateProvidersHints(pod *v1.Pod) []ProviderHints {
+	var providersHints []ProviderHints
+
+	providers := s.providers()
+	for _, provider := range providers {
 		// Get the TopologyHints for a Pod from a provider.
 		hints := provider.GetPodTopologyHints(pod)
-		providersHints = append(providersHints, hints)
-		klog.InfoS("TopologyHints", "hints", hints, "pod", klog.KObj(pod))
+		providersHints = append(providersHints, ProviderHints{Provider: hintProviderName(provider), Hints: hints, CollectedAt: time.Now()})
+		klog.InfoS("TopologyHints", "provider", hintProviderName(provider), "hints", hints, "pod", klog.KObj(pod))
 	}
-	return providersHints
+	return refreshStaleProvidersHints(providersHints, providers, func(provider HintProvider) map[string][]TopologyHint {
+		return provider.GetPodTopologyHints(pod)
+	})
 }
 
-func (s *podScope) calculateAffinity(pod *v1.Pod) (TopologyHint, bool) {
+func (s *podScope) calculateAffinity(pod *v1.Pod) (TopologyHint, bool, []string) {
+	startTime := time.Now()
 	providersHints := s.accumulateProvidersHints(pod)
+	observeAdmissionPhase(pod, metrics.AdmissionPhaseHintGeneration, startTime)
+
+	startTime = time.Now()
 	bestHint, admit := s.policy.Merge(providersHints)
+	observeAdmissionPhase(pod, metrics.AdmissionPhaseMerge, startTime)
 	klog.InfoS("PodTopologyHint", "bestHint", bestHint)
-	return bestHint, admit
+	return bestHint, admit, zeroHintResources(providersHints)
 }
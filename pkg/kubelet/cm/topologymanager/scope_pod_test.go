@@ -132,9 +132,20 @@ func TestPodCalculateAffinity(t *testing.T) {
 
 		podScope.calculateAffinity(&v1.Pod{})
 		actual := podScope.policy.(*mockPolicy).ph
-		if !reflect.DeepEqual(tc.expected, actual) {
+		if len(actual) != len(tc.expected) {
 			t.Errorf("Test Case: %s", tc.name)
 			t.Errorf("Expected result to be %v, got %v", tc.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i].Provider == "" {
+				t.Errorf("Test Case: %s", tc.name)
+				t.Errorf("Expected hints to be attributed to a provider, got %v", actual[i])
+			}
+			if !reflect.DeepEqual(tc.expected[i], actual[i].Hints) {
+				t.Errorf("Test Case: %s", tc.name)
+				t.Errorf("Expected result to be %v, got %v", tc.expected[i], actual[i].Hints)
+			}
 		}
 	}
 }
@@ -261,8 +272,138 @@ func TestPodAccumulateProvidersHints(t *testing.T) {
 			},
 		}
 		actual := pScope.accumulateProvidersHints(&v1.Pod{})
-		if !reflect.DeepEqual(actual, tc.expected) {
+		if len(actual) != len(tc.expected) {
 			t.Errorf("Test Case %s: Expected NUMANodeAffinity in result to be %v, got %v", tc.name, tc.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i].Provider == "" {
+				t.Errorf("Test Case %s: Expected hints to be attributed to a provider, got %v", tc.name, actual[i])
+			}
+			if !reflect.DeepEqual(actual[i].Hints, tc.expected[i]) {
+				t.Errorf("Test Case %s: Expected NUMANodeAffinity in result to be %v, got %v", tc.name, tc.expected[i], actual[i].Hints)
+			}
 		}
 	}
 }
+
+func TestPodScopeDryRunAdmit(t *testing.T) {
+	numaInfo := &NUMAInfo{
+		Nodes: []int{0, 1},
+		NUMADistances: NUMADistances{
+			0: {10, 11},
+			1: {11, 10},
+		},
+	}
+	singleNumaPolicy := NewSingleNumaNodePolicy(numaInfo, PolicyOptions{})
+
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "container-1"},
+				{Name: "container-2"},
+			},
+		},
+	}
+
+	pScope := &podScope{
+		scope{
+			hintProviders: []HintProvider{
+				&mockHintProvider{
+					map[string][]TopologyHint{
+						"resource": {
+							{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+						},
+					},
+				},
+			},
+			policy: singleNumaPolicy,
+			name:   podTopologyScope,
+		},
+	}
+
+	expected := []ContainerAdmissionPrediction{
+		{ContainerName: "container-1", Affinity: TopologyHint{NUMANodeAffinity: NewTestBitMask(1), Preferred: true}, Admit: true},
+		{ContainerName: "container-2", Affinity: TopologyHint{NUMANodeAffinity: NewTestBitMask(1), Preferred: true}, Admit: true},
+	}
+	actual := pScope.DryRunAdmit(pod)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected predictions to be %v, got %v", expected, actual)
+	}
+}
+
+func TestPodScopeRestartableInitContainerHints(t *testing.T) {
+	numaInfo := &NUMAInfo{
+		Nodes: []int{0, 1},
+		NUMADistances: NUMADistances{
+			0: {10, 11},
+			1: {11, 10},
+		},
+	}
+	singleNumaPolicy := NewSingleNumaNodePolicy(numaInfo, PolicyOptions{})
+
+	restartPolicyAlways := v1.ContainerRestartPolicyAlways
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{
+				{Name: "init"},
+				{Name: "sidecar", RestartPolicy: &restartPolicyAlways},
+			},
+			Containers: []v1.Container{
+				{Name: "app"},
+			},
+		},
+	}
+	pod.UID = "fakePod"
+
+	pScope := NewPodScope(singleNumaPolicy).(*podScope)
+	pScope.AddHintProvider(&mockHintProvider{
+		map[string][]TopologyHint{
+			"resource": {
+				{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+			},
+		},
+	})
+
+	if result := pScope.Admit(pod); !result.Admit {
+		t.Fatalf("Expected the pod to be admitted, got %v", result)
+	}
+	expectedHint := TopologyHint{NUMANodeAffinity: NewTestBitMask(1), Preferred: true}
+
+	pScope.AddContainer(pod, &pod.Spec.InitContainers[0], "init-1")
+	pScope.AddContainer(pod, &pod.Spec.InitContainers[1], "sidecar-1")
+	pScope.AddContainer(pod, &pod.Spec.Containers[0], "app-1")
+
+	// The regular init container completed: its hint is released as before.
+	if err := pScope.RemoveContainer("init-1"); err != nil {
+		t.Fatalf("Unexpected error removing the init container: %v", err)
+	}
+	if hint := pScope.GetAffinity("fakePod", "init"); !hint.IsEqual(TopologyHint{}) {
+		t.Errorf("Expected the init container hint to be released, have %v", hint)
+	}
+
+	// The sidecar restarts mid-run: the old container is removed before the
+	// new one is added, but its hint must survive for the whole pod lifetime.
+	if err := pScope.RemoveContainer("sidecar-1"); err != nil {
+		t.Fatalf("Unexpected error removing the sidecar container: %v", err)
+	}
+	if hint := pScope.GetAffinity("fakePod", "sidecar"); !hint.IsEqual(expectedHint) {
+		t.Errorf("Expected the sidecar hint to persist over the restart, have %v", hint)
+	}
+	pScope.AddContainer(pod, &pod.Spec.InitContainers[1], "sidecar-2")
+
+	// The whole pod goes away: everything is released, sidecar included.
+	if err := pScope.RemoveContainer("app-1"); err != nil {
+		t.Fatalf("Unexpected error removing the app container: %v", err)
+	}
+	if err := pScope.RemoveContainer("sidecar-2"); err != nil {
+		t.Fatalf("Unexpected error removing the sidecar container: %v", err)
+	}
+	if hint := pScope.GetAffinity("fakePod", "sidecar"); !hint.IsEqual(TopologyHint{}) {
+		t.Errorf("Expected the sidecar hint to be released with the pod, have %v", hint)
+	}
+	if len(pScope.podTopologyHints) != 0 || len(pScope.restartableInitContainers) != 0 {
+		t.Errorf("Expected the pod tracking to be garbage collected, have hints %v restartable %v",
+			pScope.podTopologyHints, pScope.restartableInitContainers)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cm/containermap"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGetAffinity(t *testing.T) {
@@ -125,3 +126,134 @@ func TestRemoveContainer(t *testing.T) {
 	}
 
 }
+
+func TestTrackNonPreferredAdmission(t *testing.T) {
+	scope := scope{
+		podTopologyHints: podTopologyHints{},
+		podMap:           containermap.NewContainerMap(),
+		nonPreferredPods: make(map[string]struct{}),
+	}
+
+	scope.trackNonPreferredAdmission("preferredPod", TopologyHint{Preferred: true})
+	if len(scope.nonPreferredPods) != 0 {
+		t.Errorf("expected preferred admissions not to be tracked, got %v", scope.nonPreferredPods)
+	}
+
+	scope.trackNonPreferredAdmission("nonPreferredPod", TopologyHint{Preferred: false})
+	// tracking the same pod again (e.g. pod scope with multiple containers) must be idempotent
+	scope.trackNonPreferredAdmission("nonPreferredPod", TopologyHint{Preferred: false})
+	if len(scope.nonPreferredPods) != 1 {
+		t.Errorf("expected a single tracked pod, got %v", scope.nonPreferredPods)
+	}
+	if _, ok := scope.nonPreferredPods["nonPreferredPod"]; !ok {
+		t.Errorf("expected pod %q to be tracked, got %v", "nonPreferredPod", scope.nonPreferredPods)
+	}
+
+	// once the pod is removed, it must not be tracked anymore
+	scope.podMap.Add("nonPreferredPod", "container", "containerID")
+	scope.setTopologyHints("nonPreferredPod", "container", TopologyHint{Preferred: false})
+	if err := scope.RemoveContainer("containerID"); err != nil {
+		t.Errorf("failed to remove container: %v", err)
+	}
+	if len(scope.nonPreferredPods) != 0 {
+		t.Errorf("expected no tracked pods after removal, got %v", scope.nonPreferredPods)
+	}
+}
+
+func TestRefreshStaleProvidersHints(t *testing.T) {
+	staleHints := map[string][]TopologyHint{
+		"resource1": {
+			{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+		},
+	}
+	freshHints := map[string][]TopologyHint{
+		"resource2": {
+			{NUMANodeAffinity: NewTestBitMask(1), Preferred: true},
+		},
+	}
+	recomputedHints := map[string][]TopologyHint{
+		"resource1": {
+			{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+		},
+	}
+
+	staleProvider := &mockHintProvider{th: staleHints}
+	freshProvider := &mockHintProvider{th: freshHints}
+	providers := []HintProvider{staleProvider, freshProvider}
+
+	now := time.Now()
+	providersHints := []ProviderHints{
+		{Provider: hintProviderName(staleProvider), Hints: staleHints, CollectedAt: now.Add(-2 * providersHintsTTL)},
+		{Provider: hintProviderName(freshProvider), Hints: freshHints, CollectedAt: now},
+	}
+
+	collected := []HintProvider{}
+	got := refreshStaleProvidersHints(providersHints, providers, func(provider HintProvider) map[string][]TopologyHint {
+		collected = append(collected, provider)
+		return recomputedHints
+	})
+
+	if len(collected) != 1 || collected[0] != staleProvider {
+		t.Errorf("Expected only the stale provider to be queried again, got %v", collected)
+	}
+	if !reflect.DeepEqual(got[0].Hints, recomputedHints) {
+		t.Errorf("Expected the stale hints to be recomputed, got %v", got[0].Hints)
+	}
+	if got[0].CollectedAt.Before(now) {
+		t.Errorf("Expected the recomputed hints to carry a fresh timestamp, got %v", got[0].CollectedAt)
+	}
+	if !reflect.DeepEqual(got[1].Hints, freshHints) {
+		t.Errorf("Expected the fresh hints to be kept as they are, got %v", got[1].Hints)
+	}
+}
+
+func TestIsPodAligned(t *testing.T) {
+	tcases := []struct {
+		name            string
+		hints           podTopologyHints
+		podUID          string
+		expectedAligned bool
+		expectedTracked bool
+	}{
+		{
+			name:            "pod not tracked by the scope",
+			hints:           podTopologyHints{},
+			podUID:          "fakePod",
+			expectedAligned: false,
+			expectedTracked: false,
+		},
+		{
+			name: "all the containers admitted with a preferred hint",
+			hints: podTopologyHints{
+				"fakePod": {
+					"container1": TopologyHint{Preferred: true},
+					"container2": TopologyHint{Preferred: true},
+				},
+			},
+			podUID:          "fakePod",
+			expectedAligned: true,
+			expectedTracked: true,
+		},
+		{
+			name: "one container admitted with a non-preferred hint",
+			hints: podTopologyHints{
+				"fakePod": {
+					"container1": TopologyHint{Preferred: true},
+					"container2": TopologyHint{Preferred: false},
+				},
+			},
+			podUID:          "fakePod",
+			expectedAligned: false,
+			expectedTracked: true,
+		},
+	}
+	for _, tc := range tcases {
+		scope := scope{
+			podTopologyHints: tc.hints,
+		}
+		aligned, tracked := scope.IsPodAligned(tc.podUID)
+		if aligned != tc.expectedAligned || tracked != tc.expectedTracked {
+			t.Errorf("%v: expected aligned=%t tracked=%t, got aligned=%t tracked=%t", tc.name, tc.expectedAligned, tc.expectedTracked, aligned, tracked)
+		}
+	}
+}
@@ -18,11 +18,14 @@ package topologymanager
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	cadvisorapi "github.com/google/cadvisor/info/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager"
+	"k8s.io/kubernetes/pkg/kubelet/checkpointmanager/errors"
 	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
 	"k8s.io/kubernetes/pkg/kubelet/lifecycle"
 	"k8s.io/kubernetes/pkg/kubelet/metrics"
@@ -32,20 +35,31 @@ const (
 	// maxAllowableNUMANodes specifies the maximum number of NUMA Nodes that
 	// the TopologyManager supports on the underlying machine.
 	//
-	// At present, having more than this number of NUMA Nodes will result in a
-	// state explosion when trying to enumerate possible NUMAAffinity masks and
-	// generate hints for them. As such, if more NUMA Nodes than this are
-	// present on a machine and the TopologyManager is enabled, an error will
-	// be returned and the TopologyManager will not be loaded.
-	maxAllowableNUMANodes = 8
+	// The affinity masks grow with the number of NUMA nodes and the hint
+	// permutation generator prunes dead branches early, so SNC-enabled and
+	// multi-socket machines with 16+ NUMA domains are handled fine. The cap
+	// only guards against pathological topologies, where enumerating the
+	// possible NUMAAffinity masks for a single provider would still result in
+	// a state explosion. If more NUMA Nodes than this are present on a
+	// machine and the TopologyManager is enabled, an error will be returned
+	// and the TopologyManager will not be loaded.
+	maxAllowableNUMANodes = 64
 	// ErrorTopologyAffinity represents the type for a TopologyAffinityError
 	ErrorTopologyAffinity = "TopologyAffinityError"
 )
 
 // TopologyAffinityError represents an resource alignment error
-type TopologyAffinityError struct{}
+type TopologyAffinityError struct {
+	// Resources names the resources for which a hint provider returned zero
+	// possible NUMA affinities, if any. For these resources no placement can
+	// satisfy the request, regardless of the policy.
+	Resources []string
+}
 
 func (e TopologyAffinityError) Error() string {
+	if len(e.Resources) > 0 {
+		return fmt.Sprintf("Resources cannot be allocated with Topology locality: no possible NUMA affinity for %s", strings.Join(e.Resources, ", "))
+	}
 	return "Resources cannot be allocated with Topology locality"
 }
 
@@ -53,6 +67,11 @@ func (e TopologyAffinityError) Type() string {
 	return ErrorTopologyAffinity
 }
 
+// RemediationHint suggests how to resolve the alignment failure.
+func (e TopologyAffinityError) RemediationHint() string {
+	return "verify that the requested resources are all available from a single NUMA node, or relax the topology manager policy or scope"
+}
+
 // Manager interface provides methods for Kubelet to manage pod topology hints
 type Manager interface {
 	// PodAdmitHandler is implemented by Manager
@@ -60,10 +79,25 @@ type Manager interface {
 	// AddHintProvider adds a hint provider to manager to indicate the hint provider
 	// wants to be consulted with when making topology hints
 	AddHintProvider(HintProvider)
+	// RemoveHintProvider deregisters a previously added hint provider, so it
+	// is no longer consulted during admission. Providers which register after
+	// kubelet startup (e.g. DRA drivers) must deregister when they go away.
+	RemoveHintProvider(HintProvider)
 	// AddContainer adds pod to Manager for tracking
 	AddContainer(pod *v1.Pod, container *v1.Container, containerID string)
 	// RemoveContainer removes pod from Manager tracking
 	RemoveContainer(containerID string) error
+	// IsPodAligned returns whether all the containers of the given pod were
+	// admitted with a preferred hint, and whether the manager tracks the
+	// pod at all
+	IsPodAligned(podUID string) (aligned bool, tracked bool)
+	// DryRunAdmit computes the hint merge outcome the manager would produce
+	// if the given pod was admitted now, without recording hints or
+	// allocating resources
+	DryRunAdmit(pod *v1.Pod) []ContainerAdmissionPrediction
+	// ConfigSnapshot returns a JSON-serializable copy of the effective,
+	// post-defaulting configuration the manager is running with.
+	ConfigSnapshot() interface{}
 	// Store is the interface for storing pod topology hints
 	Store
 }
@@ -71,6 +105,9 @@ type Manager interface {
 type manager struct {
 	//Topology Manager Scope
 	scope Scope
+	// checkpointManager persists the hint assignments across kubelet
+	// restarts. It is nil when persistence is disabled.
+	checkpointManager checkpointmanager.CheckpointManager
 }
 
 // HintProvider is an interface for components that want to collaborate to
@@ -106,6 +143,26 @@ type TopologyHint struct {
 	// Preferred is set to true when the NUMANodeAffinity encodes a preferred
 	// allocation for the Container. It is set to false otherwise.
 	Preferred bool
+	// Attributes optionally carries provider-specific details about the
+	// allocation behind the hint, such as a device model or a link type.
+	// The topology manager does not interpret the attributes: they pass
+	// opaquely through the merge into the hint the providers read back
+	// through the Store, so a provider can act on richer signals without a
+	// topology manager change per attribute. Providers should prefix their
+	// keys to avoid clashes; on a clash the first merged value wins.
+	Attributes map[string]string
+}
+
+// ContainerAdmissionPrediction is the outcome of a dry-run admission for a
+// single container: the affinity the hint merge would choose and whether the
+// policy would accept it. No resources are allocated to produce it.
+type ContainerAdmissionPrediction struct {
+	// ContainerName is the name of the container within the evaluated pod.
+	ContainerName string
+	// Affinity is the hint the merge would select for the container.
+	Affinity TopologyHint
+	// Admit is whether the policy would admit the container with Affinity.
+	Admit bool
 }
 
 // IsEqual checks if TopologyHint are equal
@@ -131,8 +188,31 @@ func (th *TopologyHint) LessThan(other TopologyHint) bool {
 
 var _ Manager = &manager{}
 
-// NewManager creates a new TopologyManager based on provided policy and scope
-func NewManager(topology []cadvisorapi.Node, topologyPolicyName string, topologyScopeName string, topologyPolicyOptions map[string]string) (Manager, error) {
+// newPolicyByName creates one of the known topology manager policies from its
+// user-facing name.
+func newPolicyByName(policyName string, numaInfo *NUMAInfo, opts PolicyOptions) (Policy, error) {
+	switch policyName {
+
+	case PolicyBestEffort:
+		return NewBestEffortPolicy(numaInfo, opts), nil
+
+	case PolicyRestricted:
+		return NewRestrictedPolicy(numaInfo, opts), nil
+
+	case PolicySingleNumaNode:
+		return NewSingleNumaNodePolicy(numaInfo, opts), nil
+
+	case PolicySingleNumaPreferred:
+		return NewSingleNumaPreferredPolicy(numaInfo, opts), nil
+	}
+	return nil, fmt.Errorf("unknown policy: \"%s\"", policyName)
+}
+
+// NewManager creates a new TopologyManager based on provided policy and scope.
+// The hint assignments are checkpointed under stateFileDirectory and restored
+// on the next kubelet startup; an empty stateFileDirectory disables the
+// persistence.
+func NewManager(topology []cadvisorapi.Node, topologyPolicyName string, topologyScopeName string, topologyPolicyOptions map[string]string, stateFileDirectory string) (Manager, error) {
 	// When policy is none, the scope is not relevant, so we can short circuit here.
 	if topologyPolicyName == PolicyNone {
 		klog.InfoS("Creating topology manager with none policy")
@@ -155,20 +235,17 @@ func NewManager(topology []cadvisorapi.Node, topologyPolicyName string, topology
 		return nil, fmt.Errorf("unsupported on machines with more than %v NUMA Nodes", maxAllowableNUMANodes)
 	}
 
-	var policy Policy
-	switch topologyPolicyName {
-
-	case PolicyBestEffort:
-		policy = NewBestEffortPolicy(numaInfo, opts)
-
-	case PolicyRestricted:
-		policy = NewRestrictedPolicy(numaInfo, opts)
-
-	case PolicySingleNumaNode:
-		policy = NewSingleNumaNodePolicy(numaInfo, opts)
+	policy, err := newPolicyByName(topologyPolicyName, numaInfo, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		return nil, fmt.Errorf("unknown policy: \"%s\"", topologyPolicyName)
+	if opts.ShadowPolicyName != "" {
+		shadow, err := newPolicyByName(opts.ShadowPolicyName, numaInfo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create the shadow policy: %w", err)
+		}
+		policy = NewShadowPolicy(policy, shadow)
 	}
 
 	var scope Scope
@@ -180,6 +257,9 @@ func NewManager(topology []cadvisorapi.Node, topologyPolicyName string, topology
 	case podTopologyScope:
 		scope = NewPodScope(policy)
 
+	case dieTopologyScope:
+		scope = NewDieScope(policy, dieNUMAAffinities(topology))
+
 	default:
 		return nil, fmt.Errorf("unknown scope: \"%s\"", topologyScopeName)
 	}
@@ -188,9 +268,69 @@ func NewManager(topology []cadvisorapi.Node, topologyPolicyName string, topology
 		scope: scope,
 	}
 
+	if stateFileDirectory != "" {
+		manager.checkpointManager, err = checkpointmanager.NewCheckpointManager(stateFileDirectory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize checkpoint manager: %w", err)
+		}
+		if err := manager.restoreCheckpoint(); err != nil {
+			return nil, err
+		}
+	}
+
 	return manager, nil
 }
 
+// restoreCheckpoint seeds the scope with the hint assignments persisted by a
+// previous kubelet run, so the affinity decisions for still-running containers
+// survive a restart. A checkpoint taken under a different policy or scope is
+// discarded: the hints are not comparable across configurations, and the
+// containers will get fresh hints as they are admitted again.
+func (m *manager) restoreCheckpoint() error {
+	checkpoint := newTopologyManagerCheckpoint()
+
+	// Try the v1 format first: a checkpoint taken before the hints carried
+	// attributes fails the checksum verification of the current format, but
+	// its hint assignments are still perfectly good.
+	checkpointV1 := &topologyManagerCheckpointV1{}
+	if err := m.checkpointManager.GetCheckpoint(checkpointName, checkpointV1); err == nil {
+		migrateV1Checkpoint(checkpointV1, checkpoint)
+	} else if err := m.checkpointManager.GetCheckpoint(checkpointName, checkpoint); err != nil {
+		if err == errors.ErrCheckpointNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to restore the topology manager checkpoint: %w", err)
+	}
+
+	if checkpoint.PolicyName != m.scope.GetPolicy().Name() || checkpoint.Scope != m.scope.Name() {
+		klog.InfoS("Discarding the topology manager checkpoint taken under a different configuration", "checkpointPolicy", checkpoint.PolicyName, "checkpointScope", checkpoint.Scope, "policy", m.scope.GetPolicy().Name(), "scope", m.scope.Name())
+		return nil
+	}
+
+	hints, err := topologyHintsFromCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+	m.scope.restoreTopologyHints(hints)
+	return nil
+}
+
+// syncCheckpoint persists the current hint assignments. The whole set is
+// rewritten every time, so the hints of removed pods are garbage collected
+// along the way. Errors are logged and not propagated: failing to persist
+// must not fail an admission, the hints simply will not survive the next
+// restart.
+func (m *manager) syncCheckpoint() {
+	if m.checkpointManager == nil {
+		return
+	}
+
+	checkpoint := checkpointFromTopologyHints(m.scope.GetPolicy().Name(), m.scope.Name(), m.scope.topologyHintsSnapshot())
+	if err := m.checkpointManager.CreateCheckpoint(checkpointName, checkpoint); err != nil {
+		klog.ErrorS(err, "Failed to checkpoint the topology manager hints")
+	}
+}
+
 func (m *manager) GetAffinity(podUID string, containerName string) TopologyHint {
 	return m.scope.GetAffinity(podUID, containerName)
 }
@@ -199,16 +339,43 @@ func (m *manager) GetPolicy() Policy {
 	return m.scope.GetPolicy()
 }
 
+// ConfigSnapshot returns the effective configuration the manager is running
+// with: the policy and scope picked at startup.
+func (m *manager) ConfigSnapshot() interface{} {
+	return struct {
+		PolicyName string `json:"policyName"`
+		Scope      string `json:"scope"`
+	}{
+		PolicyName: m.scope.GetPolicy().Name(),
+		Scope:      m.scope.Name(),
+	}
+}
+
 func (m *manager) AddHintProvider(h HintProvider) {
 	m.scope.AddHintProvider(h)
 }
 
+func (m *manager) RemoveHintProvider(h HintProvider) {
+	m.scope.RemoveHintProvider(h)
+}
+
 func (m *manager) AddContainer(pod *v1.Pod, container *v1.Container, containerID string) {
 	m.scope.AddContainer(pod, container, containerID)
 }
 
 func (m *manager) RemoveContainer(containerID string) error {
-	return m.scope.RemoveContainer(containerID)
+	err := m.scope.RemoveContainer(containerID)
+	m.syncCheckpoint()
+	return err
+}
+
+func (m *manager) IsPodAligned(podUID string) (bool, bool) {
+	return m.scope.IsPodAligned(podUID)
+}
+
+func (m *manager) DryRunAdmit(pod *v1.Pod) []ContainerAdmissionPrediction {
+	klog.InfoS("Topology DryRunAdmit", "podUID", pod.UID, "podNamespace", pod.Namespace, "podName", pod.Name)
+	return m.scope.DryRunAdmit(pod)
 }
 
 func (m *manager) Admit(attrs *lifecycle.PodAdmitAttributes) lifecycle.PodAdmitResult {
@@ -219,5 +386,9 @@ func (m *manager) Admit(attrs *lifecycle.PodAdmitAttributes) lifecycle.PodAdmitR
 	podAdmitResult := m.scope.Admit(attrs.Pod)
 	metrics.TopologyManagerAdmissionDuration.Observe(float64(time.Since(startTime).Milliseconds()))
 
+	if podAdmitResult.Admit {
+		m.syncCheckpoint()
+	}
+
 	return podAdmitResult
 }
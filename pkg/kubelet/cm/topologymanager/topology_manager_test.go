@@ -18,6 +18,9 @@ package topologymanager
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -34,7 +37,21 @@ func NewTestBitMask(sockets ...int) bitmask.BitMask {
 	return s
 }
 
+func testNUMANodes(count int) []cadvisorapi.Node {
+	nodes := make([]cadvisorapi.Node, count)
+	for i := range nodes {
+		nodes[i] = cadvisorapi.Node{Id: i}
+	}
+	return nodes
+}
+
 func TestNewManager(t *testing.T) {
+	// Keep the sysfs distance fallback away from the distance matrix of the
+	// machine running the tests.
+	oldSysNodeDir := sysNodeDir
+	sysNodeDir = t.TempDir()
+	defer func() { sysNodeDir = oldSysNodeDir }()
+
 	tcases := []struct {
 		description    string
 		policyName     string
@@ -94,7 +111,7 @@ func TestNewManager(t *testing.T) {
 			policyOptions: map[string]string{
 				PreferClosestNUMANodes: "true",
 			},
-			expectedError: fmt.Errorf("error getting NUMA distances from cadvisor"),
+			expectedError: fmt.Errorf("error getting NUMA distances for node 0"),
 			topology: []cadvisorapi.Node{
 				{
 					Id: 0,
@@ -102,46 +119,24 @@ func TestNewManager(t *testing.T) {
 			},
 		},
 		{
-			description:    "more than 8 NUMA nodes",
+			description:    "16 NUMA nodes",
+			policyName:     "best-effort",
+			expectedPolicy: "best-effort",
+			topology:       testNUMANodes(16),
+		},
+		{
+			description:    "more NUMA nodes than supported",
 			policyName:     "best-effort",
 			expectedPolicy: "best-effort",
 			expectedError:  fmt.Errorf("unsupported on machines with more than %v NUMA Nodes", maxAllowableNUMANodes),
-			topology: []cadvisorapi.Node{
-				{
-					Id: 0,
-				},
-				{
-					Id: 1,
-				},
-				{
-					Id: 2,
-				},
-				{
-					Id: 3,
-				},
-				{
-					Id: 4,
-				},
-				{
-					Id: 5,
-				},
-				{
-					Id: 6,
-				},
-				{
-					Id: 7,
-				},
-				{
-					Id: 8,
-				},
-			},
+			topology:       testNUMANodes(maxAllowableNUMANodes + 1),
 		},
 	}
 
 	for _, tc := range tcases {
 		topology := tc.topology
 
-		mngr, err := NewManager(topology, tc.policyName, "container", tc.policyOptions)
+		mngr, err := NewManager(topology, tc.policyName, "container", tc.policyOptions, "")
 		if tc.expectedError != nil {
 			if !strings.Contains(err.Error(), tc.expectedError.Error()) {
 				t.Errorf("Unexpected error message. Have: %s wants %s", err.Error(), tc.expectedError.Error())
@@ -178,6 +173,11 @@ func TestManagerScope(t *testing.T) {
 			scopeName:     "pod",
 			expectedScope: "pod",
 		},
+		{
+			description:   "Topology Manager Scope is set to die",
+			scopeName:     "die",
+			expectedScope: "die",
+		},
 		{
 			description:   "Topology Manager Scope is set to unknown",
 			scopeName:     "unknown",
@@ -186,7 +186,7 @@ func TestManagerScope(t *testing.T) {
 	}
 
 	for _, tc := range tcases {
-		mngr, err := NewManager(nil, "best-effort", tc.scopeName, nil)
+		mngr, err := NewManager(nil, "best-effort", tc.scopeName, nil, "")
 
 		if tc.expectedError != nil {
 			if !strings.Contains(err.Error(), tc.expectedError.Error()) {
@@ -201,6 +201,113 @@ func TestManagerScope(t *testing.T) {
 	}
 }
 
+func TestManagerCheckpoint(t *testing.T) {
+	stateDir := t.TempDir()
+
+	hints := podTopologyHints{
+		"fakePod1": {
+			"fakeContainer1": TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true, Attributes: map[string]string{"vendor.example.com/model": "fancy"}},
+			"fakeContainer2": TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+		},
+	}
+
+	mngr, err := NewManager(nil, "best-effort", "container", nil, stateDir)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the manager: %v", err)
+	}
+	mngr.(*manager).scope.restoreTopologyHints(hints)
+	mngr.(*manager).syncCheckpoint()
+
+	// A manager created over the checkpoint with the same configuration
+	// restores the hint assignments.
+	mngr, err = NewManager(nil, "best-effort", "container", nil, stateDir)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the manager: %v", err)
+	}
+	for podUID, containers := range hints {
+		for containerName, hint := range containers {
+			restored := mngr.GetAffinity(podUID, containerName)
+			if !restored.IsEqual(hint) {
+				t.Errorf("Unexpected hint restored for pod %q container %q. Have: %v wants %v", podUID, containerName, restored, hint)
+			}
+			if !reflect.DeepEqual(restored.Attributes, hint.Attributes) {
+				t.Errorf("Unexpected attributes restored for pod %q container %q. Have: %v wants %v", podUID, containerName, restored.Attributes, hint.Attributes)
+			}
+		}
+	}
+
+	// A checkpoint taken under a different policy is discarded.
+	otherMngr, err := NewManager(nil, "restricted", "container", nil, stateDir)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the manager: %v", err)
+	}
+	if restored := otherMngr.GetAffinity("fakePod1", "fakeContainer1"); !restored.IsEqual(TopologyHint{}) {
+		t.Errorf("Expected no hint restored under a different policy, have: %v", restored)
+	}
+
+	// Removing the last container of a pod garbage collects its hints from
+	// the checkpoint as well.
+	pod := v1.Pod{}
+	pod.UID = "fakePod1"
+	for _, containerName := range []string{"fakeContainer1", "fakeContainer2"} {
+		container := v1.Container{}
+		container.Name = containerName
+		mngr.AddContainer(&pod, &container, "id-"+containerName)
+	}
+	for _, containerName := range []string{"fakeContainer1", "fakeContainer2"} {
+		if err := mngr.RemoveContainer("id-" + containerName); err != nil {
+			t.Fatalf("Unexpected error removing the container: %v", err)
+		}
+	}
+
+	mngr, err = NewManager(nil, "best-effort", "container", nil, stateDir)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the manager: %v", err)
+	}
+	if restored := mngr.GetAffinity("fakePod1", "fakeContainer1"); !restored.IsEqual(TopologyHint{}) {
+		t.Errorf("Expected the hints to be garbage collected, have: %v", restored)
+	}
+}
+
+func TestManagerCheckpointV1(t *testing.T) {
+	// A verbatim checkpoint written before the hints carried attributes,
+	// checksum included. It must keep restoring, so the affinity decisions
+	// for running containers survive a kubelet upgrade.
+	blobV1 := `{"policyName":"best-effort","scope":"container","entries":{"fakePod1":{"fakeContainer1":{"numaNodeAffinity":[0],"preferred":true},"fakeContainer2":{"numaNodeAffinity":[0,1],"preferred":false}}},"checksum":737848005}`
+
+	stateDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(stateDir, checkpointName), []byte(blobV1), 0644); err != nil {
+		t.Fatalf("Unexpected error writing the v1 checkpoint: %v", err)
+	}
+
+	mngr, err := NewManager(nil, "best-effort", "container", nil, stateDir)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the manager: %v", err)
+	}
+
+	expectedHints := podTopologyHints{
+		"fakePod1": {
+			"fakeContainer1": TopologyHint{NUMANodeAffinity: NewTestBitMask(0), Preferred: true},
+			"fakeContainer2": TopologyHint{NUMANodeAffinity: NewTestBitMask(0, 1), Preferred: false},
+		},
+	}
+	for podUID, containers := range expectedHints {
+		for containerName, hint := range containers {
+			restored := mngr.GetAffinity(podUID, containerName)
+			if !restored.IsEqual(hint) {
+				t.Errorf("Unexpected hint restored for pod %q container %q. Have: %v wants %v", podUID, containerName, restored, hint)
+			}
+		}
+	}
+
+	// The next sync rewrites the checkpoint in the current format.
+	mngr.(*manager).syncCheckpoint()
+	checkpoint := newTopologyManagerCheckpoint()
+	if err := mngr.(*manager).checkpointManager.GetCheckpoint(checkpointName, checkpoint); err != nil {
+		t.Fatalf("Unexpected error reading back the migrated checkpoint: %v", err)
+	}
+}
+
 type mockHintProvider struct {
 	th map[string][]TopologyHint
 	//TODO: Add this field and add some tests to make sure things error out
@@ -223,10 +330,10 @@ func (m *mockHintProvider) Allocate(pod *v1.Pod, container *v1.Container) error
 
 type mockPolicy struct {
 	nonePolicy
-	ph []map[string][]TopologyHint
+	ph []ProviderHints
 }
 
-func (p *mockPolicy) Merge(providersHints []map[string][]TopologyHint) (TopologyHint, bool) {
+func (p *mockPolicy) Merge(providersHints []ProviderHints) (TopologyHint, bool) {
 	p.ph = providersHints
 	return TopologyHint{}, true
 }
@@ -257,6 +364,36 @@ func TestAddHintProvider(t *testing.T) {
 	}
 }
 
+func TestRemoveHintProvider(t *testing.T) {
+	hp := []HintProvider{
+		&mockHintProvider{},
+		&mockHintProvider{},
+		&mockHintProvider{},
+	}
+	mngr := manager{}
+	mngr.scope = NewContainerScope(NewNonePolicy())
+	for _, h := range hp {
+		mngr.AddHintProvider(h)
+	}
+
+	mngr.RemoveHintProvider(hp[1])
+	registered := mngr.scope.(*containerScope).hintProviders
+	if len(registered) != 2 {
+		t.Errorf("expected 2 registered hint providers, got %d", len(registered))
+	}
+	for _, h := range registered {
+		if h == hp[1] {
+			t.Errorf("expected hint provider %v to be deregistered", h)
+		}
+	}
+
+	// Removing a provider which was never registered must leave the others untouched.
+	mngr.RemoveHintProvider(&mockHintProvider{})
+	if registered := mngr.scope.(*containerScope).hintProviders; len(registered) != 2 {
+		t.Errorf("expected 2 registered hint providers, got %d", len(registered))
+	}
+}
+
 func TestAdmit(t *testing.T) {
 	numaInfo := &NUMAInfo{
 		Nodes: []int{0, 1},
@@ -76,6 +76,10 @@ const (
 	FailedMountOnFilesystemMismatch      = "FailedMountOnFilesystemMismatch"
 	FailedPrepareDynamicResources        = "FailedPrepareDynamicResources"
 	PossibleMemoryBackedVolumesOnDisk    = "PossibleMemoryBackedVolumesOnDisk"
+	SlowDevicePluginAllocation           = "SlowDevicePluginAllocation"
+	DeviceAllocatableChanged             = "DeviceAllocatableChanged"
+	CPUManagerOnlineCPUsMismatch         = "CPUManagerOnlineCPUsMismatch"
+	ResourcesAligned                     = "ResourcesAligned"
 )
 
 // Image manager event reason list
@@ -115,7 +115,6 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/util"
 	"k8s.io/kubernetes/pkg/kubelet/util/manager"
 	"k8s.io/kubernetes/pkg/kubelet/util/queue"
-	"k8s.io/kubernetes/pkg/kubelet/util/sliceutils"
 	"k8s.io/kubernetes/pkg/kubelet/volumemanager"
 	httpprobe "k8s.io/kubernetes/pkg/probe/http"
 	"k8s.io/kubernetes/pkg/security/apparmor"
@@ -2550,7 +2549,11 @@ func handleProbeSync(kl *Kubelet, update proberesults.Update, handler SyncHandle
 // a config source.
 func (kl *Kubelet) HandlePodAdditions(pods []*v1.Pod) {
 	start := kl.clock.Now()
-	sort.Sort(sliceutils.PodsByCreationTime(pods))
+	// Static pods go through admission first: the resource managers hand out
+	// exclusive resources at admission time, and on startup the static pods
+	// must get their checkpointed assignments back before regular pods can
+	// claim them.
+	cm.SortPodsByAdmissionOrder(pods)
 	if utilfeature.DefaultFeatureGate.Enabled(features.InPlacePodVerticalScaling) {
 		kl.podResizeMutex.Lock()
 		defer kl.podResizeMutex.Unlock()
@@ -2972,14 +2975,17 @@ func (kl *Kubelet) ListenAndServePodResources() {
 	}
 
 	providers := podresources.PodResourcesProviders{
-		Pods:             kl.podManager,
-		Devices:          kl.containerManager,
-		Cpus:             kl.containerManager,
-		Memory:           kl.containerManager,
-		DynamicResources: kl.containerManager,
+		Pods:              kl.podManager,
+		Devices:           kl.containerManager,
+		Cpus:              kl.containerManager,
+		Memory:            kl.containerManager,
+		DynamicResources:  kl.containerManager,
+		TopologyAdmission: kl.containerManager,
 	}
 
-	server.ListenAndServePodResources(endpoint, providers)
+	// No authorizer is configured by default: access to the socket is gated
+	// by its filesystem permissions.
+	server.ListenAndServePodResources(endpoint, providers, nil)
 }
 
 // Delete the eligible dead container instances in a pod. Depending on the configuration, the latest dead containers may be kept around.
@@ -1850,6 +1850,13 @@ func (kl *Kubelet) generateAPIPodStatus(pod *v1.Pod, podStatus *kubecontainer.Po
 	if utilfeature.DefaultFeatureGate.Enabled(features.PodReadyToStartContainersCondition) {
 		s.Conditions = append(s.Conditions, status.GeneratePodReadyToStartContainersCondition(pod, podStatus))
 	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.PodTopologyAlignedCondition) {
+		// the topology manager does not track pods admitted with the none
+		// policy, in which case the condition is intentionally not set.
+		if aligned, tracked := kl.containerManager.PodTopologyAligned(string(pod.UID)); tracked {
+			s.Conditions = append(s.Conditions, status.GenerateTopologyAlignedCondition(aligned))
+		}
+	}
 	allContainerStatuses := append(s.InitContainerStatuses, s.ContainerStatuses...)
 	s.Conditions = append(s.Conditions, status.GeneratePodInitializedCondition(&pod.Spec, allContainerStatuses, s.Phase))
 	s.Conditions = append(s.Conditions, status.GeneratePodReadyCondition(&pod.Spec, s.Conditions, allContainerStatuses, s.Phase))
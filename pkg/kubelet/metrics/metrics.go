@@ -78,8 +78,12 @@ const (
 	RuntimeOperationsDurationKey = "runtime_operations_duration_seconds"
 	RuntimeOperationsErrorsKey   = "runtime_operations_errors_total"
 	// Metrics keys of device plugin operations
-	DevicePluginRegistrationCountKey  = "device_plugin_registration_total"
-	DevicePluginAllocationDurationKey = "device_plugin_alloc_duration_seconds"
+	DevicePluginRegistrationCountKey                 = "device_plugin_registration_total"
+	DevicePluginAllocationDurationKey                = "device_plugin_alloc_duration_seconds"
+	DevicePluginGetPreferredAllocationDurationKey    = "device_plugin_get_preferred_allocation_duration_seconds"
+	DevicePluginListAndWatchUpdateProcessDurationKey = "device_plugin_list_and_watch_update_process_duration_seconds"
+	DevicePluginCheckpointPrunedEntriesKey           = "device_plugin_checkpoint_pruned_entries_total"
+	DevicePluginAllocatableDevicesKey                = "device_plugin_allocatable_devices"
 	// Metrics keys of pod resources operations
 	PodResourcesEndpointRequestsTotalKey          = "pod_resources_endpoint_requests_total"
 	PodResourcesEndpointRequestsListKey           = "pod_resources_endpoint_requests_list"
@@ -88,6 +92,8 @@ const (
 	PodResourcesEndpointErrorsGetAllocatableKey   = "pod_resources_endpoint_errors_get_allocatable"
 	PodResourcesEndpointRequestsGetKey            = "pod_resources_endpoint_requests_get"
 	PodResourcesEndpointErrorsGetKey              = "pod_resources_endpoint_errors_get"
+	PodResourcesEndpointRequestsWatchKey          = "pod_resources_endpoint_requests_watch"
+	PodResourcesEndpointRequestsDryRunKey         = "pod_resources_endpoint_requests_dry_run_admission"
 
 	// Metrics keys for RuntimeClass
 	RunPodSandboxDurationKey = "run_podsandbox_duration_seconds"
@@ -107,17 +113,33 @@ const (
 	ManagedEphemeralContainersKey = "managed_ephemeral_containers"
 
 	// Metrics to track the CPU manager behavior
-	CPUManagerPinningRequestsTotalKey = "cpu_manager_pinning_requests_total"
-	CPUManagerPinningErrorsTotalKey   = "cpu_manager_pinning_errors_total"
+	CPUManagerPinningRequestsTotalKey   = "cpu_manager_pinning_requests_total"
+	CPUManagerPinningErrorsTotalKey     = "cpu_manager_pinning_errors_total"
+	CPUManagerOnlineCheckErrorsTotalKey = "cpu_manager_online_check_errors_total"
+	CPUManagerSharedPoolNUMACPUsKey     = "cpu_manager_shared_pool_numa_cpus"
+	CPUManagerSharedPoolUncoreCPUsKey   = "cpu_manager_shared_pool_uncore_cache_cpus"
+	CPUManagerExclusiveCPUsCountKey     = "cpu_manager_exclusive_cpus_count"
 
 	// Metrics to track the Memory manager behavior
 	MemoryManagerPinningRequestsTotalKey = "memory_manager_pinning_requests_total"
 	MemoryManagerPinningErrorsTotalKey   = "memory_manager_pinning_errors_total"
+	MemoryManagerCpusetMemsDriftTotalKey = "memory_manager_cpuset_mems_drift_total"
 
 	// Metrics to track the Topology manager behavior
-	TopologyManagerAdmissionRequestsTotalKey = "topology_manager_admission_requests_total"
-	TopologyManagerAdmissionErrorsTotalKey   = "topology_manager_admission_errors_total"
-	TopologyManagerAdmissionDurationKey      = "topology_manager_admission_duration_ms"
+	TopologyManagerAdmissionRequestsTotalKey         = "topology_manager_admission_requests_total"
+	TopologyManagerAdmissionErrorsTotalKey           = "topology_manager_admission_errors_total"
+	TopologyManagerAdmissionDurationKey              = "topology_manager_admission_duration_ms"
+	TopologyManagerAdmissionPhaseDurationKey         = "topology_manager_admission_phase_duration_seconds"
+	TopologyManagerNonPreferredAdmissionsTotalKey    = "topology_manager_non_preferred_admissions_total"
+	TopologyManagerHintProvidersKey                  = "topology_manager_hint_providers"
+	TopologyManagerRealignmentPossibleTotalKey       = "topology_manager_realignment_possible_total"
+	TopologyManagerShadowPolicyDivergencesTotalKey   = "topology_manager_shadow_policy_divergences_total"
+	TopologyManagerSingleNUMAPreferredStrictTotalKey = "topology_manager_single_numa_preferred_strict_total"
+	TopologyManagerSingleNUMAPreferredSpillsTotalKey = "topology_manager_single_numa_preferred_spills_total"
+	TopologyManagerZeroHintResourcesTotalKey         = "topology_manager_zero_hint_resources_total"
+
+	// Metric to track the cross-consistency of the resource managers state
+	ContainerManagerStartupInconsistenciesTotalKey = "container_manager_startup_inconsistencies_total"
 
 	// Metrics to track orphan pod cleanup
 	orphanPodCleanedVolumesKey       = "orphan_pod_cleaned_volumes"
@@ -130,6 +152,14 @@ const (
 	Container          = "container"
 	InitContainer      = "init_container"
 	EphemeralContainer = "ephemeral_container"
+
+	// Values used in the phase label of TopologyManagerAdmissionPhaseDuration
+	AdmissionPhaseHintGeneration = "hint-generation"
+	AdmissionPhaseMerge          = "merge"
+	AdmissionPhaseCPUAlloc       = "cpu-alloc"
+	AdmissionPhaseMemoryAlloc    = "memory-alloc"
+	AdmissionPhaseDeviceAlloc    = "device-alloc"
+	AdmissionPhaseCgroupWrite    = "cgroup-write"
 )
 
 type imageSizeBucket struct {
@@ -456,6 +486,52 @@ var (
 		},
 		[]string{"resource_name"},
 	)
+	// DevicePluginGetPreferredAllocationDuration is a Histogram that tracks the duration (in seconds) to serve a device plugin GetPreferredAllocation request.
+	// Broken down by resource name.
+	DevicePluginGetPreferredAllocationDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           DevicePluginGetPreferredAllocationDurationKey,
+			Help:           "Duration in seconds to serve a device plugin GetPreferredAllocation request. Broken down by resource name.",
+			Buckets:        metrics.DefBuckets,
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource_name"},
+	)
+	// DevicePluginListAndWatchUpdateProcessDuration is a Histogram that tracks the duration (in seconds) to process a device plugin ListAndWatch update.
+	// Broken down by resource name.
+	DevicePluginListAndWatchUpdateProcessDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           DevicePluginListAndWatchUpdateProcessDurationKey,
+			Help:           "Duration in seconds to process a device plugin ListAndWatch update. Broken down by resource name.",
+			Buckets:        metrics.DefBuckets,
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource_name"},
+	)
+	// DevicePluginCheckpointPrunedEntries is a Counter that tracks the cumulative number of stale entries pruned from the device manager checkpoint.
+	// Broken down by the reason the entry went stale.
+	DevicePluginCheckpointPrunedEntries = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           DevicePluginCheckpointPrunedEntriesKey,
+			Help:           "Cumulative number of stale entries pruned from the device manager checkpoint. Broken down by the reason the entry went stale.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"reason"},
+	)
+	// DevicePluginAllocatableDevices is a Gauge that tracks the number of healthy, hence allocatable, devices
+	// reported by each device plugin. Broken down by resource name.
+	DevicePluginAllocatableDevices = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           DevicePluginAllocatableDevicesKey,
+			Help:           "Number of healthy, hence allocatable, devices reported by each device plugin. Broken down by resource name.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource_name"},
+	)
 
 	// PodResourcesEndpointRequestsTotalCount is a Counter that tracks the cumulative number of requests to the PodResource endpoints.
 	// Broken down by server API version.
@@ -541,6 +617,30 @@ var (
 		[]string{"server_api_version"},
 	)
 
+	// PodResourcesEndpointRequestsWatchCount is a Counter that tracks the number of requests to the PodResource Watch() endpoint.
+	// Broken down by server API version.
+	PodResourcesEndpointRequestsWatchCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           PodResourcesEndpointRequestsWatchKey,
+			Help:           "Number of requests to the PodResource Watch endpoint. Broken down by server api version.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"server_api_version"},
+	)
+
+	// PodResourcesEndpointRequestsDryRunCount is a Counter that tracks the number of requests to the PodResource DryRunAdmission() endpoint.
+	// Broken down by server API version.
+	PodResourcesEndpointRequestsDryRunCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           PodResourcesEndpointRequestsDryRunKey,
+			Help:           "Number of requests to the PodResource DryRunAdmission endpoint. Broken down by server api version.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"server_api_version"},
+	)
+
 	// RunPodSandboxDuration is a Histogram that tracks the duration (in seconds) it takes to run Pod Sandbox operations.
 	// Broken down by RuntimeClass.Handler.
 	RunPodSandboxDuration = metrics.NewHistogramVec(
@@ -761,6 +861,52 @@ var (
 		},
 	)
 
+	// CPUManagerOnlineCheckErrorsTotal tracks the number of times the cpu manager state was found
+	// inconsistent with the online CPUs discovered at startup (CPUs vanished or appeared)
+	CPUManagerOnlineCheckErrorsTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           CPUManagerOnlineCheckErrorsTotalKey,
+			Help:           "The number of times the cpu manager accounting was found inconsistent with the online CPU set.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// CPUManagerSharedPoolNUMACPUs tracks, per NUMA node, how many CPUs of that node
+	// currently belong to the shared pool
+	CPUManagerSharedPoolNUMACPUs = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           CPUManagerSharedPoolNUMACPUsKey,
+			Help:           "The number of CPUs in the cpu manager shared pool, broken down by NUMA node.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"numa_node"},
+	)
+
+	// CPUManagerSharedPoolUncoreCPUs tracks, per uncore cache, how many CPUs sharing
+	// that cache currently belong to the shared pool
+	CPUManagerSharedPoolUncoreCPUs = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           CPUManagerSharedPoolUncoreCPUsKey,
+			Help:           "The number of CPUs in the cpu manager shared pool, broken down by uncore cache.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"uncore_cache_id"},
+	)
+
+	// CPUManagerExclusiveCPUsCount tracks how many CPUs are currently exclusively
+	// allocated to containers
+	CPUManagerExclusiveCPUsCount = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           CPUManagerExclusiveCPUsCountKey,
+			Help:           "The number of CPUs exclusively allocated to containers by the cpu manager.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
 	// MemoryManagerPinningRequestTotal tracks the number of times the pod spec required the memory manager to pin memory pages
 	MemoryManagerPinningRequestTotal = metrics.NewCounter(
 		&metrics.CounterOpts{
@@ -780,6 +926,16 @@ var (
 		},
 	)
 
+	// MemoryManagerCpusetMemsDriftTotal tracks the number of times the reconcile loop found that the cpuset.mems applied to a running container drifted from the memory manager assignment and corrected it
+	MemoryManagerCpusetMemsDriftTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           MemoryManagerCpusetMemsDriftTotalKey,
+			Help:           "The number of times a running container cpuset.mems assignment drifted from the memory manager state and was corrected.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
 	// TopologyManagerAdmissionRequestsTotal tracks the number of times the pod spec will cause the topology manager to admit a pod
 	TopologyManagerAdmissionRequestsTotal = metrics.NewCounter(
 		&metrics.CounterOpts{
@@ -811,6 +967,111 @@ var (
 		},
 	)
 
+	// TopologyManagerAdmissionPhaseDuration is a Histogram that tracks the duration (in seconds) of the individual
+	// phases of a Guaranteed pod admission, so a regression in any one resource manager can be told apart.
+	TopologyManagerAdmissionPhaseDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerAdmissionPhaseDurationKey,
+			Help:           "Duration in seconds of the individual phases (hint generation, merge, per-manager allocation, cgroup write) of a Guaranteed pod admission. Broken down by phase.",
+			Buckets:        metrics.DefBuckets,
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"phase"},
+	)
+
+	// TopologyManagerHintProviders tracks the number of hint providers currently registered
+	// with the topology manager, as providers may come and go at runtime
+	TopologyManagerHintProviders = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerHintProvidersKey,
+			Help:           "The number of hint providers currently registered with the topology manager.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// TopologyManagerNonPreferredAdmissionsTotal tracks the number of times a pod was admitted
+	// despite only a non-preferred resource alignment being available
+	TopologyManagerNonPreferredAdmissionsTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerNonPreferredAdmissionsTotalKey,
+			Help:           "The number of admission requests which were satisfied with a non-preferred resource alignment.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// TopologyManagerRealignmentPossibleTotal tracks the number of times capacity freed up
+	// while a pod admitted with a non-preferred alignment was still running, thus a better
+	// alignment became possibly available for that pod
+	TopologyManagerRealignmentPossibleTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerRealignmentPossibleTotalKey,
+			Help:           "The number of times a better resource alignment possibly became available for a pod admitted with a non-preferred alignment.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// TopologyManagerShadowPolicyDivergencesTotal tracks the number of times the policy
+	// evaluated in shadow mode made a merge decision different from the active policy one
+	TopologyManagerShadowPolicyDivergencesTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerShadowPolicyDivergencesTotalKey,
+			Help:           "The number of times the topology manager policy evaluated in shadow mode diverged from the active policy decision.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// TopologyManagerSingleNUMAPreferredStrictTotal tracks the number of admissions under
+	// the single-numa-preferred policy which got a strict single NUMA node alignment
+	TopologyManagerSingleNUMAPreferredStrictTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerSingleNUMAPreferredStrictTotalKey,
+			Help:           "The number of admissions under the single-numa-preferred topology manager policy satisfied by a single NUMA node.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// TopologyManagerSingleNUMAPreferredSpillsTotal tracks the number of admissions under
+	// the single-numa-preferred policy which had to spill over more than one NUMA node
+	TopologyManagerSingleNUMAPreferredSpillsTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerSingleNUMAPreferredSpillsTotalKey,
+			Help:           "The number of admissions under the single-numa-preferred topology manager policy which spilled over multiple NUMA nodes.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// TopologyManagerZeroHintResourcesTotal tracks, per resource, the number of admissions
+	// during which a hint provider returned zero possible NUMA affinities for that resource,
+	// meaning the request could not be satisfied on any NUMA node
+	TopologyManagerZeroHintResourcesTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           TopologyManagerZeroHintResourcesTotalKey,
+			Help:           "The number of admission requests during which a resource had no possible NUMA affinity, broken down by resource name.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+
+	// ContainerManagerStartupInconsistenciesTotal tracks the number of discrepancies found at
+	// kubelet startup between the state the resource managers recovered from their checkpoints,
+	// e.g. a container with exclusive CPUs outside its recorded topology affinity
+	ContainerManagerStartupInconsistenciesTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           ContainerManagerStartupInconsistenciesTotalKey,
+			Help:           "The number of discrepancies between the state of the resource managers found at kubelet startup.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
 	// OrphanPodCleanedVolumes is number of orphaned Pods that times that removeOrphanedPodVolumeDirs was called during the last sweep.
 	OrphanPodCleanedVolumes = metrics.NewGauge(
 		&metrics.GaugeOpts{
@@ -946,6 +1207,10 @@ func Register(collectors ...metrics.StableCollector) {
 		legacyregistry.MustRegister(Preemptions)
 		legacyregistry.MustRegister(DevicePluginRegistrationCount)
 		legacyregistry.MustRegister(DevicePluginAllocationDuration)
+		legacyregistry.MustRegister(DevicePluginGetPreferredAllocationDuration)
+		legacyregistry.MustRegister(DevicePluginListAndWatchUpdateProcessDuration)
+		legacyregistry.MustRegister(DevicePluginCheckpointPrunedEntries)
+		legacyregistry.MustRegister(DevicePluginAllocatableDevices)
 		legacyregistry.MustRegister(RunningContainerCount)
 		legacyregistry.MustRegister(RunningPodCount)
 		legacyregistry.MustRegister(DesiredPodCount)
@@ -960,6 +1225,8 @@ func Register(collectors ...metrics.StableCollector) {
 		legacyregistry.MustRegister(PodResourcesEndpointRequestsGetAllocatableCount)
 		legacyregistry.MustRegister(PodResourcesEndpointErrorsListCount)
 		legacyregistry.MustRegister(PodResourcesEndpointErrorsGetAllocatableCount)
+		legacyregistry.MustRegister(PodResourcesEndpointRequestsWatchCount)
+		legacyregistry.MustRegister(PodResourcesEndpointRequestsDryRunCount)
 		if utilfeature.DefaultFeatureGate.Enabled(features.KubeletPodResourcesGet) {
 			legacyregistry.MustRegister(PodResourcesEndpointRequestsGetCount)
 			legacyregistry.MustRegister(PodResourcesEndpointErrorsGetCount)
@@ -974,13 +1241,27 @@ func Register(collectors ...metrics.StableCollector) {
 		legacyregistry.MustRegister(RunPodSandboxErrors)
 		legacyregistry.MustRegister(CPUManagerPinningRequestsTotal)
 		legacyregistry.MustRegister(CPUManagerPinningErrorsTotal)
+		legacyregistry.MustRegister(CPUManagerOnlineCheckErrorsTotal)
+		legacyregistry.MustRegister(CPUManagerSharedPoolNUMACPUs)
+		legacyregistry.MustRegister(CPUManagerSharedPoolUncoreCPUs)
+		legacyregistry.MustRegister(CPUManagerExclusiveCPUsCount)
 		if utilfeature.DefaultFeatureGate.Enabled(features.MemoryManager) {
 			legacyregistry.MustRegister(MemoryManagerPinningRequestTotal)
 			legacyregistry.MustRegister(MemoryManagerPinningErrorsTotal)
+			legacyregistry.MustRegister(MemoryManagerCpusetMemsDriftTotal)
 		}
 		legacyregistry.MustRegister(TopologyManagerAdmissionRequestsTotal)
 		legacyregistry.MustRegister(TopologyManagerAdmissionErrorsTotal)
 		legacyregistry.MustRegister(TopologyManagerAdmissionDuration)
+		legacyregistry.MustRegister(TopologyManagerAdmissionPhaseDuration)
+		legacyregistry.MustRegister(TopologyManagerHintProviders)
+		legacyregistry.MustRegister(TopologyManagerNonPreferredAdmissionsTotal)
+		legacyregistry.MustRegister(TopologyManagerRealignmentPossibleTotal)
+		legacyregistry.MustRegister(TopologyManagerShadowPolicyDivergencesTotal)
+		legacyregistry.MustRegister(TopologyManagerSingleNUMAPreferredStrictTotal)
+		legacyregistry.MustRegister(TopologyManagerSingleNUMAPreferredSpillsTotal)
+		legacyregistry.MustRegister(TopologyManagerZeroHintResourcesTotal)
+		legacyregistry.MustRegister(ContainerManagerStartupInconsistenciesTotal)
 		legacyregistry.MustRegister(OrphanPodCleanedVolumes)
 		legacyregistry.MustRegister(OrphanPodCleanedVolumesErrors)
 
@@ -39,6 +39,7 @@ import (
 	"k8s.io/component-base/version"
 	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
 	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
 	"k8s.io/kubernetes/pkg/kubelet/cadvisor"
 	"k8s.io/kubernetes/pkg/kubelet/cm"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
@@ -263,6 +264,7 @@ func MachineInfo(nodeName string,
 	machineInfoFunc func() (*cadvisorapiv1.MachineInfo, error), // typically Kubelet.GetCachedMachineInfo
 	capacityFunc func(localStorageCapacityIsolation bool) v1.ResourceList, // typically Kubelet.containerManager.GetCapacity
 	devicePluginResourceCapacityFunc func() (v1.ResourceList, v1.ResourceList, []string), // typically Kubelet.containerManager.GetDevicePluginResourceCapacity
+	resourceManagersCapacityFunc func() v1.ResourceList, // typically Kubelet.containerManager.GetResourceManagersCapacity
 	nodeAllocatableReservationFunc func() v1.ResourceList, // typically Kubelet.containerManager.GetNodeAllocatableReservation
 	recordEventFunc func(eventType, event, message string), // typically Kubelet.recordEvent
 	localStorageCapacityIsolation bool,
@@ -343,6 +345,13 @@ func MachineInfo(nodeName string,
 				// node status.
 				node.Status.Capacity[v1.ResourceName(removedResource)] = *resource.NewQuantity(int64(0), resource.DecimalSI)
 			}
+
+			for k, v := range resourceManagersCapacityFunc() {
+				if old, ok := node.Status.Capacity[k]; !ok || old.Value() != v.Value() {
+					klog.V(2).InfoS("Updated capacity for resource manager resource", "resource", k, "capacity", v.Value())
+				}
+				node.Status.Capacity[k] = v
+			}
 		}
 
 		// Set Allocatable.
@@ -502,6 +511,27 @@ func RuntimeHandlers(fn func() []kubecontainer.RuntimeHandler) Setter {
 	}
 }
 
+// NodeResourceTopology returns a Setter that publishes the per-zone resource
+// accounting of the resource managers as a node annotation, so topology-aware
+// consumers can predict whether topology manager admission would succeed on
+// the node. The annotation is removed when the node performs no resource
+// alignment.
+func NodeResourceTopology(topologyFunc func() *noderesourcetopology.NodeResourceTopology) Setter { // typically Kubelet.containerManager.GetNodeResourceTopology
+	return func(ctx context.Context, node *v1.Node) error {
+		topology := topologyFunc()
+		if topology == nil {
+			delete(node.Annotations, noderesourcetopology.AnnotationKey)
+			return nil
+		}
+		value, err := noderesourcetopology.Encode(topology)
+		if err != nil {
+			return fmt.Errorf("error encoding the node resource topology annotation: %w", err)
+		}
+		metav1.SetMetaDataAnnotation(&node.ObjectMeta, noderesourcetopology.AnnotationKey, value)
+		return nil
+	}
+}
+
 // ReadyCondition returns a Setter that updates the v1.NodeReady condition on the node.
 func ReadyCondition(
 	nowFunc func() time.Time, // typically Kubelet.clock.Now
@@ -43,6 +43,7 @@ import (
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/component-base/version"
 	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
 	"k8s.io/kubernetes/pkg/kubelet/cm"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	kubecontainertest "k8s.io/kubernetes/pkg/kubelet/container/testing"
@@ -782,6 +783,7 @@ func TestMachineInfo(t *testing.T) {
 		machineInfoError                     error
 		capacity                             v1.ResourceList
 		devicePluginResourceCapacity         dprc
+		resourceManagersCapacity             v1.ResourceList
 		nodeAllocatableReservation           v1.ResourceList
 		expectNode                           *v1.Node
 		expectEvents                         []testEvent
@@ -1080,6 +1082,34 @@ func TestMachineInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:    "resource manager resources are reflected in capacity and allocatable",
+			node:    &v1.Node{},
+			maxPods: 110,
+			machineInfo: &cadvisorapiv1.MachineInfo{
+				NumCores:       2,
+				MemoryCapacity: 1024,
+			},
+			resourceManagersCapacity: v1.ResourceList{
+				"kubelet.kubernetes.io/physical-cores-free": *resource.NewQuantity(1, resource.DecimalSI),
+			},
+			expectNode: &v1.Node{
+				Status: v1.NodeStatus{
+					Capacity: v1.ResourceList{
+						v1.ResourceCPU:    *resource.NewMilliQuantity(2000, resource.DecimalSI),
+						v1.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI),
+						v1.ResourcePods:   *resource.NewQuantity(110, resource.DecimalSI),
+						"kubelet.kubernetes.io/physical-cores-free": *resource.NewQuantity(1, resource.DecimalSI),
+					},
+					Allocatable: v1.ResourceList{
+						v1.ResourceCPU:    *resource.NewMilliQuantity(2000, resource.DecimalSI),
+						v1.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI),
+						v1.ResourcePods:   *resource.NewQuantity(110, resource.DecimalSI),
+						"kubelet.kubernetes.io/physical-cores-free": *resource.NewQuantity(1, resource.DecimalSI),
+					},
+				},
+			},
+		},
 		{
 			desc: "inactive device plugin resources should have their capacity set to 0",
 			node: &v1.Node{
@@ -1220,6 +1250,9 @@ func TestMachineInfo(t *testing.T) {
 				c := tc.devicePluginResourceCapacity
 				return c.capacity, c.allocatable, c.inactive
 			}
+			resourceManagersCapacityFunc := func() v1.ResourceList {
+				return tc.resourceManagersCapacity
+			}
 			nodeAllocatableReservationFunc := func() v1.ResourceList {
 				return tc.nodeAllocatableReservation
 			}
@@ -1234,7 +1267,7 @@ func TestMachineInfo(t *testing.T) {
 			}
 			// construct setter
 			setter := MachineInfo(nodeName, tc.maxPods, tc.podsPerCore, machineInfoFunc, capacityFunc,
-				devicePluginResourceCapacityFunc, nodeAllocatableReservationFunc, recordEventFunc, tc.disableLocalStorageCapacityIsolation)
+				devicePluginResourceCapacityFunc, resourceManagersCapacityFunc, nodeAllocatableReservationFunc, recordEventFunc, tc.disableLocalStorageCapacityIsolation)
 			// call setter on node
 			if err := setter(ctx, tc.node); err != nil {
 				t.Fatalf("unexpected error: %v", err)
@@ -2117,6 +2150,65 @@ func TestDaemonEndpoints(t *testing.T) {
 	}
 }
 
+func TestNodeResourceTopology(t *testing.T) {
+	topology := &noderesourcetopology.NodeResourceTopology{
+		TopologyPolicy: noderesourcetopology.PolicySingleNumaNode,
+		TopologyScope:  noderesourcetopology.ScopeContainer,
+		Zones: []noderesourcetopology.Zone{
+			{
+				Name: "numa-0",
+				Type: noderesourcetopology.ZoneTypeNUMA,
+				Resources: map[string]noderesourcetopology.ResourceInfo{
+					"cpu": {Allocatable: 4, Available: 2},
+				},
+			},
+		},
+	}
+	expectedValue, err := noderesourcetopology.Encode(topology)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name     string
+		topology *noderesourcetopology.NodeResourceTopology
+		existing map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "topology published",
+			topology: topology,
+			expected: map[string]string{noderesourcetopology.AnnotationKey: expectedValue},
+		},
+		{
+			name:     "no topology, no annotation",
+			topology: nil,
+			expected: nil,
+		},
+		{
+			name:     "no topology removes a stale annotation",
+			topology: nil,
+			existing: map[string]string{noderesourcetopology.AnnotationKey: "stale"},
+			expected: map[string]string{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			existingNode := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        testKubeletHostname,
+					Annotations: test.existing,
+				},
+			}
+
+			setter := NodeResourceTopology(func() *noderesourcetopology.NodeResourceTopology { return test.topology })
+			if err := setter(ctx, existingNode); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expected, existingNode.Annotations)
+		})
+	}
+}
+
 // Test Helpers:
 
 // testEvent is used to record events for tests
@@ -220,9 +220,21 @@ func ListenAndServeKubeletReadOnlyServer(
 	}
 }
 
-// ListenAndServePodResources initializes a gRPC server to serve the PodResources service
-func ListenAndServePodResources(endpoint string, providers podresources.PodResourcesProviders) {
-	server := grpc.NewServer(apisgrpc.WithRateLimiter("podresources", podresources.DefaultQPS, podresources.DefaultBurstTokens))
+// ListenAndServePodResources initializes a gRPC server to serve the PodResources service.
+// A nil authz disables request authorization: access to the socket is then only
+// gated by its filesystem permissions.
+func ListenAndServePodResources(endpoint string, providers podresources.PodResourcesProviders, authz apisgrpc.Authorizer) {
+	opts := []grpc.ServerOption{
+		// report the peer process identity, consumed by the authorizer and
+		// by the per-peer rate limiter.
+		grpc.Creds(apisgrpc.PeerCredentials()),
+		apisgrpc.WithRateLimiter("podresources", podresources.DefaultQPS, podresources.DefaultBurstTokens),
+		apisgrpc.WithPerPeerRateLimiter("podresources", podresources.DefaultPerPeerQPS, podresources.DefaultPerPeerBurstTokens),
+	}
+	if authz != nil {
+		opts = append(opts, apisgrpc.WithAuthorizer("podresources", authz))
+	}
+	server := grpc.NewServer(opts...)
 
 	podresourcesapiv1alpha1.RegisterPodResourcesListerServer(server, podresources.NewV1alpha1PodResourcesServer(providers))
 	podresourcesapi.RegisterPodResourcesListerServer(server, podresources.NewV1PodResourcesServer(providers))
@@ -40,6 +40,12 @@ const (
 	ContainersNotInitialized = "ContainersNotInitialized"
 	// ReadinessGatesNotReady says that one or more pod readiness gates are not ready.
 	ReadinessGatesNotReady = "ReadinessGatesNotReady"
+	// PreferredAlignment says that the topology manager admitted the pod with a
+	// preferred resource alignment.
+	PreferredAlignment = "PreferredAlignment"
+	// NonPreferredAlignment says that the topology manager admitted the pod, but
+	// with a non-preferred resource alignment.
+	NonPreferredAlignment = "NonPreferredAlignment"
 )
 
 // GenerateContainersReadyCondition returns the status of "ContainersReady" condition.
@@ -257,6 +263,26 @@ func GeneratePodReadyToStartContainersCondition(pod *v1.Pod, podStatus *kubecont
 	}
 }
 
+// GenerateTopologyAlignedCondition returns the status of the "TopologyAligned"
+// condition from the merge decision the topology manager recorded at admission
+// time, so workloads and controllers can detect a degraded alignment without
+// parsing events.
+func GenerateTopologyAlignedCondition(aligned bool) v1.PodCondition {
+	if aligned {
+		return v1.PodCondition{
+			Type:   kubetypes.TopologyAligned,
+			Status: v1.ConditionTrue,
+			Reason: PreferredAlignment,
+		}
+	}
+	return v1.PodCondition{
+		Type:    kubetypes.TopologyAligned,
+		Status:  v1.ConditionFalse,
+		Reason:  NonPreferredAlignment,
+		Message: "Pod was admitted with a non-preferred resource alignment",
+	}
+}
+
 func generateContainersReadyConditionForTerminalPhase(podPhase v1.PodPhase) v1.PodCondition {
 	condition := v1.PodCondition{
 		Type:   v1.ContainersReady,
@@ -26,6 +26,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/utils/pointer"
 )
 
@@ -622,6 +623,36 @@ func TestGeneratePodReadyToStartContainersCondition(t *testing.T) {
 	}
 }
 
+func TestGenerateTopologyAlignedCondition(t *testing.T) {
+	for desc, test := range map[string]struct {
+		aligned  bool
+		expected v1.PodCondition
+	}{
+		"Pod admitted with a preferred alignment": {
+			aligned: true,
+			expected: v1.PodCondition{
+				Status: v1.ConditionTrue,
+				Reason: PreferredAlignment,
+			},
+		},
+		"Pod admitted with a non-preferred alignment": {
+			aligned: false,
+			expected: v1.PodCondition{
+				Status: v1.ConditionFalse,
+				Reason: NonPreferredAlignment,
+			},
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			test.expected.Type = kubetypes.TopologyAligned
+			condition := GenerateTopologyAlignedCondition(test.aligned)
+			require.Equal(t, test.expected.Type, condition.Type)
+			require.Equal(t, test.expected.Status, condition.Status)
+			require.Equal(t, test.expected.Reason, condition.Reason)
+		})
+	}
+}
+
 func getPodCondition(conditionType v1.PodConditionType, status v1.ConditionStatus, reason, message string) v1.PodCondition {
 	return v1.PodCondition{
 		Type:    conditionType,
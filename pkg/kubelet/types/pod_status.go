@@ -22,6 +22,12 @@ import (
 	"k8s.io/kubernetes/pkg/features"
 )
 
+// TopologyAligned is the pod condition reporting whether the topology manager
+// admitted the pod with a preferred resource alignment. It is set by the
+// kubelet when the PodTopologyAlignedCondition feature gate is enabled and
+// the topology manager tracks the pod.
+const TopologyAligned v1.PodConditionType = "TopologyAligned"
+
 // PodConditionsByKubelet is the list of pod conditions owned by kubelet
 var PodConditionsByKubelet = []v1.PodConditionType{
 	v1.PodScheduled,
@@ -42,6 +48,11 @@ func PodConditionByKubelet(conditionType v1.PodConditionType) bool {
 			return true
 		}
 	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.PodTopologyAlignedCondition) {
+		if conditionType == TopologyAligned {
+			return true
+		}
+	}
 	return false
 }
 
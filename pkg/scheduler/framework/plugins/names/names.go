@@ -28,6 +28,7 @@ const (
 	NodePorts                       = "NodePorts"
 	NodeResourcesBalancedAllocation = "NodeResourcesBalancedAllocation"
 	NodeResourcesFit                = "NodeResourcesFit"
+	NodeResourceTopologyMatch       = "NodeResourceTopologyMatch"
 	NodeUnschedulable               = "NodeUnschedulable"
 	NodeVolumeLimits                = "NodeVolumeLimits"
 	AzureDiskLimits                 = "AzureDiskLimits"
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderesourcetopology
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
+	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
+	nrt "k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/names"
+)
+
+// NodeResourceTopologyMatch is a plugin that filters out nodes whose topology
+// manager would refuse the pod, predicted from the per-zone resource
+// accounting the kubelet publishes in the node annotation, and that scores the
+// remaining nodes by the headroom left in their best-fitting zone. Nodes not
+// publishing the annotation, or running a topology manager policy other than
+// single-numa-node, are left alone: the plugin never knows better than the
+// node itself.
+type NodeResourceTopologyMatch struct {
+	handle framework.Handle
+}
+
+var _ framework.FilterPlugin = &NodeResourceTopologyMatch{}
+var _ framework.ScorePlugin = &NodeResourceTopologyMatch{}
+var _ framework.EnqueueExtensions = &NodeResourceTopologyMatch{}
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = names.NodeResourceTopologyMatch
+
+	// ErrReasonAlignment returned when no NUMA zone of the node can satisfy
+	// the aligned resource requests of the pod.
+	ErrReasonAlignment = "node(s) cannot align the pod resources to a single NUMA zone"
+)
+
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *NodeResourceTopologyMatch) EventsToRegister() []framework.ClusterEventWithHint {
+	return []framework.ClusterEventWithHint{
+		// The zone accounting travels with the node object, so any node
+		// update may carry fresh availability.
+		{Event: framework.ClusterEvent{Resource: framework.Node, ActionType: framework.Add | framework.Update}},
+	}
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *NodeResourceTopologyMatch) Name() string {
+	return Name
+}
+
+// Filter invoked at the filter extension point.
+func (pl *NodeResourceTopologyMatch) Filter(ctx context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	topology := nodeTopology(nodeInfo.Node())
+	if topology == nil {
+		return nil
+	}
+	requests := alignedContainerRequests(pod)
+	if len(requests) == 0 {
+		return nil
+	}
+	for _, containerRequests := range requestsByScope(topology.TopologyScope, requests) {
+		if len(fittingZones(topology, containerRequests)) == 0 {
+			return framework.NewStatus(framework.Unschedulable, ErrReasonAlignment)
+		}
+	}
+	return nil
+}
+
+// Score invoked at the score extension point. Nodes keeping more headroom in
+// their best-fitting NUMA zone score higher, so aligned pods spread away from
+// the nodes where alignment is about to become impossible.
+func (pl *NodeResourceTopologyMatch) Score(ctx context.Context, _ *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("getting node %q from Snapshot: %w", nodeName, err))
+	}
+	topology := nodeTopology(nodeInfo.Node())
+	if topology == nil {
+		return 0, nil
+	}
+	requests := alignedContainerRequests(pod)
+	if len(requests) == 0 {
+		return 0, nil
+	}
+	headroom := 1.0
+	for _, containerRequests := range requestsByScope(topology.TopologyScope, requests) {
+		best := 0.0
+		for zoneName := range fittingZones(topology, containerRequests) {
+			if frac := zoneHeadroom(topology, zoneName, containerRequests); frac > best {
+				best = frac
+			}
+		}
+		if best < headroom {
+			headroom = best
+		}
+	}
+	return int64(float64(framework.MaxNodeScore) * headroom), nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *NodeResourceTopologyMatch) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// nodeTopology decodes the topology annotation of the node, returning nil -
+// meaning the plugin has nothing to say about the node - when the annotation
+// is absent, malformed or describes a policy other than single-numa-node.
+func nodeTopology(node *v1.Node) *nrt.NodeResourceTopology {
+	value, ok := node.Annotations[nrt.AnnotationKey]
+	if !ok {
+		return nil
+	}
+	topology, err := nrt.Decode(value)
+	if err != nil {
+		klog.V(4).InfoS("Ignoring undecodable node resource topology annotation", "node", klog.KObj(node), "err", err)
+		return nil
+	}
+	if topology.TopologyPolicy != nrt.PolicySingleNumaNode {
+		return nil
+	}
+	return topology
+}
+
+// alignedContainerRequests returns, per container (init containers first),
+// the requests the topology manager aligns under the single-numa-node policy:
+// whole CPUs, memory and hugepages bytes for guaranteed pods with integer CPU
+// requests, plus device plugin resources for any pod. An empty result means
+// the topology manager would not restrict the pod.
+func alignedContainerRequests(pod *v1.Pod) []map[string]int64 {
+	guaranteed := v1qos.GetPodQOS(pod) == v1.PodQOSGuaranteed
+	var requests []map[string]int64
+	containers := append([]v1.Container{}, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, container := range containers {
+		aligned := map[string]int64{}
+		for resourceName, quantity := range container.Resources.Requests {
+			if quantity.IsZero() {
+				continue
+			}
+			switch {
+			case v1helper.IsExtendedResourceName(resourceName):
+				aligned[string(resourceName)] = quantity.Value()
+			case !guaranteed:
+				// Only guaranteed pods get exclusive CPUs and memory.
+			case resourceName == v1.ResourceCPU:
+				if quantity.Value()*1000 == quantity.MilliValue() {
+					aligned[string(resourceName)] = quantity.Value()
+				}
+			case resourceName == v1.ResourceMemory, v1helper.IsHugePageResourceName(resourceName):
+				aligned[string(resourceName)] = quantity.Value()
+			}
+		}
+		if len(aligned) > 0 {
+			requests = append(requests, aligned)
+		}
+	}
+	return requests
+}
+
+// requestsByScope reshapes the per-container requests the way the topology
+// manager consumes them: per container under the container scope, folded into
+// a single aggregate under the pod scope.
+func requestsByScope(scope string, requests []map[string]int64) []map[string]int64 {
+	if scope != nrt.ScopePod {
+		return requests
+	}
+	aggregated := map[string]int64{}
+	for _, containerRequests := range requests {
+		for resourceName, amount := range containerRequests {
+			aggregated[resourceName] += amount
+		}
+	}
+	return []map[string]int64{aggregated}
+}
+
+// fittingZones returns the names of the NUMA zones whose availability covers
+// every requested resource.
+func fittingZones(topology *nrt.NodeResourceTopology, requests map[string]int64) map[string]bool {
+	fitting := map[string]bool{}
+	for _, zone := range topology.Zones {
+		if zone.Type != nrt.ZoneTypeNUMA {
+			continue
+		}
+		fits := true
+		for resourceName, amount := range requests {
+			if zone.Resources[resourceName].Available < amount {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			fitting[zone.Name] = true
+		}
+	}
+	return fitting
+}
+
+// zoneHeadroom returns the fraction of the zone left available after placing
+// the requests, taking the scarcest requested resource.
+func zoneHeadroom(topology *nrt.NodeResourceTopology, zoneName string, requests map[string]int64) float64 {
+	headroom := 1.0
+	for _, zone := range topology.Zones {
+		if zone.Name != zoneName {
+			continue
+		}
+		for resourceName, amount := range requests {
+			info := zone.Resources[resourceName]
+			if info.Allocatable == 0 {
+				return 0
+			}
+			if frac := float64(info.Available-amount) / float64(info.Allocatable); frac < headroom {
+				headroom = frac
+			}
+		}
+	}
+	return headroom
+}
+
+// New initializes a new plugin and returns it.
+func New(_ context.Context, _ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &NodeResourceTopologyMatch{handle: h}, nil
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderesourcetopology
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	nrt "k8s.io/kubernetes/pkg/kubelet/apis/noderesourcetopology"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	"k8s.io/kubernetes/pkg/scheduler/internal/cache"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+)
+
+const gpuResourceName = "vendor.example.com/gpu"
+
+// makeGuaranteedPod builds a single-container pod with requests equal to
+// limits, so it lands in the guaranteed QoS class.
+func makeGuaranteedPod(requests v1.ResourceList) *v1.Pod {
+	return &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "cnt",
+					Resources: v1.ResourceRequirements{
+						Requests: requests,
+						Limits:   requests,
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeBurstablePod(requests v1.ResourceList) *v1.Pod {
+	pod := makeGuaranteedPod(requests)
+	pod.Spec.Containers[0].Resources.Limits = nil
+	return pod
+}
+
+// makeNode builds a node carrying the given topology in its annotation, or no
+// annotation at all when the topology is nil.
+func makeNode(name string, topology *nrt.NodeResourceTopology) *v1.Node {
+	node := st.MakeNode().Name(name).Obj()
+	if topology != nil {
+		value, err := nrt.Encode(topology)
+		if err != nil {
+			panic(err)
+		}
+		metav1.SetMetaDataAnnotation(&node.ObjectMeta, nrt.AnnotationKey, value)
+	}
+	return node
+}
+
+// makeTopology builds a single-numa-node topology with two NUMA zones of
+// four allocatable CPUs each, the given CPUs still available per zone, and a
+// single GPU available only on the second zone.
+func makeTopology(scope string, availableCPUs ...int64) *nrt.NodeResourceTopology {
+	topology := &nrt.NodeResourceTopology{
+		TopologyPolicy: nrt.PolicySingleNumaNode,
+		TopologyScope:  scope,
+	}
+	for zone, available := range availableCPUs {
+		topology.Zones = append(topology.Zones, nrt.Zone{
+			Name: nrt.ZoneName(nrt.ZoneTypeNUMA, zone),
+			Type: nrt.ZoneTypeNUMA,
+			Resources: map[string]nrt.ResourceInfo{
+				"cpu":    {Allocatable: 4, Available: available},
+				"memory": {Allocatable: 1024, Available: 1024},
+				gpuResourceName: {
+					Allocatable: 1,
+					Available: func() int64 {
+						if zone == len(availableCPUs)-1 {
+							return 1
+						}
+						return 0
+					}(),
+				},
+			},
+		})
+	}
+	return topology
+}
+
+func TestNodeResourceTopologyMatchFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		node       *v1.Node
+		wantStatus *framework.Status
+	}{
+		{
+			name: "no annotation",
+			pod:  makeGuaranteedPod(v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")}),
+			node: makeNode("node", nil),
+		},
+		{
+			name: "policy other than single-numa-node",
+			pod:  makeGuaranteedPod(v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")}),
+			node: makeNode("node", &nrt.NodeResourceTopology{TopologyPolicy: "best-effort"}),
+		},
+		{
+			name: "burstable pod not restricted",
+			pod:  makeBurstablePod(v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")}),
+			node: makeNode("node", makeTopology(nrt.ScopeContainer, 2, 1)),
+		},
+		{
+			name: "guaranteed fractional CPU not restricted",
+			pod:  makeGuaranteedPod(v1.ResourceList{v1.ResourceCPU: resource.MustParse("1500m")}),
+			node: makeNode("node", makeTopology(nrt.ScopeContainer, 1, 1)),
+		},
+		{
+			name: "guaranteed integer CPU fitting one zone",
+			pod: makeGuaranteedPod(v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("2"),
+				v1.ResourceMemory: resource.MustParse("512"),
+			}),
+			node: makeNode("node", makeTopology(nrt.ScopeContainer, 2, 1)),
+		},
+		{
+			name: "guaranteed integer CPU fitting no zone",
+			pod: makeGuaranteedPod(v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("3"),
+				v1.ResourceMemory: resource.MustParse("512"),
+			}),
+			node:       makeNode("node", makeTopology(nrt.ScopeContainer, 2, 1)),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonAlignment),
+		},
+		{
+			name: "device on a zone without availability",
+			pod: makeBurstablePod(v1.ResourceList{
+				gpuResourceName: resource.MustParse("2"),
+			}),
+			node:       makeNode("node", makeTopology(nrt.ScopeContainer, 4, 4)),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonAlignment),
+		},
+		{
+			name: "pod scope aggregates the containers",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "cnt-1",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+								Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+							},
+						},
+						{
+							Name: "cnt-2",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+								Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+							},
+						},
+					},
+				},
+			},
+			node:       makeNode("node", makeTopology(nrt.ScopePod, 3, 2)),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonAlignment),
+		},
+		{
+			name: "container scope takes the containers one by one",
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "cnt-1",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+								Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+							},
+						},
+						{
+							Name: "cnt-2",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+								Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("256")},
+							},
+						},
+					},
+				},
+			},
+			node: makeNode("node", makeTopology(nrt.ScopeContainer, 3, 2)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(test.node)
+			_, ctx := ktesting.NewTestContext(t)
+			p, err := New(ctx, nil, nil)
+			if err != nil {
+				t.Fatalf("creating plugin: %v", err)
+			}
+			gotStatus := p.(framework.FilterPlugin).Filter(ctx, nil, test.pod, nodeInfo)
+			if !reflect.DeepEqual(gotStatus, test.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, test.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNodeResourceTopologyMatchScore(t *testing.T) {
+	pod := makeGuaranteedPod(v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("1"),
+		v1.ResourceMemory: resource.MustParse("512"),
+	})
+	tests := []struct {
+		name      string
+		node      *v1.Node
+		wantScore int64
+	}{
+		{
+			name:      "no annotation scores zero",
+			node:      makeNode("node", nil),
+			wantScore: 0,
+		},
+		{
+			name: "full zone keeps most of the headroom",
+			// Best zone keeps 3 of 4 CPUs and half of the memory.
+			node:      makeNode("node", makeTopology(nrt.ScopeContainer, 2, 4)),
+			wantScore: 50,
+		},
+		{
+			name:      "nearly exhausted node scores low",
+			node:      makeNode("node", makeTopology(nrt.ScopeContainer, 1, 1)),
+			wantScore: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, ctx := ktesting.NewTestContext(t)
+			snapshot := cache.NewSnapshot(nil, []*v1.Node{test.node})
+			fh, err := runtime.NewFramework(ctx, nil, nil, runtime.WithSnapshotSharedLister(snapshot))
+			if err != nil {
+				t.Fatalf("creating framework: %v", err)
+			}
+			p, err := New(ctx, nil, fh)
+			if err != nil {
+				t.Fatalf("creating plugin: %v", err)
+			}
+			gotScore, gotStatus := p.(framework.ScorePlugin).Score(ctx, nil, pod, test.node.Name)
+			if !gotStatus.IsSuccess() {
+				t.Fatalf("unexpected status: %v", gotStatus)
+			}
+			if gotScore != test.wantScore {
+				t.Errorf("score does not match: %d, want: %d", gotScore, test.wantScore)
+			}
+		})
+	}
+}
@@ -68,12 +68,21 @@ const (
 	// SingleNumaNodeTopologyManagerPolicy is a mode in which kubelet only allows
 	// pods with a single NUMA alignment of CPU and device resources.
 	SingleNumaNodeTopologyManagerPolicy = "single-numa-node"
+	// SingleNumaPreferredTopologyManagerPolicy is a mode in which kubelet favours
+	// a single NUMA alignment of CPU and device resources, but allows pods which
+	// cannot be aligned to a single NUMA node to spill over a small number of
+	// extra nodes instead of rejecting them.
+	SingleNumaPreferredTopologyManagerPolicy = "single-numa-preferred"
 	// ContainerTopologyManagerScope represents that
 	// topology policy is applied on a per-container basis.
 	ContainerTopologyManagerScope = "container"
 	// PodTopologyManagerScope represents that
 	// topology policy is applied on a per-pod basis.
 	PodTopologyManagerScope = "pod"
+	// DieTopologyManagerScope represents that
+	// topology policy is applied on a per-container basis, and the alignment
+	// is additionally kept within a single CPU die whenever possible.
+	DieTopologyManagerScope = "die"
 	// NoneMemoryManagerPolicy is a memory manager none policy, under the none policy
 	// the memory manager will not pin containers memory of guaranteed pods
 	NoneMemoryManagerPolicy = "None"
@@ -81,6 +90,11 @@ const (
 	// the memory manager will try to pin containers memory of guaranteed pods to the smallest
 	// possible sub-set of NUMA nodes
 	StaticMemoryManagerPolicy = "Static"
+	// BestEffortMemoryManagerPolicy is a memory manager best-effort policy, under the
+	// best-effort policy the memory manager will pin containers memory of guaranteed pods
+	// like the static policy does, but admit the pod with unrestricted memory affinity
+	// when the locality cannot be satisfied, instead of failing the admission
+	BestEffortMemoryManagerPolicy = "BestEffort"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -370,6 +384,14 @@ type KubeletConfiguration struct {
 	// Default: "none"
 	// +optional
 	MemoryManagerPolicy string `json:"memoryManagerPolicy,omitempty"`
+	// memoryManagerReconcilePeriod is the reconciliation period for the Memory Manager,
+	// i.e. how often the memory pinning of the running containers is checked and
+	// re-applied. Memory pinning drifts much more slowly than CPU pinning, so this
+	// period may be set higher than cpuManagerReconcilePeriod.
+	// Requires the MemoryManager feature gate to be enabled.
+	// Default: "10s"
+	// +optional
+	MemoryManagerReconcilePeriod metav1.Duration `json:"memoryManagerReconcilePeriod,omitempty"`
 	// topologyManagerPolicy is the name of the topology manager policy to use.
 	// Valid values include:
 	//
@@ -380,6 +402,9 @@ type KubeletConfiguration struct {
 	// - `none`: kubelet has no knowledge of NUMA alignment of a pod's CPU and device resources.
 	// - `single-numa-node`: kubelet only allows pods with a single NUMA alignment
 	//   of CPU and device resources.
+	// - `single-numa-preferred`: kubelet favours a single NUMA alignment of CPU and
+	//   device resources, but allows pods which cannot be aligned to a single NUMA
+	//   node to spill over a small number of extra nodes instead of rejecting them.
 	//
 	// Default: "none"
 	// +optional
@@ -389,6 +414,8 @@ type KubeletConfiguration struct {
 	//
 	// - `container`: topology policy is applied on a per-container basis.
 	// - `pod`: topology policy is applied on a per-pod basis.
+	// - `die`: topology policy is applied on a per-container basis, and the
+	//   alignment is additionally kept within a single CPU die whenever possible.
 	//
 	// Default: "container"
 	// +optional
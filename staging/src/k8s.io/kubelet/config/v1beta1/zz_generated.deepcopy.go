@@ -262,6 +262,7 @@ func (in *KubeletConfiguration) DeepCopyInto(out *KubeletConfiguration) {
 		}
 	}
 	out.CPUManagerReconcilePeriod = in.CPUManagerReconcilePeriod
+	out.MemoryManagerReconcilePeriod = in.MemoryManagerReconcilePeriod
 	if in.TopologyManagerPolicyOptions != nil {
 		in, out := &in.TopologyManagerPolicyOptions, &out.TopologyManagerPolicyOptions
 		*out = make(map[string]string, len(*in))
@@ -261,9 +261,14 @@ func (m *ListAndWatchResponse) GetDevices() []*Device {
 }
 
 type TopologyInfo struct {
-	Nodes                []*NUMANode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Nodes []*NUMANode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Uncore caches (last-level caches) whose CPUs are local to the device.
+	// Optional; plugins which cannot discover it leave the list empty.
+	UncoreCaches []*UncoreCache `protobuf:"bytes,2,rep,name=uncore_caches,json=uncoreCaches,proto3" json:"uncore_caches,omitempty"`
+	// PCIe root complexes the device hangs off. Optional.
+	PcieRootComplexes    []*PCIeRootComplex `protobuf:"bytes,3,rep,name=pcie_root_complexes,json=pcieRootComplexes,proto3" json:"pcie_root_complexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *TopologyInfo) Reset()      { *m = TopologyInfo{} }
@@ -305,6 +310,20 @@ func (m *TopologyInfo) GetNodes() []*NUMANode {
 	return nil
 }
 
+func (m *TopologyInfo) GetUncoreCaches() []*UncoreCache {
+	if m != nil {
+		return m.UncoreCaches
+	}
+	return nil
+}
+
+func (m *TopologyInfo) GetPcieRootComplexes() []*PCIeRootComplex {
+	if m != nil {
+		return m.PcieRootComplexes
+	}
+	return nil
+}
+
 type NUMANode struct {
 	ID                   int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -350,6 +369,96 @@ func (m *NUMANode) GetID() int64 {
 	return 0
 }
 
+type UncoreCache struct {
+	ID                   int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UncoreCache) Reset()      { *m = UncoreCache{} }
+func (*UncoreCache) ProtoMessage() {}
+func (*UncoreCache) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+func (m *UncoreCache) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UncoreCache) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UncoreCache.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UncoreCache) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UncoreCache.Merge(m, src)
+}
+func (m *UncoreCache) XXX_Size() int {
+	return m.Size()
+}
+func (m *UncoreCache) XXX_DiscardUnknown() {
+	xxx_messageInfo_UncoreCache.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UncoreCache proto.InternalMessageInfo
+
+func (m *UncoreCache) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+type PCIeRootComplex struct {
+	ID                   int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PCIeRootComplex) Reset()      { *m = PCIeRootComplex{} }
+func (*PCIeRootComplex) ProtoMessage() {}
+func (*PCIeRootComplex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
+}
+func (m *PCIeRootComplex) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PCIeRootComplex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PCIeRootComplex.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PCIeRootComplex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PCIeRootComplex.Merge(m, src)
+}
+func (m *PCIeRootComplex) XXX_Size() int {
+	return m.Size()
+}
+func (m *PCIeRootComplex) XXX_DiscardUnknown() {
+	xxx_messageInfo_PCIeRootComplex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PCIeRootComplex proto.InternalMessageInfo
+
+func (m *PCIeRootComplex) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
 // E.g:
 //
 //	struct Device {
@@ -1140,6 +1249,8 @@ func init() {
 	proto.RegisterType((*ListAndWatchResponse)(nil), "v1beta1.ListAndWatchResponse")
 	proto.RegisterType((*TopologyInfo)(nil), "v1beta1.TopologyInfo")
 	proto.RegisterType((*NUMANode)(nil), "v1beta1.NUMANode")
+	proto.RegisterType((*UncoreCache)(nil), "v1beta1.UncoreCache")
+	proto.RegisterType((*PCIeRootComplex)(nil), "v1beta1.PCIeRootComplex")
 	proto.RegisterType((*Device)(nil), "v1beta1.Device")
 	proto.RegisterType((*PreStartContainerRequest)(nil), "v1beta1.PreStartContainerRequest")
 	proto.RegisterType((*PreStartContainerResponse)(nil), "v1beta1.PreStartContainerResponse")
@@ -1161,75 +1272,81 @@ func init() {
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 1088 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xef, 0x6e, 0x1b, 0x45,
-	0x10, 0xcf, 0xc5, 0x71, 0x62, 0x8f, 0xd3, 0xfc, 0xd9, 0x84, 0xc8, 0x71, 0x8a, 0x93, 0x6e, 0x0a,
-	0x0d, 0x52, 0x62, 0x13, 0x17, 0xb5, 0x15, 0x1f, 0x10, 0x6e, 0x1c, 0xc0, 0x84, 0xa6, 0xd1, 0x85,
-	0x0a, 0x09, 0x10, 0xa7, 0xf3, 0xdd, 0xc6, 0x3e, 0xe5, 0xbc, 0x7b, 0xdc, 0xae, 0x2d, 0xb9, 0x12,
-	0x12, 0x8f, 0xd0, 0x77, 0x80, 0x57, 0xe0, 0x1d, 0xca, 0x37, 0x3e, 0xf2, 0x09, 0xd1, 0xf0, 0x22,
-	0xc8, 0xbb, 0xb7, 0x77, 0xa7, 0x8b, 0x63, 0xa5, 0x52, 0xbf, 0xdd, 0xce, 0xcc, 0x6f, 0x66, 0xf6,
-	0x37, 0xb3, 0x33, 0x07, 0x45, 0x3b, 0xf0, 0x6a, 0x41, 0xc8, 0x04, 0x43, 0x0b, 0xc3, 0xc3, 0x0e,
-	0x11, 0xf6, 0x61, 0xe5, 0xa0, 0xeb, 0x89, 0xde, 0xa0, 0x53, 0x73, 0x58, 0xbf, 0xde, 0x65, 0x5d,
-	0x56, 0x97, 0xfa, 0xce, 0xe0, 0x42, 0x9e, 0xe4, 0x41, 0x7e, 0x29, 0x1c, 0x7e, 0x65, 0xc0, 0x5a,
-	0x8b, 0x0c, 0x3d, 0x87, 0x9c, 0xf9, 0x83, 0xae, 0x47, 0x9f, 0x07, 0xc2, 0x63, 0x94, 0xa3, 0x7d,
-	0x40, 0x41, 0x48, 0x2c, 0x2e, 0xec, 0x50, 0x58, 0x21, 0xf9, 0x79, 0xe0, 0x85, 0xc4, 0x2d, 0x1b,
-	0x3b, 0xc6, 0x5e, 0xc1, 0x5c, 0x09, 0x42, 0x72, 0x3e, 0x56, 0x98, 0x91, 0x1c, 0x9d, 0x00, 0xee,
-	0x12, 0x61, 0x05, 0x21, 0xb9, 0x20, 0x61, 0x48, 0x5c, 0xcb, 0xf6, 0x7d, 0xe6, 0xd8, 0x63, 0x57,
-	0x96, 0x3d, 0xb4, 0x3d, 0xdf, 0xee, 0xf8, 0xa4, 0x3c, 0x2b, 0xd1, 0xdb, 0x5d, 0x22, 0xce, 0xb4,
-	0x61, 0x33, 0xb6, 0x6b, 0x6a, 0x33, 0xfc, 0xbb, 0x01, 0xcb, 0x26, 0xe9, 0x7a, 0x5c, 0x90, 0x70,
-	0x1c, 0x81, 0x70, 0x81, 0xca, 0xb0, 0x30, 0x24, 0x21, 0xf7, 0x18, 0x95, 0x39, 0x14, 0x4d, 0x7d,
-	0x44, 0x15, 0x28, 0x10, 0xea, 0x06, 0xcc, 0xa3, 0x42, 0x06, 0x28, 0x9a, 0xf1, 0x19, 0xed, 0xc2,
-	0x9d, 0x90, 0x70, 0x36, 0x08, 0x1d, 0x62, 0x51, 0xbb, 0x4f, 0xca, 0x39, 0x69, 0xb0, 0xa8, 0x85,
-	0xa7, 0x76, 0x9f, 0xa0, 0x47, 0xb0, 0xc0, 0xd4, 0xa5, 0xcb, 0x73, 0x3b, 0xc6, 0x5e, 0xa9, 0x71,
-	0xb7, 0x16, 0x71, 0x59, 0x9b, 0x40, 0x8c, 0xa9, 0x8d, 0xf1, 0x02, 0xe4, 0x8f, 0xfb, 0x81, 0x18,
-	0xe1, 0x26, 0xac, 0x7f, 0xe3, 0x71, 0xd1, 0xa4, 0xee, 0x77, 0xb6, 0x70, 0x7a, 0x26, 0xe1, 0x01,
-	0xa3, 0x9c, 0xa0, 0x8f, 0x60, 0xc1, 0x95, 0x0e, 0x78, 0xd9, 0xd8, 0xc9, 0xed, 0x95, 0x1a, 0xcb,
-	0x19, 0xc7, 0xa6, 0xd6, 0xe3, 0xc7, 0xb0, 0xf8, 0x2d, 0x0b, 0x98, 0xcf, 0xba, 0xa3, 0x36, 0xbd,
-	0x60, 0xe8, 0x01, 0xe4, 0x29, 0x73, 0x63, 0xe0, 0x6a, 0x0c, 0x3c, 0x7d, 0xf1, 0xac, 0x79, 0xca,
-	0x5c, 0x62, 0x2a, 0x3d, 0xae, 0x40, 0x41, 0x8b, 0xd0, 0x12, 0xcc, 0xb6, 0x5b, 0x92, 0x9e, 0x9c,
-	0x39, 0xdb, 0x6e, 0x61, 0x07, 0xe6, 0x55, 0x9c, 0x94, 0xa6, 0x38, 0xd6, 0xa0, 0x0d, 0x98, 0xef,
-	0x11, 0xdb, 0x17, 0xbd, 0x88, 0xb1, 0xe8, 0x84, 0x0e, 0xa1, 0x20, 0xa2, 0x34, 0x24, 0x55, 0xa5,
-	0xc6, 0x7b, 0x71, 0xe4, 0x74, 0x7e, 0x66, 0x6c, 0x86, 0x4f, 0xa0, 0x7c, 0x16, 0x75, 0xc3, 0x11,
-	0xa3, 0xc2, 0xf6, 0x68, 0x52, 0xb4, 0x3a, 0x94, 0xa2, 0x0b, 0x5a, 0x9e, 0xab, 0xee, 0x52, 0x7c,
-	0xba, 0x74, 0xf5, 0xcf, 0x36, 0xa8, 0xbc, 0x78, 0xbb, 0xc5, 0x4d, 0x88, 0x4c, 0xda, 0x2e, 0xc7,
-	0x5b, 0xb0, 0x39, 0xc1, 0x99, 0xa2, 0x13, 0x8f, 0xa0, 0x32, 0xa1, 0x6d, 0x74, 0xac, 0x1f, 0x00,
-	0x39, 0x1a, 0x22, 0xfb, 0x95, 0x70, 0xa1, 0xe9, 0xdb, 0x8f, 0x2f, 0x11, 0x7b, 0xbd, 0xd9, 0x93,
-	0xb9, 0xea, 0x64, 0xee, 0xc1, 0xf1, 0x1f, 0x06, 0xec, 0xde, 0x02, 0x8a, 0xea, 0xb0, 0x16, 0x77,
-	0xbb, 0xa5, 0xee, 0xd5, 0x6e, 0x45, 0x17, 0x37, 0x51, 0xac, 0x6a, 0x69, 0x0d, 0xfa, 0x04, 0x36,
-	0xfa, 0x03, 0x2e, 0x2c, 0x8f, 0x3a, 0xfe, 0xc0, 0x4d, 0x63, 0x66, 0x25, 0x66, 0x7d, 0xac, 0x6d,
-	0x2b, 0x65, 0x82, 0x7a, 0x00, 0xcb, 0xa9, 0xf7, 0xc5, 0xbd, 0x97, 0xaa, 0xb1, 0xf3, 0xe6, 0x52,
-	0x22, 0x3e, 0xf7, 0x5e, 0x12, 0xfc, 0x0b, 0x6c, 0x4d, 0xcc, 0x36, 0x6a, 0xd0, 0x9f, 0x60, 0x2d,
-	0xcd, 0x99, 0x92, 0x6a, 0xd2, 0x0e, 0x6e, 0x49, 0x9a, 0x42, 0x99, 0xc8, 0xc9, 0x16, 0x8c, 0xe3,
-	0x16, 0xdc, 0xbf, 0x0d, 0x16, 0xdd, 0x85, 0x62, 0x96, 0xac, 0x44, 0x80, 0x1d, 0x58, 0x8e, 0x30,
-	0x44, 0xf3, 0x7c, 0x36, 0xa5, 0xd8, 0xf7, 0xae, 0xe7, 0x9d, 0x81, 0x4f, 0xaa, 0xf0, 0x09, 0x94,
-	0x6f, 0x32, 0x7f, 0xfb, 0x36, 0xde, 0x86, 0xe2, 0x51, 0xab, 0x1d, 0xbd, 0x3d, 0x04, 0x73, 0x72,
-	0xf4, 0xa8, 0xd7, 0x27, 0xbf, 0x71, 0x17, 0x56, 0x92, 0x20, 0x11, 0x09, 0xe7, 0xd3, 0x8a, 0x81,
-	0xa7, 0x5d, 0x6a, 0x4a, 0x05, 0xfe, 0xcc, 0xc1, 0xe6, 0x8d, 0x08, 0xf4, 0x39, 0xcc, 0x11, 0x3a,
-	0x9c, 0xf2, 0x4a, 0xb2, 0x88, 0xda, 0x31, 0x1d, 0xf2, 0x63, 0x2a, 0xc2, 0x91, 0x29, 0x91, 0xe8,
-	0x43, 0x98, 0xef, 0xb3, 0x01, 0x15, 0xaa, 0x5f, 0x4b, 0x8d, 0xa5, 0xd8, 0xc7, 0xb3, 0xb1, 0xd8,
-	0x8c, 0xb4, 0xe8, 0x20, 0x19, 0x85, 0x39, 0x69, 0xb8, 0x96, 0x19, 0x85, 0xe7, 0x01, 0x71, 0xe2,
-	0x71, 0x88, 0x5e, 0x40, 0xc9, 0xa6, 0x94, 0x09, 0x5b, 0x8f, 0xe5, 0x31, 0xe4, 0xe1, 0x2d, 0xf2,
-	0x6b, 0x26, 0x28, 0x95, 0x66, 0xda, 0x0f, 0x3a, 0x82, 0x92, 0xe3, 0x7a, 0x96, 0xce, 0x24, 0x2f,
-	0xdd, 0xa2, 0xc4, 0xad, 0xae, 0x99, 0x2a, 0x6e, 0x7c, 0xe4, 0x26, 0x38, 0xae, 0x17, 0x7d, 0x57,
-	0x1e, 0x43, 0x31, 0x66, 0x01, 0xad, 0x40, 0xee, 0x92, 0x8c, 0xa2, 0xda, 0x8e, 0x3f, 0xd1, 0x3a,
-	0xe4, 0x87, 0xb6, 0x3f, 0x20, 0xd1, 0x64, 0x55, 0x87, 0x4f, 0x67, 0x9f, 0x18, 0x95, 0xcf, 0x60,
-	0x25, 0x9b, 0xde, 0xdb, 0xe0, 0x71, 0x0f, 0xf2, 0x92, 0x54, 0xf4, 0x01, 0x2c, 0x25, 0x9d, 0x12,
-	0xd8, 0xa2, 0x17, 0xe1, 0xef, 0xc4, 0xd2, 0x33, 0x5b, 0xf4, 0xd0, 0x16, 0x14, 0x7b, 0x8c, 0x0b,
-	0x65, 0x11, 0x6d, 0xc6, 0xb1, 0x40, 0x2b, 0x43, 0x62, 0xbb, 0x16, 0xa3, 0xbe, 0x1a, 0xf5, 0x05,
-	0xb3, 0x30, 0x16, 0x3c, 0xa7, 0xfe, 0x08, 0x87, 0x00, 0x49, 0x55, 0xde, 0x49, 0xb8, 0x1d, 0x28,
-	0x05, 0x24, 0xec, 0x7b, 0x9c, 0xcb, 0x82, 0xaa, 0x35, 0x9c, 0x16, 0x35, 0xbe, 0x80, 0x45, 0xb5,
-	0xf3, 0x43, 0xc9, 0x0f, 0x7a, 0x04, 0x05, 0xfd, 0x0f, 0x80, 0xca, 0x71, 0x89, 0x32, 0xbf, 0x05,
-	0x95, 0xa4, 0xdf, 0xd4, 0x2a, 0x9e, 0x69, 0xfc, 0x96, 0x83, 0xc5, 0xf4, 0xda, 0x46, 0x5f, 0xc1,
-	0xc6, 0x97, 0x44, 0x4c, 0xfa, 0xc5, 0xc9, 0x80, 0x2b, 0x53, 0xf7, 0x3e, 0x9e, 0x41, 0x4d, 0x58,
-	0x4c, 0xef, 0xf9, 0x6b, 0xf8, 0xf7, 0xe3, 0xf3, 0xa4, 0xdf, 0x01, 0x3c, 0xf3, 0xb1, 0x81, 0x88,
-	0x4c, 0x66, 0xc2, 0x2c, 0x44, 0xbb, 0x31, 0xf8, 0xe6, 0xfd, 0x52, 0xb9, 0x3f, 0xdd, 0x48, 0x07,
-	0x42, 0x4d, 0x28, 0xe8, 0xa7, 0x91, 0x22, 0x2f, 0x33, 0xd7, 0x2a, 0x9b, 0x13, 0x34, 0xb1, 0x8b,
-	0x1f, 0x61, 0xf5, 0xda, 0x2a, 0x46, 0xf7, 0xd2, 0xf1, 0x27, 0xee, 0xfc, 0x0a, 0x9e, 0x66, 0xa2,
-	0xbd, 0x3f, 0xfd, 0xfa, 0xf5, 0x9b, 0xaa, 0xf1, 0xf7, 0x9b, 0xea, 0xcc, 0xaf, 0x57, 0x55, 0xe3,
-	0xf5, 0x55, 0xd5, 0xf8, 0xeb, 0xaa, 0x6a, 0xfc, 0x7b, 0x55, 0x35, 0x5e, 0xfd, 0x57, 0x9d, 0xf9,
-	0x7e, 0xff, 0xf2, 0x09, 0xaf, 0x79, 0xac, 0x7e, 0x39, 0xe8, 0x10, 0x9f, 0x88, 0x7a, 0x70, 0xd9,
-	0xad, 0xdb, 0x81, 0xc7, 0xeb, 0xea, 0xe5, 0x06, 0xb2, 0x2e, 0xf5, 0x28, 0x4e, 0x67, 0x5e, 0xfe,
-	0xc8, 0x3e, 0xfc, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xe0, 0x26, 0x52, 0x96, 0x0d, 0x0b, 0x00, 0x00,
+	// 1173 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0x1b, 0xc5,
+	0x17, 0xcf, 0xda, 0x75, 0x62, 0x1f, 0xbb, 0xf9, 0x98, 0xe4, 0x1f, 0x39, 0x4e, 0xeb, 0x24, 0x9b,
+	0xfe, 0x69, 0x90, 0x12, 0x9b, 0xa4, 0xa8, 0x14, 0x2e, 0x10, 0xae, 0x1d, 0xa8, 0x09, 0x4d, 0xad,
+	0x09, 0x11, 0x12, 0x20, 0x56, 0xeb, 0xdd, 0x89, 0xbd, 0xca, 0x7a, 0x67, 0xd9, 0x99, 0xb5, 0x70,
+	0x25, 0x24, 0x1e, 0xa1, 0xef, 0x00, 0xaf, 0xc0, 0x1d, 0x0f, 0x50, 0xee, 0xb8, 0xe4, 0x0a, 0xd1,
+	0xf0, 0x22, 0x68, 0x67, 0xf6, 0x4b, 0x1b, 0xc7, 0x4a, 0x25, 0xee, 0xe6, 0x7c, 0xfc, 0xce, 0x9c,
+	0x39, 0x67, 0xce, 0x07, 0x94, 0x74, 0xd7, 0x6a, 0xb8, 0x1e, 0xe5, 0x14, 0x2d, 0x8c, 0x0f, 0xfb,
+	0x84, 0xeb, 0x87, 0xb5, 0x83, 0x81, 0xc5, 0x87, 0x7e, 0xbf, 0x61, 0xd0, 0x51, 0x73, 0x40, 0x07,
+	0xb4, 0x29, 0xe4, 0x7d, 0xff, 0x42, 0x50, 0x82, 0x10, 0x27, 0x89, 0x53, 0x5f, 0x29, 0xb0, 0xda,
+	0x21, 0x63, 0xcb, 0x20, 0x3d, 0xdb, 0x1f, 0x58, 0xce, 0x0b, 0x97, 0x5b, 0xd4, 0x61, 0x68, 0x1f,
+	0x90, 0xeb, 0x11, 0x8d, 0x71, 0xdd, 0xe3, 0x9a, 0x47, 0xbe, 0xf7, 0x2d, 0x8f, 0x98, 0x55, 0x65,
+	0x5b, 0xd9, 0x2b, 0xe2, 0x65, 0xd7, 0x23, 0x67, 0x81, 0x00, 0x87, 0x7c, 0x74, 0x02, 0xea, 0x80,
+	0x70, 0xcd, 0xf5, 0xc8, 0x05, 0xf1, 0x3c, 0x62, 0x6a, 0xba, 0x6d, 0x53, 0x43, 0x0f, 0x4c, 0x69,
+	0xfa, 0x58, 0xb7, 0x6c, 0xbd, 0x6f, 0x93, 0x6a, 0x4e, 0xa0, 0xb7, 0x06, 0x84, 0xf7, 0x22, 0xc5,
+	0x56, 0xac, 0xd7, 0x8a, 0xd4, 0xd4, 0x5f, 0x14, 0x58, 0xc2, 0x64, 0x60, 0x31, 0x4e, 0xbc, 0xe0,
+	0x06, 0xc2, 0x38, 0xaa, 0xc2, 0xc2, 0x98, 0x78, 0xcc, 0xa2, 0x8e, 0xf0, 0xa1, 0x84, 0x23, 0x12,
+	0xd5, 0xa0, 0x48, 0x1c, 0xd3, 0xa5, 0x96, 0xc3, 0xc5, 0x05, 0x25, 0x1c, 0xd3, 0x68, 0x17, 0xee,
+	0x7a, 0x84, 0x51, 0xdf, 0x33, 0x88, 0xe6, 0xe8, 0x23, 0x52, 0xcd, 0x0b, 0x85, 0x4a, 0xc4, 0x3c,
+	0xd5, 0x47, 0x04, 0x3d, 0x86, 0x05, 0x2a, 0x1f, 0x5d, 0xbd, 0xb3, 0xad, 0xec, 0x95, 0x8f, 0xee,
+	0x35, 0xc2, 0x58, 0x36, 0xa6, 0x04, 0x06, 0x47, 0xca, 0xea, 0x02, 0x14, 0x8e, 0x47, 0x2e, 0x9f,
+	0xa8, 0x2d, 0x58, 0xfb, 0xc2, 0x62, 0xbc, 0xe5, 0x98, 0x5f, 0xe9, 0xdc, 0x18, 0x62, 0xc2, 0x5c,
+	0xea, 0x30, 0x82, 0xde, 0x85, 0x05, 0x53, 0x18, 0x60, 0x55, 0x65, 0x3b, 0xbf, 0x57, 0x3e, 0x5a,
+	0xca, 0x18, 0xc6, 0x91, 0x5c, 0xfd, 0x4d, 0x81, 0xca, 0x97, 0xd4, 0xa5, 0x36, 0x1d, 0x4c, 0xba,
+	0xce, 0x05, 0x45, 0x0f, 0xa1, 0xe0, 0x50, 0x33, 0x46, 0xae, 0xc4, 0xc8, 0xd3, 0xf3, 0xe7, 0xad,
+	0x53, 0x6a, 0x12, 0x2c, 0xe5, 0xe8, 0x43, 0xb8, 0xeb, 0x3b, 0x06, 0xf5, 0x88, 0x66, 0xe8, 0xc6,
+	0x90, 0xb0, 0x6a, 0x4e, 0x00, 0xd6, 0x62, 0xc0, 0xb9, 0x90, 0xb6, 0x03, 0x21, 0xae, 0xf8, 0x09,
+	0xc1, 0xd0, 0x33, 0x58, 0x75, 0x0d, 0x8b, 0x68, 0x1e, 0xa5, 0x5c, 0x33, 0xe8, 0xc8, 0xb5, 0xc9,
+	0x0f, 0x84, 0x55, 0xf3, 0xc2, 0x40, 0x35, 0x36, 0xd0, 0x6b, 0x77, 0x09, 0xa6, 0x94, 0xb7, 0xa5,
+	0x06, 0x5e, 0x09, 0x40, 0x29, 0x06, 0x61, 0x6a, 0x0d, 0x8a, 0x91, 0x5f, 0x68, 0x11, 0x72, 0xdd,
+	0x8e, 0x48, 0x52, 0x1e, 0xe7, 0xba, 0x1d, 0xd5, 0x80, 0x79, 0xf9, 0xda, 0x94, 0xa4, 0x14, 0x48,
+	0xd0, 0x3a, 0xcc, 0x0f, 0x89, 0x6e, 0xf3, 0x61, 0x98, 0xb7, 0x90, 0x42, 0x87, 0x50, 0xe4, 0x61,
+	0x2c, 0x44, 0xc2, 0xca, 0x47, 0xff, 0x8b, 0x9d, 0x49, 0x07, 0x09, 0xc7, 0x6a, 0xea, 0x09, 0x54,
+	0x7b, 0xe1, 0x9f, 0x6c, 0x53, 0x87, 0xeb, 0x96, 0x93, 0x7c, 0x9d, 0x26, 0x94, 0xc3, 0x30, 0x6b,
+	0x96, 0x29, 0x03, 0x5a, 0x7a, 0xba, 0x78, 0xf5, 0xd7, 0x16, 0x48, 0xbf, 0x58, 0xb7, 0xc3, 0x30,
+	0x84, 0x2a, 0x5d, 0x93, 0xa9, 0x9b, 0xb0, 0x31, 0xc5, 0x98, 0x4c, 0xaa, 0x3a, 0x81, 0xda, 0x94,
+	0xcf, 0x1b, 0xdd, 0xf5, 0x0d, 0x20, 0x23, 0x82, 0x88, 0xaa, 0x21, 0x8c, 0x47, 0x39, 0xdc, 0x8f,
+	0x1f, 0x11, 0x5b, 0xbd, 0xd9, 0x12, 0x5e, 0x31, 0x32, 0xef, 0x60, 0xea, 0xaf, 0x0a, 0xec, 0xde,
+	0x02, 0x8a, 0x9a, 0xb0, 0x1a, 0xd7, 0x9c, 0x26, 0xdf, 0xd5, 0xed, 0x84, 0x0f, 0xc7, 0x28, 0x16,
+	0x75, 0x22, 0x09, 0x7a, 0x1f, 0xd6, 0x47, 0x3e, 0xe3, 0x9a, 0xe5, 0x18, 0xb6, 0x6f, 0xa6, 0x31,
+	0x39, 0x81, 0x59, 0x0b, 0xa4, 0x5d, 0x29, 0x4c, 0x50, 0x0f, 0x61, 0x29, 0x55, 0xe5, 0xcc, 0x7a,
+	0x29, 0xcb, 0xab, 0x80, 0x17, 0x13, 0xf6, 0x99, 0xf5, 0x92, 0xa8, 0x3f, 0xc2, 0xe6, 0x54, 0x6f,
+	0xc3, 0x32, 0xf9, 0x0e, 0x56, 0xd3, 0x31, 0x93, 0xdc, 0x28, 0x68, 0x07, 0xb7, 0x0c, 0x9a, 0x44,
+	0x61, 0x64, 0x64, 0x13, 0xc6, 0xd4, 0x0e, 0x3c, 0xb8, 0x0d, 0x16, 0xdd, 0x83, 0x52, 0x36, 0x58,
+	0x09, 0x43, 0x35, 0x60, 0x29, 0xc4, 0x90, 0x28, 0xce, 0xbd, 0x19, 0xc9, 0xde, 0xb9, 0xee, 0x77,
+	0x06, 0x3e, 0x2d, 0xc3, 0x27, 0x50, 0xbd, 0x49, 0xfd, 0xed, 0xbf, 0xf1, 0x16, 0x94, 0xda, 0x9d,
+	0x6e, 0x58, 0x7b, 0x08, 0xee, 0x88, 0x06, 0x28, 0xab, 0x4f, 0x9c, 0xd5, 0x01, 0x2c, 0x27, 0x97,
+	0x84, 0x41, 0x38, 0x9b, 0x95, 0x0c, 0x75, 0xd6, 0xa3, 0x66, 0x64, 0xe0, 0xf7, 0x3c, 0x6c, 0xdc,
+	0x88, 0x40, 0x9f, 0xc0, 0x1d, 0xe2, 0x8c, 0x67, 0x54, 0x49, 0x16, 0xd1, 0x38, 0x76, 0xc6, 0xec,
+	0xd8, 0xe1, 0xde, 0x04, 0x0b, 0x24, 0x7a, 0x07, 0xe6, 0x47, 0xd4, 0x77, 0x78, 0xd4, 0xfc, 0x16,
+	0x63, 0x1b, 0xcf, 0x03, 0x36, 0x0e, 0xa5, 0xe8, 0x20, 0x69, 0xc8, 0xb2, 0xc9, 0xad, 0x66, 0x1a,
+	0xf2, 0x99, 0x4b, 0x8c, 0xb8, 0x29, 0xa3, 0x73, 0x28, 0xeb, 0x8e, 0x43, 0xb9, 0x1e, 0x0d, 0x87,
+	0x00, 0xf2, 0xe8, 0x16, 0xfe, 0xb5, 0x12, 0x94, 0x74, 0x33, 0x6d, 0x07, 0xb5, 0xa1, 0x6c, 0x98,
+	0x96, 0x16, 0x79, 0x52, 0x10, 0x66, 0x51, 0x62, 0x36, 0xca, 0x99, 0x4c, 0x6e, 0x4c, 0x32, 0x0c,
+	0x86, 0x69, 0x85, 0xe7, 0xda, 0x07, 0x50, 0x8a, 0xa3, 0x80, 0x96, 0x21, 0x7f, 0x49, 0x26, 0x61,
+	0x6e, 0x83, 0x23, 0x5a, 0x83, 0xc2, 0x58, 0xb7, 0x7d, 0x12, 0x76, 0x56, 0x49, 0x7c, 0x94, 0x7b,
+	0xa2, 0xd4, 0x3e, 0x86, 0xe5, 0xac, 0x7b, 0x6f, 0x83, 0x57, 0x87, 0x50, 0x10, 0x41, 0x45, 0xff,
+	0x87, 0xc5, 0xe4, 0xa7, 0xb8, 0x3a, 0x1f, 0x86, 0xf8, 0xbb, 0x31, 0xb7, 0xa7, 0xf3, 0x21, 0xda,
+	0x84, 0xd2, 0x90, 0x32, 0x2e, 0x35, 0xc2, 0xf9, 0x1c, 0x30, 0x22, 0xa1, 0x47, 0x74, 0x53, 0xa3,
+	0x8e, 0x2d, 0x5b, 0x7d, 0x11, 0x17, 0x03, 0xc6, 0x0b, 0xc7, 0x9e, 0xa8, 0x1e, 0x40, 0x92, 0x95,
+	0xff, 0xe4, 0xba, 0x6d, 0x28, 0xbb, 0xc4, 0x1b, 0x59, 0x8c, 0x89, 0x84, 0xca, 0x65, 0x20, 0xcd,
+	0x52, 0xef, 0x43, 0x39, 0x35, 0x2f, 0xaf, 0xcd, 0xb2, 0x1d, 0x58, 0xca, 0x4c, 0xc3, 0xac, 0xca,
+	0xd1, 0xa7, 0x50, 0x91, 0xbb, 0x8b, 0x27, 0x22, 0x8c, 0x1e, 0x43, 0x31, 0xda, 0x65, 0x50, 0x32,
+	0x53, 0x33, 0xeb, 0x4d, 0x2d, 0xf9, 0xb1, 0x72, 0xa5, 0x98, 0x3b, 0xfa, 0x39, 0x0f, 0x95, 0xf4,
+	0xfa, 0x81, 0x9e, 0xc1, 0xfa, 0x67, 0x84, 0x4f, 0x5b, 0xd5, 0x32, 0xe0, 0xda, 0xcc, 0xfd, 0x45,
+	0x9d, 0x43, 0x2d, 0xa8, 0xa4, 0xf7, 0x95, 0x6b, 0xf8, 0xfb, 0x31, 0x3d, 0x6d, 0xad, 0x51, 0xe7,
+	0xde, 0x53, 0x10, 0x11, 0xce, 0x4c, 0xe9, 0xa6, 0x68, 0x37, 0xd9, 0x1b, 0x6e, 0x9c, 0x50, 0xb5,
+	0x07, 0xb3, 0x95, 0xa2, 0x8b, 0x50, 0x0b, 0x8a, 0x51, 0x71, 0xa5, 0x82, 0x97, 0xe9, 0x8c, 0xb5,
+	0x8d, 0x29, 0x92, 0xd8, 0xc4, 0xb7, 0xb0, 0x72, 0x6d, 0x98, 0xa3, 0x9d, 0xf4, 0xfd, 0x53, 0xb7,
+	0x86, 0x9a, 0x3a, 0x4b, 0x25, 0xb2, 0xfe, 0xf4, 0xf3, 0xd7, 0x6f, 0xea, 0xca, 0x9f, 0x6f, 0xea,
+	0x73, 0x3f, 0x5d, 0xd5, 0x95, 0xd7, 0x57, 0x75, 0xe5, 0x8f, 0xab, 0xba, 0xf2, 0xf7, 0x55, 0x5d,
+	0x79, 0xf5, 0x4f, 0x7d, 0xee, 0xeb, 0xfd, 0xcb, 0x27, 0xac, 0x61, 0xd1, 0xe6, 0xa5, 0xdf, 0x27,
+	0x36, 0xe1, 0x4d, 0xf7, 0x72, 0xd0, 0xd4, 0x5d, 0x8b, 0x35, 0x65, 0xed, 0xbb, 0x22, 0x2f, 0xcd,
+	0xf0, 0x9e, 0xfe, 0xbc, 0x58, 0xc8, 0x1f, 0xfd, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x32, 0xf9, 0x56,
+	0x0a, 0xd5, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1767,6 +1884,34 @@ func (m *TopologyInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PcieRootComplexes) > 0 {
+		for iNdEx := len(m.PcieRootComplexes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PcieRootComplexes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.UncoreCaches) > 0 {
+		for iNdEx := len(m.UncoreCaches) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.UncoreCaches[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if len(m.Nodes) > 0 {
 		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1812,6 +1957,62 @@ func (m *NUMANode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *UncoreCache) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UncoreCache) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UncoreCache) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PCIeRootComplex) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PCIeRootComplex) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PCIeRootComplex) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Device) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2485,6 +2686,18 @@ func (m *TopologyInfo) Size() (n int) {
 			n += 1 + l + sovApi(uint64(l))
 		}
 	}
+	if len(m.UncoreCaches) > 0 {
+		for _, e := range m.UncoreCaches {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if len(m.PcieRootComplexes) > 0 {
+		for _, e := range m.PcieRootComplexes {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2500,6 +2713,30 @@ func (m *NUMANode) Size() (n int) {
 	return n
 }
 
+func (m *UncoreCache) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovApi(uint64(m.ID))
+	}
+	return n
+}
+
+func (m *PCIeRootComplex) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovApi(uint64(m.ID))
+	}
+	return n
+}
+
 func (m *Device) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2819,8 +3056,20 @@ func (this *TopologyInfo) String() string {
 		repeatedStringForNodes += strings.Replace(f.String(), "NUMANode", "NUMANode", 1) + ","
 	}
 	repeatedStringForNodes += "}"
+	repeatedStringForUncoreCaches := "[]*UncoreCache{"
+	for _, f := range this.UncoreCaches {
+		repeatedStringForUncoreCaches += strings.Replace(f.String(), "UncoreCache", "UncoreCache", 1) + ","
+	}
+	repeatedStringForUncoreCaches += "}"
+	repeatedStringForPcieRootComplexes := "[]*PCIeRootComplex{"
+	for _, f := range this.PcieRootComplexes {
+		repeatedStringForPcieRootComplexes += strings.Replace(f.String(), "PCIeRootComplex", "PCIeRootComplex", 1) + ","
+	}
+	repeatedStringForPcieRootComplexes += "}"
 	s := strings.Join([]string{`&TopologyInfo{`,
 		`Nodes:` + repeatedStringForNodes + `,`,
+		`UncoreCaches:` + repeatedStringForUncoreCaches + `,`,
+		`PcieRootComplexes:` + repeatedStringForPcieRootComplexes + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2835,6 +3084,26 @@ func (this *NUMANode) String() string {
 	}, "")
 	return s
 }
+func (this *UncoreCache) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UncoreCache{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PCIeRootComplex) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PCIeRootComplex{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *Device) String() string {
 	if this == nil {
 		return "nil"
@@ -3518,6 +3787,74 @@ func (m *TopologyInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UncoreCaches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UncoreCaches = append(m.UncoreCaches, &UncoreCache{})
+			if err := m.UncoreCaches[len(m.UncoreCaches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PcieRootComplexes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PcieRootComplexes = append(m.PcieRootComplexes, &PCIeRootComplex{})
+			if err := m.PcieRootComplexes[len(m.PcieRootComplexes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -3608,6 +3945,144 @@ func (m *NUMANode) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *UncoreCache) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UncoreCache: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UncoreCache: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PCIeRootComplex) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PCIeRootComplex: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PCIeRootComplex: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Device) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -45,6 +45,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// WatchPodAction is the kind of change a watch event reports
+type WatchPodAction int32
+
+const (
+	WatchPodAction_ADDED    WatchPodAction = 0
+	WatchPodAction_MODIFIED WatchPodAction = 1
+	WatchPodAction_DELETED  WatchPodAction = 2
+)
+
+var WatchPodAction_name = map[int32]string{
+	0: "ADDED",
+	1: "MODIFIED",
+	2: "DELETED",
+}
+
+var WatchPodAction_value = map[string]int32{
+	"ADDED":    0,
+	"MODIFIED": 1,
+	"DELETED":  2,
+}
+
+func (x WatchPodAction) String() string {
+	return proto.EnumName(WatchPodAction_name, int32(x))
+}
+
+func (WatchPodAction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{0}
+}
+
 type AllocatableResourcesRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -87,6 +116,7 @@ type AllocatableResourcesResponse struct {
 	Devices              []*ContainerDevices `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
 	CpuIds               []int64             `protobuf:"varint,2,rep,packed,name=cpu_ids,json=cpuIds,proto3" json:"cpu_ids,omitempty"`
 	Memory               []*ContainerMemory  `protobuf:"bytes,3,rep,name=memory,proto3" json:"memory,omitempty"`
+	CpuTopology          []*CPUTopologyInfo  `protobuf:"bytes,4,rep,name=cpu_topology,json=cpuTopology,proto3" json:"cpu_topology,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
 }
@@ -144,8 +174,23 @@ func (m *AllocatableResourcesResponse) GetMemory() []*ContainerMemory {
 	return nil
 }
 
-// ListPodResourcesRequest is the request made to the PodResourcesLister service
+func (m *AllocatableResourcesResponse) GetCpuTopology() []*CPUTopologyInfo {
+	if m != nil {
+		return m.CpuTopology
+	}
+	return nil
+}
+
+// ListPodResourcesRequest is the request made to the PodResourcesLister service.
+// Every field is an optional filter; an empty request returns the full resource
+// assignment of every pod on the node, as it always did.
 type ListPodResourcesRequest struct {
+	Namespaces           []string `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	PodNamePrefix        string   `protobuf:"bytes,2,opt,name=pod_name_prefix,json=podNamePrefix,proto3" json:"pod_name_prefix,omitempty"`
+	ResourceNames        []string `protobuf:"bytes,3,rep,name=resource_names,json=resourceNames,proto3" json:"resource_names,omitempty"`
+	IncludeDevices       bool     `protobuf:"varint,4,opt,name=include_devices,json=includeDevices,proto3" json:"include_devices,omitempty"`
+	IncludeCpus          bool     `protobuf:"varint,5,opt,name=include_cpus,json=includeCpus,proto3" json:"include_cpus,omitempty"`
+	IncludeMemory        bool     `protobuf:"varint,6,opt,name=include_memory,json=includeMemory,proto3" json:"include_memory,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -182,6 +227,48 @@ func (m *ListPodResourcesRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListPodResourcesRequest proto.InternalMessageInfo
 
+func (m *ListPodResourcesRequest) GetNamespaces() []string {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+func (m *ListPodResourcesRequest) GetPodNamePrefix() string {
+	if m != nil {
+		return m.PodNamePrefix
+	}
+	return ""
+}
+
+func (m *ListPodResourcesRequest) GetResourceNames() []string {
+	if m != nil {
+		return m.ResourceNames
+	}
+	return nil
+}
+
+func (m *ListPodResourcesRequest) GetIncludeDevices() bool {
+	if m != nil {
+		return m.IncludeDevices
+	}
+	return false
+}
+
+func (m *ListPodResourcesRequest) GetIncludeCpus() bool {
+	if m != nil {
+		return m.IncludeCpus
+	}
+	return false
+}
+
+func (m *ListPodResourcesRequest) GetIncludeMemory() bool {
+	if m != nil {
+		return m.IncludeMemory
+	}
+	return false
+}
+
 // ListPodResourcesResponse is the response returned by List function
 type ListPodResourcesResponse struct {
 	PodResources         []*PodResources `protobuf:"bytes,1,rep,name=pod_resources,json=podResources,proto3" json:"pod_resources,omitempty"`
@@ -297,6 +384,7 @@ type ContainerResources struct {
 	CpuIds               []int64             `protobuf:"varint,3,rep,packed,name=cpu_ids,json=cpuIds,proto3" json:"cpu_ids,omitempty"`
 	Memory               []*ContainerMemory  `protobuf:"bytes,4,rep,name=memory,proto3" json:"memory,omitempty"`
 	DynamicResources     []*DynamicResource  `protobuf:"bytes,5,rep,name=dynamic_resources,json=dynamicResources,proto3" json:"dynamic_resources,omitempty"`
+	CpuTopology          []*CPUTopologyInfo  `protobuf:"bytes,6,rep,name=cpu_topology,json=cpuTopology,proto3" json:"cpu_topology,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
 }
@@ -368,6 +456,13 @@ func (m *ContainerResources) GetDynamicResources() []*DynamicResource {
 	return nil
 }
 
+func (m *ContainerResources) GetCpuTopology() []*CPUTopologyInfo {
+	if m != nil {
+		return m.CpuTopology
+	}
+	return nil
+}
+
 // ContainerMemory contains information about memory and hugepages assigned to a container
 type ContainerMemory struct {
 	MemoryType           string        `protobuf:"bytes,1,opt,name=memory_type,json=memoryType,proto3" json:"memory_type,omitempty"`
@@ -584,6 +679,70 @@ func (m *NUMANode) GetID() int64 {
 	return 0
 }
 
+// CPUTopologyInfo describes the hardware placement of one logical CPU, so
+// monitoring agents can verify the socket and last-level-cache alignment of
+// exclusively allocated CPUs without parsing the CPU manager checkpoint.
+type CPUTopologyInfo struct {
+	CPUID                int64    `protobuf:"varint,1,opt,name=cpu_id,json=cpuId,proto3" json:"cpu_id,omitempty"`
+	SocketID             int64    `protobuf:"varint,2,opt,name=socket_id,json=socketId,proto3" json:"socket_id,omitempty"`
+	UncoreCacheID        int64    `protobuf:"varint,3,opt,name=uncore_cache_id,json=uncoreCacheId,proto3" json:"uncore_cache_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CPUTopologyInfo) Reset()      { *m = CPUTopologyInfo{} }
+func (*CPUTopologyInfo) ProtoMessage() {}
+func (*CPUTopologyInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+}
+func (m *CPUTopologyInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CPUTopologyInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CPUTopologyInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CPUTopologyInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CPUTopologyInfo.Merge(m, src)
+}
+func (m *CPUTopologyInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *CPUTopologyInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CPUTopologyInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CPUTopologyInfo proto.InternalMessageInfo
+
+func (m *CPUTopologyInfo) GetCPUID() int64 {
+	if m != nil {
+		return m.CPUID
+	}
+	return 0
+}
+
+func (m *CPUTopologyInfo) GetSocketID() int64 {
+	if m != nil {
+		return m.SocketID
+	}
+	return 0
+}
+
+func (m *CPUTopologyInfo) GetUncoreCacheID() int64 {
+	if m != nil {
+		return m.UncoreCacheID
+	}
+	return 0
+}
+
 // DynamicResource contains information about the devices assigned to a container by DRA
 type DynamicResource struct {
 	ClassName            string           `protobuf:"bytes,1,opt,name=class_name,json=className,proto3" json:"class_name,omitempty"`
@@ -850,7 +1009,292 @@ func (m *GetPodResourcesResponse) GetPodResources() *PodResources {
 	return nil
 }
 
+// WatchPodResourcesRequest is the request made to the Watch function
+type WatchPodResourcesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchPodResourcesRequest) Reset()      { *m = WatchPodResourcesRequest{} }
+func (*WatchPodResourcesRequest) ProtoMessage() {}
+func (*WatchPodResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+}
+func (m *WatchPodResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchPodResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchPodResourcesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchPodResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPodResourcesRequest.Merge(m, src)
+}
+func (m *WatchPodResourcesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchPodResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPodResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPodResourcesRequest proto.InternalMessageInfo
+
+// WatchPodResourcesResponse is a single event of the Watch stream, reporting
+// the full resource assignment of one pod after the change
+type WatchPodResourcesResponse struct {
+	Action               WatchPodAction `protobuf:"varint,1,opt,name=action,proto3,enum=v1.WatchPodAction" json:"action,omitempty"`
+	PodResources         *PodResources  `protobuf:"bytes,2,opt,name=pod_resources,json=podResources,proto3" json:"pod_resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *WatchPodResourcesResponse) Reset()      { *m = WatchPodResourcesResponse{} }
+func (*WatchPodResourcesResponse) ProtoMessage() {}
+func (*WatchPodResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+func (m *WatchPodResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchPodResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchPodResourcesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchPodResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPodResourcesResponse.Merge(m, src)
+}
+func (m *WatchPodResourcesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchPodResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPodResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPodResourcesResponse proto.InternalMessageInfo
+
+func (m *WatchPodResourcesResponse) GetAction() WatchPodAction {
+	if m != nil {
+		return m.Action
+	}
+	return WatchPodAction_ADDED
+}
+
+func (m *WatchPodResourcesResponse) GetPodResources() *PodResources {
+	if m != nil {
+		return m.PodResources
+	}
+	return nil
+}
+
+// DryRunAdmissionRequest carries the pod to evaluate, JSON-encoded in the
+// same v1.Pod format the kubelet receives pods in
+type DryRunAdmissionRequest struct {
+	Pod                  []byte   `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DryRunAdmissionRequest) Reset()      { *m = DryRunAdmissionRequest{} }
+func (*DryRunAdmissionRequest) ProtoMessage() {}
+func (*DryRunAdmissionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+func (m *DryRunAdmissionRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DryRunAdmissionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DryRunAdmissionRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DryRunAdmissionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DryRunAdmissionRequest.Merge(m, src)
+}
+func (m *DryRunAdmissionRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DryRunAdmissionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DryRunAdmissionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DryRunAdmissionRequest proto.InternalMessageInfo
+
+func (m *DryRunAdmissionRequest) GetPod() []byte {
+	if m != nil {
+		return m.Pod
+	}
+	return nil
+}
+
+// ContainerAdmissionPrediction is the hint merge outcome for one container:
+// the NUMA affinity the topology manager would choose and whether the policy
+// would accept it
+type ContainerAdmissionPrediction struct {
+	ContainerName        string   `protobuf:"bytes,1,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	NumaAffinity         []int64  `protobuf:"varint,2,rep,packed,name=numa_affinity,json=numaAffinity,proto3" json:"numa_affinity,omitempty"`
+	Preferred            bool     `protobuf:"varint,3,opt,name=preferred,proto3" json:"preferred,omitempty"`
+	Admit                bool     `protobuf:"varint,4,opt,name=admit,proto3" json:"admit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerAdmissionPrediction) Reset()      { *m = ContainerAdmissionPrediction{} }
+func (*ContainerAdmissionPrediction) ProtoMessage() {}
+func (*ContainerAdmissionPrediction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
+}
+func (m *ContainerAdmissionPrediction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContainerAdmissionPrediction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContainerAdmissionPrediction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContainerAdmissionPrediction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerAdmissionPrediction.Merge(m, src)
+}
+func (m *ContainerAdmissionPrediction) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContainerAdmissionPrediction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerAdmissionPrediction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerAdmissionPrediction proto.InternalMessageInfo
+
+func (m *ContainerAdmissionPrediction) GetContainerName() string {
+	if m != nil {
+		return m.ContainerName
+	}
+	return ""
+}
+
+func (m *ContainerAdmissionPrediction) GetNumaAffinity() []int64 {
+	if m != nil {
+		return m.NumaAffinity
+	}
+	return nil
+}
+
+func (m *ContainerAdmissionPrediction) GetPreferred() bool {
+	if m != nil {
+		return m.Preferred
+	}
+	return false
+}
+
+func (m *ContainerAdmissionPrediction) GetAdmit() bool {
+	if m != nil {
+		return m.Admit
+	}
+	return false
+}
+
+// DryRunAdmissionResponse reports whether the topology manager would admit
+// the pod if it was scheduled on the node now. Evaluating it allocates
+// nothing and leaves no state behind
+type DryRunAdmissionResponse struct {
+	Admit                bool                            `protobuf:"varint,1,opt,name=admit,proto3" json:"admit,omitempty"`
+	TopologyPolicy       string                          `protobuf:"bytes,2,opt,name=topology_policy,json=topologyPolicy,proto3" json:"topology_policy,omitempty"`
+	TopologyScope        string                          `protobuf:"bytes,3,opt,name=topology_scope,json=topologyScope,proto3" json:"topology_scope,omitempty"`
+	Predictions          []*ContainerAdmissionPrediction `protobuf:"bytes,4,rep,name=predictions,proto3" json:"predictions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *DryRunAdmissionResponse) Reset()      { *m = DryRunAdmissionResponse{} }
+func (*DryRunAdmissionResponse) ProtoMessage() {}
+func (*DryRunAdmissionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+func (m *DryRunAdmissionResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DryRunAdmissionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DryRunAdmissionResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DryRunAdmissionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DryRunAdmissionResponse.Merge(m, src)
+}
+func (m *DryRunAdmissionResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DryRunAdmissionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DryRunAdmissionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DryRunAdmissionResponse proto.InternalMessageInfo
+
+func (m *DryRunAdmissionResponse) GetAdmit() bool {
+	if m != nil {
+		return m.Admit
+	}
+	return false
+}
+
+func (m *DryRunAdmissionResponse) GetTopologyPolicy() string {
+	if m != nil {
+		return m.TopologyPolicy
+	}
+	return ""
+}
+
+func (m *DryRunAdmissionResponse) GetTopologyScope() string {
+	if m != nil {
+		return m.TopologyScope
+	}
+	return ""
+}
+
+func (m *DryRunAdmissionResponse) GetPredictions() []*ContainerAdmissionPrediction {
+	if m != nil {
+		return m.Predictions
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("v1.WatchPodAction", WatchPodAction_name, WatchPodAction_value)
 	proto.RegisterType((*AllocatableResourcesRequest)(nil), "v1.AllocatableResourcesRequest")
 	proto.RegisterType((*AllocatableResourcesResponse)(nil), "v1.AllocatableResourcesResponse")
 	proto.RegisterType((*ListPodResourcesRequest)(nil), "v1.ListPodResourcesRequest")
@@ -861,11 +1305,17 @@ func init() {
 	proto.RegisterType((*ContainerDevices)(nil), "v1.ContainerDevices")
 	proto.RegisterType((*TopologyInfo)(nil), "v1.TopologyInfo")
 	proto.RegisterType((*NUMANode)(nil), "v1.NUMANode")
+	proto.RegisterType((*CPUTopologyInfo)(nil), "v1.CPUTopologyInfo")
 	proto.RegisterType((*DynamicResource)(nil), "v1.DynamicResource")
 	proto.RegisterType((*ClaimResource)(nil), "v1.ClaimResource")
 	proto.RegisterType((*CDIDevice)(nil), "v1.CDIDevice")
 	proto.RegisterType((*GetPodResourcesRequest)(nil), "v1.GetPodResourcesRequest")
 	proto.RegisterType((*GetPodResourcesResponse)(nil), "v1.GetPodResourcesResponse")
+	proto.RegisterType((*WatchPodResourcesRequest)(nil), "v1.WatchPodResourcesRequest")
+	proto.RegisterType((*WatchPodResourcesResponse)(nil), "v1.WatchPodResourcesResponse")
+	proto.RegisterType((*DryRunAdmissionRequest)(nil), "v1.DryRunAdmissionRequest")
+	proto.RegisterType((*ContainerAdmissionPrediction)(nil), "v1.ContainerAdmissionPrediction")
+	proto.RegisterType((*DryRunAdmissionResponse)(nil), "v1.DryRunAdmissionResponse")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
@@ -937,6 +1387,8 @@ type PodResourcesListerClient interface {
 	List(ctx context.Context, in *ListPodResourcesRequest, opts ...grpc.CallOption) (*ListPodResourcesResponse, error)
 	GetAllocatableResources(ctx context.Context, in *AllocatableResourcesRequest, opts ...grpc.CallOption) (*AllocatableResourcesResponse, error)
 	Get(ctx context.Context, in *GetPodResourcesRequest, opts ...grpc.CallOption) (*GetPodResourcesResponse, error)
+	Watch(ctx context.Context, in *WatchPodResourcesRequest, opts ...grpc.CallOption) (PodResourcesLister_WatchClient, error)
+	DryRunAdmission(ctx context.Context, in *DryRunAdmissionRequest, opts ...grpc.CallOption) (*DryRunAdmissionResponse, error)
 }
 
 type podResourcesListerClient struct {
@@ -974,12 +1426,55 @@ func (c *podResourcesListerClient) Get(ctx context.Context, in *GetPodResourcesR
 	return out, nil
 }
 
-// PodResourcesListerServer is the server API for PodResourcesLister service.
-type PodResourcesListerServer interface {
-	List(context.Context, *ListPodResourcesRequest) (*ListPodResourcesResponse, error)
-	GetAllocatableResources(context.Context, *AllocatableResourcesRequest) (*AllocatableResourcesResponse, error)
-	Get(context.Context, *GetPodResourcesRequest) (*GetPodResourcesResponse, error)
-}
+func (c *podResourcesListerClient) Watch(ctx context.Context, in *WatchPodResourcesRequest, opts ...grpc.CallOption) (PodResourcesLister_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PodResourcesLister_serviceDesc.Streams[0], "/v1.PodResourcesLister/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &podResourcesListerWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PodResourcesLister_WatchClient interface {
+	Recv() (*WatchPodResourcesResponse, error)
+	grpc.ClientStream
+}
+
+type podResourcesListerWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *podResourcesListerWatchClient) Recv() (*WatchPodResourcesResponse, error) {
+	m := new(WatchPodResourcesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *podResourcesListerClient) DryRunAdmission(ctx context.Context, in *DryRunAdmissionRequest, opts ...grpc.CallOption) (*DryRunAdmissionResponse, error) {
+	out := new(DryRunAdmissionResponse)
+	err := c.cc.Invoke(ctx, "/v1.PodResourcesLister/DryRunAdmission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PodResourcesListerServer is the server API for PodResourcesLister service.
+type PodResourcesListerServer interface {
+	List(context.Context, *ListPodResourcesRequest) (*ListPodResourcesResponse, error)
+	GetAllocatableResources(context.Context, *AllocatableResourcesRequest) (*AllocatableResourcesResponse, error)
+	Get(context.Context, *GetPodResourcesRequest) (*GetPodResourcesResponse, error)
+	Watch(*WatchPodResourcesRequest, PodResourcesLister_WatchServer) error
+	DryRunAdmission(context.Context, *DryRunAdmissionRequest) (*DryRunAdmissionResponse, error)
+}
 
 // UnimplementedPodResourcesListerServer can be embedded to have forward compatible implementations.
 type UnimplementedPodResourcesListerServer struct {
@@ -994,6 +1489,12 @@ func (*UnimplementedPodResourcesListerServer) GetAllocatableResources(ctx contex
 func (*UnimplementedPodResourcesListerServer) Get(ctx context.Context, req *GetPodResourcesRequest) (*GetPodResourcesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (*UnimplementedPodResourcesListerServer) Watch(req *WatchPodResourcesRequest, srv PodResourcesLister_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (*UnimplementedPodResourcesListerServer) DryRunAdmission(ctx context.Context, req *DryRunAdmissionRequest) (*DryRunAdmissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunAdmission not implemented")
+}
 
 func RegisterPodResourcesListerServer(s *grpc.Server, srv PodResourcesListerServer) {
 	s.RegisterService(&_PodResourcesLister_serviceDesc, srv)
@@ -1053,6 +1554,45 @@ func _PodResourcesLister_Get_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PodResourcesLister_DryRunAdmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunAdmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PodResourcesListerServer).DryRunAdmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.PodResourcesLister/DryRunAdmission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PodResourcesListerServer).DryRunAdmission(ctx, req.(*DryRunAdmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PodResourcesLister_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPodResourcesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PodResourcesListerServer).Watch(m, &podResourcesListerWatchServer{stream})
+}
+
+type PodResourcesLister_WatchServer interface {
+	Send(*WatchPodResourcesResponse) error
+	grpc.ServerStream
+}
+
+type podResourcesListerWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *podResourcesListerWatchServer) Send(m *WatchPodResourcesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _PodResourcesLister_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.PodResourcesLister",
 	HandlerType: (*PodResourcesListerServer)(nil),
@@ -1069,8 +1609,18 @@ var _PodResourcesLister_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _PodResourcesLister_Get_Handler,
 		},
+		{
+			MethodName: "DryRunAdmission",
+			Handler:    _PodResourcesLister_DryRunAdmission_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _PodResourcesLister_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
 
@@ -1117,6 +1667,20 @@ func (m *AllocatableResourcesResponse) MarshalToSizedBuffer(dAtA []byte) (int, e
 	_ = i
 	var l int
 	_ = l
+	if len(m.CpuTopology) > 0 {
+		for iNdEx := len(m.CpuTopology) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CpuTopology[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.Memory) > 0 {
 		for iNdEx := len(m.Memory) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1187,6 +1751,61 @@ func (m *ListPodResourcesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.IncludeMemory {
+		i--
+		if m.IncludeMemory {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.IncludeCpus {
+		i--
+		if m.IncludeCpus {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.IncludeDevices {
+		i--
+		if m.IncludeDevices {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ResourceNames) > 0 {
+		for iNdEx := len(m.ResourceNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ResourceNames[iNdEx])
+			copy(dAtA[i:], m.ResourceNames[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.ResourceNames[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.PodNamePrefix) > 0 {
+		i -= len(m.PodNamePrefix)
+		copy(dAtA[i:], m.PodNamePrefix)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.PodNamePrefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespaces) > 0 {
+		for iNdEx := len(m.Namespaces) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Namespaces[iNdEx])
+			copy(dAtA[i:], m.Namespaces[iNdEx])
+			i = encodeVarintApi(dAtA, i, uint64(len(m.Namespaces[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -1298,6 +1917,20 @@ func (m *ContainerResources) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CpuTopology) > 0 {
+		for iNdEx := len(m.CpuTopology) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CpuTopology[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.DynamicResources) > 0 {
 		for iNdEx := len(m.DynamicResources) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1532,6 +2165,44 @@ func (m *NUMANode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CPUTopologyInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CPUTopologyInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CPUTopologyInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.UncoreCacheID != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.UncoreCacheID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.SocketID != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.SocketID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CPUID != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.CPUID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *DynamicResource) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1729,87 +2400,341 @@ func (m *GetPodResourcesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintApi(dAtA []byte, offset int, v uint64) int {
-	offset -= sovApi(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *WatchPodResourcesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *AllocatableResourcesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *WatchPodResourcesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchPodResourcesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *AllocatableResourcesResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *WatchPodResourcesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchPodResourcesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchPodResourcesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Devices) > 0 {
-		for _, e := range m.Devices {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
-		}
-	}
-	if len(m.CpuIds) > 0 {
-		l = 0
-		for _, e := range m.CpuIds {
-			l += sovApi(uint64(e))
+	if m.PodResources != nil {
+		{
+			size, err := m.PodResources.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintApi(dAtA, i, uint64(size))
 		}
-		n += 1 + sovApi(uint64(l)) + l
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Memory) > 0 {
-		for _, e := range m.Memory {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
-		}
+	if m.Action != 0 {
+		i = encodeVarintApi(dAtA, i, uint64(m.Action))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ListPodResourcesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DryRunAdmissionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ListPodResourcesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *DryRunAdmissionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DryRunAdmissionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.PodResources) > 0 {
-		for _, e := range m.PodResources {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
-		}
+	if len(m.Pod) > 0 {
+		i -= len(m.Pod)
+		copy(dAtA[i:], m.Pod)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.Pod)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PodResources) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ContainerAdmissionPrediction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ContainerAdmissionPrediction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContainerAdmissionPrediction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovApi(uint64(l))
+	if m.Admit {
+		i--
+		if m.Admit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Preferred {
+		i--
+		if m.Preferred {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.NumaAffinity) > 0 {
+		dAtA9 := make([]byte, len(m.NumaAffinity)*10)
+		var j8 int
+		for _, num1 := range m.NumaAffinity {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j8++
+			}
+			dAtA9[j8] = uint8(num)
+			j8++
+		}
+		i -= j8
+		copy(dAtA[i:], dAtA9[:j8])
+		i = encodeVarintApi(dAtA, i, uint64(j8))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContainerName) > 0 {
+		i -= len(m.ContainerName)
+		copy(dAtA[i:], m.ContainerName)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.ContainerName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DryRunAdmissionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DryRunAdmissionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DryRunAdmissionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Predictions) > 0 {
+		for iNdEx := len(m.Predictions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Predictions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.TopologyScope) > 0 {
+		i -= len(m.TopologyScope)
+		copy(dAtA[i:], m.TopologyScope)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.TopologyScope)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TopologyPolicy) > 0 {
+		i -= len(m.TopologyPolicy)
+		copy(dAtA[i:], m.TopologyPolicy)
+		i = encodeVarintApi(dAtA, i, uint64(len(m.TopologyPolicy)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Admit {
+		i--
+		if m.Admit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintApi(dAtA []byte, offset int, v uint64) int {
+	offset -= sovApi(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *AllocatableResourcesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AllocatableResourcesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if len(m.CpuIds) > 0 {
+		l = 0
+		for _, e := range m.CpuIds {
+			l += sovApi(uint64(e))
+		}
+		n += 1 + sovApi(uint64(l)) + l
+	}
+	if len(m.Memory) > 0 {
+		for _, e := range m.Memory {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if len(m.CpuTopology) > 0 {
+		for _, e := range m.CpuTopology {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ListPodResourcesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Namespaces) > 0 {
+		for _, s := range m.Namespaces {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	l = len(m.PodNamePrefix)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.ResourceNames) > 0 {
+		for _, s := range m.ResourceNames {
+			l = len(s)
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.IncludeDevices {
+		n += 2
+	}
+	if m.IncludeCpus {
+		n += 2
+	}
+	if m.IncludeMemory {
+		n += 2
+	}
+	return n
+}
+
+func (m *ListPodResourcesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.PodResources) > 0 {
+		for _, e := range m.PodResources {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PodResources) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
 	}
 	l = len(m.Namespace)
 	if l > 0 {
@@ -1859,6 +2784,12 @@ func (m *ContainerResources) Size() (n int) {
 			n += 1 + l + sovApi(uint64(l))
 		}
 	}
+	if len(m.CpuTopology) > 0 {
+		for _, e := range m.CpuTopology {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1932,6 +2863,24 @@ func (m *NUMANode) Size() (n int) {
 	return n
 }
 
+func (m *CPUTopologyInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CPUID != 0 {
+		n += 1 + sovApi(uint64(m.CPUID))
+	}
+	if m.SocketID != 0 {
+		n += 1 + sovApi(uint64(m.SocketID))
+	}
+	if m.UncoreCacheID != 0 {
+		n += 1 + sovApi(uint64(m.UncoreCacheID))
+	}
+	return n
+}
+
 func (m *DynamicResource) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2017,6 +2966,96 @@ func (m *GetPodResourcesResponse) Size() (n int) {
 	return n
 }
 
+func (m *WatchPodResourcesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *WatchPodResourcesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Action != 0 {
+		n += 1 + sovApi(uint64(m.Action))
+	}
+	if m.PodResources != nil {
+		l = m.PodResources.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	return n
+}
+
+func (m *DryRunAdmissionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Pod)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	return n
+}
+
+func (m *ContainerAdmissionPrediction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerName)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.NumaAffinity) > 0 {
+		l = 0
+		for _, e := range m.NumaAffinity {
+			l += sovApi(uint64(e))
+		}
+		n += 1 + sovApi(uint64(l)) + l
+	}
+	if m.Preferred {
+		n += 2
+	}
+	if m.Admit {
+		n += 2
+	}
+	return n
+}
+
+func (m *DryRunAdmissionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Admit {
+		n += 2
+	}
+	l = len(m.TopologyPolicy)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.TopologyScope)
+	if l > 0 {
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if len(m.Predictions) > 0 {
+		for _, e := range m.Predictions {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovApi(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2046,10 +3085,16 @@ func (this *AllocatableResourcesResponse) String() string {
 		repeatedStringForMemory += strings.Replace(f.String(), "ContainerMemory", "ContainerMemory", 1) + ","
 	}
 	repeatedStringForMemory += "}"
+	repeatedStringForCpuTopology := "[]*CPUTopologyInfo{"
+	for _, f := range this.CpuTopology {
+		repeatedStringForCpuTopology += strings.Replace(f.String(), "CPUTopologyInfo", "CPUTopologyInfo", 1) + ","
+	}
+	repeatedStringForCpuTopology += "}"
 	s := strings.Join([]string{`&AllocatableResourcesResponse{`,
 		`Devices:` + repeatedStringForDevices + `,`,
 		`CpuIds:` + fmt.Sprintf("%v", this.CpuIds) + `,`,
 		`Memory:` + repeatedStringForMemory + `,`,
+		`CpuTopology:` + repeatedStringForCpuTopology + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2059,6 +3104,12 @@ func (this *ListPodResourcesRequest) String() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&ListPodResourcesRequest{`,
+		`Namespaces:` + fmt.Sprintf("%v", this.Namespaces) + `,`,
+		`PodNamePrefix:` + fmt.Sprintf("%v", this.PodNamePrefix) + `,`,
+		`ResourceNames:` + fmt.Sprintf("%v", this.ResourceNames) + `,`,
+		`IncludeDevices:` + fmt.Sprintf("%v", this.IncludeDevices) + `,`,
+		`IncludeCpus:` + fmt.Sprintf("%v", this.IncludeCpus) + `,`,
+		`IncludeMemory:` + fmt.Sprintf("%v", this.IncludeMemory) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2114,12 +3165,18 @@ func (this *ContainerResources) String() string {
 		repeatedStringForDynamicResources += strings.Replace(f.String(), "DynamicResource", "DynamicResource", 1) + ","
 	}
 	repeatedStringForDynamicResources += "}"
+	repeatedStringForCpuTopology := "[]*CPUTopologyInfo{"
+	for _, f := range this.CpuTopology {
+		repeatedStringForCpuTopology += strings.Replace(f.String(), "CPUTopologyInfo", "CPUTopologyInfo", 1) + ","
+	}
+	repeatedStringForCpuTopology += "}"
 	s := strings.Join([]string{`&ContainerResources{`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`Devices:` + repeatedStringForDevices + `,`,
 		`CpuIds:` + fmt.Sprintf("%v", this.CpuIds) + `,`,
 		`Memory:` + repeatedStringForMemory + `,`,
 		`DynamicResources:` + repeatedStringForDynamicResources + `,`,
+		`CpuTopology:` + repeatedStringForCpuTopology + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2173,6 +3230,18 @@ func (this *NUMANode) String() string {
 	}, "")
 	return s
 }
+func (this *CPUTopologyInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CPUTopologyInfo{`,
+		`CPUID:` + fmt.Sprintf("%v", this.CPUID) + `,`,
+		`SocketID:` + fmt.Sprintf("%v", this.SocketID) + `,`,
+		`UncoreCacheID:` + fmt.Sprintf("%v", this.UncoreCacheID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *DynamicResource) String() string {
 	if this == nil {
 		return "nil"
@@ -2237,6 +3306,67 @@ func (this *GetPodResourcesResponse) String() string {
 	}, "")
 	return s
 }
+func (this *WatchPodResourcesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WatchPodResourcesRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WatchPodResourcesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WatchPodResourcesResponse{`,
+		`Action:` + fmt.Sprintf("%v", this.Action) + `,`,
+		`PodResources:` + strings.Replace(this.PodResources.String(), "PodResources", "PodResources", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DryRunAdmissionRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DryRunAdmissionRequest{`,
+		`Pod:` + fmt.Sprintf("%v", this.Pod) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ContainerAdmissionPrediction) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ContainerAdmissionPrediction{`,
+		`ContainerName:` + fmt.Sprintf("%v", this.ContainerName) + `,`,
+		`NumaAffinity:` + fmt.Sprintf("%v", this.NumaAffinity) + `,`,
+		`Preferred:` + fmt.Sprintf("%v", this.Preferred) + `,`,
+		`Admit:` + fmt.Sprintf("%v", this.Admit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DryRunAdmissionResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForPredictions := "[]*ContainerAdmissionPrediction{"
+	for _, f := range this.Predictions {
+		repeatedStringForPredictions += strings.Replace(f.String(), "ContainerAdmissionPrediction", "ContainerAdmissionPrediction", 1) + ","
+	}
+	repeatedStringForPredictions += "}"
+	s := strings.Join([]string{`&DryRunAdmissionResponse{`,
+		`Admit:` + fmt.Sprintf("%v", this.Admit) + `,`,
+		`TopologyPolicy:` + fmt.Sprintf("%v", this.TopologyPolicy) + `,`,
+		`TopologyScope:` + fmt.Sprintf("%v", this.TopologyScope) + `,`,
+		`Predictions:` + repeatedStringForPredictions + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringApi(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -2468,6 +3598,40 @@ func (m *AllocatableResourcesResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuTopology", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CpuTopology = append(m.CpuTopology, &CPUTopologyInfo{})
+			if err := m.CpuTopology[len(m.CpuTopology)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -2518,6 +3682,162 @@ func (m *ListPodResourcesRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: ListPodResourcesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespaces", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespaces = append(m.Namespaces, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodNamePrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PodNamePrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceNames = append(m.ResourceNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeDevices", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeDevices = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeCpus", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeCpus = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeMemory", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeMemory = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -3010,6 +4330,40 @@ func (m *ContainerResources) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuTopology", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CpuTopology = append(m.CpuTopology, &CPUTopologyInfo{})
+			if err := m.CpuTopology[len(m.CpuTopology)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipApi(dAtA[iNdEx:])
@@ -3471,7 +4825,7 @@ func (m *NUMANode) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DynamicResource) Unmarshal(dAtA []byte) error {
+func (m *CPUTopologyInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3494,17 +4848,17 @@ func (m *DynamicResource) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DynamicResource: wiretype end group for non-group")
+			return fmt.Errorf("proto: CPUTopologyInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DynamicResource: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CPUTopologyInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClassName", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CPUID", wireType)
 			}
-			var stringLen uint64
+			m.CPUID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowApi
@@ -3514,24 +4868,132 @@ func (m *DynamicResource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.CPUID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthApi
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ClassName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SocketID", wireType)
+			}
+			m.SocketID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SocketID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UncoreCacheID", wireType)
+			}
+			m.UncoreCacheID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UncoreCacheID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *DynamicResource) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DynamicResource: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DynamicResource: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClassName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClassName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ClaimName", wireType)
@@ -4017,6 +5479,611 @@ func (m *GetPodResourcesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *WatchPodResourcesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchPodResourcesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchPodResourcesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchPodResourcesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchPodResourcesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchPodResourcesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			m.Action = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Action |= WatchPodAction(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PodResources == nil {
+				m.PodResources = &PodResources{}
+			}
+			if err := m.PodResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DryRunAdmissionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DryRunAdmissionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DryRunAdmissionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pod", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pod = append(m.Pod[:0], dAtA[iNdEx:postIndex]...)
+			if m.Pod == nil {
+				m.Pod = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContainerAdmissionPrediction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContainerAdmissionPrediction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContainerAdmissionPrediction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.NumaAffinity = append(m.NumaAffinity, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthApi
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthApi
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.NumaAffinity) == 0 {
+					m.NumaAffinity = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NumaAffinity = append(m.NumaAffinity, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumaAffinity", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Preferred", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Preferred = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Admit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DryRunAdmissionResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DryRunAdmissionResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DryRunAdmissionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Admit = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopologyPolicy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TopologyPolicy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopologyScope", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TopologyScope = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Predictions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Predictions = append(m.Predictions, &ContainerAdmissionPrediction{})
+			if err := m.Predictions[len(m.Predictions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipApi(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
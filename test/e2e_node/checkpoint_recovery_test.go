@@ -0,0 +1,143 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2enode
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfig "k8s.io/kubernetes/pkg/kubelet/apis/config"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
+	"k8s.io/kubernetes/test/e2e/feature"
+	"k8s.io/kubernetes/test/e2e/framework"
+	admissionapi "k8s.io/pod-security-admission/api"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// mangleStateFile rewrites the given state file through a shell command, so
+// the tests can simulate the various ways a checkpoint gets damaged on a real
+// node: truncation from a full disk, garbage from a torn write, and so on.
+func mangleStateFile(stateFileName, mangleCmd string) {
+	err := exec.Command("/bin/sh", "-c", fmt.Sprintf(mangleCmd, stateFileName)).Run()
+	framework.ExpectNoError(err, "failed to mangle the state file %q", stateFileName)
+}
+
+// Serial because the test restarts the kubelet with damaged checkpoints.
+var _ = SIGDescribe("Checkpoint corruption recovery", framework.WithDisruptive(), framework.WithSerial(), feature.CPUManager, feature.MemoryManager, func() {
+	f := framework.NewDefaultFramework("checkpoint-recovery-test")
+	f.NamespacePodSecurityLevel = admissionapi.LevelPrivileged
+
+	tempSetCurrentKubeletConfig(f, func(ctx context.Context, initialConfig *kubeletconfig.KubeletConfiguration) {
+		memoryQuantity := resource.MustParse("1100Mi")
+		kubeParams := &memoryManagerKubeletParams{
+			policy: staticPolicy,
+			systemReservedMemory: []kubeletconfig.MemoryReservation{
+				{
+					NumaNode: 0,
+					Limits: v1.ResourceList{
+						resourceMemory: memoryQuantity,
+					},
+				},
+			},
+			systemReserved: map[string]string{resourceMemory: "500Mi"},
+			kubeReserved:   map[string]string{resourceMemory: "500Mi"},
+			evictionHard:   map[string]string{evictionHardMemory: "100Mi"},
+		}
+		updateKubeletConfigWithMemoryManagerParams(initialConfig, kubeParams)
+
+		initialConfig.CPUManagerPolicy = string(cpumanager.PolicyStatic)
+		initialConfig.CPUManagerReconcilePeriod = metav1.Duration{Duration: 1 * time.Second}
+		if initialConfig.KubeReserved == nil {
+			initialConfig.KubeReserved = map[string]string{}
+		}
+		if _, ok := initialConfig.KubeReserved["cpu"]; !ok {
+			initialConfig.KubeReserved["cpu"] = "200m"
+		}
+	})
+
+	// runCheckpointDamageTest stops the kubelet, damages the given state file
+	// with mangleCmd, and verifies that the kubelet refuses to come up again
+	// until the damaged checkpoint is deleted, after which it must rebuild a
+	// clean checkpoint for the configured policy.
+	runCheckpointDamageTest := func(ctx context.Context, stateFileName, mangleCmd, expectedPolicyName string) {
+		ginkgo.By("Stopping the kubelet")
+		startKubelet := stopKubelet()
+		gomega.Eventually(ctx, func() bool {
+			return kubeletHealthCheck(kubeletHealthCheckURL)
+		}, time.Minute, time.Second).Should(gomega.BeFalse())
+
+		ginkgo.By(fmt.Sprintf("Damaging the state file %q", stateFileName))
+		mangleStateFile(stateFileName, mangleCmd)
+
+		ginkgo.By("Starting the kubelet and expecting it to stay unhealthy")
+		startKubelet()
+		gomega.Consistently(ctx, func() bool {
+			return kubeletHealthCheck(kubeletHealthCheckURL)
+		}, 1*time.Minute, 5*time.Second).Should(gomega.BeFalse(),
+			"the kubelet became healthy despite the damaged checkpoint %q", stateFileName)
+
+		ginkgo.By("Deleting the damaged state file and restarting the kubelet")
+		deleteStateFile(stateFileName)
+		restartKubelet(false)
+		waitForKubeletToStart(ctx, f)
+
+		ginkgo.By("Verifying a clean checkpoint was rebuilt")
+		gomega.Eventually(ctx, func() string {
+			data, err := os.ReadFile(stateFileName)
+			if err != nil {
+				return ""
+			}
+			return string(data)
+		}, time.Minute, time.Second).Should(gomega.ContainSubstring(fmt.Sprintf("%q:%q", "policyName", expectedPolicyName)),
+			"the rebuilt checkpoint %q does not record the configured policy", stateFileName)
+	}
+
+	ginkgo.It("should recover from a truncated CPU manager checkpoint", func(ctx context.Context) {
+		runCheckpointDamageTest(ctx, cpuManagerStateFile, ": > %s", string(cpumanager.PolicyStatic))
+	})
+
+	ginkgo.It("should recover from a corrupted CPU manager checkpoint", func(ctx context.Context) {
+		runCheckpointDamageTest(ctx, cpuManagerStateFile, "echo garbage-not-a-checkpoint > %s", string(cpumanager.PolicyStatic))
+	})
+
+	ginkgo.It("should recover from a CPU manager checkpoint recorded for another policy", func(ctx context.Context) {
+		runCheckpointDamageTest(ctx, cpuManagerStateFile, "sed -i s/static/none/ %s", string(cpumanager.PolicyStatic))
+	})
+
+	ginkgo.It("should recover from a truncated memory manager checkpoint", func(ctx context.Context) {
+		runCheckpointDamageTest(ctx, memoryManagerStateFile, ": > %s", staticPolicy)
+	})
+
+	ginkgo.It("should recover from a corrupted memory manager checkpoint", func(ctx context.Context) {
+		runCheckpointDamageTest(ctx, memoryManagerStateFile, "echo garbage-not-a-checkpoint > %s", staticPolicy)
+	})
+
+	ginkgo.It("should recover from a memory manager checkpoint recorded for another policy", func(ctx context.Context) {
+		runCheckpointDamageTest(ctx, memoryManagerStateFile, fmt.Sprintf("sed -i s/%s/%s/ %%s", staticPolicy, nonePolicy), staticPolicy)
+	})
+})
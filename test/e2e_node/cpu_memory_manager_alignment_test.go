@@ -0,0 +1,301 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2enode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeletconfig "k8s.io/kubernetes/pkg/kubelet/apis/config"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager"
+	"k8s.io/kubernetes/test/e2e/feature"
+	"k8s.io/kubernetes/test/e2e/framework"
+	e2epod "k8s.io/kubernetes/test/e2e/framework/pod"
+	e2eskipper "k8s.io/kubernetes/test/e2e/framework/skipper"
+	admissionapi "k8s.io/pod-security-admission/api"
+	"k8s.io/utils/cpuset"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+const (
+	// allowedListsCmd prints the allowed cpu list on the first line and the
+	// allowed memory node list on the second line, in that order, because
+	// Cpus_allowed_list precedes Mems_allowed_list in /proc/self/status.
+	allowedListsCmd      = `grep -E "Cpus_allowed_list|Mems_allowed_list" /proc/self/status | cut -f2`
+	allowedListsSleepCmd = allowedListsCmd + " && sleep 1d"
+)
+
+// Helper for makeCPUMemoryAlignmentPod().
+type alignmentCtnAttributes struct {
+	ctnName       string
+	cpus          string
+	memory        string
+	restartPolicy *v1.ContainerRestartPolicy
+}
+
+// makeCPUMemoryAlignmentContainers returns slice of guaranteed containers with the provided attributes.
+func makeCPUMemoryAlignmentContainers(ctnCmd string, ctnAttributes []alignmentCtnAttributes) []v1.Container {
+	var containers []v1.Container
+	for _, ctnAttr := range ctnAttributes {
+		cmd := ctnCmd
+		if ctnAttr.restartPolicy != nil && *ctnAttr.restartPolicy == v1.ContainerRestartPolicyAlways {
+			cmd = allowedListsSleepCmd
+		}
+		containers = append(containers, v1.Container{
+			Name:  ctnAttr.ctnName,
+			Image: busyboxImage,
+			Resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(ctnAttr.cpus),
+					v1.ResourceMemory: resource.MustParse(ctnAttr.memory),
+				},
+			},
+			Command:       []string{"sh", "-c", cmd},
+			RestartPolicy: ctnAttr.restartPolicy,
+		})
+	}
+	return containers
+}
+
+// makeCPUMemoryAlignmentPod returns a guaranteed pod whose containers report their allowed cpu and memory node lists.
+func makeCPUMemoryAlignmentPod(podName string, initCtnAttributes, ctnAttributes []alignmentCtnAttributes) *v1.Pod {
+	var initContainers []v1.Container
+	if len(initCtnAttributes) > 0 {
+		initContainers = makeCPUMemoryAlignmentContainers(allowedListsCmd, initCtnAttributes)
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: podName,
+		},
+		Spec: v1.PodSpec{
+			RestartPolicy:  v1.RestartPolicyNever,
+			InitContainers: initContainers,
+			Containers:     makeCPUMemoryAlignmentContainers(allowedListsSleepCmd, ctnAttributes),
+		},
+	}
+}
+
+// parseAllowedListsOutput parses the two lines produced by allowedListsCmd.
+func parseAllowedListsOutput(output string) (cpuset.CPUSet, cpuset.CPUSet, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 {
+		return cpuset.CPUSet{}, cpuset.CPUSet{}, fmt.Errorf("expected allowed cpus and mems lines, got %d lines: %q", len(lines), output)
+	}
+	cpus, err := cpuset.Parse(strings.TrimSpace(lines[0]))
+	if err != nil {
+		return cpuset.CPUSet{}, cpuset.CPUSet{}, err
+	}
+	mems, err := cpuset.Parse(strings.TrimSpace(lines[1]))
+	if err != nil {
+		return cpuset.CPUSet{}, cpuset.CPUSet{}, err
+	}
+	return cpus, mems, nil
+}
+
+// checkCPUMemoryAlignment verifies that the allowed cpus and the allowed memory
+// nodes reported by a container reference the same NUMA node.
+func checkCPUMemoryAlignment(output, ctnName string) error {
+	cpus, mems, err := parseAllowedListsOutput(output)
+	if err != nil {
+		return fmt.Errorf("parsing the allowed lists reported by container %q: %w", ctnName, err)
+	}
+	if mems.Size() != 1 {
+		return fmt.Errorf("container %q memory is not pinned to a single NUMA node: %q", ctnName, mems.String())
+	}
+	numaNode := mems.List()[0]
+	nodeCPUList, err := getCPUsPerNUMANode(numaNode)
+	if err != nil {
+		return err
+	}
+	nodeCPUs := cpuset.New(nodeCPUList...)
+	if !cpus.IsSubsetOf(nodeCPUs) {
+		return fmt.Errorf("container %q cpuset %q is not aligned to NUMA node %d (node cpus %q)", ctnName, cpus.String(), numaNode, nodeCPUs.String())
+	}
+	return nil
+}
+
+// Serial because the test updates kubelet configuration.
+var _ = SIGDescribe("CPU Manager Memory Manager NUMA Alignment", framework.WithDisruptive(), framework.WithSerial(), feature.CPUManager, feature.MemoryManager, func() {
+	var testPod *v1.Pod
+
+	f := framework.NewDefaultFramework("cpu-memory-alignment-test")
+	f.NamespacePodSecurityLevel = admissionapi.LevelPrivileged
+
+	ginkgo.BeforeEach(func(ctx context.Context) {
+		if !isMultiNUMA() {
+			e2eskipper.Skipf("Skipping CPU Manager Memory Manager NUMA Alignment tests since the node has a single NUMA node")
+		}
+
+		_, cpuAlloc, _ := getLocalNodeCPUDetails(ctx, f)
+		if cpuAlloc < 4 {
+			e2eskipper.Skipf("Skipping CPU Manager Memory Manager NUMA Alignment tests since allocatable CPU capacity < 4")
+		}
+	})
+
+	tempSetCurrentKubeletConfig(f, func(ctx context.Context, initialConfig *kubeletconfig.KubeletConfiguration) {
+		memoryQuantity := resource.MustParse("1100Mi")
+		kubeParams := &memoryManagerKubeletParams{
+			policy: staticPolicy,
+			systemReservedMemory: []kubeletconfig.MemoryReservation{
+				{
+					NumaNode: 0,
+					Limits: v1.ResourceList{
+						resourceMemory: memoryQuantity,
+					},
+				},
+			},
+			systemReserved: map[string]string{resourceMemory: "500Mi"},
+			kubeReserved:   map[string]string{resourceMemory: "500Mi"},
+			evictionHard:   map[string]string{evictionHardMemory: "100Mi"},
+		}
+		updateKubeletConfigWithMemoryManagerParams(initialConfig, kubeParams)
+
+		initialConfig.CPUManagerPolicy = string(cpumanager.PolicyStatic)
+		initialConfig.CPUManagerReconcilePeriod = metav1.Duration{Duration: 1 * time.Second}
+		if initialConfig.KubeReserved == nil {
+			initialConfig.KubeReserved = map[string]string{}
+		}
+		if _, ok := initialConfig.KubeReserved["cpu"]; !ok {
+			initialConfig.KubeReserved["cpu"] = "200m"
+		}
+
+		initialConfig.TopologyManagerPolicy = kubeletconfig.SingleNumaNodeTopologyManagerPolicy
+		initialConfig.TopologyManagerScope = kubeletconfig.ContainerTopologyManagerScope
+	})
+
+	ginkgo.JustAfterEach(func(ctx context.Context) {
+		if testPod != nil && testPod.Name != "" {
+			e2epod.NewPodClient(f).DeleteSync(ctx, testPod.Name, metav1.DeleteOptions{}, 2*time.Minute)
+		}
+		testPod = nil
+	})
+
+	verifyContainerAlignment := func(ctx context.Context, pod *v1.Pod, ctnName string) {
+		ginkgo.By(fmt.Sprintf("Verifying the NUMA alignment of container %s", ctnName))
+		output, err := e2epod.GetPodLogs(ctx, f.ClientSet, f.Namespace.Name, pod.Name, ctnName)
+		framework.ExpectNoError(err)
+		framework.ExpectNoError(checkCPUMemoryAlignment(output, ctnName))
+	}
+
+	ginkgo.It("should align the cpuset and the memory of a Guaranteed container to the same NUMA node", func(ctx context.Context) {
+		ctnAttributes := []alignmentCtnAttributes{
+			{
+				ctnName: "gu-container",
+				cpus:    "1000m",
+				memory:  "128Mi",
+			},
+		}
+		testPod = makeCPUMemoryAlignmentPod("gu-pod-alignment", nil, ctnAttributes)
+		testPod = e2epod.NewPodClient(f).CreateSync(ctx, testPod)
+
+		verifyContainerAlignment(ctx, testPod, "gu-container")
+	})
+
+	ginkgo.It("should align the cpuset and the memory of init containers to the same NUMA node", func(ctx context.Context) {
+		initCtnAttributes := []alignmentCtnAttributes{
+			{
+				ctnName: "init-container",
+				cpus:    "1000m",
+				memory:  "128Mi",
+			},
+		}
+		ctnAttributes := []alignmentCtnAttributes{
+			{
+				ctnName: "gu-container",
+				cpus:    "1000m",
+				memory:  "128Mi",
+			},
+		}
+		testPod = makeCPUMemoryAlignmentPod("gu-pod-init-ctns", initCtnAttributes, ctnAttributes)
+		testPod = e2epod.NewPodClient(f).CreateSync(ctx, testPod)
+
+		verifyContainerAlignment(ctx, testPod, "init-container")
+		verifyContainerAlignment(ctx, testPod, "gu-container")
+	})
+
+	ginkgo.It("should align the cpuset and the memory of restartable init containers to the same NUMA node", func(ctx context.Context) {
+		restartAlways := v1.ContainerRestartPolicyAlways
+		initCtnAttributes := []alignmentCtnAttributes{
+			{
+				ctnName:       "sidecar-container",
+				cpus:          "1000m",
+				memory:        "128Mi",
+				restartPolicy: &restartAlways,
+			},
+		}
+		ctnAttributes := []alignmentCtnAttributes{
+			{
+				ctnName: "gu-container",
+				cpus:    "1000m",
+				memory:  "128Mi",
+			},
+		}
+		testPod = makeCPUMemoryAlignmentPod("gu-pod-sidecar", initCtnAttributes, ctnAttributes)
+		testPod = e2epod.NewPodClient(f).CreateSync(ctx, testPod)
+
+		verifyContainerAlignment(ctx, testPod, "sidecar-container")
+		verifyContainerAlignment(ctx, testPod, "gu-container")
+	})
+
+	ginkgo.It("should keep the cpuset and the memory aligned across container restarts", func(ctx context.Context) {
+		ctnAttributes := []alignmentCtnAttributes{
+			{
+				ctnName: "gu-container",
+				cpus:    "1000m",
+				memory:  "128Mi",
+			},
+		}
+		testPod = makeCPUMemoryAlignmentPod("gu-pod-restart", nil, ctnAttributes)
+		// make the container exit on its own so the kubelet restarts it
+		testPod.Spec.RestartPolicy = v1.RestartPolicyAlways
+		testPod.Spec.Containers[0].Command = []string{"sh", "-c", allowedListsCmd + " && sleep 10"}
+		testPod = e2epod.NewPodClient(f).CreateSync(ctx, testPod)
+
+		verifyContainerAlignment(ctx, testPod, "gu-container")
+
+		ginkgo.By("Waiting for the container to be restarted")
+		gomega.Eventually(ctx, func(ctx context.Context) (int32, error) {
+			pod, err := f.ClientSet.CoreV1().Pods(f.Namespace.Name).Get(ctx, testPod.Name, metav1.GetOptions{})
+			if err != nil {
+				return 0, err
+			}
+			if len(pod.Status.ContainerStatuses) == 0 {
+				return 0, nil
+			}
+			return pod.Status.ContainerStatuses[0].RestartCount, nil
+		}, 2*time.Minute, framework.Poll).Should(gomega.BeNumerically(">=", 1))
+
+		ginkgo.By("Verifying the NUMA alignment after the restart")
+		gomega.Eventually(ctx, func(ctx context.Context) error {
+			output, err := e2epod.GetPodLogs(ctx, f.ClientSet, f.Namespace.Name, testPod.Name, "gu-container")
+			if err != nil {
+				return err
+			}
+			return checkCPUMemoryAlignment(output, "gu-container")
+		}, 2*time.Minute, framework.Poll).Should(gomega.Succeed())
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -35,6 +36,14 @@ const (
 	cdiPath      = "/var/run/cdi/example.com.json"
 	cdiVersion   = "0.3.0"
 	cdiPrefix    = "CDI-"
+	// deviceIDsEnvVarName is the name of the environment variable injected in
+	// each container which got devices allocated, holding the IDs of the
+	// assigned devices. The e2e tests read it back to verify the allocation.
+	deviceIDsEnvVarName = "EXAMPLE_DEVICE_IDS"
+	// preStartMarkerPathTemplate is the path template of the marker file
+	// created when PreStartContainer runs for a device, so tests can verify
+	// the kubelet honored the PreStartRequired plugin option.
+	preStartMarkerPathTemplate = "/tmp/%s-prestart"
 )
 
 // stubAllocFunc creates and returns allocation response for the input allocate request
@@ -80,12 +89,39 @@ func stubAllocFunc(r *pluginapi.AllocateRequest, devs map[string]pluginapi.Devic
 				response.CDIDevices = append(response.CDIDevices, cdiDevice)
 			}
 		}
+		// inject the IDs of the devices assigned to this container, so the
+		// workload (and the tests) can learn about them from the environment.
+		response.Envs = map[string]string{
+			deviceIDsEnvVarName: strings.Join(req.DevicesIDs, ","),
+		}
+
 		responses.ContainerResponses = append(responses.ContainerResponses, response)
 	}
 
 	return &responses, nil
 }
 
+// stubPreStartFunc creates a marker file for each device the container is
+// about to use, to make the PreStartContainer flow observable from tests.
+func stubPreStartFunc(r *pluginapi.PreStartContainerRequest, devs map[string]pluginapi.Device) (*pluginapi.PreStartContainerResponse, error) {
+	for _, requestID := range r.DevicesIDs {
+		dev, ok := devs[requestID]
+		if !ok {
+			return nil, fmt.Errorf("invalid prestart request with non-existing device %s", requestID)
+		}
+
+		fpath := fmt.Sprintf(preStartMarkerPathTemplate, dev.ID)
+		f, err := os.Create(fpath)
+		if err != nil && !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create prestart marker file: %s", err)
+		}
+
+		f.Close()
+	}
+
+	return &pluginapi.PreStartContainerResponse{}, nil
+}
+
 // stubAllocFunc creates and returns allocation response for the input allocate request
 func stubRegisterControlFunc() bool {
 	return false
@@ -109,12 +145,38 @@ func main() {
 
 	socketPath := pluginSocksDir + "/dp." + fmt.Sprintf("%d", time.Now().Unix())
 
-	dp1 := plugin.NewDevicePluginStub(devs, socketPath, resourceName, false, false)
+	// PRESTART_CONTAINER makes the plugin require (and implement) the
+	// PreStartContainer call for the devices it serves.
+	preStartEnabled := os.Getenv("PRESTART_CONTAINER") != ""
+	klog.Infof("preStartEnabled: %v", preStartEnabled)
+
+	// DEVICE_CONFIG_FILE points to a mounted DeviceConfig YAML file the
+	// device list is loaded from, instead of the hardcoded one. The file is
+	// reloaded on SIGHUP, so tests can reshape the device inventory of a
+	// running plugin.
+	deviceConfigFile := os.Getenv("DEVICE_CONFIG_FILE")
+	klog.Infof("deviceConfigFile: %s", deviceConfigFile)
+
+	var dp1 *plugin.Stub
+	if deviceConfigFile != "" {
+		var err error
+		if devs, err = plugin.LoadDeviceConfig(deviceConfigFile); err != nil {
+			panic(err)
+		}
+		if dp1, err = plugin.NewDevicePluginStubFromConfig(deviceConfigFile, socketPath, resourceName, preStartEnabled, false); err != nil {
+			panic(err)
+		}
+	} else {
+		dp1 = plugin.NewDevicePluginStub(devs, socketPath, resourceName, preStartEnabled, false)
+	}
 	if err := dp1.Start(); err != nil {
 		panic(err)
 
 	}
 	dp1.SetAllocFunc(stubAllocFunc)
+	if preStartEnabled {
+		dp1.SetPreStartFunc(stubPreStartFunc)
+	}
 
 	cdiEnabled := os.Getenv("CDI_ENABLED")
 	klog.Infof("CDI_ENABLED: %s", cdiEnabled)
@@ -176,6 +238,13 @@ func main() {
 				}
 			// Catch termination signals
 			case sig := <-sigCh:
+				if sig == syscall.SIGHUP && deviceConfigFile != "" {
+					klog.InfoS("Reloading the device config", "path", deviceConfigFile)
+					if err := dp1.ReloadConfig(); err != nil {
+						klog.ErrorS(err, "Unable to reload the device config, keeping the previous device list")
+					}
+					continue
+				}
 				klog.InfoS("Shutting down, received signal", "signal", sig)
 				if err := dp1.Stop(); err != nil {
 					panic(err)
@@ -191,12 +260,20 @@ func main() {
 
 		go dp1.Watch(pluginapi.KubeletSocket, resourceName, pluginapi.DevicePluginPath)
 		// Catch termination signals
-		sig := <-sigCh
-		klog.InfoS("Shutting down, received signal", "signal", sig)
-		if err := dp1.Stop(); err != nil {
-			panic(err)
+		for sig := range sigCh {
+			if sig == syscall.SIGHUP && deviceConfigFile != "" {
+				klog.InfoS("Reloading the device config", "path", deviceConfigFile)
+				if err := dp1.ReloadConfig(); err != nil {
+					klog.ErrorS(err, "Unable to reload the device config, keeping the previous device list")
+				}
+				continue
+			}
+			klog.InfoS("Shutting down, received signal", "signal", sig)
+			if err := dp1.Stop(); err != nil {
+				panic(err)
+			}
+			return
 		}
-		return
 	}
 }
 